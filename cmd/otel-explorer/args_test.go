@@ -1,8 +1,12 @@
 package main
 
 import (
+	"regexp"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
 )
 
 func TestParseArgs(t *testing.T) {
@@ -167,12 +171,72 @@ func TestParseArgs(t *testing.T) {
 			isTerminal: true,
 			want:       config{urls: []string{"url"}, outputFormat: "markdown"},
 		},
+		{
+			name:       "--output=json sets outputFormat and disables TUI",
+			args:       []string{"url", "--output=json"},
+			isTerminal: true,
+			want:       config{urls: []string{"url"}, outputFormat: "json"},
+		},
 		{
 			name:       "--output=invalid returns error",
 			args:       []string{"url", "--output=invalid"},
 			isTerminal: false,
 			wantErr:    true,
 		},
+		{
+			name:       "--output=aggregate sets outputFormat and disables TUI",
+			args:       []string{"trace.json", "--output=aggregate", "--aggregate-by=http.route", "--aggregate-sort=p95"},
+			isTerminal: true,
+			want:       config{urls: []string{"trace.json"}, outputFormat: "aggregate", aggregateBy: "http.route", aggregateSort: "p95"},
+		},
+		{
+			name:       "--aggregate-sort=invalid returns error",
+			args:       []string{"url", "--aggregate-sort=invalid"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--output=runners sets outputFormat and grouping",
+			args:       []string{"url", "--output=runners", "--runners-by=label"},
+			isTerminal: true,
+			want:       config{urls: []string{"url"}, outputFormat: "runners", runnersBy: "label"},
+		},
+		{
+			name:       "--runners-by=invalid returns error",
+			args:       []string{"url", "--runners-by=pod"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--baseline=<branch> uses the default window",
+			args:       []string{"url", "--baseline=main"},
+			isTerminal: false,
+			want:       config{urls: []string{"url"}, baselineBranch: "main", baselineDays: 14},
+		},
+		{
+			name:       "--baseline=<branch>:<days> sets the window",
+			args:       []string{"url", "--baseline=release/v2:30"},
+			isTerminal: false,
+			want:       config{urls: []string{"url"}, baselineBranch: "release/v2", baselineDays: 30},
+		},
+		{
+			name:       "--baseline with invalid days returns error",
+			args:       []string{"url", "--baseline=main:soon"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--baseline without branch returns error",
+			args:       []string{"url", "--baseline=:7"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "lifecycle subcommand takes the repo",
+			args:       []string{"lifecycle", "owner/repo", "--days=7"},
+			isTerminal: false,
+			want:       config{lifecycleMode: true, trendsRepo: "owner/repo"},
+		},
 		{
 			name:       "--no-sample flag in trends mode",
 			args:       []string{"trends", "owner/repo", "--no-sample"},
@@ -203,6 +267,148 @@ func TestParseArgs(t *testing.T) {
 			isTerminal: false,
 			wantErr:    true,
 		},
+		{
+			name:       "--simulate-runners with policy and cost",
+			args:       []string{"trends", "owner/repo", "--simulate-runners=ubuntu-latest:4,ubuntu-latest:8", "--simulate-policy=shortest", "--runner-cost=0.48"},
+			isTerminal: false,
+			want: config{trendsMode: true, trendsRepo: "owner/repo", trendsPolicy: "shortest", trendsRunnerCost: 0.48,
+				trendsSimulate: []analyzer.RunnerScenario{{Label: "ubuntu-latest", Runners: 4}, {Label: "ubuntu-latest", Runners: 8}}},
+		},
+		{
+			name:       "--simulate-runners without a count returns error",
+			args:       []string{"trends", "owner/repo", "--simulate-runners=ubuntu-latest"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--simulate-policy=invalid returns error",
+			args:       []string{"trends", "owner/repo", "--simulate-policy=random"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--group-by with several dimensions",
+			args:       []string{"trends", "owner/repo", "--group-by=event, os,event,actor-type"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "owner/repo", trendsGroupBy: []string{"event", "os", "actor-type"}},
+		},
+		{
+			name:       "--group-by=invalid returns error",
+			args:       []string{"trends", "owner/repo", "--group-by=event,branch"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--timezone sets the heatmap location",
+			args:       []string{"trends", "owner/repo", "--timezone=America/New_York"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "owner/repo", trendsTimezone: mustLoadLocation("America/New_York")},
+		},
+		{
+			name:       "--timezone=invalid returns error",
+			args:       []string{"trends", "owner/repo", "--timezone=Mars/Olympus_Mons"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--failure-logs reads failed job logs",
+			args:       []string{"trends", "owner/repo", "--failure-logs"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "owner/repo", trendsFailLogs: true},
+		},
+		{
+			name:       "--approval-waits looks up deployments",
+			args:       []string{"trends", "owner/repo", "--approval-waits"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "owner/repo", approvalWaits: true},
+		},
+		{
+			name:       "--job-name-rule is repeatable",
+			args:       []string{"trends", "owner/repo", `--job-name-rule=pr-\d+=>pr-<n>`, "--job-name-rule=preview-.+=>preview", "--collapse-matrix", "--default-job-name-rules"},
+			isTerminal: false,
+			want: config{trendsMode: true, trendsRepo: "owner/repo", trendsCollapse: true, trendsJobDefault: true, trendsJobRules: []analyzer.JobNameRule{
+				{Pattern: regexp.MustCompile(`pr-\d+`), Replacement: "pr-<n>"},
+				{Pattern: regexp.MustCompile(`preview-.+`), Replacement: "preview"},
+			}},
+		},
+		{
+			name:       "--job-name-rule without a replacement returns error",
+			args:       []string{"trends", "owner/repo", "--job-name-rule=pr-\\d+"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--job-name-rule with a bad pattern returns error",
+			args:       []string{"trends", "owner/repo", "--job-name-rule=pr-(=>pr"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "trends org:<owner> is kept as the repository",
+			args:       []string{"trends", "org:acme", "--repo-concurrency=8"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "org:acme", trendsRepoConc: 8},
+		},
+		{
+			name:       "--repos-file replaces the repository",
+			args:       []string{"trends", "--repos-file=repos.txt"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsReposFile: "repos.txt"},
+		},
+		{
+			name:       "--repos-file with a repository returns error",
+			args:       []string{"trends", "owner/repo", "--repos-file=repos.txt"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--repo-concurrency=0 returns error",
+			args:       []string{"trends", "org:acme", "--repo-concurrency=0"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--otel-metrics=<endpoint> exports trend metrics",
+			args:       []string{"trends", "owner/repo", "--otel-metrics=localhost:4318"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "owner/repo", trendsMetricsURL: "localhost:4318"},
+		},
+		{
+			name:       "--otel-metrics writes trend metrics to stdout",
+			args:       []string{"trends", "owner/repo", "--otel-metrics"},
+			isTerminal: false,
+			want:       config{trendsMode: true, trendsRepo: "owner/repo", trendsMetricsOut: true},
+		},
+		{
+			name:       "--otel-metrics= without an endpoint returns error",
+			args:       []string{"trends", "owner/repo", "--otel-metrics="},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--metrics-dimensions and --metrics-buckets configure the receiver's metrics",
+			args:       []string{"--listen", "--metrics-dimensions=service.name, http.route", "--metrics-buckets=10,0.5,1"},
+			isTerminal: false,
+			want:       config{listenAddr: ":4318", metricsDims: []string{"service.name", "http.route"}, metricsBuckets: []float64{0.5, 1, 10}},
+		},
+		{
+			name:       "--metrics-dimensions= without attributes returns error",
+			args:       []string{"--listen", "--metrics-dimensions=,"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--metrics-buckets with a bad boundary returns error",
+			args:       []string{"--listen", "--metrics-buckets=1,fast"},
+			isTerminal: false,
+			wantErr:    true,
+		},
+		{
+			name:       "--runner-cost=-1 returns error",
+			args:       []string{"trends", "owner/repo", "--runner-cost=-1"},
+			isTerminal: false,
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -254,6 +460,27 @@ func TestParseArgs(t *testing.T) {
 			if got.outputFormat != tt.want.outputFormat {
 				t.Errorf("outputFormat = %q, want %q", got.outputFormat, tt.want.outputFormat)
 			}
+			if got.aggregateBy != tt.want.aggregateBy {
+				t.Errorf("aggregateBy = %q, want %q", got.aggregateBy, tt.want.aggregateBy)
+			}
+			if got.aggregateSort != tt.want.aggregateSort {
+				t.Errorf("aggregateSort = %q, want %q", got.aggregateSort, tt.want.aggregateSort)
+			}
+			if got.runnersBy != tt.want.runnersBy {
+				t.Errorf("runnersBy = %q, want %q", got.runnersBy, tt.want.runnersBy)
+			}
+			if got.lifecycleMode != tt.want.lifecycleMode {
+				t.Errorf("lifecycleMode = %v, want %v", got.lifecycleMode, tt.want.lifecycleMode)
+			}
+			if got.trendsRepo != tt.want.trendsRepo {
+				t.Errorf("trendsRepo = %q, want %q", got.trendsRepo, tt.want.trendsRepo)
+			}
+			if got.baselineBranch != tt.want.baselineBranch {
+				t.Errorf("baselineBranch = %q, want %q", got.baselineBranch, tt.want.baselineBranch)
+			}
+			if got.baselineDays != tt.want.baselineDays {
+				t.Errorf("baselineDays = %d, want %d", got.baselineDays, tt.want.baselineDays)
+			}
 			if got.trendsNoSample != tt.want.trendsNoSample {
 				t.Errorf("trendsNoSample = %v, want %v", got.trendsNoSample, tt.want.trendsNoSample)
 			}
@@ -263,10 +490,71 @@ func TestParseArgs(t *testing.T) {
 			if tt.want.trendsMargin != 0 && got.trendsMargin != tt.want.trendsMargin {
 				t.Errorf("trendsMargin = %v, want %v", got.trendsMargin, tt.want.trendsMargin)
 			}
+			if !slices.Equal(got.trendsSimulate, tt.want.trendsSimulate) {
+				t.Errorf("trendsSimulate = %v, want %v", got.trendsSimulate, tt.want.trendsSimulate)
+			}
+			if !slices.Equal(got.trendsGroupBy, tt.want.trendsGroupBy) {
+				t.Errorf("trendsGroupBy = %v, want %v", got.trendsGroupBy, tt.want.trendsGroupBy)
+			}
+			if got.trendsTimezone.String() != tt.want.trendsTimezone.String() {
+				t.Errorf("trendsTimezone = %v, want %v", got.trendsTimezone, tt.want.trendsTimezone)
+			}
+			if got.trendsFailLogs != tt.want.trendsFailLogs {
+				t.Errorf("trendsFailLogs = %v, want %v", got.trendsFailLogs, tt.want.trendsFailLogs)
+			}
+			if got.approvalWaits != tt.want.approvalWaits {
+				t.Errorf("approvalWaits = %v, want %v", got.approvalWaits, tt.want.approvalWaits)
+			}
+			if got.trendsCollapse != tt.want.trendsCollapse {
+				t.Errorf("trendsCollapse = %v, want %v", got.trendsCollapse, tt.want.trendsCollapse)
+			}
+			if got.trendsJobDefault != tt.want.trendsJobDefault {
+				t.Errorf("trendsJobDefault = %v, want %v", got.trendsJobDefault, tt.want.trendsJobDefault)
+			}
+			if !slices.EqualFunc(got.trendsJobRules, tt.want.trendsJobRules, func(a, b analyzer.JobNameRule) bool {
+				return a.Pattern.String() == b.Pattern.String() && a.Replacement == b.Replacement
+			}) {
+				t.Errorf("trendsJobRules = %v, want %v", got.trendsJobRules, tt.want.trendsJobRules)
+			}
+			if got.trendsReposFile != tt.want.trendsReposFile {
+				t.Errorf("trendsReposFile = %q, want %q", got.trendsReposFile, tt.want.trendsReposFile)
+			}
+			if got.trendsRepoConc != tt.want.trendsRepoConc {
+				t.Errorf("trendsRepoConc = %d, want %d", got.trendsRepoConc, tt.want.trendsRepoConc)
+			}
+			if got.trendsMetricsURL != tt.want.trendsMetricsURL {
+				t.Errorf("trendsMetricsURL = %q, want %q", got.trendsMetricsURL, tt.want.trendsMetricsURL)
+			}
+			if got.trendsMetricsOut != tt.want.trendsMetricsOut {
+				t.Errorf("trendsMetricsOut = %v, want %v", got.trendsMetricsOut, tt.want.trendsMetricsOut)
+			}
+			if got.listenAddr != tt.want.listenAddr {
+				t.Errorf("listenAddr = %q, want %q", got.listenAddr, tt.want.listenAddr)
+			}
+			if !slices.Equal(got.metricsDims, tt.want.metricsDims) {
+				t.Errorf("metricsDims = %v, want %v", got.metricsDims, tt.want.metricsDims)
+			}
+			if !slices.Equal(got.metricsBuckets, tt.want.metricsBuckets) {
+				t.Errorf("metricsBuckets = %v, want %v", got.metricsBuckets, tt.want.metricsBuckets)
+			}
+			if got.trendsPolicy != tt.want.trendsPolicy {
+				t.Errorf("trendsPolicy = %q, want %q", got.trendsPolicy, tt.want.trendsPolicy)
+			}
+			if got.trendsRunnerCost != tt.want.trendsRunnerCost {
+				t.Errorf("trendsRunnerCost = %v, want %v", got.trendsRunnerCost, tt.want.trendsRunnerCost)
+			}
 		})
 	}
 }
 
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
 func slicesEqual(a, b []string) bool {
 	if len(a) == 0 && len(b) == 0 {
 		return true
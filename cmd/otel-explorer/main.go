@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -97,11 +98,17 @@ type config struct {
 	otelStdout       bool
 	otelGRPCEndpoint string
 	tuiMode          bool
-	outputFormat     string // "stdout" or "markdown"
+	outputFormat     string // "stdout", "markdown", "json", "aggregate", or "runners"
+	aggregateBy      string // --aggregate-by=<name|attribute key>
+	aggregateSort    string // --aggregate-sort=<column>
+	runnersBy        string // --runners-by=<runner|group|label>
+	baselineBranch   string // --baseline=<branch>[:days]
+	baselineDays     int
 	clearCache       bool
 	window           time.Duration
 	showHelp         bool
 	trendsMode       bool
+	lifecycleMode    bool   // lifecycle subcommand; shares the trends repo, --days, --format and --branch
 	trendsRepo       string
 	trendsDays       int
 	trendsFormat     string
@@ -110,7 +117,22 @@ type config struct {
 	trendsNoSample   bool
 	trendsConfidence float64
 	trendsMargin     float64
+	trendsSimulate   []analyzer.RunnerScenario // --simulate-runners=label:N,...
+	trendsPolicy     string                    // --simulate-policy=<fifo|shortest>
+	trendsRunnerCost float64                   // --runner-cost=<price per runner-hour>
+	trendsGroupBy    []string                  // --group-by=event,os,actor-type
+	trendsTimezone   *time.Location            // --timezone=<IANA name> for the queue heatmap
+	trendsFailLogs   bool                      // --failure-logs: read failed job logs for failure signatures
+	trendsJobRules   []analyzer.JobNameRule    // --job-name-rule=PATTERN=>REPLACEMENT, repeatable
+	trendsCollapse   bool                      // --collapse-matrix: track matrix jobs as one job
+	trendsJobDefault bool                      // --default-job-name-rules: also collapse dates and PR numbers, and follow renames
+	trendsReposFile  string                    // --repos-file=<path>: org trends over the listed repos
+	trendsRepoConc   int                       // --repo-concurrency=<n> repos analyzed at once
+	trendsMetricsURL string                    // --otel-metrics=<endpoint>: export trends as OTLP metrics
+	trendsMetricsOut bool                      // --otel-metrics: write them as OTLP JSON to stdout
 	noArtifacts      bool
+	compositeSteps   bool
+	approvalWaits    bool // --approval-waits: look up deployments for environment approval waits
 	convertMode      bool
 	convertFiles     []string
 	// OTel alignment features
@@ -119,6 +141,10 @@ type config struct {
 	listenAddr     string // --listen=<addr>
 	enrichmentFile string // --enrichment=<file>
 	lintMode       bool   // --lint
+
+	// Span metrics served by the receiver on /metrics
+	metricsDims    []string  // --metrics-dimensions=<attr,...>
+	metricsBuckets []float64 // --metrics-buckets=<seconds,...>
 }
 
 func parseArgs(args []string, terminal bool) (config, error) {
@@ -151,6 +177,12 @@ func parseArgs(args []string, terminal bool) (config, error) {
 		args = args[1:] // consume the "trends" subcommand
 	}
 
+	// Check if first arg is "lifecycle" subcommand
+	if len(args) > 0 && args[0] == "lifecycle" {
+		cfg.lifecycleMode = true
+		args = args[1:] // consume the "lifecycle" subcommand
+	}
+
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 
@@ -204,12 +236,47 @@ func parseArgs(args []string, terminal bool) (config, error) {
 		}
 		if strings.HasPrefix(arg, "--output=") {
 			cfg.outputFormat = strings.TrimPrefix(arg, "--output=")
-			if cfg.outputFormat != "stdout" && cfg.outputFormat != "markdown" {
-				return cfg, fmt.Errorf("invalid --output value: %s (must be 'stdout' or 'markdown')", cfg.outputFormat)
+			if cfg.outputFormat != "stdout" && cfg.outputFormat != "markdown" && cfg.outputFormat != "json" && cfg.outputFormat != "aggregate" && cfg.outputFormat != "runners" {
+				return cfg, fmt.Errorf("invalid --output value: %s (must be 'stdout', 'markdown', 'json', 'aggregate', or 'runners')", cfg.outputFormat)
 			}
 			cfg.tuiMode = false
 			continue
 		}
+		if strings.HasPrefix(arg, "--aggregate-by=") {
+			cfg.aggregateBy = strings.TrimPrefix(arg, "--aggregate-by=")
+			continue
+		}
+		if strings.HasPrefix(arg, "--aggregate-sort=") {
+			cfg.aggregateSort = strings.TrimPrefix(arg, "--aggregate-sort=")
+			if !slices.Contains(analyzer.AggregateSortFields, cfg.aggregateSort) {
+				return cfg, fmt.Errorf("invalid --aggregate-sort value: %s (must be one of: %s)", cfg.aggregateSort, strings.Join(analyzer.AggregateSortFields, ", "))
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--runners-by=") {
+			cfg.runnersBy = strings.TrimPrefix(arg, "--runners-by=")
+			if !slices.Contains(analyzer.RunnerGroupings, cfg.runnersBy) {
+				return cfg, fmt.Errorf("invalid --runners-by value: %s (must be one of: %s)", cfg.runnersBy, strings.Join(analyzer.RunnerGroupings, ", "))
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--baseline=") {
+			value := strings.TrimPrefix(arg, "--baseline=")
+			branch, days, hasDays := strings.Cut(value, ":")
+			if branch == "" {
+				return cfg, fmt.Errorf("invalid --baseline value: %s (must be <branch>[:days])", value)
+			}
+			cfg.baselineBranch = branch
+			cfg.baselineDays = analyzer.DefaultBaselineDays
+			if hasDays {
+				n, err := strconv.Atoi(days)
+				if err != nil || n < 1 {
+					return cfg, fmt.Errorf("invalid --baseline days: %s", days)
+				}
+				cfg.baselineDays = n
+			}
+			continue
+		}
 		if strings.HasPrefix(arg, "--trace=") {
 			cfg.traceFiles = append(cfg.traceFiles, strings.TrimPrefix(arg, "--trace="))
 			continue
@@ -234,6 +301,14 @@ func parseArgs(args []string, terminal bool) (config, error) {
 			cfg.noArtifacts = true
 			continue
 		}
+		if arg == "--composite-steps" {
+			cfg.compositeSteps = true
+			continue
+		}
+		if arg == "--approval-waits" {
+			cfg.approvalWaits = true
+			continue
+		}
 		if strings.HasPrefix(arg, "--filter=") {
 			cfg.filterExpr = strings.TrimPrefix(arg, "--filter=")
 			continue
@@ -250,6 +325,25 @@ func parseArgs(args []string, terminal bool) (config, error) {
 			cfg.listenAddr = ":4318"
 			continue
 		}
+		if strings.HasPrefix(arg, "--metrics-dimensions=") {
+			for _, d := range strings.Split(strings.TrimPrefix(arg, "--metrics-dimensions="), ",") {
+				if d = strings.TrimSpace(d); d != "" {
+					cfg.metricsDims = append(cfg.metricsDims, d)
+				}
+			}
+			if len(cfg.metricsDims) == 0 {
+				return cfg, fmt.Errorf("invalid --metrics-dimensions value: at least one attribute required")
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--metrics-buckets=") {
+			buckets, err := receiver.ParseMetricsBuckets(strings.TrimPrefix(arg, "--metrics-buckets="))
+			if err != nil {
+				return cfg, fmt.Errorf("invalid --metrics-buckets value: %w", err)
+			}
+			cfg.metricsBuckets = buckets
+			continue
+		}
 		if strings.HasPrefix(arg, "--enrichment=") {
 			cfg.enrichmentFile = strings.TrimPrefix(arg, "--enrichment=")
 			continue
@@ -304,9 +398,98 @@ func parseArgs(args []string, terminal bool) (config, error) {
 			cfg.trendsMargin = val
 			continue
 		}
+		if strings.HasPrefix(arg, "--simulate-runners=") {
+			scenarios, err := analyzer.ParseRunnerScenarios(strings.TrimPrefix(arg, "--simulate-runners="))
+			if err != nil {
+				return cfg, fmt.Errorf("invalid --simulate-runners value: %w", err)
+			}
+			cfg.trendsSimulate = scenarios
+			continue
+		}
+		if strings.HasPrefix(arg, "--simulate-policy=") {
+			cfg.trendsPolicy = strings.TrimPrefix(arg, "--simulate-policy=")
+			if !slices.Contains(analyzer.SimulationPolicies, cfg.trendsPolicy) {
+				return cfg, fmt.Errorf("invalid --simulate-policy value: %s (must be one of: %s)", cfg.trendsPolicy, strings.Join(analyzer.SimulationPolicies, ", "))
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--runner-cost=") {
+			val, err := strconv.ParseFloat(strings.TrimPrefix(arg, "--runner-cost="), 64)
+			if err != nil || val < 0 {
+				return cfg, fmt.Errorf("invalid --runner-cost value: must be a non-negative price per runner-hour (e.g., 0.48)")
+			}
+			cfg.trendsRunnerCost = val
+			continue
+		}
+		if arg == "--failure-logs" {
+			cfg.trendsFailLogs = true
+			continue
+		}
+		if strings.HasPrefix(arg, "--job-name-rule=") {
+			rule, err := analyzer.ParseJobNameRule(strings.TrimPrefix(arg, "--job-name-rule="))
+			if err != nil {
+				return cfg, fmt.Errorf("invalid --job-name-rule value: %w", err)
+			}
+			cfg.trendsJobRules = append(cfg.trendsJobRules, rule)
+			continue
+		}
+		if arg == "--collapse-matrix" {
+			cfg.trendsCollapse = true
+			continue
+		}
+		if arg == "--default-job-name-rules" {
+			cfg.trendsJobDefault = true
+			continue
+		}
+		if strings.HasPrefix(arg, "--repos-file=") {
+			cfg.trendsReposFile = strings.TrimPrefix(arg, "--repos-file=")
+			if cfg.trendsReposFile == "" {
+				return cfg, fmt.Errorf("invalid --repos-file value: path required")
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--repo-concurrency=") {
+			n := strings.TrimPrefix(arg, "--repo-concurrency=")
+			if _, err := fmt.Sscanf(n, "%d", &cfg.trendsRepoConc); err != nil || cfg.trendsRepoConc < 1 {
+				return cfg, fmt.Errorf("invalid --repo-concurrency value: %s", n)
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--otel-metrics=") {
+			cfg.trendsMetricsURL = strings.TrimPrefix(arg, "--otel-metrics=")
+			if cfg.trendsMetricsURL == "" {
+				return cfg, fmt.Errorf("invalid --otel-metrics value: endpoint required")
+			}
+			continue
+		}
+		if arg == "--otel-metrics" {
+			cfg.trendsMetricsOut = true
+			continue
+		}
+		if strings.HasPrefix(arg, "--timezone=") {
+			loc, err := time.LoadLocation(strings.TrimPrefix(arg, "--timezone="))
+			if err != nil {
+				return cfg, fmt.Errorf("invalid --timezone value: %w", err)
+			}
+			cfg.trendsTimezone = loc
+			continue
+		}
+		if strings.HasPrefix(arg, "--group-by=") {
+			cfg.trendsGroupBy = nil
+			for _, dim := range strings.Split(strings.TrimPrefix(arg, "--group-by="), ",") {
+				dim = strings.TrimSpace(dim)
+				if !slices.Contains(analyzer.TrendGroupings, dim) {
+					return cfg, fmt.Errorf("invalid --group-by value: %s (must be one of: %s)", dim, strings.Join(analyzer.TrendGroupings, ", "))
+				}
+				if !slices.Contains(cfg.trendsGroupBy, dim) {
+					cfg.trendsGroupBy = append(cfg.trendsGroupBy, dim)
+				}
+			}
+			continue
+		}
 
-		// For trends mode, first non-flag arg is the repo
-		if cfg.trendsMode && cfg.trendsRepo == "" && !strings.HasPrefix(arg, "-") {
+		// For trends and lifecycle mode, first non-flag arg is the repo
+		if (cfg.trendsMode || cfg.lifecycleMode) && cfg.trendsRepo == "" && !strings.HasPrefix(arg, "-") {
 			cfg.trendsRepo = arg
 			continue
 		}
@@ -323,6 +506,10 @@ func parseArgs(args []string, terminal bool) (config, error) {
 		cfg.urls = append(cfg.urls, arg)
 	}
 
+	if cfg.trendsReposFile != "" && cfg.trendsRepo != "" {
+		return cfg, fmt.Errorf("--repos-file replaces the repository argument, pass one or the other")
+	}
+
 	return cfg, nil
 }
 
@@ -348,15 +535,19 @@ func main() {
 			os.Exit(1)
 		}
 		fmt.Fprintf(os.Stderr, "Cache cleared: %s\n", cacheDir)
-		if len(args) == 0 && !cfg.trendsMode {
+		if len(args) == 0 && !cfg.trendsMode && !cfg.lifecycleMode {
 			os.Exit(0)
 		}
 	}
 
 	// Handle trends mode
 	if cfg.trendsMode {
+		if cfg.trendsReposFile != "" || strings.HasPrefix(cfg.trendsRepo, analyzer.OrgPrefix) {
+			runOrgTrends(cfg)
+			return
+		}
 		if cfg.trendsRepo == "" {
-			printErrorMsg("Trends mode requires a repository in format 'owner/repo'\n\n  Usage: otel-explorer trends owner/repo [--days=30] [--format=terminal|json]\n\n  Run 'otel-explorer --help' for more information.")
+			printErrorMsg("Trends mode requires a repository in format 'owner/repo', or 'org:<owner>'\n\n  Usage: otel-explorer trends owner/repo [--days=30] [--format=terminal|json]\n\n  Run 'otel-explorer --help' for more information.")
 			os.Exit(1)
 		}
 
@@ -383,11 +574,7 @@ func main() {
 		progress.StartURL(0, cfg.trendsRepo)
 
 		// Perform trend analysis
-		analysis, err := analyzer.AnalyzeTrends(ctx, client, owner, repo, cfg.trendsDays, cfg.trendsBranch, cfg.trendsWorkflow, analyzer.TrendOptions{
-			NoSample:      cfg.trendsNoSample,
-			Confidence:    cfg.trendsConfidence,
-			MarginOfError: cfg.trendsMargin,
-		}, progress)
+		analysis, runs, err := analyzer.AnalyzeTrendsWithRuns(ctx, client, owner, repo, cfg.trendsDays, cfg.trendsBranch, cfg.trendsWorkflow, trendOptions(cfg), progress)
 
 		progress.Finish()
 		progress.Wait()
@@ -403,6 +590,50 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err := exportTrendMetrics(ctx, cfg, analysis, runs); err != nil {
+			printError(err, "metrics export failed")
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	// Handle lifecycle mode
+	if cfg.lifecycleMode {
+		owner, repo, ok := strings.Cut(cfg.trendsRepo, "/")
+		if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			printErrorMsg("Lifecycle mode requires a repository in format 'owner/repo'\n\n  Usage: otel-explorer lifecycle owner/repo [--days=30] [--branch=<base>] [--format=terminal|json]\n\n  Run 'otel-explorer --help' for more information.")
+			os.Exit(1)
+		}
+
+		token := resolveGitHubToken()
+		if token == "" {
+			printErrorMsg("GITHUB_TOKEN environment variable is required.\n  Tip: install the GitHub CLI (gh) and run `gh auth login` to authenticate automatically.")
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		client := githubapi.NewClient(githubapi.NewContext(token))
+
+		progress := tui.NewProgress(1, os.Stderr)
+		progress.Start()
+		progress.StartURL(0, cfg.trendsRepo)
+
+		analysis, err := analyzer.AnalyzeLifecycle(ctx, client, owner, repo, cfg.trendsDays, cfg.trendsBranch, progress)
+
+		progress.Finish()
+		progress.Wait()
+
+		if err != nil {
+			printError(err, "lifecycle analysis failed")
+			os.Exit(1)
+		}
+
+		if err := output.OutputLifecycle(os.Stderr, analysis, cfg.trendsFormat); err != nil {
+			printError(err, "output failed")
+			os.Exit(1)
+		}
+
 		return
 	}
 
@@ -499,9 +730,14 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Starting OTLP/HTTP receiver on %s...\n", cfg.listenAddr)
 		fmt.Fprintf(os.Stderr, "  POST traces to http://localhost%s/v1/traces\n", cfg.listenAddr)
 		fmt.Fprintf(os.Stderr, "  Set OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost%s in your app\n", cfg.listenAddr)
+		fmt.Fprintf(os.Stderr, "  Scrape span metrics from http://localhost%s/metrics\n", cfg.listenAddr)
 		fmt.Fprintf(os.Stderr, "  Press Ctrl+C to stop and analyze collected spans\n")
 
 		recv := receiver.New(cfg.listenAddr)
+		if err := recv.SetMetricsOptions(receiver.MetricsOptions{Dimensions: cfg.metricsDims, Buckets: cfg.metricsBuckets}); err != nil {
+			printError(err, "invalid metrics options")
+			os.Exit(1)
+		}
 		ctx, cancel := context.WithCancel(ctx)
 
 		errCh := make(chan error, 1)
@@ -695,14 +931,17 @@ func main() {
 	var results []analyzer.URLResult
 	var globalEarliest, globalLatest int64
 	var ghaSpans []sdktrace.ReadOnlySpan
+	var baseline *analyzer.Baseline
 	if len(args) > 0 {
 		client := githubapi.NewClient(githubapi.NewContext(token))
 		progress := tui.NewProgress(len(args), os.Stderr)
 		progress.Start()
 
 		ingestor := polling.NewPollingIngestor(client, args, progress, analyzer.AnalyzeOptions{
-			Window:      cfg.window,
-			NoArtifacts: cfg.noArtifacts,
+			Window:         cfg.window,
+			NoArtifacts:    cfg.noArtifacts,
+			CompositeSteps: cfg.compositeSteps,
+			ApprovalWaits:  cfg.approvalWaits,
 		})
 		var err error
 		results, globalEarliest, globalLatest, ghaSpans, err = ingestor.Ingest(ctx)
@@ -714,6 +953,11 @@ func main() {
 			printError(err, "ingestion failed")
 			os.Exit(1)
 		}
+
+		if cfg.baselineBranch != "" {
+			baseline = fetchBaseline(ctx, client, cfg, ghaSpans)
+			ghaSpans = analyzer.AnnotateBaseline(ghaSpans, baseline)
+		}
 	}
 
 	// 6. Combine all spans
@@ -819,7 +1063,8 @@ func main() {
 					return nil, time.Time{}, time.Time{}, err
 				}
 
-				allSpans = append(allSpans, reloadGHASpans...)
+				// The baseline history is reused; only the new runs are re-ranked
+				allSpans = append(allSpans, analyzer.AnnotateBaseline(reloadGHASpans, baseline)...)
 				if reloadEarliest == 0 || ghaEarliest < reloadEarliest {
 					reloadEarliest = ghaEarliest
 				}
@@ -866,7 +1111,15 @@ func main() {
 			inputSources = append(inputSources, filepath.Base(tf))
 		}
 
-		if err := tuiresults.Run(spans, globalStartTime, globalEndTime, inputSources, reloadFunc, openPerfettoFunc, enricher); err != nil {
+		// Sessions are keyed by the GitHub URLs plus, for trace files and
+		// backends, the trace IDs loaded from them
+		var sessionTraceIDs []string
+		if len(cfg.traceFiles) > 0 || hasTraceBackend {
+			sessionTraceIDs = distinctTraceIDs(spans)
+		}
+		session := tuiresults.WithSession(tuiresults.NewSessionStore(), tuiresults.SessionKey(args, sessionTraceIDs))
+
+		if err := tuiresults.Run(spans, globalStartTime, globalEndTime, inputSources, reloadFunc, openPerfettoFunc, enricher, session); err != nil {
 			fmt.Fprintf(os.Stderr, "%sError: TUI failed: %v%s\n", colorRed, err, colorReset)
 			os.Exit(1)
 		}
@@ -883,6 +1136,29 @@ func main() {
 	switch cfg.outputFormat {
 	case "markdown":
 		output.OutputCombinedResultsMarkdown(os.Stdout, results, combined, allTraceEvents, globalEarliest, globalLatest, perfettoFile, cfg.openInPerfetto, spans, enricher)
+	case "json":
+		if err := output.OutputJSON(os.Stdout, results, combined, globalEarliest, globalLatest, spans, enricher); err != nil {
+			printError(err, "output failed")
+			os.Exit(1)
+		}
+	case "aggregate":
+		roots := analyzer.BuildTreeFromSpans(spans, time.UnixMilli(globalEarliest), time.UnixMilli(globalLatest), enricher)
+		groups := analyzer.AggregateSpans(roots, cfg.aggregateBy)
+		if err := analyzer.SortSpanGroups(groups, cfg.aggregateSort); err != nil {
+			printError(err, "sorting aggregate failed")
+			os.Exit(1)
+		}
+		if err := output.OutputAggregate(os.Stdout, groups, cfg.aggregateBy, cfg.aggregateSort); err != nil {
+			printError(err, "output failed")
+			os.Exit(1)
+		}
+	case "runners":
+		start, end := time.UnixMilli(globalEarliest), time.UnixMilli(globalLatest)
+		roots := analyzer.BuildTreeFromSpans(spans, start, end, enricher)
+		if err := output.OutputRunners(os.Stdout, analyzer.RunnerLanes(roots, cfg.runnersBy), cfg.runnersBy, start, end); err != nil {
+			printError(err, "output failed")
+			os.Exit(1)
+		}
 	default:
 		output.OutputStyledResults(os.Stderr, results, combined, allTraceEvents, globalEarliest, globalLatest, spans, enricher)
 		// Handle perfetto export for styled output
@@ -909,6 +1185,38 @@ func sumRuns(results []analyzer.URLResult) int {
 	return total
 }
 
+// fetchBaseline collects the baseline history for every workflow found in
+// spans. Failures are reported but never fatal: the analysis is still useful
+// without percentile ranks.
+func fetchBaseline(ctx context.Context, client githubapi.GitHubProvider, cfg config, spans []sdktrace.ReadOnlySpan) *analyzer.Baseline {
+	baseline := analyzer.NewBaseline(cfg.baselineBranch, cfg.baselineDays)
+	for repo, paths := range analyzer.BaselineWorkflows(spans) {
+		owner, name, ok := strings.Cut(repo, "/")
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Fetching baseline for %s (%s, last %d days)...\n", repo, baseline.Branch, baseline.Days)
+		if err := baseline.Fetch(ctx, client, owner, name, paths, nil); err != nil {
+			printError(err, fmt.Sprintf("baseline for %s unavailable", repo))
+		}
+	}
+	return baseline
+}
+
+// distinctTraceIDs returns the trace IDs of spans, each once.
+func distinctTraceIDs(spans []sdktrace.ReadOnlySpan) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, s := range spans {
+		id := s.SpanContext().TraceID().String()
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func collectStarts(results []analyzer.URLResult) []analyzer.JobEvent {
 	var events []analyzer.JobEvent
 	for _, result := range results {
@@ -935,6 +1243,113 @@ func tagSpansWithIndex(spans []sdktrace.ReadOnlySpan, urlIndex int) []sdktrace.R
 	return stubs.Snapshots()
 }
 
+// trendOptions returns the trend analysis options of the trends flags.
+func trendOptions(cfg config) analyzer.TrendOptions {
+	return analyzer.TrendOptions{
+		NoSample:      cfg.trendsNoSample,
+		Confidence:    cfg.trendsConfidence,
+		MarginOfError: cfg.trendsMargin,
+
+		SimulateRunners:   cfg.trendsSimulate,
+		SimulationPolicy:  cfg.trendsPolicy,
+		RunnerCostPerHour: cfg.trendsRunnerCost,
+
+		GroupBy:       cfg.trendsGroupBy,
+		Timezone:      cfg.trendsTimezone,
+		FailureLogs:   cfg.trendsFailLogs,
+		ApprovalWaits: cfg.approvalWaits,
+
+		JobNameRules:        cfg.trendsJobRules,
+		CollapseMatrix:      cfg.trendsCollapse,
+		DefaultJobNameRules: cfg.trendsJobDefault,
+	}
+}
+
+// exportTrendMetrics exports the trends as OTLP metrics when --otel-metrics
+// is given.
+func exportTrendMetrics(ctx context.Context, cfg config, analysis *analyzer.TrendAnalysis, runs []analyzer.RunData) error {
+	var exporter *otelexport.MetricsExporter
+	switch {
+	case cfg.trendsMetricsOut:
+		exporter = otelexport.NewStdoutMetricsExporter(os.Stdout)
+	case cfg.trendsMetricsURL != "":
+		var err error
+		if exporter, err = otelexport.NewMetricsExporter(cfg.trendsMetricsURL); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exporting trend metrics to %s...\n", cfg.trendsMetricsURL)
+	default:
+		return nil
+	}
+	return exporter.Export(ctx, otelexport.TrendMetrics(analysis, runs))
+}
+
+// runOrgTrends analyzes the trends of the repositories of an organization
+// ("org:<owner>") or of the --repos-file list and prints the org report.
+func runOrgTrends(cfg config) {
+	if cfg.trendsMetricsOut || cfg.trendsMetricsURL != "" {
+		printErrorMsg("--otel-metrics exports the trends of one repository; it can't be combined with org trends")
+		os.Exit(1)
+	}
+
+	token := resolveGitHubToken()
+	if token == "" {
+		printErrorMsg("GITHUB_TOKEN environment variable is required.\n  Tip: install the GitHub CLI (gh) and run `gh auth login` to authenticate automatically.")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	client := githubapi.NewClient(githubapi.NewContext(token))
+
+	org := strings.TrimPrefix(cfg.trendsRepo, analyzer.OrgPrefix)
+	var repos []string
+	if cfg.trendsReposFile != "" {
+		data, err := os.ReadFile(cfg.trendsReposFile)
+		if err != nil {
+			printError(err, "failed to read --repos-file")
+			os.Exit(1)
+		}
+		if repos, err = analyzer.ParseRepoList(string(data)); err != nil {
+			printError(err, "invalid --repos-file")
+			os.Exit(1)
+		}
+	} else {
+		if org == "" || strings.Contains(org, "/") {
+			printErrorMsg(fmt.Sprintf("Invalid organization: %s (expected 'org:<owner>')", cfg.trendsRepo))
+			os.Exit(1)
+		}
+		since := time.Now().AddDate(0, 0, -cfg.trendsDays)
+		var err error
+		if repos, err = analyzer.ListOrgRepos(ctx, client, org, since); err != nil {
+			printError(err, "org trend analysis failed")
+			os.Exit(1)
+		}
+	}
+
+	progress := tui.NewProgress(1, os.Stderr)
+	progress.Start()
+	label := cfg.trendsRepo
+	if cfg.trendsReposFile != "" {
+		label = cfg.trendsReposFile
+	}
+	progress.StartURL(0, label)
+
+	analysis, err := analyzer.AnalyzeOrgTrends(ctx, client, org, repos, cfg.trendsDays, cfg.trendsBranch, cfg.trendsWorkflow, trendOptions(cfg), cfg.trendsRepoConc, progress)
+
+	progress.Finish()
+	progress.Wait()
+
+	if err != nil {
+		printError(err, "org trend analysis failed")
+		os.Exit(1)
+	}
+
+	if err := output.OutputOrgTrends(os.Stderr, analysis, cfg.trendsFormat); err != nil {
+		printError(err, "output failed")
+		os.Exit(1)
+	}
+}
+
 func printUsage() {
 	fmt.Println("OTel Analyzer")
 	fmt.Println("\nUsage:")
@@ -942,10 +1357,17 @@ func printUsage() {
 	fmt.Println("  otel-explorer <trace_file.json> [flags]")
 	fmt.Println("  otel-explorer convert <file1> [file2...] [flags]")
 	fmt.Println("  otel-explorer trends <owner/repo> [flags]")
+	fmt.Println("  otel-explorer trends org:<owner> [flags]")
+	fmt.Println("  otel-explorer trends --repos-file=<path> [flags]")
+	fmt.Println("  otel-explorer lifecycle <owner/repo> [flags]")
 	fmt.Println("\nFlags:")
 	fmt.Println("  --tui                     Force interactive TUI mode (default when terminal is available)")
 	fmt.Println("  --no-tui                  Disable interactive TUI, use CLI output instead")
-	fmt.Println("  --output=<format>         Output format: 'stdout' (styled terminal) or 'markdown' (implies --no-tui)")
+	fmt.Println("  --output=<format>         Output format: 'stdout' (styled terminal), 'markdown', 'json', 'aggregate', or 'runners' (implies --no-tui)")
+	fmt.Println("  --aggregate-by=<key>      Group spans by 'name' (default) or an attribute key (e.g. http.route, db.statement)")
+	fmt.Println("  --aggregate-sort=<col>    Sort aggregate by total, self, count, p50, p95, max, errors, or key (default: total)")
+	fmt.Println("  --runners-by=<key>        Runner swimlanes per 'runner' (default), runner 'group', or runs-on 'label' set")
+	fmt.Println("  --baseline=<branch>[:days] Rank workflows and jobs against recent successful runs on <branch> (default: 14 days)")
 	fmt.Println("  --perfetto=<file.pftrace> Save trace for Perfetto.dev analysis")
 	fmt.Println("  --open-in-perfetto        Automatically open the generated trace in Perfetto UI")
 	fmt.Println("  --otel                    Write OTel spans as JSON to stdout")
@@ -958,9 +1380,13 @@ func printUsage() {
 	fmt.Println("  --jaeger=<baseURL>        Fetch traces from Jaeger v2 (e.g., http://localhost:16686)")
 	fmt.Println("  --trace-id=<id>           Trace ID to fetch from Tempo/Jaeger (can be repeated)")
 	fmt.Println("  --no-artifacts            Skip downloading and ingesting trace artifacts from workflow runs")
+	fmt.Println("  --composite-steps         Fetch job logs to show the inner steps of composite actions")
+	fmt.Println("  --approval-waits          Look up deployments to split environment approval waits out of queue time (also in trends)")
 	fmt.Println("  --filter=<expr>           Filter spans by attributes (e.g., 'service.name=checkout,http.status_code=5*')")
 	fmt.Println("  --errors-only             Only show spans with ERROR status")
 	fmt.Println("  --listen[=<addr>]         Start OTLP/HTTP receiver (default: :4318)")
+	fmt.Println("  --metrics-dimensions=<attrs> Attributes the receiver's /metrics are broken down by (default: service.name,span.name,cicd.pipeline.task.name)")
+	fmt.Println("  --metrics-buckets=<secs>  Duration histogram bucket boundaries of /metrics, in seconds (comma separated)")
 	fmt.Println("  --enrichment=<file>       Load custom enrichment rules from a JSON file")
 	fmt.Println("  --lint                    Analyze spans for OTel semantic convention compliance")
 	fmt.Println("  --clear-cache             Clear the HTTP cache (can be combined with other flags)")
@@ -971,8 +1397,24 @@ func printUsage() {
 	fmt.Println("  --branch=<name>           Filter by branch name (e.g., main, master)")
 	fmt.Println("  --workflow=<file>         Filter by workflow file name (e.g., post-merge.yaml)")
 	fmt.Println("  --no-sample               Fetch job details for all runs (disables statistical sampling)")
-	fmt.Println("  --confidence=<0-1>        Confidence level for sampling (default: 0.95)")
+	fmt.Println("  --confidence=<0-1>        Confidence level for sampling, intervals and significance (default: 0.95)")
 	fmt.Println("  --margin=<0-1>            Margin of error for sampling (default: 0.10)")
+	fmt.Println("  --simulate-runners=<label:N,...> Replay jobs against pools of N runners per runs-on label ('*' for all jobs)")
+	fmt.Println("  --simulate-policy=<name>  Scheduling policy for the replay: fifo (default) or shortest")
+	fmt.Println("  --runner-cost=<price>     Price of a runner-hour, to report simulated pool cost")
+	fmt.Println("  --group-by=<dims>         Break trends down by event, os and/or actor-type (comma separated)")
+	fmt.Println("  --timezone=<name>         Timezone of the queue heatmap hours, e.g. America/New_York (default: UTC)")
+	fmt.Println("  --failure-logs            Also read failed job logs for failure signatures (more API calls)")
+	fmt.Println("  --job-name-rule=<p=>r>    Rewrite job names matching regexp p to r, to track dynamic names as one job (repeatable)")
+	fmt.Println("  --collapse-matrix         Track the jobs of a matrix as one job, e.g. 'test (*)'")
+	fmt.Println("  --default-job-name-rules  Also track jobs whose names differ only by a date or PR number as one job, and follow renamed jobs")
+	fmt.Println("  --repos-file=<path>       Org trends over the repositories listed in a file, one owner/repo per line")
+	fmt.Println("  --repo-concurrency=<n>    Repositories analyzed at once in org trends (default: 4)")
+	fmt.Println("  --otel-metrics            Write duration, success, queue time and flake metrics as OTLP JSON to stdout")
+	fmt.Println("  --otel-metrics=<endpoint> Export them via OTLP/HTTP (default port: 4318)")
+	fmt.Println("\nLifecycle Mode:")
+	fmt.Println("  Review, approval, CI wait and lead-time distributions of recently merged PRs.")
+	fmt.Println("  Accepts --days, --format and --branch (base branch) from the trends flags.")
 	fmt.Println("\nConvert Mode:")
 	fmt.Println("  Converts any supported trace format to OTel JSON on stdout.")
 	fmt.Println("  Supported formats: Chrome Tracing, Jaeger, Zipkin, OTLP proto-JSON, stdouttrace, binary protobuf.")
@@ -985,9 +1427,18 @@ func printUsage() {
 	fmt.Println("  otel-explorer https://github.com/owner/repo/pull/123 --no-tui")
 	fmt.Println("  otel-explorer https://github.com/owner/repo/pull/123 --output=stdout")
 	fmt.Println("  otel-explorer https://github.com/owner/repo/pull/123 --output=markdown > report.md")
+	fmt.Println("  otel-explorer https://github.com/owner/repo/pull/123 --output=json > report.json")
+	fmt.Println("  otel-explorer https://github.com/owner/repo/pull/123 --baseline=main:30")
+	fmt.Println("  otel-explorer trace.json --output=aggregate --aggregate-by=http.route --aggregate-sort=p95")
+	fmt.Println("  otel-explorer https://github.com/owner/repo/pull/123 --output=runners --runners-by=label")
 	fmt.Println("  otel-explorer trends owner/repo")
 	fmt.Println("  otel-explorer trends owner/repo --days=7 --format=json")
 	fmt.Println("  otel-explorer trends owner/repo --branch=main --workflow=post-merge.yaml")
+	fmt.Println("  otel-explorer trends owner/repo --no-sample --simulate-runners=self-hosted:4,self-hosted:8")
+	fmt.Println("  otel-explorer trends owner/repo --group-by=event,actor-type")
+	fmt.Println("  otel-explorer trends owner/repo --collapse-matrix --job-name-rule='preview-.+=>preview'")
+	fmt.Println("  otel-explorer trends org:my-org --days=14 --repo-concurrency=8")
+	fmt.Println("  otel-explorer lifecycle owner/repo --days=30")
 	fmt.Println("  otel-explorer trace.json                      # auto-detects OTel or Chrome Tracing format")
 	fmt.Println("  otel-explorer chrome-profile.json spans.json   # multiple trace files as args")
 	fmt.Println("  otel-explorer --trace=spans.json https://github.com/owner/repo/pull/123")
@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// AggregateByName groups spans by their span name rather than an attribute.
+const AggregateByName = "name"
+
+// AggregateSortFields lists the columns a span aggregation can be sorted by.
+var AggregateSortFields = []string{"total", "self", "count", "p50", "p95", "max", "errors", "key"}
+
+// SpanGroup summarizes every span that shares a grouping key.
+type SpanGroup struct {
+	Key    string
+	Count  int
+	Errors int
+	Total  time.Duration
+	Self   time.Duration
+	P50    time.Duration
+	P95    time.Duration
+	Max    time.Duration
+	Nodes  []*TreeNode // individual spans, longest first
+}
+
+// ErrorRate returns the percentage of spans in the group that failed.
+func (g SpanGroup) ErrorRate() float64 {
+	if g.Count == 0 {
+		return 0
+	}
+	return float64(g.Errors) * 100 / float64(g.Count)
+}
+
+// AggregateSpans walks the span tree and groups nodes by name or by the value
+// of an attribute key (e.g. http.route, db.statement, cicd.pipeline.task.name).
+// Spans without the attribute and zero-width markers are left out. Groups are
+// returned sorted by total time, heaviest first.
+func AggregateSpans(roots []*TreeNode, groupBy string) []SpanGroup {
+	if groupBy == "" {
+		groupBy = AggregateByName
+	}
+
+	byKey := make(map[string]*SpanGroup)
+	var order []string
+	var walk func(nodes []*TreeNode)
+	walk = func(nodes []*TreeNode) {
+		for _, n := range nodes {
+			walk(n.Children)
+			if n.Hints.IsMarker {
+				continue
+			}
+			key := n.Name
+			if groupBy != AggregateByName {
+				key = n.Attrs[groupBy]
+			}
+			if key == "" {
+				continue
+			}
+			g, ok := byKey[key]
+			if !ok {
+				g = &SpanGroup{Key: key}
+				byKey[key] = g
+				order = append(order, key)
+			}
+			g.Nodes = append(g.Nodes, n)
+		}
+	}
+	walk(roots)
+
+	groups := make([]SpanGroup, 0, len(order))
+	for _, key := range order {
+		g := byKey[key]
+		sort.SliceStable(g.Nodes, func(i, j int) bool {
+			return g.Nodes[i].Duration() > g.Nodes[j].Duration()
+		})
+		durations := make([]float64, 0, len(g.Nodes))
+		for _, n := range g.Nodes {
+			d := n.Duration()
+			if d < 0 {
+				d = 0
+			}
+			g.Count++
+			g.Total += d
+			g.Self += n.SelfTime()
+			if n.Hints.Outcome == "failure" {
+				g.Errors++
+			}
+			if d > g.Max {
+				g.Max = d
+			}
+			durations = append(durations, float64(d))
+		}
+		g.P50 = time.Duration(calculatePercentile(durations, 50))
+		g.P95 = time.Duration(calculatePercentile(durations, 95))
+		groups = append(groups, *g)
+	}
+
+	SortSpanGroups(groups, "total")
+	return groups
+}
+
+// SortSpanGroups orders groups by one of AggregateSortFields. Numeric columns
+// sort descending; "key" sorts alphabetically.
+func SortSpanGroups(groups []SpanGroup, field string) error {
+	var value func(g SpanGroup) float64
+	switch field {
+	case "total", "":
+		value = func(g SpanGroup) float64 { return float64(g.Total) }
+	case "self":
+		value = func(g SpanGroup) float64 { return float64(g.Self) }
+	case "count":
+		value = func(g SpanGroup) float64 { return float64(g.Count) }
+	case "p50":
+		value = func(g SpanGroup) float64 { return float64(g.P50) }
+	case "p95":
+		value = func(g SpanGroup) float64 { return float64(g.P95) }
+	case "max":
+		value = func(g SpanGroup) float64 { return float64(g.Max) }
+	case "errors":
+		value = func(g SpanGroup) float64 { return g.ErrorRate() }
+	case "key":
+		sort.SliceStable(groups, func(i, j int) bool { return groups[i].Key < groups[j].Key })
+		return nil
+	default:
+		return errors.Newf("invalid sort field %q (must be one of: %s)", field, strings.Join(AggregateSortFields, ", "))
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := value(groups[i]), value(groups[j])
+		if a != b {
+			return a > b
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return nil
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+func aggregateTestRoots() []*TreeNode {
+	ok := enrichment.SpanHints{Category: "span", Outcome: "success"}
+	failed := enrichment.SpanHints{Category: "span", Outcome: "failure"}
+	users := map[string]string{"http.route": "/users/:id"}
+	return []*TreeNode{
+		makeTreeNode("GET", ok, users, 0, 100*time.Millisecond,
+			makeTreeNode("SELECT", ok, nil, 10*time.Millisecond, 50*time.Millisecond),
+			makeTreeNode("SELECT", ok, nil, 60*time.Millisecond, 80*time.Millisecond),
+		),
+		makeTreeNode("GET", failed, users, time.Second, 1300*time.Millisecond),
+		makeTreeNode("POST", ok, map[string]string{"http.route": "/orders"}, 2*time.Second, 2050*time.Millisecond,
+			makeTreeNode("deployed", enrichment.SpanHints{Category: "marker", IsMarker: true}, nil, 2*time.Second, 2*time.Second),
+		),
+	}
+}
+
+func TestAggregateSpans(t *testing.T) {
+	t.Parallel()
+
+	t.Run("groups by name", func(t *testing.T) {
+		groups := AggregateSpans(aggregateTestRoots(), "")
+		assert.Len(t, groups, 3)
+
+		get := groups[0]
+		assert.Equal(t, "GET", get.Key)
+		assert.Equal(t, 2, get.Count)
+		assert.Equal(t, 400*time.Millisecond, get.Total)
+		// 100ms - 60ms of SELECT children + 300ms without children
+		assert.Equal(t, 340*time.Millisecond, get.Self)
+		assert.Equal(t, 100*time.Millisecond, get.P50)
+		assert.Equal(t, 300*time.Millisecond, get.P95)
+		assert.Equal(t, 300*time.Millisecond, get.Max)
+		assert.Equal(t, 1, get.Errors)
+		assert.InDelta(t, 50.0, get.ErrorRate(), 0.001)
+		assert.Equal(t, 300*time.Millisecond, get.Nodes[0].Duration(), "nodes are sorted longest first")
+	})
+
+	t.Run("groups by attribute and skips spans without it", func(t *testing.T) {
+		groups := AggregateSpans(aggregateTestRoots(), "http.route")
+		assert.Len(t, groups, 2)
+		assert.Equal(t, "/users/:id", groups[0].Key)
+		assert.Equal(t, "/orders", groups[1].Key)
+	})
+
+	t.Run("skips markers", func(t *testing.T) {
+		for _, g := range AggregateSpans(aggregateTestRoots(), AggregateByName) {
+			assert.NotEqual(t, "deployed", g.Key)
+		}
+	})
+}
+
+func TestSortSpanGroups(t *testing.T) {
+	t.Parallel()
+
+	groups := AggregateSpans(aggregateTestRoots(), AggregateByName)
+
+	assert.NoError(t, SortSpanGroups(groups, "count"))
+	assert.Equal(t, "GET", groups[0].Key)
+	assert.Equal(t, "SELECT", groups[1].Key)
+
+	assert.NoError(t, SortSpanGroups(groups, "key"))
+	assert.Equal(t, []string{"GET", "POST", "SELECT"}, []string{groups[0].Key, groups[1].Key, groups[2].Key})
+
+	assert.NoError(t, SortSpanGroups(groups, "errors"))
+	assert.Equal(t, "GET", groups[0].Key)
+
+	assert.Error(t, SortSpanGroups(groups, "bogus"))
+}
+
+func TestTreeNodeSelfTime(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	node := &TreeNode{
+		Name:      "parent",
+		StartTime: base,
+		EndTime:   base.Add(10 * time.Second),
+		Children: []*TreeNode{
+			{Name: "a", StartTime: base.Add(1 * time.Second), EndTime: base.Add(4 * time.Second)},
+			{Name: "b", StartTime: base.Add(3 * time.Second), EndTime: base.Add(6 * time.Second)},
+			{Name: "c", StartTime: base.Add(8 * time.Second), EndTime: base.Add(12 * time.Second)},
+		},
+	}
+	// covered: [1,6] + [8,10] = 7s
+	assert.Equal(t, 3*time.Second, node.SelfTime())
+
+	leaf := &TreeNode{StartTime: base, EndTime: base.Add(time.Second)}
+	assert.Equal(t, time.Second, leaf.SelfTime())
+}
@@ -62,8 +62,10 @@ func (e URLError) Error() string {
 }
 
 type AnalyzeOptions struct {
-	Window      time.Duration
-	NoArtifacts bool
+	Window         time.Duration
+	NoArtifacts    bool
+	CompositeSteps bool // fetch job logs to break composite actions into their steps
+	ApprovalWaits  bool // fetch deployments to find environment approval waits
 }
 
 func AnalyzeURLs(ctx context.Context, urls []string, client githubapi.GitHubProvider, reporter ProgressReporter, opts AnalyzeOptions) ([]URLResult, []TraceEvent, int64, int64, []sdktrace.ReadOnlySpan, []URLError) {
@@ -95,6 +97,8 @@ func AnalyzeURLs(ctx context.Context, urls []string, client githubapi.GitHubProv
 
 		// Emit marker spans for review/merge events
 		emitter.EmitMarkers(rawData, urlIndex)
+		// Emit spans for CI reported outside GitHub Actions
+		checksEarliest, checksLatest := emitter.EmitChecks(rawData, urlIndex)
 
 		// Calculate urlEarliestTime here to ensure it's consistent
 		urlEarliestTime := FindEarliestTimestamp(rawData.Runs)
@@ -110,8 +114,11 @@ func AnalyzeURLs(ctx context.Context, urls []string, client githubapi.GitHubProv
 				urlEarliestTime = ms
 			}
 		}
+		if checksEarliest != 0 && checksEarliest < urlEarliestTime {
+			urlEarliestTime = checksEarliest
+		}
 
-		result, err := buildURLResult(ctx, rawData.Parsed, urlIndex, rawData.HeadSHA, rawData.BranchName, rawData.DisplayName, rawData.DisplayURL, rawData.ReviewEvents, rawData.MergedAtMs, rawData.CommitTimeMs, rawData.CommitPushedAtMs, rawData.AllCommitRunsCount, rawData.AllCommitRunsComputeMs, rawData.Runs, rawData.RequiredContexts, rawData.ChangedFilesCount, rawData.ChangedAdditions, rawData.ChangedDeletions, client, reporter, urlEarliestTime, builder, emitter, opts)
+		result, err := buildURLResult(ctx, rawData.Parsed, urlIndex, rawData.HeadSHA, rawData.BranchName, rawData.DisplayName, rawData.DisplayURL, rawData.ReviewEvents, rawData.MergedAtMs, rawData.CommitTimeMs, rawData.CommitPushedAtMs, rawData.AllCommitRunsCount, rawData.AllCommitRunsComputeMs, rawData.Runs, rawData.RequiredContexts, rawData.ChangedFilesCount, rawData.ChangedAdditions, rawData.ChangedDeletions, client, reporter, urlEarliestTime, builder, emitter, opts, rawData.ApprovalWaits)
 		if err != nil {
 			urlErrors = append(urlErrors, URLError{URL: githubURL, Err: err})
 			continue
@@ -119,6 +126,10 @@ func AnalyzeURLs(ctx context.Context, urls []string, client githubapi.GitHubProv
 		if result == nil {
 			continue
 		}
+		result.OpenedAtMs = rawData.OpenedAtMs
+		result.FirstCommitMs = rawData.FirstCommitMs
+		result.Metrics.Waste.Add(rawData.SupersededWaste)
+		result.MergeGate = rawData.MergeGate
 		urlResults = append(urlResults, *result)
 		allTraceEvents = append(allTraceEvents, result.TraceEvents...)
 		allJobStartTimes = append(allJobStartTimes, result.JobStartTimes...)
@@ -140,6 +151,9 @@ func AnalyzeURLs(ctx context.Context, urls []string, client githubapi.GitHubProv
 				urlLatest = ms
 			}
 		}
+		if checksLatest > urlLatest {
+			urlLatest = checksLatest
+		}
 		if urlLatest > globalLatestTime {
 			globalLatestTime = urlLatest
 		}
@@ -161,7 +175,7 @@ func AnalyzeURLs(ctx context.Context, urls []string, client githubapi.GitHubProv
 	return urlResults, allTraceEvents, globalEarliestTime, globalLatestTime, builder.Spans(), urlErrors
 }
 
-func buildURLResult(ctx context.Context, parsed utils.ParsedGitHubURL, urlIndex int, headSHA, branchName, displayName, displayURL string, reviewEvents []ReviewEvent, mergedAtMs, commitTimeMs, commitPushedAtMs *int64, allCommitRunsCount int, allCommitRunsComputeMs int64, runs []githubapi.WorkflowRun, requiredContexts []string, changedFilesCount, changedAdditions, changedDeletions int, client githubapi.GitHubProvider, reporter ProgressReporter, urlEarliestTime int64, builder *SpanBuilder, emitter *TraceEmitter, opts AnalyzeOptions) (*URLResult, error) {
+func buildURLResult(ctx context.Context, parsed utils.ParsedGitHubURL, urlIndex int, headSHA, branchName, displayName, displayURL string, reviewEvents []ReviewEvent, mergedAtMs, commitTimeMs, commitPushedAtMs *int64, allCommitRunsCount int, allCommitRunsComputeMs int64, runs []githubapi.WorkflowRun, requiredContexts []string, changedFilesCount, changedAdditions, changedDeletions int, client githubapi.GitHubProvider, reporter ProgressReporter, urlEarliestTime int64, builder *SpanBuilder, emitter *TraceEmitter, opts AnalyzeOptions, approvalWaits []ApprovalWait) (*URLResult, error) {
 	if reporter != nil {
 		reporter.SetURLRuns(len(runs))
 		reporter.SetPhase("Processing workflow runs")
@@ -171,6 +185,15 @@ func buildURLResult(ctx context.Context, parsed utils.ParsedGitHubURL, urlIndex
 	traceEvents := []TraceEvent{}
 	jobStartTimes := []JobEvent{}
 	jobEndTimes := []JobEvent{}
+
+	// Runs triggered through workflow_run are linked to the run that triggered them
+	var commitMs int64
+	if commitTimeMs != nil {
+		commitMs = *commitTimeMs
+	}
+	chains := BuildWorkflowChains(runs, commitMs)
+	members := chainMembers(chains)
+	superseded := SupersededRuns(runs)
 	
 	type runResult struct {
 		metrics     Metrics
@@ -198,7 +221,11 @@ func buildURLResult(ctx context.Context, parsed utils.ParsedGitHubURL, urlIndex
 			defer wg.Done()
 			for job := range jobsCh {
 				processID := (urlIndex+1)*1000 + job.index + 1
-				runMetrics, runTrace, runStarts, runEnds, err := processWorkflowRun(ctx, job.run, job.index, processID, urlEarliestTime, parsed.Owner, parsed.Repo, parsed.Identifier, urlIndex, displayURL, parsed.Type, requiredContexts, changedFilesCount, changedAdditions, changedDeletions, client, reporter, builder, emitter, opts)
+				var member *chainMember
+				if m, ok := members[job.run.ID]; ok {
+					member = &m
+				}
+				runMetrics, runTrace, runStarts, runEnds, err := processWorkflowRun(ctx, job.run, job.index, processID, urlEarliestTime, parsed.Owner, parsed.Repo, parsed.Identifier, urlIndex, displayURL, parsed.Type, requiredContexts, changedFilesCount, changedAdditions, changedDeletions, client, reporter, builder, emitter, opts, member, approvalWaits, superseded[job.run.ID] != 0)
 				resultsCh <- runResult{
 					metrics:     runMetrics,
 					traceEvents: runTrace,
@@ -255,11 +282,12 @@ func buildURLResult(ctx context.Context, parsed utils.ParsedGitHubURL, urlIndex
 		CommitPushedAtMs:       commitPushedAtMs,
 		AllCommitRunsCount:     allCommitRunsCount,
 		AllCommitRunsComputeMs: allCommitRunsComputeMs,
+		WorkflowChains:         chains,
 	}
 	return &result, nil
 }
 
-func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex, processID int, earliestTime int64, owner, repo, identifier string, urlIndex int, displayURL, sourceType string, requiredContexts []string, changedFilesCount, changedAdditions, changedDeletions int, client githubapi.GitHubProvider, reporter ProgressReporter, builder *SpanBuilder, emitter *TraceEmitter, opts AnalyzeOptions) (Metrics, []TraceEvent, []JobEvent, []JobEvent, error) {
+func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex, processID int, earliestTime int64, owner, repo, identifier string, urlIndex int, displayURL, sourceType string, requiredContexts []string, changedFilesCount, changedAdditions, changedDeletions int, client githubapi.GitHubProvider, reporter ProgressReporter, builder *SpanBuilder, emitter *TraceEmitter, opts AnalyzeOptions, chain *chainMember, approvalWaits []ApprovalWait, superseded bool) (Metrics, []TraceEvent, []JobEvent, []JobEvent, error) {
 	metrics := InitializeMetrics()
 	traceEvents := []TraceEvent{}
 	jobStartTimes := []JobEvent{}
@@ -268,6 +296,9 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 	metrics.TotalRuns = 1
 	if run.Status == "completed" && run.Conclusion == "success" {
 		metrics.SuccessfulRuns = 1
+	} else if superseded && run.Conclusion == "cancelled" {
+		// Cancelled by a newer push, so the run neither passed nor failed
+		metrics.SupersededRuns = 1
 	} else {
 		metrics.FailedRuns = 1
 	}
@@ -285,6 +316,7 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 	if err != nil {
 		return metrics, traceEvents, jobStartTimes, jobEndTimes, err
 	}
+	metrics.Waste = runWaste(run, jobs, superseded)
 
 	// Fetch and process previous retry attempts.
 	// The default /jobs endpoint only returns the latest attempt's jobs,
@@ -296,6 +328,7 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 			if err != nil {
 				continue // best-effort: skip attempts we can't fetch
 			}
+			metrics.Waste.Add(attemptWaste(attemptJobs))
 			processPreviousAttempt(attempt, attemptJobs, run, processID, earliestTime, owner, repo, identifier, urlIndex, displayURL, sourceType, requiredContexts, builder, &traceEvents, &metrics, &jobStartTimes, &jobEndTimes)
 		}
 	}
@@ -480,9 +513,42 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 		}
 	}
 
+	// Waits on environment protection rules are split out of queue time
+	jobApprovals := approvalWaitsByJob(approvalWaits, run.ID)
+	for _, w := range approvalWaits {
+		if w.RunID == run.ID && !w.IsPending() {
+			metrics.ApprovalTimes = append(metrics.ApprovalTimes, float64(w.DurationMs()))
+		}
+	}
+	emitApprovalWaits(approvalWaits, run, jobs, builder, tid, wfSC)
+
+	// Nest jobs run by reusable workflows under their callers. The definition
+	// tells callers apart from jobs that merely have " / " in their name.
+	var placements map[int64]reusablePlacement
+	if hasReusableJobs(jobs) {
+		var calls map[string]string
+		if run.Path != "" {
+			if definition, err := client.FetchWorkflowFile(ctx, run.Repository.Owner.Login, run.Repository.Name, run.Path, run.HeadSHA); err == nil {
+				calls, _ = ReusableWorkflowCalls(definition)
+			}
+		}
+		placements = emitReusableWorkflows(jobs, run, calls, builder, tid, wfSC)
+	}
+
 	for jobIndex, job := range jobs {
 		jobThreadID := jobIndex + 10
-		processJob(job, jobIndex, run, jobThreadID, processID, earliestTime, &metrics, &traceEvents, &jobStartTimes, &jobEndTimes, prURL, urlIndex, displayURL, sourceType, identifier, requiredContexts, builder, tid, wfSC, jobAnnotations[job.Name])
+		parentSC, spanName := wfSC, job.Name
+		if p, ok := placements[job.ID]; ok {
+			parentSC, spanName = p.parent, p.name
+		}
+		processJob(job, jobIndex, run, jobThreadID, processID, earliestTime, &metrics, &traceEvents, &jobStartTimes, &jobEndTimes, prURL, urlIndex, displayURL, sourceType, identifier, requiredContexts, builder, tid, parentSC, spanName, jobAnnotations[job.Name], jobApprovals[job.ID])
+
+		// Logs are best-effort: they expire and need more scopes than the API
+		if opts.CompositeSteps && job.Status == "completed" {
+			if log, err := client.FetchJobLogs(ctx, run.Repository.Owner.Login, run.Repository.Name, job.ID); err == nil {
+				emitCompositeSteps(job, CompositeSteps(job.Steps, ParseLogGroups(log)), builder, tid)
+			}
+		}
 	}
 
 	// Fetch billable timing (best-effort, don't fail on error)
@@ -493,6 +559,9 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 				if billable.TotalMs > 0 {
 					metrics.BillableMs[osName] += billable.TotalMs
 				}
+				if run.Conclusion == "cancelled" {
+					metrics.Waste.BillableMs += billable.TotalMs
+				}
 			}
 		}
 	}
@@ -531,6 +600,12 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 	if run.RunAttempt > 1 {
 		wfAttrs = append(wfAttrs, attribute.Int64("github.run_attempt", run.RunAttempt))
 	}
+	if run.Event != "" {
+		wfAttrs = append(wfAttrs, attribute.String("github.event", run.Event))
+	}
+	if chain != nil {
+		wfAttrs = append(wfAttrs, chain.attributes()...)
+	}
 	// Add billable timing attributes
 	for osName, ms := range metrics.BillableMs {
 		wfAttrs = append(wfAttrs, attribute.Int64(fmt.Sprintf("billable.%s_ms", strings.ToLower(osName)), ms))
@@ -593,6 +668,12 @@ func processWorkflowRun(ctx context.Context, run githubapi.WorkflowRun, runIndex
 			},
 		})
 	}
+	// Link workflow_run-triggered runs to the run that triggered them
+	if chain != nil {
+		if link, ok := chain.link(); ok {
+			wfLinks = append(wfLinks, link)
+		}
+	}
 
 	wfName := defaultRunName(run)
 	if run.RunAttempt > 1 {
@@ -703,11 +784,11 @@ func processPreviousAttempt(attempt int64, jobs []githubapi.Job, run githubapi.W
 	prURL := fmt.Sprintf("https://github.com/%s/%s/pull/%s", owner, repo, identifier)
 	for jobIndex, job := range jobs {
 		jobThreadID := jobIndex + 10
-		processJob(job, jobIndex, run, jobThreadID, processID, earliestTime, metrics, traceEvents, jobStartTimes, jobEndTimes, prURL, urlIndex, displayURL, sourceType, identifier, requiredContexts, builder, tid, wfSC, nil)
+		processJob(job, jobIndex, run, jobThreadID, processID, earliestTime, metrics, traceEvents, jobStartTimes, jobEndTimes, prURL, urlIndex, displayURL, sourceType, identifier, requiredContexts, builder, tid, wfSC, job.Name, nil, nil)
 	}
 }
 
-func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobThreadID, processID int, earliestTime int64, metrics *Metrics, traceEvents *[]TraceEvent, jobStartTimes, jobEndTimes *[]JobEvent, prURL string, urlIndex int, displayURL, sourceType, identifier string, requiredContexts []string, builder *SpanBuilder, traceID trace.TraceID, parentSC trace.SpanContext, annotations []githubapi.Annotation) {
+func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobThreadID, processID int, earliestTime int64, metrics *Metrics, traceEvents *[]TraceEvent, jobStartTimes, jobEndTimes *[]JobEvent, prURL string, urlIndex int, displayURL, sourceType, identifier string, requiredContexts []string, builder *SpanBuilder, traceID trace.TraceID, parentSC trace.SpanContext, spanName string, annotations []githubapi.Annotation, approval *ApprovalWait) {
 	if job.StartedAt == "" {
 		return
 	}
@@ -784,7 +865,7 @@ func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobT
 
 	// Queue time: CreatedAt → StartedAt (only for jobs that actually ran)
 	if job.CreatedAt != "" && job.Conclusion != "skipped" && job.Conclusion != "cancelled" {
-		if createdAt, ok := utils.ParseTime(job.CreatedAt); ok {
+		if createdAt, ok := jobQueuedAt(job, absoluteJobStart, approval); ok {
 			queueMs := float64(absoluteJobStart.UnixMilli() - createdAt.UnixMilli())
 			if queueMs > 0 {
 				metrics.QueueTimes = append(metrics.QueueTimes, queueMs)
@@ -822,7 +903,7 @@ func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobT
 
 	// Emit queued span (CreatedAt → StartedAt) — only for jobs that actually ran
 	if job.CreatedAt != "" && job.Conclusion != "skipped" && job.Conclusion != "cancelled" {
-		if createdAt, ok := utils.ParseTime(job.CreatedAt); ok {
+		if createdAt, ok := jobQueuedAt(job, absoluteJobStart, approval); ok {
 			queueStartTs := createdAt.UnixMilli()
 			if queueStartTs < jobStartTs {
 				normalizedQueueStart := (queueStartTs - earliestTime) * 1000
@@ -918,9 +999,15 @@ func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobT
 	if job.RunnerName != "" {
 		jobAttrs = append(jobAttrs, attribute.String("k8s.pod.name", job.RunnerName))
 	}
+	if job.RunnerGroupName != "" {
+		jobAttrs = append(jobAttrs, attribute.String(AttrRunnerGroup, job.RunnerGroupName))
+	}
+	if len(job.Labels) > 0 {
+		jobAttrs = append(jobAttrs, attribute.String(AttrRunnerLabels, strings.Join(job.Labels, ",")))
+	}
 	// Add queue_time_ms if we have CreatedAt
 	if job.CreatedAt != "" {
-		if createdAt, ok := utils.ParseTime(job.CreatedAt); ok {
+		if createdAt, ok := jobQueuedAt(job, absoluteJobStart, approval); ok {
 			queueMs := absoluteJobStart.UnixMilli() - createdAt.UnixMilli()
 			if queueMs > 0 {
 				jobAttrs = append(jobAttrs, attribute.Int64("queue_time_ms", queueMs))
@@ -945,7 +1032,7 @@ func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobT
 		})
 	}
 	builder.Add(tracetest.SpanStub{
-		Name:        job.Name + requiredSuffix,
+		Name:        spanName + requiredSuffix,
 		SpanContext: jobSC,
 		Parent:      parentSC,
 		StartTime:   jobStart,
@@ -956,6 +1043,21 @@ func processJob(job githubapi.Job, jobIndex int, run githubapi.WorkflowRun, jobT
 	})
 }
 
+// jobQueuedAt is when a job started queueing for a runner: its creation, or
+// for jobs held by environment protection rules, when the wait ended.
+func jobQueuedAt(job githubapi.Job, jobStart time.Time, approval *ApprovalWait) (time.Time, bool) {
+	createdAt, ok := utils.ParseTime(job.CreatedAt)
+	if !ok {
+		return time.Time{}, false
+	}
+	if approval != nil && !approval.IsPending() {
+		if end := time.UnixMilli(approval.EndMs); end.After(createdAt) && !end.After(jobStart) {
+			return end, true
+		}
+	}
+	return createdAt, true
+}
+
 func processStep(step githubapi.Step, job githubapi.Job, run githubapi.WorkflowRun, jobThreadID, processID int, earliestTime, jobEndTs int64, metrics *Metrics, traceEvents *[]TraceEvent, prURL string, urlIndex int, displayURL, sourceType, identifier string, builder *SpanBuilder, traceID trace.TraceID, parentSC trace.SpanContext) {
 	if step.StartedAt == "" || step.CompletedAt == "" {
 		return
@@ -1286,7 +1388,10 @@ func mergeMetrics(target *Metrics, source Metrics) {
 		target.RunnerDurations[runner] += dur
 	}
 	target.QueueTimes = append(target.QueueTimes, source.QueueTimes...)
+	target.ApprovalTimes = append(target.ApprovalTimes, source.ApprovalTimes...)
 	target.RetriedRuns += source.RetriedRuns
+	target.SupersededRuns += source.SupersededRuns
+	target.Waste.Add(source.Waste)
 	for os, ms := range source.BillableMs {
 		target.BillableMs[os] += ms
 	}
@@ -0,0 +1,398 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	// DefaultBaselineDays is the history window for --baseline=<branch>.
+	DefaultBaselineDays = 14
+	// BaselineMinSamples is the smallest baseline a duration is ranked against.
+	BaselineMinSamples = 5
+	// baselineJobRuns caps the runs per workflow whose jobs are fetched.
+	baselineJobRuns = 30
+)
+
+// Span attributes added to workflow and job spans by AnnotateBaseline.
+const (
+	AttrBaselineBranch     = "baseline.branch"
+	AttrBaselinePercentile = "baseline.percentile"
+	AttrBaselineOutlier    = "baseline.outlier"
+	AttrBaselineP50Ms      = "baseline.p50_ms"
+	AttrBaselineP95Ms      = "baseline.p95_ms"
+	AttrBaselineSamples    = "baseline.samples"
+)
+
+// Distribution is a sorted set of durations in milliseconds.
+type Distribution struct {
+	values []float64
+}
+
+// Add records a duration.
+func (d *Distribution) Add(ms float64) {
+	i := sort.SearchFloat64s(d.values, ms)
+	d.values = append(d.values, 0)
+	copy(d.values[i+1:], d.values[i:])
+	d.values[i] = ms
+}
+
+// Len returns the number of durations.
+func (d *Distribution) Len() int {
+	return len(d.values)
+}
+
+// Percentile returns the p-th percentile (0-100) using nearest rank.
+func (d *Distribution) Percentile(p int) float64 {
+	return calculatePercentile(d.values, p)
+}
+
+// Rank returns the percentile rank of ms: the share of durations below it,
+// counting ties as half, from 0 to 100.
+func (d *Distribution) Rank(ms float64) float64 {
+	if len(d.values) == 0 {
+		return 0
+	}
+	below := sort.SearchFloat64s(d.values, ms)
+	above := sort.Search(len(d.values), func(i int) bool { return d.values[i] > ms })
+	return (float64(below) + float64(above-below)/2) / float64(len(d.values)) * 100
+}
+
+// IsOutlier reports whether ms lies outside Tukey's fences (1.5 × IQR beyond
+// the quartiles).
+func (d *Distribution) IsOutlier(ms float64) bool {
+	if len(d.values) < BaselineMinSamples {
+		return false
+	}
+	q1, q3 := d.Percentile(25), d.Percentile(75)
+	iqr := q3 - q1
+	return ms > q3+1.5*iqr || ms < q1-1.5*iqr
+}
+
+// Baseline holds the durations of recent runs on a branch, per workflow and
+// per job, to rank the analyzed runs against. Workflows are keyed by
+// repository and workflow file path, jobs additionally by job name.
+type Baseline struct {
+	Branch    string
+	Days      int
+	Workflows map[string]*Distribution
+	Jobs      map[string]*Distribution
+}
+
+// BaselineRank places one duration in its baseline distribution.
+type BaselineRank struct {
+	Branch     string
+	Percentile float64
+	Outlier    bool
+	P50Ms      float64
+	P95Ms      float64
+	Samples    int
+}
+
+// NewBaseline returns an empty baseline for branch.
+func NewBaseline(branch string, days int) *Baseline {
+	if days <= 0 {
+		days = DefaultBaselineDays
+	}
+	return &Baseline{
+		Branch:    branch,
+		Days:      days,
+		Workflows: make(map[string]*Distribution),
+		Jobs:      make(map[string]*Distribution),
+	}
+}
+
+func baselineKey(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// AddWorkflow records a workflow run duration.
+func (b *Baseline) AddWorkflow(repo, path string, ms float64) {
+	key := baselineKey(repo, path)
+	if b.Workflows[key] == nil {
+		b.Workflows[key] = &Distribution{}
+	}
+	b.Workflows[key].Add(ms)
+}
+
+// AddJob records a job duration.
+func (b *Baseline) AddJob(repo, path, job string, ms float64) {
+	key := baselineKey(repo, path, job)
+	if b.Jobs[key] == nil {
+		b.Jobs[key] = &Distribution{}
+	}
+	b.Jobs[key].Add(ms)
+}
+
+func (b *Baseline) rank(d *Distribution, ms float64) (BaselineRank, bool) {
+	if d == nil || d.Len() < BaselineMinSamples {
+		return BaselineRank{}, false
+	}
+	return BaselineRank{
+		Branch:     b.Branch,
+		Percentile: d.Rank(ms),
+		Outlier:    d.IsOutlier(ms),
+		P50Ms:      d.Percentile(50),
+		P95Ms:      d.Percentile(95),
+		Samples:    d.Len(),
+	}, true
+}
+
+// RankWorkflow ranks a workflow run duration; false when there is too little history.
+func (b *Baseline) RankWorkflow(repo, path string, ms float64) (BaselineRank, bool) {
+	return b.rank(b.Workflows[baselineKey(repo, path)], ms)
+}
+
+// RankJob ranks a job duration; false when there is too little history.
+func (b *Baseline) RankJob(repo, path, job string, ms float64) (BaselineRank, bool) {
+	return b.rank(b.Jobs[baselineKey(repo, path, job)], ms)
+}
+
+// Fetch adds the successful runs of the given workflow files on the baseline
+// branch of owner/repo from the last Days days. Jobs are fetched for the most
+// recent runs of each workflow only.
+func (b *Baseline) Fetch(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, workflowPaths []string, reporter ProgressReporter) error {
+	if reporter != nil {
+		reporter.SetPhase("Fetching baseline")
+		reporter.SetDetail(fmt.Sprintf("%s/%s, branch: %s, last %d days", owner, repo, b.Branch, b.Days))
+	}
+	runs, err := client.FetchRecentWorkflowRuns(ctx, owner, repo, b.Days, b.Branch, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch baseline runs: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(workflowPaths))
+	for _, p := range workflowPaths {
+		wanted[p] = true
+	}
+	fullRepo := owner + "/" + repo
+
+	// Runs come newest first; keep that order for the job sample
+	byPath := make(map[string][]int)
+	for i, run := range runs {
+		if !wanted[run.Path] || run.Status != "completed" || run.Conclusion != "success" {
+			continue
+		}
+		byPath[run.Path] = append(byPath[run.Path], i)
+	}
+
+	runData := convertRuns(runs)
+	var sample []int
+	for path, indices := range byPath {
+		for _, i := range indices {
+			if runData[i].Duration > 0 {
+				b.AddWorkflow(fullRepo, path, float64(runData[i].Duration))
+			}
+		}
+		if len(indices) > baselineJobRuns {
+			indices = indices[:baselineJobRuns]
+		}
+		sample = append(sample, indices...)
+	}
+	sort.Ints(sample)
+
+	if reporter != nil {
+		reporter.SetURLRuns(len(sample))
+	}
+	if err := fetchJobsForRuns(ctx, client, runData, runs, sample, reporter); err != nil {
+		return fmt.Errorf("failed to fetch baseline jobs: %w", err)
+	}
+	for _, i := range sample {
+		for _, job := range runData[i].Jobs {
+			if job.Conclusion != "success" || job.Duration <= 0 {
+				continue
+			}
+			b.AddJob(fullRepo, runs[i].Path, job.Name, float64(job.Duration))
+		}
+	}
+	return nil
+}
+
+// BaselineWorkflows returns the workflow file paths found in GitHub workflow
+// spans, grouped by "owner/repo".
+func BaselineWorkflows(spans []trace.ReadOnlySpan) map[string][]string {
+	seen := make(map[string]bool)
+	out := make(map[string][]string)
+	for _, s := range spans {
+		attrs := spanAttrs(s)
+		if attrs["type"] != "workflow" || attrs["github.repo"] == "" || attrs["cicd.pipeline.definition"] == "" {
+			continue
+		}
+		repo, path := attrs["github.repo"], attrs["cicd.pipeline.definition"]
+		if seen[baselineKey(repo, path)] {
+			continue
+		}
+		seen[baselineKey(repo, path)] = true
+		out[repo] = append(out[repo], path)
+	}
+	for _, paths := range out {
+		sort.Strings(paths)
+	}
+	return out
+}
+
+// AnnotateBaseline returns copies of the spans with baseline.* attributes on
+// every completed workflow and job span that has enough baseline history.
+func AnnotateBaseline(spans []trace.ReadOnlySpan, b *Baseline) []trace.ReadOnlySpan {
+	if b == nil || len(spans) == 0 {
+		return spans
+	}
+
+	// Jobs only know their parent span, which is the workflow span carrying
+	// repo and path or, for jobs of reusable workflows, a call nested in it
+	workflows := make(map[string]map[string]string)
+	parents := make(map[string]string)
+	for _, s := range spans {
+		if attrs := spanAttrs(s); attrs["type"] == "workflow" {
+			workflows[s.SpanContext().SpanID().String()] = attrs
+		}
+		if s.Parent().IsValid() {
+			parents[s.SpanContext().SpanID().String()] = s.Parent().SpanID().String()
+		}
+	}
+	enclosingWorkflow := func(parentID string) map[string]string {
+		for range len(parents) + 1 {
+			if wf, ok := workflows[parentID]; ok {
+				return wf
+			}
+			next, ok := parents[parentID]
+			if !ok {
+				return nil
+			}
+			parentID = next
+		}
+		return nil
+	}
+
+	stubs := tracetest.SpanStubsFromReadOnlySpans(spans)
+	for i := range stubs {
+		stub := &stubs[i]
+		attrs := make(map[string]string, len(stub.Attributes))
+		for _, kv := range stub.Attributes {
+			attrs[string(kv.Key)] = kv.Value.AsString()
+		}
+		if attrs["github.status"] != "completed" {
+			continue
+		}
+		ms := float64(stub.EndTime.Sub(stub.StartTime).Milliseconds())
+
+		var rank BaselineRank
+		var ok bool
+		switch attrs["type"] {
+		case "workflow":
+			rank, ok = b.RankWorkflow(attrs["github.repo"], attrs["cicd.pipeline.definition"], ms)
+		case "job":
+			wf := enclosingWorkflow(stub.Parent.SpanID().String())
+			if wf == nil {
+				continue
+			}
+			rank, ok = b.RankJob(wf["github.repo"], wf["cicd.pipeline.definition"], attrs["cicd.pipeline.task.name"], ms)
+		}
+		if !ok {
+			continue
+		}
+		// Stored as strings so they survive the string-only attribute maps
+		// used by the tree and the renderers
+		stub.Attributes = append(stub.Attributes,
+			attribute.String(AttrBaselineBranch, rank.Branch),
+			attribute.String(AttrBaselinePercentile, strconv.FormatFloat(math.Round(rank.Percentile*10)/10, 'f', -1, 64)),
+			attribute.String(AttrBaselineOutlier, strconv.FormatBool(rank.Outlier)),
+			attribute.String(AttrBaselineP50Ms, strconv.FormatInt(int64(rank.P50Ms), 10)),
+			attribute.String(AttrBaselineP95Ms, strconv.FormatInt(int64(rank.P95Ms), 10)),
+			attribute.String(AttrBaselineSamples, strconv.Itoa(rank.Samples)),
+		)
+	}
+	return stubs.Snapshots()
+}
+
+// BaselineRankFromAttrs reads the baseline.* attributes written by
+// AnnotateBaseline.
+func BaselineRankFromAttrs(attrs map[string]string) (BaselineRank, bool) {
+	pct, err := strconv.ParseFloat(attrs[AttrBaselinePercentile], 64)
+	if err != nil {
+		return BaselineRank{}, false
+	}
+	p50, _ := strconv.ParseFloat(attrs[AttrBaselineP50Ms], 64)
+	p95, _ := strconv.ParseFloat(attrs[AttrBaselineP95Ms], 64)
+	samples, _ := strconv.Atoi(attrs[AttrBaselineSamples])
+	return BaselineRank{
+		Branch:     attrs[AttrBaselineBranch],
+		Percentile: pct,
+		Outlier:    attrs[AttrBaselineOutlier] == "true",
+		P50Ms:      p50,
+		P95Ms:      p95,
+		Samples:    samples,
+	}, true
+}
+
+// Label is the short form shown next to a duration, e.g. "p97 vs main ⚠".
+func (r BaselineRank) Label() string {
+	label := fmt.Sprintf("p%.0f vs %s", r.Percentile, r.Branch)
+	if r.Outlier {
+		label += " ⚠"
+	}
+	return label
+}
+
+// P50 returns the baseline median as a duration.
+func (r BaselineRank) P50() time.Duration {
+	return time.Duration(r.P50Ms) * time.Millisecond
+}
+
+// P95 returns the baseline 95th percentile as a duration.
+func (r BaselineRank) P95() time.Duration {
+	return time.Duration(r.P95Ms) * time.Millisecond
+}
+
+// FindBaselineNodes returns the ranked nodes, outliers first and then by
+// percentile rank, highest first, capped at limit (0 for all).
+func FindBaselineNodes(roots []*TreeNode, limit int) []*TreeNode {
+	type ranked struct {
+		node *TreeNode
+		rank BaselineRank
+	}
+	var found []ranked
+	var walk func(nodes []*TreeNode)
+	walk = func(nodes []*TreeNode) {
+		for _, n := range nodes {
+			if r, ok := BaselineRankFromAttrs(n.Attrs); ok {
+				found = append(found, ranked{n, r})
+			}
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].rank.Outlier != found[j].rank.Outlier {
+			return found[i].rank.Outlier
+		}
+		return found[i].rank.Percentile > found[j].rank.Percentile
+	})
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	nodes := make([]*TreeNode, len(found))
+	for i, f := range found {
+		nodes[i] = f.node
+	}
+	return nodes
+}
+
+func spanAttrs(s trace.ReadOnlySpan) map[string]string {
+	attrs := make(map[string]string, len(s.Attributes()))
+	for _, kv := range s.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+	return attrs
+}
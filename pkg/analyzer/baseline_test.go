@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDistribution(t *testing.T) {
+	t.Parallel()
+
+	d := &Distribution{}
+	for _, v := range []float64{50, 10, 40, 20, 30, 60, 70, 80, 90, 100} {
+		d.Add(v)
+	}
+	assert.Equal(t, 10, d.Len())
+	assert.Equal(t, 50.0, d.Percentile(50))
+	assert.Equal(t, 100.0, d.Percentile(95))
+
+	assert.Equal(t, 0.0, d.Rank(5))
+	assert.Equal(t, 45.0, d.Rank(50), "ties count as half")
+	assert.Equal(t, 100.0, d.Rank(500))
+
+	assert.False(t, d.IsOutlier(100))
+	assert.True(t, d.IsOutlier(500))
+	assert.False(t, (&Distribution{values: []float64{1, 2}}).IsOutlier(500), "too few samples")
+}
+
+func TestBaselineRank(t *testing.T) {
+	t.Parallel()
+
+	b := NewBaseline("main", 0)
+	assert.Equal(t, DefaultBaselineDays, b.Days)
+	for i := 1; i <= 10; i++ {
+		b.AddWorkflow("o/r", ".github/workflows/ci.yml", float64(i*1000))
+	}
+	for i := 1; i < BaselineMinSamples; i++ {
+		b.AddJob("o/r", ".github/workflows/ci.yml", "build", float64(i*1000))
+	}
+
+	rank, ok := b.RankWorkflow("o/r", ".github/workflows/ci.yml", 60000)
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, rank.Percentile)
+	assert.True(t, rank.Outlier)
+	assert.Equal(t, 10, rank.Samples)
+	assert.Equal(t, "p100 vs main ⚠", rank.Label())
+
+	_, ok = b.RankJob("o/r", ".github/workflows/ci.yml", "build", 1000)
+	assert.False(t, ok, "not enough job samples")
+	_, ok = b.RankWorkflow("o/r", ".github/workflows/other.yml", 1000)
+	assert.False(t, ok)
+}
+
+// baselineTestSpans returns a completed CI workflow (15 min) with build (8 min)
+// and lint (30s) jobs, plus a trace-file span without GitHub attributes.
+func baselineTestSpans() []sdktrace.ReadOnlySpan {
+	completed := attribute.String("github.status", "completed")
+	return tracetest.SpanStubs{
+		makeSpanStub("CI", 1, 0, 0, 15*time.Minute,
+			attribute.String("type", "workflow"), completed,
+			attribute.String("github.repo", "o/r"),
+			attribute.String("cicd.pipeline.definition", ".github/workflows/ci.yml"),
+		),
+		makeSpanStub("build 🔒", 2, 1, 0, 8*time.Minute,
+			attribute.String("type", "job"), completed, attribute.String("cicd.pipeline.task.name", "build")),
+		makeSpanStub("lint", 3, 1, 0, 30*time.Second,
+			attribute.String("type", "job"), completed, attribute.String("cicd.pipeline.task.name", "lint")),
+		makeSpanStub("GET /", 4, 0, 0, time.Second),
+		makeSpanStub("deploy", 5, 1, 0, 10*time.Minute, attribute.String("type", "reusable_workflow"), completed),
+		makeSpanStub("push", 6, 5, 0, 10*time.Minute,
+			attribute.String("type", "job"), completed, attribute.String("cicd.pipeline.task.name", "deploy / push")),
+	}.Snapshots()
+}
+
+func TestAnnotateBaseline(t *testing.T) {
+	t.Parallel()
+
+	b := NewBaseline("main", 14)
+	for i := 0; i < 10; i++ {
+		b.AddWorkflow("o/r", ".github/workflows/ci.yml", float64((5+i%3)*60000))
+		b.AddJob("o/r", ".github/workflows/ci.yml", "build", float64((4+i%2)*60000))
+		b.AddJob("o/r", ".github/workflows/ci.yml", "deploy / push", float64((9+i%2)*60000))
+	}
+
+	spans := AnnotateBaseline(baselineTestSpans(), b)
+	attrs := make(map[string]map[string]string)
+	for _, s := range spans {
+		attrs[s.Name()] = spanAttrs(s)
+	}
+
+	wf, ok := BaselineRankFromAttrs(attrs["CI"])
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, wf.Percentile)
+	assert.True(t, wf.Outlier)
+	assert.Equal(t, "main", wf.Branch)
+	assert.Equal(t, 6*time.Minute, wf.P50())
+
+	build, ok := BaselineRankFromAttrs(attrs["build 🔒"])
+	assert.True(t, ok, "job is matched by task name, not span name")
+	assert.True(t, build.Outlier)
+	assert.Equal(t, 10, build.Samples)
+
+	push, ok := BaselineRankFromAttrs(attrs["push"])
+	assert.True(t, ok, "jobs of reusable workflows find the workflow above their call")
+	assert.Equal(t, 10, push.Samples)
+
+	_, ok = BaselineRankFromAttrs(attrs["lint"])
+	assert.False(t, ok, "no baseline for lint")
+	_, ok = BaselineRankFromAttrs(attrs["GET /"])
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string][]string{"o/r": {".github/workflows/ci.yml"}}, BaselineWorkflows(spans))
+}
+
+func TestFindBaselineNodes(t *testing.T) {
+	t.Parallel()
+
+	roots := []*TreeNode{{
+		Name: "CI",
+		Children: []*TreeNode{
+			{Name: "fast", Attrs: map[string]string{AttrBaselinePercentile: "10", AttrBaselineOutlier: "true"}},
+			{Name: "slow", Attrs: map[string]string{AttrBaselinePercentile: "90", AttrBaselineOutlier: "false"}},
+			{Name: "typical", Attrs: map[string]string{AttrBaselinePercentile: "50", AttrBaselineOutlier: "false"}},
+			{Name: "unranked"},
+		},
+	}}
+
+	var names []string
+	for _, n := range FindBaselineNodes(roots, 2) {
+		names = append(names, n.Name)
+	}
+	assert.Equal(t, []string{"fast", "slow"}, names)
+	assert.Len(t, FindBaselineNodes(roots, 0), 3)
+}
+
+func TestBaselineFetch(t *testing.T) {
+	t.Parallel()
+
+	client := new(mockGitHubProvider)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var runs []githubapi.WorkflowRun
+	for i := 0; i < 6; i++ {
+		start := base.Add(time.Duration(i) * time.Hour)
+		runs = append(runs, githubapi.WorkflowRun{
+			ID:         int64(100 + i),
+			Path:       ".github/workflows/ci.yml",
+			Status:     "completed",
+			Conclusion: "success",
+			CreatedAt:  start.Format(time.RFC3339),
+			UpdatedAt:  start.Add(time.Duration(5+i) * time.Minute).Format(time.RFC3339),
+			Repository: githubapi.RepoRef{Owner: githubapi.RepoOwner{Login: "o"}, Name: "r"},
+		})
+		client.On("FetchJobsPaginated", mock.Anything, fmt.Sprintf("https://api.github.com/repos/o/r/actions/runs/%d/jobs", 100+i)).Return([]githubapi.Job{{
+			Name:        "build",
+			Status:      "completed",
+			Conclusion:  "success",
+			StartedAt:   start.Format(time.RFC3339),
+			CompletedAt: start.Add(4 * time.Minute).Format(time.RFC3339),
+		}}, nil)
+	}
+	// Failed runs and other workflows are not part of the baseline
+	runs = append(runs,
+		githubapi.WorkflowRun{ID: 200, Path: ".github/workflows/ci.yml", Status: "completed", Conclusion: "failure"},
+		githubapi.WorkflowRun{ID: 201, Path: ".github/workflows/release.yml", Status: "completed", Conclusion: "success"},
+	)
+	client.On("FetchRecentWorkflowRuns", mock.Anything, "o", "r", 14, "main", "", mock.Anything).Return(runs, nil)
+
+	b := NewBaseline("main", 14)
+	assert.NoError(t, b.Fetch(context.Background(), client, "o", "r", []string{".github/workflows/ci.yml"}, nil))
+
+	rank, ok := b.RankWorkflow("o/r", ".github/workflows/ci.yml", float64(7*time.Minute/time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, 6, rank.Samples)
+	assert.InDelta(t, 41.7, rank.Percentile, 0.1, "one tie counts as half")
+
+	job, ok := b.RankJob("o/r", ".github/workflows/ci.yml", "build", float64(4*time.Minute/time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, 6, job.Samples)
+	assert.False(t, job.Outlier)
+}
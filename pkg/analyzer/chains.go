@@ -0,0 +1,294 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attributes placing a workflow run in a workflow_run chain.
+const (
+	AttrWorkflowChain         = "github.workflow_chain" // run ID of the run starting the chain
+	AttrWorkflowChainPosition = "github.workflow_chain.position"
+	AttrWorkflowChainCommitMs = "github.workflow_chain.commit_ms"
+	AttrTriggeredByRunID      = "github.triggered_by_run_id"
+)
+
+// maxTriggerDelay bounds the time between a run finishing and the run it
+// triggers through workflow_run being created.
+const maxTriggerDelay = 10 * time.Minute
+
+// triggerClockSkew tolerates a triggering run's updated_at trailing the
+// creation of the run it triggered.
+const triggerClockSkew = time.Minute
+
+// FindWorkflowTriggers matches runs started by a workflow_run event to the run
+// that triggered them, keyed by the triggered run's ID. The API does not
+// name the triggering run, so it is the run of another workflow for the same
+// head SHA that completed closest to the triggered run's creation.
+func FindWorkflowTriggers(runs []githubapi.WorkflowRun) map[int64]githubapi.WorkflowRun {
+	triggers := make(map[int64]githubapi.WorkflowRun)
+	for _, run := range runs {
+		if run.Event != "workflow_run" {
+			continue
+		}
+		created, ok := utils.ParseTime(run.CreatedAt)
+		if !ok {
+			continue
+		}
+		var best *githubapi.WorkflowRun
+		var bestGap time.Duration
+		for i := range runs {
+			candidate := &runs[i]
+			if candidate.ID == run.ID || candidate.HeadSHA != run.HeadSHA || candidate.Status != "completed" || sameWorkflow(*candidate, run) {
+				continue
+			}
+			done, ok := utils.ParseTime(candidate.UpdatedAt)
+			if !ok {
+				continue
+			}
+			gap := created.Sub(done)
+			if gap < -triggerClockSkew || gap > maxTriggerDelay {
+				continue
+			}
+			if gap < 0 {
+				gap = -gap
+			}
+			if best == nil || gap < bestGap {
+				best, bestGap = candidate, gap
+			}
+		}
+		if best != nil {
+			triggers[run.ID] = *best
+		}
+	}
+	return triggers
+}
+
+// sameWorkflow reports whether two runs belong to the same workflow.
+func sameWorkflow(a, b githubapi.WorkflowRun) bool {
+	if a.WorkflowID != 0 && b.WorkflowID != 0 {
+		return a.WorkflowID == b.WorkflowID
+	}
+	if a.Path != "" || b.Path != "" {
+		return a.Path == b.Path
+	}
+	return a.Name == b.Name
+}
+
+// ChainRun is one workflow run of a WorkflowChain. Times are unix
+// milliseconds; EndMs is zero while the run is in progress.
+type ChainRun struct {
+	RunID       int64
+	RunAttempt  int64
+	Name        string
+	URL         string
+	Event       string
+	Status      string
+	Conclusion  string
+	StartMs     int64
+	EndMs       int64
+	TriggeredBy int64 // run ID of the triggering run; 0 for the first run
+}
+
+// WorkflowChain is a set of workflow runs linked by workflow_run triggers,
+// e.g. a build workflow and the deploy workflow it triggers. Runs are in
+// start order, the triggering root first.
+type WorkflowChain struct {
+	ID       int64 // run ID of the root run
+	Runs     []ChainRun
+	CommitMs int64 // when the commit was created; 0 when unknown
+}
+
+// Name joins the run names in start order, e.g. "CI → Deploy".
+func (c WorkflowChain) Name() string {
+	names := make([]string, len(c.Runs))
+	for i, r := range c.Runs {
+		names[i] = r.Name
+	}
+	return strings.Join(names, " → ")
+}
+
+// StartMs is when the first run of the chain was created.
+func (c WorkflowChain) StartMs() int64 {
+	if len(c.Runs) == 0 {
+		return 0
+	}
+	return c.Runs[0].StartMs
+}
+
+// EndMs is when the last run of the chain finished; zero while any run is
+// still in progress.
+func (c WorkflowChain) EndMs() int64 {
+	var end int64
+	for _, r := range c.Runs {
+		if r.EndMs == 0 {
+			return 0
+		}
+		end = max(end, r.EndMs)
+	}
+	return end
+}
+
+// IsPending reports whether a run of the chain is still in progress.
+func (c WorkflowChain) IsPending() bool {
+	return c.EndMs() == 0
+}
+
+// EndToEndMs is the latency from the commit (or, when its time is unknown,
+// the first run) to the end of the last run; -1 while the chain is pending.
+func (c WorkflowChain) EndToEndMs() int64 {
+	end := c.EndMs()
+	if end == 0 {
+		return -1
+	}
+	return max(end-firstNonZero(c.CommitMs, c.StartMs()), 0)
+}
+
+// Conclusion is the first non-successful conclusion of the chain's runs, or
+// "success" when every run succeeded.
+func (c WorkflowChain) Conclusion() string {
+	for _, r := range c.Runs {
+		if r.Status != "completed" {
+			return ""
+		}
+		if r.Conclusion != "success" {
+			return r.Conclusion
+		}
+	}
+	return "success"
+}
+
+// BuildWorkflowChains groups runs linked by workflow_run triggers into chains
+// of at least two runs, ordered by start time. commitMs is the commit creation
+// time used for end-to-end latency; zero when unknown.
+func BuildWorkflowChains(runs []githubapi.WorkflowRun, commitMs int64) []WorkflowChain {
+	triggers := FindWorkflowTriggers(runs)
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	// Follow trigger links up to the root of each run's chain
+	rootOf := func(run githubapi.WorkflowRun) int64 {
+		seen := map[int64]bool{run.ID: true}
+		for {
+			parent, ok := triggers[run.ID]
+			if !ok || seen[parent.ID] {
+				return run.ID
+			}
+			seen[parent.ID] = true
+			run = parent
+		}
+	}
+
+	byRoot := make(map[int64][]ChainRun)
+	var roots []int64
+	for _, run := range runs {
+		root := rootOf(run)
+		if _, ok := byRoot[root]; !ok {
+			roots = append(roots, root)
+		}
+		r := ChainRun{
+			RunID:      run.ID,
+			RunAttempt: run.RunAttempt,
+			Name:       defaultRunName(run),
+			URL:        fmt.Sprintf("https://github.com/%s/%s/actions/runs/%d", run.Repository.Owner.Login, run.Repository.Name, run.ID),
+			Event:      run.Event,
+			Status:     run.Status,
+			Conclusion: run.Conclusion,
+		}
+		if t, ok := utils.ParseTime(run.CreatedAt); ok {
+			r.StartMs = t.UnixMilli()
+		}
+		if t, ok := utils.ParseTime(run.UpdatedAt); ok && run.Status == "completed" {
+			r.EndMs = t.UnixMilli()
+		}
+		if parent, ok := triggers[run.ID]; ok && run.ID != root {
+			r.TriggeredBy = parent.ID
+		}
+		byRoot[root] = append(byRoot[root], r)
+	}
+
+	var chains []WorkflowChain
+	for _, root := range roots {
+		members := byRoot[root]
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			if members[i].RunID == root || members[j].RunID == root {
+				return members[i].RunID == root
+			}
+			return members[i].StartMs < members[j].StartMs
+		})
+		chains = append(chains, WorkflowChain{ID: root, Runs: members, CommitMs: commitMs})
+	}
+	sort.SliceStable(chains, func(i, j int) bool { return chains[i].StartMs() < chains[j].StartMs() })
+	return chains
+}
+
+// chainMember places a workflow run in its chain for span emission.
+type chainMember struct {
+	chain    *WorkflowChain
+	position int
+	run      ChainRun
+}
+
+// chainMembers indexes the runs of chains by run ID.
+func chainMembers(chains []WorkflowChain) map[int64]chainMember {
+	members := make(map[int64]chainMember)
+	for i := range chains {
+		for pos, r := range chains[i].Runs {
+			members[r.RunID] = chainMember{chain: &chains[i], position: pos, run: r}
+		}
+	}
+	return members
+}
+
+// attributes returns the span attributes placing the run in its chain.
+func (m chainMember) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String(AttrWorkflowChain, strconv.FormatInt(m.chain.ID, 10)),
+		attribute.String(AttrWorkflowChainPosition, strconv.Itoa(m.position)),
+	}
+	if m.chain.CommitMs != 0 {
+		attrs = append(attrs, attribute.String(AttrWorkflowChainCommitMs, strconv.FormatInt(m.chain.CommitMs, 10)))
+	}
+	if m.run.TriggeredBy != 0 {
+		attrs = append(attrs, attribute.String(AttrTriggeredByRunID, strconv.FormatInt(m.run.TriggeredBy, 10)))
+	}
+	return attrs
+}
+
+// link returns the span link from the run to the workflow span of the run
+// that triggered it; false for the root of the chain.
+func (m chainMember) link() (sdktrace.Link, bool) {
+	if m.run.TriggeredBy == 0 {
+		return sdktrace.Link{}, false
+	}
+	attempt := int64(1)
+	for _, r := range m.chain.Runs {
+		if r.RunID == m.run.TriggeredBy {
+			attempt = max(r.RunAttempt, 1)
+		}
+	}
+	return sdktrace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    githubapi.NewTraceID(m.run.TriggeredBy, attempt),
+			SpanID:     githubapi.NewSpanID(m.run.TriggeredBy),
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Attributes: []attribute.KeyValue{
+			attribute.String("link.type", "workflow_run"),
+			attribute.Int64("github.triggered_by_run_id", m.run.TriggeredBy),
+		},
+	}, true
+}
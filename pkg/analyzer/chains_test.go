@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func chainTestRuns() []githubapi.WorkflowRun {
+	repo := githubapi.RepoRef{Owner: githubapi.RepoOwner{Login: "o"}, Name: "r"}
+	return []githubapi.WorkflowRun{
+		{ID: 1, RunAttempt: 1, Name: "CI", WorkflowID: 10, Event: "push", HeadSHA: "abc", Status: "completed", Conclusion: "success",
+			CreatedAt: "2024-03-01T09:00:00Z", UpdatedAt: "2024-03-01T09:20:00Z", Repository: repo},
+		{ID: 2, RunAttempt: 1, Name: "Lint", WorkflowID: 11, Event: "push", HeadSHA: "abc", Status: "completed", Conclusion: "success",
+			CreatedAt: "2024-03-01T09:00:00Z", UpdatedAt: "2024-03-01T09:03:00Z", Repository: repo},
+		// Triggered by CI finishing; Lint finished much earlier
+		{ID: 3, RunAttempt: 1, Name: "Deploy", WorkflowID: 12, Event: "workflow_run", HeadSHA: "abc", Status: "completed", Conclusion: "success",
+			CreatedAt: "2024-03-01T09:20:05Z", UpdatedAt: "2024-03-01T09:30:00Z", Repository: repo},
+		// Triggered by Deploy
+		{ID: 4, RunAttempt: 1, Name: "Smoke test", WorkflowID: 13, Event: "workflow_run", HeadSHA: "abc", Status: "completed", Conclusion: "success",
+			CreatedAt: "2024-03-01T09:30:02Z", UpdatedAt: "2024-03-01T09:33:00Z", Repository: repo},
+		// Nothing finished shortly before it
+		{ID: 5, RunAttempt: 1, Name: "Nightly", WorkflowID: 14, Event: "workflow_run", HeadSHA: "abc", Status: "completed", Conclusion: "success",
+			CreatedAt: "2024-03-01T12:00:00Z", UpdatedAt: "2024-03-01T13:00:00Z", Repository: repo},
+	}
+}
+
+func TestFindWorkflowTriggers(t *testing.T) {
+	t.Parallel()
+
+	runs := chainTestRuns()
+	triggers := FindWorkflowTriggers(runs)
+	assert.Len(t, triggers, 2)
+	assert.Equal(t, int64(1), triggers[3].ID)
+	assert.Equal(t, int64(3), triggers[4].ID)
+
+	// Runs of another commit cannot have triggered it, nor runs finishing
+	// long before it
+	runs[0].HeadSHA = "def"
+	assert.Empty(t, FindWorkflowTriggers(runs[:3]))
+}
+
+func TestBuildWorkflowChains(t *testing.T) {
+	t.Parallel()
+
+	commitMs := time.Date(2024, 3, 1, 8, 58, 0, 0, time.UTC).UnixMilli()
+	chains := BuildWorkflowChains(chainTestRuns(), commitMs)
+	if !assert.Len(t, chains, 1) {
+		return
+	}
+	chain := chains[0]
+	assert.Equal(t, int64(1), chain.ID)
+	assert.Equal(t, "CI → Deploy → Smoke test", chain.Name())
+	assert.Equal(t, []int64{0, 1, 3}, []int64{chain.Runs[0].TriggeredBy, chain.Runs[1].TriggeredBy, chain.Runs[2].TriggeredBy})
+	assert.Equal(t, (35 * time.Minute).Milliseconds(), chain.EndToEndMs(), "commit to the end of the smoke test")
+	assert.Equal(t, "success", chain.Conclusion())
+
+	chain.CommitMs = 0
+	assert.Equal(t, (33 * time.Minute).Milliseconds(), chain.EndToEndMs(), "falls back to the first run")
+	chain.Runs[2].EndMs = 0
+	assert.True(t, chain.IsPending())
+	assert.Equal(t, int64(-1), chain.EndToEndMs())
+
+	assert.Nil(t, BuildWorkflowChains(chainTestRuns()[:2], 0))
+}
+
+func TestChainMemberLink(t *testing.T) {
+	t.Parallel()
+
+	chains := BuildWorkflowChains(chainTestRuns(), 0)
+	members := chainMembers(chains)
+
+	_, ok := members[1].link()
+	assert.False(t, ok, "the root run has no trigger")
+	_, chained := members[5]
+	assert.False(t, chained)
+
+	link, ok := members[3].link()
+	assert.True(t, ok)
+	assert.Equal(t, githubapi.NewTraceID(1, 1), link.SpanContext.TraceID())
+	assert.Equal(t, githubapi.NewSpanID(1), link.SpanContext.SpanID())
+
+	attrs := make(map[string]string)
+	for _, kv := range members[4].attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+	assert.Equal(t, map[string]string{
+		AttrWorkflowChain:         "1",
+		AttrWorkflowChainPosition: "2",
+		AttrTriggeredByRunID:      "3",
+	}, attrs)
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span types of checks reported outside GitHub Actions.
+const (
+	SpanTypeCheckRun     = "check_run"
+	SpanTypeCommitStatus = "commit_status"
+)
+
+// IsActionsCheckRun reports whether a check run was created by GitHub Actions,
+// whose jobs already have their own spans.
+func IsActionsCheckRun(run githubapi.CheckRun) bool {
+	return run.App != nil && (run.App.ID == GitHubActionsAppID || run.App.Slug == "github-actions")
+}
+
+// CommitStatusRuns folds the legacy statuses of each context into one check
+// run-shaped record: started at the first status, completed at the latest
+// one unless that is still pending. The status creator stands in for the app.
+// Records are ordered by start time.
+func CommitStatusRuns(statuses []githubapi.CommitStatus) []githubapi.CheckRun {
+	byContext := make(map[string][]githubapi.CommitStatus)
+	var contexts []string
+	for _, status := range statuses {
+		if status.Context == "" {
+			continue
+		}
+		if _, ok := byContext[status.Context]; !ok {
+			contexts = append(contexts, status.Context)
+		}
+		byContext[status.Context] = append(byContext[status.Context], status)
+	}
+
+	var runs []githubapi.CheckRun
+	for _, context := range contexts {
+		history := byContext[context]
+		// The API lists statuses newest first; don't rely on it
+		sort.SliceStable(history, func(i, j int) bool {
+			if history[i].CreatedAt != history[j].CreatedAt {
+				return history[i].CreatedAt < history[j].CreatedAt
+			}
+			return history[i].ID < history[j].ID
+		})
+		first, latest := history[0], history[len(history)-1]
+		run := githubapi.CheckRun{
+			ID:         latest.ID,
+			Name:       context,
+			Status:     "in_progress",
+			StartedAt:  first.CreatedAt,
+			HTMLURL:    latest.TargetURL,
+			DetailsURL: latest.TargetURL,
+		}
+		if latest.State != "pending" {
+			run.Status = "completed"
+			run.CompletedAt = firstNonEmpty(latest.UpdatedAt, latest.CreatedAt)
+			run.Conclusion = latest.State
+			if latest.State == "error" {
+				run.Conclusion = "failure"
+			}
+		}
+		if latest.Creator != nil {
+			run.App = &githubapi.CheckRunApp{Slug: latest.Creator.Login, Name: latest.Creator.Login}
+		}
+		runs = append(runs, run)
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartedAt < runs[j].StartedAt })
+	return runs
+}
+
+// EmitChecks creates spans for the check runs and commit statuses of the head
+// commit that GitHub Actions did not report, e.g. CircleCI, Buildkite or
+// Vercel. They are root spans so they sit next to the workflows under the
+// PR or commit. Returns the unix millisecond bounds of the spans, zero when
+// there are none.
+func (e *TraceEmitter) EmitChecks(data *RawData, urlIndex int) (earliestMs, latestMs int64) {
+	emit := func(kind string, run githubapi.CheckRun) {
+		start, ok := utils.ParseTime(run.StartedAt)
+		if !ok {
+			return // queued, never started
+		}
+		// Still running: extend to now like pending jobs
+		end := time.Now()
+		if t, ok := utils.ParseTime(run.CompletedAt); ok && run.Status == "completed" {
+			end = t
+		}
+		if !end.After(start) {
+			end = start.Add(time.Millisecond)
+		}
+
+		required := false
+		for _, check := range data.RequiredChecks {
+			if check.Matches(run) {
+				required = true
+				break
+			}
+		}
+		app := ""
+		if run.App != nil {
+			app = firstNonEmpty(run.App.Name, run.App.Slug)
+		}
+		name := run.Name
+		if required {
+			name += " 🔒"
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.String("type", kind),
+			attribute.String("github.check_name", run.Name),
+			attribute.String("github.app", app),
+			attribute.String("github.status", run.Status),
+			attribute.String("github.conclusion", run.Conclusion),
+			attribute.String("github.url", firstNonEmpty(run.DetailsURL, run.HTMLURL)),
+			attribute.Int("github.url_index", urlIndex),
+			attribute.Bool("github.is_required", required),
+			attribute.String("vcs.revision", data.HeadSHA),
+			attribute.String("cicd.pipeline.task.run.result", ghConclusionToResult(run.Conclusion)),
+		}
+		if kind == SpanTypeCheckRun {
+			attrs = append(attrs, attribute.Int64("github.check_run_id", run.ID))
+		}
+		e.builder.Add(tracetest.SpanStub{
+			Name: name,
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				SpanID:     githubapi.NewSpanIDFromString(fmt.Sprintf("%s-%s-%s-%d", kind, data.HeadSHA, run.Name, run.ID)),
+				TraceFlags: trace.FlagsSampled,
+			}),
+			StartTime:  start,
+			EndTime:    end,
+			Attributes: attrs,
+			Status:     ghConclusionToStatus(run.Conclusion),
+		})
+
+		if earliestMs == 0 || start.UnixMilli() < earliestMs {
+			earliestMs = start.UnixMilli()
+		}
+		if end.UnixMilli() > latestMs {
+			latestMs = end.UnixMilli()
+		}
+	}
+
+	for _, run := range data.CheckRuns {
+		if !IsActionsCheckRun(run) {
+			emit(SpanTypeCheckRun, run)
+		}
+	}
+	for _, run := range CommitStatusRuns(data.CommitStatuses) {
+		emit(SpanTypeCommitStatus, run)
+	}
+	return earliestMs, latestMs
+}
@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitStatusRuns(t *testing.T) {
+	t.Parallel()
+
+	vercel := &githubapi.UserInfo{Login: "vercel[bot]"}
+	runs := CommitStatusRuns([]githubapi.CommitStatus{
+		{ID: 4, Context: "ci/buildkite", State: "pending", CreatedAt: "2024-03-01T09:02:00Z", TargetURL: "https://buildkite.com/o/r/builds/7"},
+		{ID: 3, Context: "Vercel", State: "error", CreatedAt: "2024-03-01T09:06:00Z", TargetURL: "https://vercel.com/o/r/2", Creator: vercel},
+		{ID: 1, Context: "Vercel", State: "pending", CreatedAt: "2024-03-01T09:01:00Z", TargetURL: "https://vercel.com/o/r/1", Creator: vercel},
+	})
+
+	assert.Equal(t, []githubapi.CheckRun{
+		{
+			ID:          3,
+			Name:        "Vercel",
+			Status:      "completed",
+			Conclusion:  "failure",
+			StartedAt:   "2024-03-01T09:01:00Z",
+			CompletedAt: "2024-03-01T09:06:00Z",
+			HTMLURL:     "https://vercel.com/o/r/2",
+			DetailsURL:  "https://vercel.com/o/r/2",
+			App:         &githubapi.CheckRunApp{Slug: "vercel[bot]", Name: "vercel[bot]"},
+		},
+		{
+			ID:         4,
+			Name:       "ci/buildkite",
+			Status:     "in_progress",
+			StartedAt:  "2024-03-01T09:02:00Z",
+			HTMLURL:    "https://buildkite.com/o/r/builds/7",
+			DetailsURL: "https://buildkite.com/o/r/builds/7",
+		},
+	}, runs)
+}
+
+func TestEmitChecks(t *testing.T) {
+	t.Parallel()
+
+	builder := &SpanBuilder{}
+	emitter := NewTraceEmitter(builder)
+	earliest, latest := emitter.EmitChecks(&RawData{
+		HeadSHA:        "abc123",
+		RequiredChecks: []RequiredCheck{{Context: "ci/circleci: test"}},
+		CheckRuns: []githubapi.CheckRun{
+			{ID: 1, Name: "build", Status: "completed", Conclusion: "success", StartedAt: "2024-03-01T09:00:00Z", CompletedAt: "2024-03-01T09:10:00Z",
+				App: &githubapi.CheckRunApp{ID: GitHubActionsAppID, Slug: "github-actions"}},
+			{ID: 2, Name: "ci/circleci: test", Status: "completed", Conclusion: "failure", StartedAt: "2024-03-01T09:01:00Z", CompletedAt: "2024-03-01T09:20:00Z",
+				DetailsURL: "https://circleci.com/gh/o/r/2", App: &githubapi.CheckRunApp{ID: 18001, Slug: "circleci-checks", Name: "CircleCI Checks"}},
+			{ID: 3, Name: "queued", Status: "queued"},
+		},
+		CommitStatuses: []githubapi.CommitStatus{
+			{ID: 9, Context: "Vercel", State: "success", CreatedAt: "2024-03-01T09:05:00Z", Creator: &githubapi.UserInfo{Login: "vercel[bot]"}},
+		},
+	}, 2)
+
+	spans := builder.Spans()
+	if !assert.Len(t, spans, 2, "actions and never-started check runs have no span") {
+		return
+	}
+	assert.Equal(t, "ci/circleci: test 🔒", spans[0].Name())
+	attrs := make(map[string]string)
+	for _, kv := range spans[0].Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, SpanTypeCheckRun, attrs["type"])
+	assert.Equal(t, "CircleCI Checks", attrs["github.app"])
+	assert.Equal(t, "failure", attrs["github.conclusion"])
+	assert.Equal(t, "https://circleci.com/gh/o/r/2", attrs["github.url"])
+	assert.Equal(t, "true", attrs["github.is_required"])
+	assert.Equal(t, "2", attrs["github.url_index"])
+	assert.False(t, spans[0].Parent().IsValid(), "checks sit directly under the PR or commit")
+
+	assert.Equal(t, "Vercel", spans[1].Name())
+	assert.Equal(t, SpanTypeCommitStatus, spanAttrs(spans[1])["type"])
+
+	assert.Equal(t, spans[0].StartTime().UnixMilli(), earliest)
+	assert.Equal(t, spans[0].EndTime().UnixMilli(), latest)
+}
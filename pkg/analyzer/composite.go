@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AttrCompositeAction names the step whose composite action ran an inner
+// step.
+const AttrCompositeAction = "github.composite_action"
+
+// LogGroup is a "##[group]Run ..." line of a job log, which the runner
+// writes as each step, or each step of a composite action, starts.
+type LogGroup struct {
+	Time  time.Time
+	Title string // e.g. "actions/checkout@v4" or the first line of a script
+}
+
+// ParseLogGroups extracts the step groups of a job log. Each log line starts
+// with an RFC 3339 timestamp.
+func ParseLogGroups(log string) []LogGroup {
+	var groups []LogGroup
+	for _, line := range strings.Split(log, "\n") {
+		ts, rest, ok := strings.Cut(strings.TrimRight(line, "\r"), " ")
+		if !ok {
+			continue
+		}
+		title, ok := strings.CutPrefix(rest, "##[group]Run ")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, strings.TrimPrefix(ts, "\ufeff"))
+		if err != nil {
+			continue
+		}
+		groups = append(groups, LogGroup{Time: t, Title: strings.TrimSpace(title)})
+	}
+	return groups
+}
+
+// CompositeStep is a step run inside a composite action.
+type CompositeStep struct {
+	Name       string
+	Start, End time.Time
+	Conclusion string
+}
+
+// CompositeSteps assigns log groups to the job's steps and returns the inner
+// steps of those that ran composite actions, keyed by step number. The first
+// group of a step is the step itself; a composite action logs another group
+// per inner step. Each inner step lasts until the next one starts, the last
+// until its step ends and with the step's conclusion, as earlier inner steps
+// must have succeeded for it to run.
+func CompositeSteps(steps []githubapi.Step, groups []LogGroup) map[int][]CompositeStep {
+	type span struct {
+		step       githubapi.Step
+		start, end time.Time
+		groups     []LogGroup
+	}
+	var spans []*span
+	for _, step := range steps {
+		start, ok := utils.ParseTime(step.StartedAt)
+		if !ok {
+			continue
+		}
+		end, ok := utils.ParseTime(step.CompletedAt)
+		if !ok {
+			continue
+		}
+		spans = append(spans, &span{step: step, start: start, end: end})
+	}
+
+	for _, g := range groups {
+		// Step times are truncated to the second, group times are not
+		var owner *span
+		for _, s := range spans {
+			if !g.Time.Before(s.start) {
+				owner = s
+			}
+		}
+		if owner != nil {
+			owner.groups = append(owner.groups, g)
+		}
+	}
+
+	composite := make(map[int][]CompositeStep)
+	for _, s := range spans {
+		if len(s.groups) < 2 {
+			continue
+		}
+		inner := s.groups[1:]
+		for i, g := range inner {
+			end := s.end
+			conclusion := s.step.Conclusion
+			if i+1 < len(inner) {
+				end = inner[i+1].Time
+				conclusion = "success"
+			}
+			if end.Before(g.Time) {
+				end = g.Time
+			}
+			composite[s.step.Number] = append(composite[s.step.Number], CompositeStep{
+				Name:       g.Title,
+				Start:      g.Time,
+				End:        end,
+				Conclusion: conclusion,
+			})
+		}
+	}
+	return composite
+}
+
+// emitCompositeSteps emits the inner steps of a job's composite action steps
+// as children of the step spans.
+func emitCompositeSteps(job githubapi.Job, composite map[int][]CompositeStep, builder *SpanBuilder, traceID trace.TraceID) {
+	for _, step := range job.Steps {
+		inner := composite[step.Number]
+		if len(inner) == 0 {
+			continue
+		}
+		stepSC := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     githubapi.NewSpanIDFromString(fmt.Sprintf("%d-%s", job.ID, step.Name)),
+			TraceFlags: trace.FlagsSampled,
+		})
+		for i, cs := range inner {
+			builder.Add(tracetest.SpanStub{
+				Name: cs.Name,
+				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID:    traceID,
+					SpanID:     githubapi.NewSpanIDFromString(fmt.Sprintf("%d-%s-%d", job.ID, step.Name, i)),
+					TraceFlags: trace.FlagsSampled,
+				}),
+				Parent:    stepSC,
+				StartTime: cs.Start,
+				EndTime:   cs.End,
+				Attributes: []attribute.KeyValue{
+					attribute.String("cicd.pipeline.task.name", cs.Name),
+					attribute.String("cicd.pipeline.task.run.result", ghConclusionToResult(cs.Conclusion)),
+					attribute.String("type", "step"),
+					attribute.String("github.status", "completed"),
+					attribute.String("github.conclusion", cs.Conclusion),
+					attribute.String(AttrCompositeAction, step.Name),
+				},
+				Status: ghConclusionToStatus(cs.Conclusion),
+			})
+		}
+	}
+}
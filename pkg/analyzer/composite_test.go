@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const compositeTestLog = "\ufeff2026-03-18T17:01:00.1000000Z ##[group]Run actions/checkout@v4\n" +
+	"2026-03-18T17:01:00.2000000Z with:\n" +
+	"2026-03-18T17:01:00.3000000Z ##[endgroup]\n" +
+	"2026-03-18T17:01:05.1000000Z ##[group]Run ./.github/actions/setup\n" +
+	"2026-03-18T17:01:05.2000000Z ##[endgroup]\n" +
+	"2026-03-18T17:01:05.3000000Z ##[group]Run actions/setup-go@v5\r\n" +
+	"2026-03-18T17:01:20.0000000Z ##[group]Run go mod download\n" +
+	"2026-03-18T17:02:00.5000000Z ##[group]Run go test ./...\n"
+
+func TestParseLogGroups(t *testing.T) {
+	t.Parallel()
+
+	groups := ParseLogGroups(compositeTestLog)
+	var titles []string
+	for _, g := range groups {
+		titles = append(titles, g.Title)
+	}
+	assert.Equal(t, []string{
+		"actions/checkout@v4",
+		"./.github/actions/setup",
+		"actions/setup-go@v5",
+		"go mod download",
+		"go test ./...",
+	}, titles)
+	assert.Equal(t, time.Date(2026, 3, 18, 17, 1, 0, 100_000_000, time.UTC), groups[0].Time)
+}
+
+func TestCompositeSteps(t *testing.T) {
+	t.Parallel()
+
+	steps := []githubapi.Step{
+		{Number: 1, Name: "Run actions/checkout@v4", Conclusion: "success", StartedAt: "2026-03-18T17:01:00Z", CompletedAt: "2026-03-18T17:01:05Z"},
+		{Number: 2, Name: "Setup", Conclusion: "failure", StartedAt: "2026-03-18T17:01:05Z", CompletedAt: "2026-03-18T17:02:00Z"},
+		{Number: 3, Name: "Run go test ./...", Conclusion: "skipped", StartedAt: "2026-03-18T17:02:00Z", CompletedAt: "2026-03-18T17:02:00Z"},
+	}
+	composite := CompositeSteps(steps, ParseLogGroups(compositeTestLog))
+
+	assert.NotContains(t, composite, 1, "a plain step has no inner steps")
+	assert.NotContains(t, composite, 3)
+	inner := composite[2]
+	if !assert.Len(t, inner, 2) {
+		return
+	}
+	assert.Equal(t, "actions/setup-go@v5", inner[0].Name)
+	assert.Equal(t, "success", inner[0].Conclusion)
+	assert.Equal(t, inner[1].Start, inner[0].End, "runs until the next inner step")
+	assert.Equal(t, "go mod download", inner[1].Name)
+	assert.Equal(t, "failure", inner[1].Conclusion, "the last inner step ends the composite action")
+	assert.Equal(t, time.Date(2026, 3, 18, 17, 2, 0, 0, time.UTC), inner[1].End)
+}
+
+func TestEmitCompositeSteps(t *testing.T) {
+	t.Parallel()
+
+	job := githubapi.Job{ID: 42, Steps: []githubapi.Step{{Number: 2, Name: "Setup"}}}
+	builder := &SpanBuilder{}
+	start := time.Date(2026, 3, 18, 17, 1, 5, 0, time.UTC)
+	emitCompositeSteps(job, map[int][]CompositeStep{
+		2: {{Name: "actions/setup-go@v5", Start: start, End: start.Add(15 * time.Second), Conclusion: "success"}},
+	}, builder, githubapi.NewTraceID(1, 1))
+
+	spans := builder.Spans()
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+	assert.Equal(t, "actions/setup-go@v5", spans[0].Name())
+	assert.Equal(t, githubapi.NewSpanIDFromString("42-Setup"), spans[0].Parent().SpanID(), "nested under the step span")
+	attrs := spanAttrs(spans[0])
+	assert.Equal(t, "step", attrs["type"])
+	assert.Equal(t, "Setup", attrs[AttrCompositeAction])
+}
@@ -23,10 +23,21 @@ type RawData struct {
 	MergedAtMs             *int64
 	CommitTimeMs           *int64
 	CommitPushedAtMs       *int64
+	OpenedAtMs             *int64 // when the (associated) PR was opened
+	FirstCommitMs          *int64 // author time of the (associated) PR's first commit
 	Runs                   []githubapi.WorkflowRun
 	AllCommitRunsCount     int
 	AllCommitRunsComputeMs int64
 	RequiredContexts       []string
+	RequiredChecks         []RequiredCheck
+	MergeGate              *MergeGate // nil without an associated PR
+	// Check runs and commit statuses reported for HeadSHA (PRs and commits)
+	CheckRuns      []githubapi.CheckRun
+	CommitStatuses []githubapi.CommitStatus
+	// Environment protection waits of jobs deploying HeadSHA
+	ApprovalWaits []ApprovalWait
+	// Runs of earlier pushes to the PR branch cancelled by newer pushes
+	SupersededWaste Waste
 	// VCS change stats (from PR or commit metadata — no extra API call)
 	ChangedFilesCount int
 	ChangedAdditions  int
@@ -58,12 +69,22 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 	var mergedAtMs *int64
 	var commitTimeMs *int64
 	var commitPushedAtMs *int64
+	var openedAtMs *int64
+	var firstCommitTimeMs *int64
 	var runs []githubapi.WorkflowRun
 	var changedFilesCount, changedAdditions, changedDeletions int
 	allCommitRunsCount := 0
 	var allCommitRunsComputeMs int64
 	var requiredContexts []string
+	var requiredChecks []RequiredCheck
+	var checkRuns []githubapi.CheckRun
+	var commitStatuses []githubapi.CommitStatus
+	var approvalWaits []ApprovalWait
+	var supersededWaste Waste
 	var protectionTargetBranch string
+	var mergeGate *MergeGate
+	// Head commit of the PR whose checks gate its merge; empty without a PR
+	var gateSHA string
 
 	if parsed.Type == "pr" {
 		if reporter != nil {
@@ -129,6 +150,14 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 			}
 		}
 
+		if t, ok := utils.ParseTime(prData.CreatedAt); ok {
+			ms := t.UnixMilli()
+			openedAtMs = &ms
+		}
+		if ms := firstCommitMs(ctx, p.client, parsed.Owner, parsed.Repo, parsed.Identifier); ms != 0 {
+			firstCommitTimeMs = &ms
+		}
+
 		// PR stats are already in the response — no extra API call
 		changedFilesCount = prData.ChangedFiles
 		changedAdditions = prData.Additions
@@ -142,8 +171,28 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 		if err != nil {
 			return nil, err
 		}
+
+		// Runs of earlier pushes to the branch that newer pushes cancelled,
+		// over the last supersededDays at most
+		if opened, ok := utils.ParseTime(prData.CreatedAt); ok && len(runs) > 0 {
+			if reporter != nil {
+				reporter.SetPhase("Fetching superseded runs")
+				reporter.SetDetail(branchName)
+			}
+			branchRuns, err := p.client.FetchRecentWorkflowRuns(ctx, parsed.Owner, parsed.Repo, min(daysSince(opened), supersededDays), branchName, "", nil)
+			if err == nil {
+				var sincePR []githubapi.WorkflowRun
+				for _, run := range branchRuns {
+					if t, ok := utils.ParseTime(run.CreatedAt); ok && !t.Before(opened) {
+						sincePR = append(sincePR, run)
+					}
+				}
+				supersededWaste = FetchSupersededWaste(ctx, p.client, parsed.Owner, parsed.Repo, runs, sincePR)
+			}
+		}
 		// Track target branch for branch protection lookup (base branch of PR)
 		protectionTargetBranch = prData.Base.Ref
+		gateSHA = headSHA
 	} else if parsed.Type == "run" {
 		runID, err := strconv.ParseInt(parsed.Identifier, 10, 64)
 		if err != nil {
@@ -176,6 +225,11 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 		prs, err := p.client.FetchCommitAssociatedPRs(ctx, parsed.Owner, parsed.Repo, headSHA)
 		if err == nil && len(prs) > 0 {
 			targetBranch = prs[0].Base.Ref
+			gateSHA = firstNonEmpty(prs[0].Head.SHA, headSHA)
+			if t, ok := utils.ParseTime(prs[0].CreatedAt); ok {
+				ms := t.UnixMilli()
+				openedAtMs = &ms
+			}
 			
 			// Fetch reviews and comments for the first associated PR
 			prNumber := fmt.Sprintf("%d", prs[0].Number)
@@ -183,6 +237,9 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 				reporter.SetPhase("Fetching associated PR metadata")
 				reporter.SetDetail(prNumber)
 			}
+			if ms := firstCommitMs(ctx, p.client, parsed.Owner, parsed.Repo, prNumber); ms != 0 {
+				firstCommitTimeMs = &ms
+			}
 			
 			reviews, err := p.client.FetchPRReviews(ctx, parsed.Owner, parsed.Repo, prNumber)
 			if err == nil {
@@ -354,18 +411,51 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 		protectionTargetBranch = branchName
 	}
 
-	// Fetch branch protection for target branch
+	// Every check run and legacy status of the head commit, so CI outside
+	// GitHub Actions shows up too. Failures only lose those spans.
+	if (parsed.Type == "pr" || parsed.Type == "commit") && headSHA != "" {
+		if reporter != nil {
+			reporter.SetPhase("Fetching check runs")
+			reporter.SetDetail(headSHA)
+		}
+		if runs, err := p.client.FetchCheckRunsForCommit(ctx, parsed.Owner, parsed.Repo, headSHA); err == nil {
+			checkRuns = runs
+		}
+		if statuses, err := p.client.FetchCommitStatuses(ctx, parsed.Owner, parsed.Repo, headSHA); err == nil {
+			commitStatuses = statuses
+		}
+		// Jobs deploying the head commit to protected environments
+		if opts.ApprovalWaits {
+			if waits, err := FetchApprovalWaits(ctx, p.client, parsed.Owner, parsed.Repo, headSHA); err == nil {
+				approvalWaits = waits
+			}
+		}
+	}
+
+	// Fetch branch protection and rulesets for target branch
 	if protectionTargetBranch != "" && protectionTargetBranch != "unknown" {
 		if reporter != nil {
 			reporter.SetPhase("Fetching branch protection")
 			reporter.SetDetail(protectionTargetBranch)
 		}
-		protection, err := p.client.FetchBranchProtection(ctx, parsed.Owner, parsed.Repo, protectionTargetBranch)
-		if err == nil && protection != nil && protection.RequiredStatusChecks != nil {
-			requiredContexts = append(requiredContexts, protection.RequiredStatusChecks.Contexts...)
-			for _, check := range protection.RequiredStatusChecks.Checks {
-				requiredContexts = append(requiredContexts, check.Context)
+		requiredChecks = FetchRequiredChecks(ctx, p.client, parsed.Owner, parsed.Repo, protectionTargetBranch)
+		requiredContexts = RequiredContexts(requiredChecks)
+
+		if gateSHA != "" {
+			// Legacy statuses satisfy required checks just like check runs
+			gateRuns := append(append([]githubapi.CheckRun{}, checkRuns...), CommitStatusRuns(commitStatuses)...)
+			if gateSHA != headSHA {
+				if reporter != nil {
+					reporter.SetPhase("Fetching check runs")
+					reporter.SetDetail(gateSHA)
+				}
+				gateRuns = FetchGateRuns(ctx, p.client, parsed.Owner, parsed.Repo, gateSHA)
+			}
+			var merged int64
+			if mergedAtMs != nil {
+				merged = *mergedAtMs
 			}
+			mergeGate = FindMergeGate(requiredChecks, gateRuns, merged)
 		}
 	}
 
@@ -440,10 +530,18 @@ func (p *DataProvider) Fetch(ctx context.Context, githubURL string, urlIndex int
 		MergedAtMs:             mergedAtMs,
 		CommitTimeMs:           commitTimeMs,
 		CommitPushedAtMs:       commitPushedAtMs,
+		OpenedAtMs:             openedAtMs,
+		FirstCommitMs:          firstCommitTimeMs,
 		Runs:                   runs,
 		AllCommitRunsCount:     allCommitRunsCount,
 		AllCommitRunsComputeMs: allCommitRunsComputeMs,
 		RequiredContexts:       requiredContexts,
+		RequiredChecks:         requiredChecks,
+		MergeGate:              mergeGate,
+		CheckRuns:              checkRuns,
+		CommitStatuses:         commitStatuses,
+		ApprovalWaits:          approvalWaits,
+		SupersededWaste:        supersededWaste,
 		ChangedFilesCount:      changedFilesCount,
 		ChangedAdditions:       changedAdditions,
 		ChangedDeletions:       changedDeletions,
@@ -459,6 +557,15 @@ func firstNonEmpty(values ...string) string {
 	return ""
 }
 
+func firstNonZero(values ...int64) int64 {
+	for _, value := range values {
+		if value != 0 {
+			return value
+		}
+	}
+	return 0
+}
+
 func resolvedUser(user *githubapi.UserInfo) string {
 	if user == nil {
 		return ""
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
 	"github.com/stretchr/testify/assert"
@@ -58,6 +59,12 @@ func TestCommitFetchFallsBackToUnfilteredRuns(t *testing.T) {
 	// Branch protection
 	mockClient.On("FetchBranchProtection", mock.Anything, "owner", "repo", "main").
 		Return((*githubapi.BranchProtection)(nil), nil)
+	mockClient.On("FetchBranchRules", mock.Anything, "owner", "repo", "main").
+		Return([]githubapi.BranchRule{}, nil)
+	mockClient.On("FetchCheckRunsForCommit", mock.Anything, "owner", "repo", sha).
+		Return([]githubapi.CheckRun{}, nil)
+	mockClient.On("FetchCommitStatuses", mock.Anything, "owner", "repo", sha).
+		Return([]githubapi.CommitStatus{}, nil)
 
 	provider := NewDataProvider(mockClient)
 	result, err := provider.Fetch(ctx, commitURL, 0, nil, AnalyzeOptions{})
@@ -108,6 +115,12 @@ func TestCommitFetchUsesFilteredRunsWhenAvailable(t *testing.T) {
 
 	mockClient.On("FetchBranchProtection", mock.Anything, "owner", "repo", "main").
 		Return((*githubapi.BranchProtection)(nil), nil)
+	mockClient.On("FetchBranchRules", mock.Anything, "owner", "repo", "main").
+		Return([]githubapi.BranchRule{}, nil)
+	mockClient.On("FetchCheckRunsForCommit", mock.Anything, "owner", "repo", sha).
+		Return([]githubapi.CheckRun{}, nil)
+	mockClient.On("FetchCommitStatuses", mock.Anything, "owner", "repo", sha).
+		Return([]githubapi.CommitStatus{}, nil)
 
 	provider := NewDataProvider(mockClient)
 	result, err := provider.Fetch(ctx, commitURL, 0, nil, AnalyzeOptions{})
@@ -116,3 +129,62 @@ func TestCommitFetchUsesFilteredRunsWhenAvailable(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, filteredRuns, result.Runs, "should use filtered runs when branch+push filter returns results")
 }
+
+func TestPRFetchFindsMergeGate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseURL := "https://api.github.com/repos/owner/repo"
+	mergedAt := "2026-01-15T11:00:00Z"
+
+	mockClient := new(mockGitHubProvider)
+	mockClient.On("FetchPullRequest", mock.Anything, baseURL, "9").
+		Return(&githubapi.PullRequest{
+			Number:    9,
+			CreatedAt: "2026-01-15T09:00:00Z",
+			Head:      githubapi.PRRef{Ref: "feature", SHA: "abc123"},
+			Base:      githubapi.PRRef{Ref: "main"},
+			MergedAt:  &mergedAt,
+		}, nil)
+	mockClient.On("FetchPRReviews", mock.Anything, "owner", "repo", "9").Return([]githubapi.Review{}, nil)
+	mockClient.On("FetchPRComments", mock.Anything, "owner", "repo", "9").Return([]githubapi.Review{}, nil)
+	mockClient.On("FetchPRCommits", mock.Anything, "owner", "repo", "9").Return([]githubapi.PRCommit{
+		{SHA: "abc123", Commit: githubapi.CommitDetails{Author: githubapi.CommitAuthor{Date: "2026-01-15T08:30:00Z"}}},
+	}, nil)
+	mockClient.On("FetchWorkflowRuns", mock.Anything, baseURL, "abc123", "", "").Return([]githubapi.WorkflowRun{}, nil)
+	mockClient.On("FetchBranchProtection", mock.Anything, "owner", "repo", "main").
+		Return((*githubapi.BranchProtection)(nil), nil)
+	mockClient.On("FetchBranchRules", mock.Anything, "owner", "repo", "main").
+		Return([]githubapi.BranchRule{{Type: "required_status_checks", Parameters: &githubapi.RuleParameters{
+			RequiredStatusChecks: []githubapi.RequiredStatusCheck{{Context: "build"}, {Context: "security/scan"}, {Context: "ci/circleci"}},
+		}}}, nil)
+	mockClient.On("FetchCheckRunsForCommit", mock.Anything, "owner", "repo", "abc123").
+		Return([]githubapi.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success", StartedAt: "2026-01-15T09:00:00Z", CompletedAt: "2026-01-15T09:10:00Z"},
+			{Name: "security/scan", Status: "completed", Conclusion: "success", StartedAt: "2026-01-15T09:00:00Z", CompletedAt: "2026-01-15T10:30:00Z",
+				App: &githubapi.CheckRunApp{ID: 1, Slug: "scanner"}},
+			{Name: "docs", Status: "completed", Conclusion: "success", StartedAt: "2026-01-15T09:00:00Z", CompletedAt: "2026-01-15T10:45:00Z"},
+		}, nil)
+	mockClient.On("FetchCommitStatuses", mock.Anything, "owner", "repo", "abc123").
+		Return([]githubapi.CommitStatus{
+			{ID: 2, Context: "ci/circleci", State: "success", CreatedAt: "2026-01-15T10:40:00Z", Creator: &githubapi.UserInfo{Login: "circleci"}},
+			{ID: 1, Context: "ci/circleci", State: "pending", CreatedAt: "2026-01-15T09:05:00Z", Creator: &githubapi.UserInfo{Login: "circleci"}},
+		}, nil)
+
+	provider := NewDataProvider(mockClient)
+	result, err := provider.Fetch(ctx, "https://github.com/owner/repo/pull/9", 0, nil, AnalyzeOptions{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, []string{"build", "security/scan", "ci/circleci"}, result.RequiredContexts)
+	assert.Len(t, result.CheckRuns, 3)
+	assert.Len(t, result.CommitStatuses, 2)
+	if assert.NotNil(t, result.FirstCommitMs) {
+		assert.Equal(t, time.Date(2026, 1, 15, 8, 30, 0, 0, time.UTC).UnixMilli(), *result.FirstCommitMs)
+	}
+	if assert.NotNil(t, result.MergeGate) {
+		assert.Equal(t, "ci/circleci", result.MergeGate.Check, "docs is not required; legacy statuses count")
+		assert.Equal(t, "circleci", result.MergeGate.App)
+		assert.Equal(t, RequiredCheckSourceRuleset, result.MergeGate.Source)
+	}
+}
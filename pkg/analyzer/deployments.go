@@ -0,0 +1,312 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanTypeApprovalWait is the span type of a job waiting on an environment's
+// protection rules: required reviewers or a wait timer.
+const SpanTypeApprovalWait = "approval_wait"
+
+// Span attributes of approval waits.
+const (
+	AttrEnvironment       = "github.environment"
+	AttrApprovalState     = "github.approval.state" // approved, rejected or waiting
+	AttrApprovalUser      = "github.approval.user"
+	AttrApprovalComment   = "github.approval.comment"
+	AttrApprovalReviewers = "github.approval.reviewers" // comma-separated, while waiting
+	AttrApprovalWaitMs    = "approval_wait_ms"
+)
+
+// ApprovalWait is the time a job spent waiting for an environment's
+// protection rules before its deployment could proceed. Times are unix
+// milliseconds.
+type ApprovalWait struct {
+	RunID        int64
+	JobID        int64 // 0 when the deployment does not link its job
+	DeploymentID int64
+	Environment  string
+	StartMs      int64
+	EndMs        int64  // 0 while waiting
+	State        string // approved, rejected or waiting
+	Approver     string // login of the reviewer; empty for wait timers
+	Comment      string
+	Reviewers    []string // users and teams that can approve, while waiting
+}
+
+// IsPending reports whether the job is still waiting.
+func (w ApprovalWait) IsPending() bool {
+	return w.EndMs == 0
+}
+
+// DurationMs is how long the job waited; -1 while it is still waiting.
+func (w ApprovalWait) DurationMs() int64 {
+	if w.IsPending() {
+		return -1
+	}
+	return max(w.EndMs-w.StartMs, 0)
+}
+
+// actionsJobURLPattern matches the job links of deployment statuses created
+// by Actions: .../actions/runs/<run>/job/<job>.
+var actionsJobURLPattern = regexp.MustCompile(`/actions/runs/(\d+)(?:/job/(\d+))?`)
+
+// parseActionsJobURL extracts the run and job IDs of an Actions link; the job
+// ID is 0 for run links.
+func parseActionsJobURL(u string) (runID, jobID int64, ok bool) {
+	m := actionsJobURLPattern.FindStringSubmatch(u)
+	if m == nil {
+		return 0, 0, false
+	}
+	runID, _ = strconv.ParseInt(m[1], 10, 64)
+	if m[2] != "" {
+		jobID, _ = strconv.ParseInt(m[2], 10, 64)
+	}
+	return runID, jobID, true
+}
+
+// ApprovalWaitsFromDeployments finds the protection rule waits of deployments
+// created by Actions jobs, in start order. A wait starts with the deployment's
+// "waiting" status and ends with the next status: rejected reviews fail the
+// deployment, anything else means it was allowed to proceed. statuses are
+// keyed by deployment ID, in any order.
+func ApprovalWaitsFromDeployments(deployments []githubapi.Deployment, statuses map[int64][]githubapi.DeploymentStatus) []ApprovalWait {
+	var waits []ApprovalWait
+	for _, d := range deployments {
+		sts := append([]githubapi.DeploymentStatus(nil), statuses[d.ID]...)
+		sort.SliceStable(sts, func(i, j int) bool { return sts[i].CreatedAt < sts[j].CreatedAt })
+
+		w := ApprovalWait{DeploymentID: d.ID, Environment: d.Environment}
+		for _, st := range sts {
+			if w.RunID != 0 {
+				break
+			}
+			for _, u := range []string{st.LogURL, st.TargetURL} {
+				if runID, jobID, ok := parseActionsJobURL(u); ok {
+					w.RunID, w.JobID = runID, jobID
+					break
+				}
+			}
+		}
+		if w.RunID == 0 {
+			continue // not deployed by Actions
+		}
+
+		waiting := -1
+		for i, st := range sts {
+			if st.State == "waiting" {
+				waiting = i
+				break
+			}
+		}
+		if waiting < 0 {
+			continue // no protection rules
+		}
+		t, ok := utils.ParseTime(sts[waiting].CreatedAt)
+		if !ok {
+			continue
+		}
+		w.StartMs = t.UnixMilli()
+		w.State = "waiting"
+		for _, st := range sts[waiting+1:] {
+			if st.State == "waiting" {
+				continue
+			}
+			if t, ok := utils.ParseTime(st.CreatedAt); ok {
+				w.EndMs = t.UnixMilli()
+			}
+			w.State = "approved"
+			if st.State == "failure" || st.State == "error" {
+				w.State = "rejected"
+			}
+			break
+		}
+		waits = append(waits, w)
+	}
+	sort.SliceStable(waits, func(i, j int) bool { return waits[i].StartMs < waits[j].StartMs })
+	return waits
+}
+
+// applyRunApprovals fills in who reviewed the waits of a run.
+func applyRunApprovals(waits []ApprovalWait, runID int64, approvals []githubapi.EnvironmentApproval) {
+	for i := range waits {
+		w := &waits[i]
+		if w.RunID != runID {
+			continue
+		}
+		for _, a := range approvals {
+			if !approvesEnvironment(a, w.Environment) {
+				continue
+			}
+			w.Approver = a.User.Login
+			w.Comment = a.Comment
+			if !w.IsPending() && a.State != "" {
+				w.State = a.State
+			}
+			break
+		}
+	}
+}
+
+// approvesEnvironment reports whether a review covers the environment.
+func approvesEnvironment(a githubapi.EnvironmentApproval, environment string) bool {
+	for _, env := range a.Environments {
+		if env.Name == environment {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPendingDeployments fills in who can approve the pending waits of a run.
+func applyPendingDeployments(waits []ApprovalWait, runID int64, pending []githubapi.PendingDeployment) {
+	for i := range waits {
+		w := &waits[i]
+		if w.RunID != runID || !w.IsPending() {
+			continue
+		}
+		for _, p := range pending {
+			if p.Environment.Name != w.Environment {
+				continue
+			}
+			for _, r := range p.Reviewers {
+				w.Reviewers = append(w.Reviewers, firstNonEmpty(r.Reviewer.Login, r.Reviewer.Slug, r.Reviewer.Name))
+			}
+		}
+	}
+}
+
+// FetchApprovalWaits fetches the deployments of a commit and returns the
+// protection rule waits of its Actions jobs, with their reviewers. Reviews
+// and pending deployments are best-effort.
+func FetchApprovalWaits(ctx context.Context, client githubapi.GitHubProvider, owner, repo, sha string) ([]ApprovalWait, error) {
+	deployments, err := client.FetchDeployments(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[int64][]githubapi.DeploymentStatus, len(deployments))
+	for _, d := range deployments {
+		if sts, err := client.FetchDeploymentStatuses(ctx, owner, repo, d.ID); err == nil {
+			statuses[d.ID] = sts
+		}
+	}
+	waits := ApprovalWaitsFromDeployments(deployments, statuses)
+
+	seen := make(map[int64]bool)
+	for _, w := range waits {
+		if seen[w.RunID] {
+			continue
+		}
+		seen[w.RunID] = true
+		if approvals, err := client.FetchRunApprovals(ctx, owner, repo, w.RunID); err == nil {
+			applyRunApprovals(waits, w.RunID, approvals)
+		}
+		pending := false
+		for _, other := range waits {
+			pending = pending || (other.RunID == w.RunID && other.IsPending())
+		}
+		if pending {
+			if p, err := client.FetchPendingDeployments(ctx, owner, repo, w.RunID); err == nil {
+				applyPendingDeployments(waits, w.RunID, p)
+			}
+		}
+	}
+	return waits, nil
+}
+
+// approvalWaitsByJob indexes the finished waits of a run by job ID.
+func approvalWaitsByJob(waits []ApprovalWait, runID int64) map[int64]*ApprovalWait {
+	byJob := make(map[int64]*ApprovalWait)
+	for i := range waits {
+		w := &waits[i]
+		if w.RunID == runID && w.JobID != 0 {
+			byJob[w.JobID] = w
+		}
+	}
+	return byJob
+}
+
+// emitApprovalWaits emits a span per protection rule wait of the run. Waits
+// of jobs that went on to run are children of the job span, next to its queued
+// span; others are children of the workflow span.
+func emitApprovalWaits(waits []ApprovalWait, run githubapi.WorkflowRun, jobs []githubapi.Job, builder *SpanBuilder, traceID trace.TraceID, wfSC trace.SpanContext) {
+	started := make(map[int64]bool, len(jobs))
+	for _, job := range jobs {
+		started[job.ID] = job.StartedAt != ""
+	}
+	for _, w := range waits {
+		if w.RunID != run.ID {
+			continue
+		}
+		parent := wfSC
+		if w.State == "approved" && started[w.JobID] {
+			parent = trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     githubapi.NewSpanID(w.JobID),
+				TraceFlags: trace.FlagsSampled,
+			})
+		}
+
+		start := time.UnixMilli(w.StartMs)
+		end := time.Now()
+		status, conclusion := "waiting", ""
+		if !w.IsPending() {
+			end = time.UnixMilli(w.EndMs)
+			status, conclusion = "completed", "success"
+			if w.State == "rejected" {
+				conclusion = "failure"
+			}
+		}
+		if !end.After(start) {
+			end = start.Add(time.Millisecond)
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.String("type", SpanTypeApprovalWait),
+			attribute.String(AttrEnvironment, w.Environment),
+			attribute.String(AttrApprovalState, w.State),
+			attribute.String("github.status", status),
+			attribute.String("github.conclusion", conclusion),
+			attribute.String("github.url", fmt.Sprintf("https://github.com/%s/%s/actions/runs/%d", run.Repository.Owner.Login, run.Repository.Name, run.ID)),
+			attribute.Int64("github.deployment_id", w.DeploymentID),
+			attribute.Int64(AttrApprovalWaitMs, end.Sub(start).Milliseconds()),
+		}
+		if w.JobID != 0 {
+			attrs = append(attrs, attribute.Int64("github.job_id", w.JobID))
+		}
+		if w.Approver != "" {
+			attrs = append(attrs, attribute.String(AttrApprovalUser, w.Approver))
+		}
+		if w.Comment != "" {
+			attrs = append(attrs, attribute.String(AttrApprovalComment, w.Comment))
+		}
+		if len(w.Reviewers) > 0 {
+			attrs = append(attrs, attribute.String(AttrApprovalReviewers, strings.Join(w.Reviewers, ",")))
+		}
+		builder.Add(tracetest.SpanStub{
+			Name: "🛡️ Approval: " + w.Environment,
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     githubapi.NewSpanIDFromString(fmt.Sprintf("approval-%d", w.DeploymentID)),
+				TraceFlags: trace.FlagsSampled,
+			}),
+			Parent:     parent,
+			StartTime:  start,
+			EndTime:    end,
+			Attributes: attrs,
+			Status:     ghConclusionToStatus(conclusion),
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func deploymentStatus(state, createdAt, logURL string) githubapi.DeploymentStatus {
+	return githubapi.DeploymentStatus{State: state, CreatedAt: "2026-03-18T" + createdAt + "Z", LogURL: logURL}
+}
+
+func TestParseActionsJobURL(t *testing.T) {
+	t.Parallel()
+
+	runID, jobID, ok := parseActionsJobURL("https://github.com/o/r/actions/runs/100/job/200")
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), runID)
+	assert.Equal(t, int64(200), jobID)
+
+	runID, jobID, ok = parseActionsJobURL("https://github.com/o/r/actions/runs/100")
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), runID)
+	assert.Equal(t, int64(0), jobID)
+
+	_, _, ok = parseActionsJobURL("https://vercel.com/o/r/deployments/1")
+	assert.False(t, ok)
+}
+
+func TestApprovalWaitsFromDeployments(t *testing.T) {
+	t.Parallel()
+
+	jobURL := "https://github.com/o/r/actions/runs/100/job/200"
+	deployments := []githubapi.Deployment{
+		{ID: 1, Environment: "production"},
+		{ID: 2, Environment: "staging"},
+		{ID: 3, Environment: "preview"},
+		{ID: 4, Environment: "qa"},
+	}
+	statuses := map[int64][]githubapi.DeploymentStatus{
+		// Out of order, as the API returns newest first
+		1: {
+			deploymentStatus("success", "17:20:00", jobURL),
+			deploymentStatus("queued", "17:15:00", jobURL),
+			deploymentStatus("waiting", "17:05:00", jobURL),
+		},
+		2: {
+			deploymentStatus("waiting", "17:01:00", "https://github.com/o/r/actions/runs/100/job/201"),
+			deploymentStatus("failure", "17:03:00", ""),
+		},
+		3: {
+			deploymentStatus("waiting", "17:02:00", "https://vercel.com/o/r/deployments/3"),
+		},
+		4: {
+			deploymentStatus("in_progress", "17:02:00", "https://github.com/o/r/actions/runs/100/job/202"),
+			deploymentStatus("success", "17:04:00", ""),
+		},
+	}
+
+	waits := ApprovalWaitsFromDeployments(deployments, statuses)
+	if !assert.Len(t, waits, 2, "deployments not made by Actions or without protection rules have no wait") {
+		return
+	}
+
+	staging := waits[0]
+	assert.Equal(t, "staging", staging.Environment)
+	assert.Equal(t, int64(201), staging.JobID)
+	assert.Equal(t, "rejected", staging.State)
+	assert.Equal(t, (2 * time.Minute).Milliseconds(), staging.DurationMs())
+
+	production := waits[1]
+	assert.Equal(t, int64(100), production.RunID)
+	assert.Equal(t, int64(200), production.JobID)
+	assert.Equal(t, "approved", production.State)
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), production.DurationMs(), "ends at the status after waiting")
+}
+
+func TestApprovalWaitsPending(t *testing.T) {
+	t.Parallel()
+
+	waits := ApprovalWaitsFromDeployments(
+		[]githubapi.Deployment{{ID: 1, Environment: "production"}},
+		map[int64][]githubapi.DeploymentStatus{1: {deploymentStatus("waiting", "17:05:00", "https://github.com/o/r/actions/runs/100/job/200")}},
+	)
+	if !assert.Len(t, waits, 1) {
+		return
+	}
+	assert.True(t, waits[0].IsPending())
+	assert.Equal(t, int64(-1), waits[0].DurationMs())
+
+	applyPendingDeployments(waits, 100, []githubapi.PendingDeployment{
+		{Environment: githubapi.EnvironmentRef{Name: "staging"}},
+		{
+			Environment: githubapi.EnvironmentRef{Name: "production"},
+			Reviewers: []githubapi.DeploymentReviewer{
+				{Type: "User", Reviewer: struct {
+					Login string `json:"login"`
+					Slug  string `json:"slug"`
+					Name  string `json:"name"`
+				}{Login: "octocat"}},
+				{Type: "Team", Reviewer: struct {
+					Login string `json:"login"`
+					Slug  string `json:"slug"`
+					Name  string `json:"name"`
+				}{Slug: "release", Name: "Release"}},
+			},
+		},
+	})
+	assert.Equal(t, []string{"octocat", "release"}, waits[0].Reviewers)
+}
+
+func TestApplyRunApprovals(t *testing.T) {
+	t.Parallel()
+
+	waits := []ApprovalWait{
+		{RunID: 100, Environment: "production", StartMs: 1, EndMs: 2, State: "approved"},
+		{RunID: 100, Environment: "staging", StartMs: 1, EndMs: 2, State: "approved"},
+		{RunID: 101, Environment: "production", StartMs: 1, EndMs: 2, State: "approved"},
+	}
+	applyRunApprovals(waits, 100, []githubapi.EnvironmentApproval{{
+		State:        "rejected",
+		Comment:      "not on a friday",
+		Environments: []githubapi.EnvironmentRef{{Name: "production"}},
+		User:         githubapi.UserInfo{Login: "octocat"},
+	}})
+
+	assert.Equal(t, "octocat", waits[0].Approver)
+	assert.Equal(t, "not on a friday", waits[0].Comment)
+	assert.Equal(t, "rejected", waits[0].State)
+	assert.Empty(t, waits[1].Approver, "other environments are untouched")
+	assert.Empty(t, waits[2].Approver, "other runs are untouched")
+}
+
+func TestFetchApprovalWaits(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockGitHubProvider)
+	m.On("FetchDeployments", mock.Anything, "o", "r", "abc123").Return([]githubapi.Deployment{{ID: 1, Environment: "production"}}, nil)
+	m.On("FetchDeploymentStatuses", mock.Anything, "o", "r", int64(1)).Return([]githubapi.DeploymentStatus{
+		deploymentStatus("waiting", "17:05:00", "https://github.com/o/r/actions/runs/100/job/200"),
+		deploymentStatus("queued", "17:15:00", ""),
+	}, nil)
+	m.On("FetchRunApprovals", mock.Anything, "o", "r", int64(100)).Return([]githubapi.EnvironmentApproval{{
+		State:        "approved",
+		Environments: []githubapi.EnvironmentRef{{Name: "production"}},
+		User:         githubapi.UserInfo{Login: "octocat"},
+	}}, nil)
+
+	waits, err := FetchApprovalWaits(context.Background(), m, "o", "r", "abc123")
+	assert.NoError(t, err)
+	m.AssertExpectations(t)
+	m.AssertNotCalled(t, "FetchPendingDeployments", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	if assert.Len(t, waits, 1) {
+		assert.Equal(t, "octocat", waits[0].Approver)
+		assert.Equal(t, "approved", waits[0].State)
+	}
+}
+
+func TestFetchApprovalWaitsForRunsLimitsCommits(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockGitHubProvider)
+	m.On("FetchDeployments", mock.Anything, "o", "r", mock.Anything).Return([]githubapi.Deployment{}, nil)
+
+	// A commit per queue time of 1 to 30 minutes, and one queued briefly
+	var runs []RunData
+	for i := 0; i <= 30; i++ {
+		queue := time.Duration(i) * time.Minute
+		if i == 0 {
+			queue = 30 * time.Second
+		}
+		runs = append(runs, RunData{HeadSHA: fmt.Sprintf("sha%d", i), Jobs: []JobData{{ID: int64(i), QueueTime: queue.Milliseconds()}}})
+	}
+	indices := make([]int, len(runs))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	fetchApprovalWaitsForRuns(context.Background(), m, "o", "r", runs, indices)
+	m.AssertNumberOfCalls(t, "FetchDeployments", maxApprovalCommits)
+	m.AssertCalled(t, "FetchDeployments", mock.Anything, "o", "r", "sha30")
+	for _, sha := range []string{"sha0", "sha1", "sha5"} {
+		m.AssertNotCalled(t, "FetchDeployments", mock.Anything, "o", "r", sha)
+	}
+}
+
+func TestEmitApprovalWaits(t *testing.T) {
+	t.Parallel()
+
+	run := githubapi.WorkflowRun{
+		ID: 100, RunAttempt: 1,
+		Repository: githubapi.RepoRef{Owner: githubapi.RepoOwner{Login: "o"}, Name: "r"},
+	}
+	jobs := []githubapi.Job{
+		{ID: 200, StartedAt: "2026-03-18T17:15:00Z"},
+		{ID: 201},
+	}
+	start := time.Date(2026, 3, 18, 17, 5, 0, 0, time.UTC)
+	waits := []ApprovalWait{
+		{RunID: 100, JobID: 200, DeploymentID: 1, Environment: "production", StartMs: start.UnixMilli(), EndMs: start.Add(10 * time.Minute).UnixMilli(), State: "approved", Approver: "octocat"},
+		{RunID: 100, JobID: 201, DeploymentID: 2, Environment: "staging", StartMs: start.UnixMilli(), Reviewers: []string{"octocat", "release"}},
+		{RunID: 999, JobID: 300, DeploymentID: 3, Environment: "other", StartMs: start.UnixMilli()},
+	}
+
+	builder := &SpanBuilder{}
+	traceID := githubapi.NewTraceID(100, 1)
+	wfSC := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     githubapi.NewSpanID(100),
+		TraceFlags: trace.FlagsSampled,
+	})
+	emitApprovalWaits(waits, run, jobs, builder, traceID, wfSC)
+
+	spans := builder.Spans()
+	if !assert.Len(t, spans, 2, "waits of other runs are skipped") {
+		return
+	}
+	byName := map[string]map[string]string{}
+	for _, s := range spans {
+		byName[s.Name()] = spanAttrs(s)
+		switch s.Name() {
+		case "🛡️ Approval: production":
+			assert.Equal(t, githubapi.NewSpanID(200), s.Parent().SpanID(), "approved waits sit under their job")
+			assert.Equal(t, 10*time.Minute, s.EndTime().Sub(s.StartTime()))
+		case "🛡️ Approval: staging":
+			assert.Equal(t, wfSC.SpanID(), s.Parent().SpanID(), "pending waits sit under the workflow")
+		}
+	}
+	assert.Equal(t, "octocat", byName["🛡️ Approval: production"][AttrApprovalUser])
+	assert.Equal(t, "approved", byName["🛡️ Approval: production"][AttrApprovalState])
+	assert.Equal(t, "octocat,release", byName["🛡️ Approval: staging"][AttrApprovalReviewers])
+	assert.Equal(t, "waiting", byName["🛡️ Approval: staging"]["github.status"])
+}
+
+func TestJobQueuedAt(t *testing.T) {
+	t.Parallel()
+
+	job := githubapi.Job{CreatedAt: "2026-03-18T17:00:00Z"}
+	created := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+	jobStart := created.Add(15 * time.Minute)
+
+	queued, ok := jobQueuedAt(job, jobStart, nil)
+	assert.True(t, ok)
+	assert.True(t, queued.Equal(created))
+
+	approved := &ApprovalWait{StartMs: created.UnixMilli(), EndMs: created.Add(10 * time.Minute).UnixMilli()}
+	queued, _ = jobQueuedAt(job, jobStart, approved)
+	assert.True(t, queued.Equal(created.Add(10*time.Minute)), "queue time starts once approved")
+
+	pending := &ApprovalWait{StartMs: created.UnixMilli()}
+	queued, _ = jobQueuedAt(job, jobStart, pending)
+	assert.True(t, queued.Equal(created))
+
+	_, ok = jobQueuedAt(githubapi.Job{}, jobStart, nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,288 @@
+package analyzer
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+)
+
+// Sources of failure messages.
+const (
+	FailureFromAnnotation = "annotation" // an error annotation of the job's check run
+	FailureFromLog        = "log"        // an error line near the end of the job's log
+)
+
+// FailureMessage is a message a failed job failed with.
+type FailureMessage struct {
+	Source  string // FailureFromAnnotation or FailureFromLog
+	Message string
+}
+
+// FailureCluster is a failure signature: similar messages of failed jobs,
+// normalized to what they have in common.
+type FailureCluster struct {
+	Signature string    // normalized message, e.g. "dial tcp <ip>:<n>: connection refused"
+	Example   string    // one of the messages as written
+	Source    string    // FailureFromAnnotation or FailureFromLog
+	Count     int       // failed jobs with the signature
+	Jobs      []string  // names of the affected jobs
+	FirstSeen time.Time // created time of the first run with the signature
+	LastSeen  time.Time // created time of the last run with the signature
+	URLs      []string  // sample failed job URLs (newest first)
+}
+
+// failureClusterLimit is how many signatures are reported.
+const failureClusterLimit = 10
+
+// failureSimilarity is how much of their words two signatures must share to
+// be clustered together.
+const failureSimilarity = 0.8
+
+// fetchFailuresForRuns collects the error annotations of the failed jobs of
+// the runs at the given indices and, with logs, an error line from the end of
+// their logs (best-effort). A job's check run ID is its job ID.
+func fetchFailuresForRuns(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, runData []RunData, indices []int, logs bool) {
+	for _, idx := range indices {
+		for j := range runData[idx].Jobs {
+			job := &runData[idx].Jobs[j]
+			if job.Conclusion != "failure" {
+				continue
+			}
+			if annotations, err := client.FetchAnnotations(ctx, owner, repo, job.ID); err == nil {
+				for _, a := range annotations {
+					if a.Level == "failure" && strings.TrimSpace(a.Message) != "" {
+						job.Failures = append(job.Failures, FailureMessage{Source: FailureFromAnnotation, Message: a.Message})
+					}
+				}
+			}
+			if logs {
+				if log, err := client.FetchJobLogs(ctx, owner, repo, job.ID); err == nil {
+					if line := logFailureLine(log); line != "" {
+						job.Failures = append(job.Failures, FailureMessage{Source: FailureFromLog, Message: line})
+					}
+				}
+			}
+		}
+	}
+}
+
+var (
+	logTimestampPattern = regexp.MustCompile(`^\x{feff}?\d{4}-\d{2}-\d{2}T[\d:.]+Z\s?`)
+	logErrorPattern     = regexp.MustCompile(`(?i)\b(error|errors|fail|failed|failure|panic|exception|fatal)\b`)
+)
+
+// logFailureTail is how many lines before the runner's final error
+// logFailureLine looks through.
+const logFailureTail = 30
+
+// logFailureLine picks the line a failed job's log most likely explains its
+// failure with: the last line that reads like an error in the tail of the
+// log before the runner's own "##[error]" line, or that line itself.
+func logFailureLine(log string) string {
+	lines := strings.Split(log, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(logTimestampPattern.ReplaceAllString(strings.TrimRight(lines[i], "\r"), ""))
+	}
+
+	end := len(lines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "##[error]") {
+			end = i
+			break
+		}
+	}
+	for i := end - 1; i >= max(0, end-logFailureTail); i-- {
+		if lines[i] != "" && !strings.HasPrefix(lines[i], "##[") && logErrorPattern.MatchString(lines[i]) {
+			return lines[i]
+		}
+	}
+	if end < len(lines) {
+		return strings.TrimPrefix(lines[end], "##[error]")
+	}
+	return ""
+}
+
+// Patterns NormalizeFailureMessage replaces, in order.
+var failureNormalizers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`https?://\S+`), "<url>"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d{1,2}:\d{2}:\d{2}(\.\d+)?\b`), "<time>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<id>"},
+	// Hex strings of 7+ digits need a letter, so long numbers stay <n>
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b|\b(?:[a-f][0-9a-f]{6,}|\d[a-f][0-9a-f]{5,}|\d{2}[a-f][0-9a-f]{4,}|\d{3}[a-f][0-9a-f]{3,}|\d{4}[a-f][0-9a-f]{2,}|\d{5}[a-f][0-9a-f]+|\d{6,}[a-f][0-9a-f]*)\b`), "<hex>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}\b`), "<ip>"},
+	// Paths need a letter in one of their segments, so ratios like 3/5 stay <n>/<n>
+	{regexp.MustCompile(`(?:[A-Za-z]:)?(?:(?:[\w.~-]*[/\\])*[\w.~-]*[A-Za-z][\w.~-]*[/\\](?:[\w.~-]*[/\\])*[\w.-]+|(?:[\w.~-]*[/\\])+[\w.-]*[A-Za-z][\w.-]*)`), "<path>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "<n>"},
+	{regexp.MustCompile(`\s+`), " "},
+}
+
+// failureSignatureMax caps the length of a signature.
+const failureSignatureMax = 200
+
+// NormalizeFailureMessage reduces a failure message to its signature: its
+// first line with URLs, timestamps, IDs, hex strings, addresses, paths and
+// numbers replaced by placeholders, so that the same failure in different
+// runs reads the same.
+func NormalizeFailureMessage(message string) string {
+	line := ""
+	for _, l := range strings.Split(message, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			line = l
+			break
+		}
+	}
+	for _, n := range failureNormalizers {
+		line = n.pattern.ReplaceAllString(line, n.replacement)
+	}
+	line = strings.TrimSpace(line)
+	if len(line) > failureSignatureMax {
+		line = line[:failureSignatureMax]
+	}
+	return line
+}
+
+// genericFailurePattern matches the annotation the runner adds to every
+// failed step, which says nothing about why it failed.
+var genericFailurePattern = regexp.MustCompile(`^Process completed with exit code <n>\.?$`)
+
+// clusterFailures groups the failure messages of the failed jobs of the runs
+// by signature, merging signatures that share most of their words, and
+// returns the most frequent. Each job counts once per signature. The runner's
+// generic "Process completed with exit code" annotation only counts for jobs
+// without a more specific message.
+func clusterFailures(runs []RunData) []FailureCluster {
+	type occurrence struct {
+		run RunData
+		job JobData
+		msg FailureMessage
+	}
+	bySignature := make(map[string][]occurrence)
+	var order []string
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			specific := false
+			for _, f := range job.Failures {
+				if !genericFailurePattern.MatchString(NormalizeFailureMessage(f.Message)) {
+					specific = true
+				}
+			}
+			seen := make(map[string]bool)
+			for _, f := range job.Failures {
+				sig := NormalizeFailureMessage(f.Message)
+				if sig == "" || seen[sig] || (specific && genericFailurePattern.MatchString(sig)) {
+					continue
+				}
+				seen[sig] = true
+				if _, ok := bySignature[sig]; !ok {
+					order = append(order, sig)
+				}
+				bySignature[sig] = append(bySignature[sig], occurrence{run, job, f})
+			}
+		}
+	}
+
+	// Most frequent signatures first, so they represent their clusters
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(bySignature[order[i]]) > len(bySignature[order[j]])
+	})
+	type cluster struct {
+		words       map[string]bool
+		occurrences []occurrence
+		signature   string
+	}
+	var clusters []*cluster
+	for _, sig := range order {
+		words := signatureWords(sig)
+		var into *cluster
+		for _, c := range clusters {
+			if jaccard(words, c.words) >= failureSimilarity {
+				into = c
+				break
+			}
+		}
+		if into == nil {
+			into = &cluster{words: words, signature: sig}
+			clusters = append(clusters, into)
+		}
+		into.occurrences = append(into.occurrences, bySignature[sig]...)
+	}
+
+	var result []FailureCluster
+	for _, c := range clusters {
+		sort.SliceStable(c.occurrences, func(i, j int) bool {
+			return c.occurrences[i].run.CreatedAt.Before(c.occurrences[j].run.CreatedAt)
+		})
+		first := c.occurrences[0]
+		fc := FailureCluster{
+			Signature: c.signature,
+			Example:   strings.TrimSpace(first.msg.Message),
+			Source:    first.msg.Source,
+			FirstSeen: first.run.CreatedAt,
+			LastSeen:  c.occurrences[len(c.occurrences)-1].run.CreatedAt,
+		}
+		// A job whose messages merged into one cluster counts once
+		type jobKey struct {
+			runID, jobID int64
+			name         string
+		}
+		counted := make(map[jobKey]bool)
+		jobs := make(map[string]bool)
+		for i := len(c.occurrences) - 1; i >= 0; i-- {
+			o := c.occurrences[i]
+			counted[jobKey{o.run.ID, o.job.ID, o.job.Name}] = true
+			jobs[o.job.Name] = true
+			if len(fc.URLs) < 5 && o.job.URL != "" && !slices.Contains(fc.URLs, o.job.URL) {
+				fc.URLs = append(fc.URLs, o.job.URL)
+			}
+		}
+		fc.Count = len(counted)
+		for name := range jobs {
+			fc.Jobs = append(fc.Jobs, name)
+		}
+		sort.Strings(fc.Jobs)
+		result = append(result, fc)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].LastSeen.After(result[j].LastSeen)
+	})
+	if len(result) > failureClusterLimit {
+		result = result[:failureClusterLimit]
+	}
+	return result
+}
+
+// signatureWords returns the set of words of a signature.
+func signatureWords(sig string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(sig) {
+		words[w] = true
+	}
+	return words
+}
+
+// jaccard is the share of the words of a and b that both have.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
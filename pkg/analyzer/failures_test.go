@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNormalizeFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"Process completed with exit code 1.", "Process completed with exit code <n>."},
+		{"dial tcp 10.1.2.3:5432: connect: connection refused", "dial tcp <ip>:<n>: connect: connection refused"},
+		{"  \n2026-03-10T12:00:01.123Z timeout after 30s waiting for a0b1c2d3e4f5\nstack trace", "<time> timeout after <n>s waiting for <hex>"},
+		{"FAIL: src/app/user_test.go:42: expected 3, got 4", "FAIL: <path>:<n>: expected <n>, got <n>"},
+		{"run 1234567890 wrote 10485760 bytes to build/out.bin", "run <n> wrote <n> bytes to <path>"},
+		{"digest 0123456789abcdef does not match deadbeef", "digest <hex> does not match <hex>"},
+		{"3/5 tests failed in ./pkg/app", "<n>/<n> tests failed in <path>"},
+		{"request 123e4567-e89b-12d3-a456-426614174000 failed, see https://example.com/x?y=1", "request <id> failed, see <url>"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeFailureMessage(tt.message), tt.message)
+	}
+}
+
+func TestLogFailureLine(t *testing.T) {
+	t.Parallel()
+
+	log := "2026-03-10T12:00:00.0000000Z ##[group]Run make test\n" +
+		"2026-03-10T12:00:01.0000000Z ok  pkg/a\n" +
+		"2026-03-10T12:00:02.0000000Z --- FAIL: TestThing (0.01s)\n" +
+		"2026-03-10T12:00:02.5000000Z make: *** [test] Error 1\n" +
+		"2026-03-10T12:00:03.0000000Z ##[error]Process completed with exit code 2.\n" +
+		"2026-03-10T12:00:04.0000000Z Post job cleanup.\n"
+	assert.Equal(t, "make: *** [test] Error 1", logFailureLine(log))
+
+	assert.Equal(t, "Process completed with exit code 2.", logFailureLine("2026-03-10T12:00:03Z ##[error]Process completed with exit code 2.\n"))
+	assert.Equal(t, "", logFailureLine("2026-03-10T12:00:03Z all good\n"))
+}
+
+func TestClusterFailures(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	failed := func(name, url string, messages ...string) JobData {
+		job := JobData{Name: name, URL: url, Conclusion: "failure"}
+		for _, m := range messages {
+			job.Failures = append(job.Failures, FailureMessage{Source: FailureFromAnnotation, Message: m})
+		}
+		return job
+	}
+	runs := []RunData{
+		{ID: 1, CreatedAt: base, Jobs: []JobData{
+			failed("test", "https://github.com/o/r/actions/runs/1/job/11", "dial tcp 10.0.0.1:5432: connect: connection refused", "Process completed with exit code 1."),
+			failed("lint", "https://github.com/o/r/actions/runs/1/job/12", "Process completed with exit code 1."),
+		}},
+		{ID: 2, CreatedAt: base.Add(24 * time.Hour), Jobs: []JobData{
+			failed("test", "https://github.com/o/r/actions/runs/2/job/21", "dial tcp 10.0.0.7:5432: connect: connection refused"),
+		}},
+		{ID: 3, CreatedAt: base.Add(48 * time.Hour), Jobs: []JobData{
+			// Similar enough to merge with the message above
+			failed("e2e", "https://github.com/o/r/actions/runs/3/job/31", "error: dial tcp 10.0.0.9:5432: connect: connection refused"),
+			{Name: "build", Conclusion: "success"},
+		}},
+	}
+
+	clusters := clusterFailures(runs)
+	assert.Len(t, clusters, 2)
+
+	refused := clusters[0]
+	assert.Equal(t, "dial tcp <ip>:<n>: connect: connection refused", refused.Signature)
+	assert.Equal(t, "dial tcp 10.0.0.1:5432: connect: connection refused", refused.Example)
+	assert.Equal(t, FailureFromAnnotation, refused.Source)
+	assert.Equal(t, 3, refused.Count)
+	assert.Equal(t, []string{"e2e", "test"}, refused.Jobs)
+	assert.Equal(t, base, refused.FirstSeen)
+	assert.Equal(t, base.Add(48*time.Hour), refused.LastSeen)
+	assert.Equal(t, "https://github.com/o/r/actions/runs/3/job/31", refused.URLs[0])
+
+	// The generic annotation only counts for the job without a better message
+	generic := clusters[1]
+	assert.Equal(t, "Process completed with exit code <n>.", generic.Signature)
+	assert.Equal(t, 1, generic.Count)
+	assert.Equal(t, []string{"lint"}, generic.Jobs)
+
+	assert.Empty(t, clusterFailures([]RunData{{Jobs: []JobData{{Name: "build", Conclusion: "success"}}}}))
+}
+
+func TestFetchFailuresForRuns(t *testing.T) {
+	t.Parallel()
+
+	client := new(mockGitHubProvider)
+	client.On("FetchAnnotations", mock.Anything, "o", "r", int64(11)).Return([]githubapi.Annotation{
+		{Level: "warning", Message: "Node.js 16 actions are deprecated"},
+		{Level: "failure", Message: "tests failed"},
+	}, nil)
+	client.On("FetchJobLogs", mock.Anything, "o", "r", int64(11)).Return("2026-03-10T12:00:00Z panic: boom\n2026-03-10T12:00:01Z ##[error]Process completed with exit code 2.\n", nil)
+
+	runData := []RunData{
+		{Jobs: []JobData{{ID: 10, Conclusion: "success"}, {ID: 11, Conclusion: "failure"}}},
+		{Jobs: []JobData{{ID: 20, Conclusion: "failure"}}}, // not sampled
+	}
+	fetchFailuresForRuns(context.Background(), client, "o", "r", runData, []int{0}, true)
+
+	assert.Empty(t, runData[0].Jobs[0].Failures)
+	assert.Equal(t, []FailureMessage{
+		{Source: FailureFromAnnotation, Message: "tests failed"},
+		{Source: FailureFromLog, Message: "panic: boom"},
+	}, runData[0].Jobs[1].Failures)
+	assert.Empty(t, runData[1].Jobs[0].Failures)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+)
+
+// HeatmapCell is one hour of one weekday in a QueueHeatmap.
+type HeatmapCell struct {
+	Runs     int     // runs created in the hour
+	Jobs     int     // sampled jobs queued in the hour
+	AvgQueue float64 // seconds
+	P95Queue float64 // seconds
+}
+
+// HeatmapWindow is a weekday hour with long queue times.
+type HeatmapWindow struct {
+	Weekday  time.Weekday
+	Hour     int
+	Jobs     int
+	AvgQueue float64 // seconds
+	P95Queue float64 // seconds
+}
+
+// QueueHeatmap breaks run volume and queue times down by hour of day and day
+// of week, to find the windows where runners run short.
+type QueueHeatmap struct {
+	Timezone string             // IANA name the hours are in
+	Cells    [7][24]HeatmapCell // by time.Weekday, then hour
+	Worst    []HeatmapWindow    // longest average queues, worst first
+	MaxRuns  int                // busiest cell, for scaling
+	MaxQueue float64            // longest cell average queue, for scaling
+}
+
+// heatmapMinJobs is how many jobs a cell needs to count among the worst
+// windows, so that a single stuck job doesn't make an hour look bad.
+const heatmapMinJobs = 3
+
+// heatmapWorstWindows is how many of the worst windows are kept.
+const heatmapWorstWindows = 5
+
+// calculateQueueHeatmap places the runs by when they were created and the
+// jobs by when they were queued, in loc (UTC when nil). Run volume covers all
+// runs; queue times cover the jobs of the sampled runs.
+func calculateQueueHeatmap(runs []RunData, loc *time.Location) QueueHeatmap {
+	if loc == nil {
+		loc = time.UTC
+	}
+	heatmap := QueueHeatmap{Timezone: loc.String()}
+
+	var queues [7][24][]float64
+	for _, run := range runs {
+		if !run.CreatedAt.IsZero() {
+			t := run.CreatedAt.In(loc)
+			heatmap.Cells[t.Weekday()][t.Hour()].Runs++
+		}
+		for _, job := range run.Jobs {
+			queuedAt := job.CreatedAt
+			if queuedAt.IsZero() {
+				if job.StartedAt.IsZero() {
+					continue
+				}
+				queuedAt = job.StartedAt.Add(-time.Duration(job.QueueTime) * time.Millisecond)
+			}
+			t := queuedAt.In(loc)
+			queues[t.Weekday()][t.Hour()] = append(queues[t.Weekday()][t.Hour()], float64(max(job.QueueTime, 0))/1000.0)
+		}
+	}
+
+	for day := range heatmap.Cells {
+		for hour := range heatmap.Cells[day] {
+			cell := &heatmap.Cells[day][hour]
+			q := queues[day][hour]
+			cell.Jobs = len(q)
+			cell.AvgQueue = average(q)
+			cell.P95Queue = calculatePercentile(q, 95)
+
+			heatmap.MaxRuns = max(heatmap.MaxRuns, cell.Runs)
+			heatmap.MaxQueue = max(heatmap.MaxQueue, cell.AvgQueue)
+			if cell.Jobs >= heatmapMinJobs && cell.AvgQueue > 0 {
+				heatmap.Worst = append(heatmap.Worst, HeatmapWindow{
+					Weekday:  time.Weekday(day),
+					Hour:     hour,
+					Jobs:     cell.Jobs,
+					AvgQueue: cell.AvgQueue,
+					P95Queue: cell.P95Queue,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(heatmap.Worst, func(i, j int) bool {
+		return heatmap.Worst[i].AvgQueue > heatmap.Worst[j].AvgQueue
+	})
+	if len(heatmap.Worst) > heatmapWorstWindows {
+		heatmap.Worst = heatmap.Worst[:heatmapWorstWindows]
+	}
+	return heatmap
+}
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateQueueHeatmap(t *testing.T) {
+	t.Parallel()
+
+	// Monday 2026-03-16, 09:xx UTC
+	monday9 := time.Date(2026, 3, 16, 9, 10, 0, 0, time.UTC)
+	job := func(queuedAt time.Time, queueSec int64) JobData {
+		return JobData{
+			CreatedAt: queuedAt,
+			StartedAt: queuedAt.Add(time.Duration(queueSec) * time.Second),
+			QueueTime: queueSec * 1000,
+		}
+	}
+	runs := []RunData{
+		{CreatedAt: monday9, Jobs: []JobData{job(monday9, 60), job(monday9, 120), job(monday9.Add(time.Minute), 300)}},
+		{CreatedAt: monday9.Add(10 * time.Minute)},
+		// Tuesday 14:xx; the job has no created time, so it's placed by start minus queue
+		{CreatedAt: monday9.Add(29 * time.Hour), Jobs: []JobData{{StartedAt: monday9.Add(29*time.Hour + 10*time.Minute), QueueTime: 30000}}},
+		// A single stuck job doesn't count among the worst windows
+		{CreatedAt: monday9.Add(48 * time.Hour), Jobs: []JobData{job(monday9.Add(48*time.Hour), 3600)}},
+	}
+
+	heatmap := calculateQueueHeatmap(runs, nil)
+	assert.Equal(t, "UTC", heatmap.Timezone)
+
+	cell := heatmap.Cells[time.Monday][9]
+	assert.Equal(t, 2, cell.Runs)
+	assert.Equal(t, 3, cell.Jobs)
+	assert.Equal(t, 160.0, cell.AvgQueue)
+	assert.Equal(t, 300.0, cell.P95Queue)
+
+	tuesday := heatmap.Cells[time.Tuesday][14]
+	assert.Equal(t, 1, tuesday.Runs)
+	assert.Equal(t, 1, tuesday.Jobs)
+	assert.Equal(t, 30.0, tuesday.AvgQueue)
+
+	assert.Equal(t, 2, heatmap.MaxRuns)
+	assert.Equal(t, 3600.0, heatmap.MaxQueue)
+	assert.Equal(t, []HeatmapWindow{{Weekday: time.Monday, Hour: 9, Jobs: 3, AvgQueue: 160, P95Queue: 300}}, heatmap.Worst)
+
+	t.Run("in a timezone", func(t *testing.T) {
+		tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+		heatmap := calculateQueueHeatmap(runs, tokyo)
+		assert.Equal(t, "Asia/Tokyo", heatmap.Timezone)
+		assert.Equal(t, 3, heatmap.Cells[time.Monday][18].Jobs)
+		assert.Equal(t, 0, heatmap.Cells[time.Monday][9].Jobs)
+		assert.Equal(t, 1, heatmap.Cells[time.Tuesday][23].Jobs)
+	})
+}
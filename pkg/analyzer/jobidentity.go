@@ -0,0 +1,353 @@
+package analyzer
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"gopkg.in/yaml.v3"
+)
+
+// JobNameRule rewrites the part of job names matching Pattern, so that jobs
+// named after something that changes from run to run (a PR number, a date)
+// are tracked as one job. Replacement may refer to groups as $1 or ${name}.
+type JobNameRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// jobNameRuleSeparator separates the pattern of a rule from its replacement.
+const jobNameRuleSeparator = "=>"
+
+// ParseJobNameRule parses a PATTERN=>REPLACEMENT rule, e.g.
+// `deploy-pr-\d+=>deploy-pr-<n>`.
+func ParseJobNameRule(spec string) (JobNameRule, error) {
+	i := strings.LastIndex(spec, jobNameRuleSeparator)
+	if i <= 0 {
+		return JobNameRule{}, errors.Newf("invalid job name rule %q (want PATTERN=>REPLACEMENT)", spec)
+	}
+	pattern, err := regexp.Compile(spec[:i])
+	if err != nil {
+		return JobNameRule{}, errors.Wrapf(err, "invalid job name pattern %q", spec[:i])
+	}
+	return JobNameRule{Pattern: pattern, Replacement: spec[i+len(jobNameRuleSeparator):]}, nil
+}
+
+// DefaultJobNameRules collapse the dynamic parts job names most often carry:
+// dates and PR numbers. Numbered jobs ("test #1", "test #2") are left alone,
+// as they are usually shards running side by side. The rules apply after
+// TrendOptions.JobNameRules when TrendOptions.DefaultJobNameRules is set,
+// which also turns on following renamed jobs.
+var DefaultJobNameRules = []JobNameRule{
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}(T[\d:.]+Z?)?\b`), "<date>"},
+	{regexp.MustCompile(`(?i)\b(pr|pull)([-_ ]?)\d+\b`), "${1}${2}<n>"},
+}
+
+// matrixValues matches the matrix values GitHub appends to the names of
+// matrix jobs, e.g. "test (ubuntu-latest, 18)", including under a reusable
+// workflow call ("build (linux) / compile").
+var matrixValues = regexp.MustCompile(`\s\([^()]*\)( / |$)`)
+
+// MatrixPlaceholder stands in for the matrix values of collapsed job names.
+const MatrixPlaceholder = "(*)"
+
+// normalizeJobName applies the rules to a job name and, with collapseMatrix,
+// replaces its matrix values with MatrixPlaceholder.
+func normalizeJobName(name string, rules []JobNameRule, collapseMatrix bool) string {
+	normalized := name
+	for _, rule := range rules {
+		normalized = rule.Pattern.ReplaceAllString(normalized, rule.Replacement)
+	}
+	if collapseMatrix {
+		normalized = matrixValues.ReplaceAllString(normalized, " "+MatrixPlaceholder+"${1}")
+	}
+	if normalized = strings.TrimSpace(normalized); normalized == "" {
+		return name
+	}
+	return normalized
+}
+
+// normalizeJobNames renames the jobs of the runs by the rules, keeping the
+// name GitHub reported as RawName.
+func normalizeJobNames(runs []RunData, rules []JobNameRule, collapseMatrix bool) {
+	cache := make(map[string]string)
+	for i := range runs {
+		for j := range runs[i].Jobs {
+			job := &runs[i].Jobs[j]
+			if job.RawName == "" {
+				job.RawName = job.Name
+			}
+			normalized, ok := cache[job.RawName]
+			if !ok {
+				normalized = normalizeJobName(job.RawName, rules, collapseMatrix)
+				cache[job.RawName] = normalized
+			}
+			job.Name = normalized
+		}
+	}
+}
+
+// JobRename is a job renamed within the analyzed period. Its runs under the
+// old name are reported under the new one.
+type JobRename struct {
+	Workflow string // path of the workflow definition
+	From     string
+	To       string
+	At       time.Time // created time of the first run with the new name
+}
+
+// JobAlias lists the names GitHub reported for a job that job name rules
+// folded into Name.
+type JobAlias struct {
+	Name    string
+	Aliases []string // sorted
+}
+
+// workflowJob is a job of a workflow definition.
+type workflowJob struct {
+	Key   string   // the job's ID under `jobs:`
+	Name  string   // its `name:`, the key when unnamed
+	Needs []string // sorted
+	Index int      // position among the jobs of the definition
+}
+
+// parseWorkflowJobs returns the jobs of a workflow definition in the order
+// they are defined.
+func parseWorkflowJobs(definition []byte) ([]workflowJob, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(definition, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	jobsNode := mappingValue(doc.Content[0], "jobs")
+	if jobsNode == nil || jobsNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var jobs []workflowJob
+	for i := 0; i+1 < len(jobsNode.Content); i += 2 {
+		key := jobsNode.Content[i].Value
+		var job struct {
+			Name  string    `yaml:"name"`
+			Needs yaml.Node `yaml:"needs"`
+		}
+		if err := jobsNode.Content[i+1].Decode(&job); err != nil {
+			return nil, err
+		}
+		var needs []string
+		switch job.Needs.Kind {
+		case yaml.ScalarNode:
+			if job.Needs.Value != "" {
+				needs = []string{job.Needs.Value}
+			}
+		case yaml.SequenceNode:
+			if err := job.Needs.Decode(&needs); err != nil {
+				return nil, err
+			}
+		}
+		sort.Strings(needs)
+		jobs = append(jobs, workflowJob{Key: key, Name: defaultString(job.Name, key), Needs: needs, Index: len(jobs)})
+	}
+	return jobs, nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// definitionJob finds the job of a definition a job name was rendered from:
+// by its name, or by prefix for matrix jobs ("test (linux)"), jobs run by a
+// reusable workflow ("build / compile") and names with expressions.
+func definitionJob(jobs []workflowJob, name string) (workflowJob, bool) {
+	for _, job := range jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	for _, job := range jobs {
+		if strings.HasPrefix(name, job.Name+" (") || strings.HasPrefix(name, job.Name+reusableJobSeparator) {
+			return job, true
+		}
+		if i := strings.Index(job.Name, "${{"); i > 0 && strings.HasPrefix(name, job.Name[:i]) {
+			return job, true
+		}
+	}
+	return workflowJob{}, false
+}
+
+// detectJobRenames finds jobs of a workflow that stopped appearing when a job
+// with a new name started appearing at the same position among the jobs of
+// the runs (which must be in chronological order). A candidate is only a
+// rename when the workflow definitions at the two runs' commits define both
+// under the same key, or at the same position with the same needs; when
+// either definition can't be read, the jobs stay apart.
+func detectJobRenames(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, runs []RunData) []JobRename {
+	byWorkflow := make(map[string][]RunData)
+	var workflows []string
+	for _, run := range runs {
+		if run.Workflow == "" || len(run.Jobs) == 0 {
+			continue
+		}
+		if _, ok := byWorkflow[run.Workflow]; !ok {
+			workflows = append(workflows, run.Workflow)
+		}
+		byWorkflow[run.Workflow] = append(byWorkflow[run.Workflow], run)
+	}
+
+	// Definitions are only read for candidates, once per commit
+	definitions := make(map[string][]workflowJob)
+	definition := func(run RunData) []workflowJob {
+		key := run.Workflow + "@" + run.HeadSHA
+		if jobs, ok := definitions[key]; ok {
+			return jobs
+		}
+		var jobs []workflowJob
+		if run.HeadSHA != "" {
+			if raw, err := client.FetchWorkflowFile(ctx, owner, repo, run.Workflow, run.HeadSHA); err == nil {
+				jobs, _ = parseWorkflowJobs(raw)
+			}
+		}
+		definitions[key] = jobs
+		return jobs
+	}
+
+	type sighting struct {
+		run int // index into the runs of the workflow
+		pos int // position among the distinct job names of the run
+		raw string
+	}
+	var renames []JobRename
+	for _, path := range workflows {
+		wfRuns := byWorkflow[path]
+		first := make(map[string]sighting)
+		last := make(map[string]sighting)
+		var names []string
+		for i, run := range wfRuns {
+			seen := make(map[string]bool)
+			for _, job := range run.Jobs {
+				if seen[job.Name] {
+					continue
+				}
+				s := sighting{run: i, pos: len(seen), raw: defaultString(job.RawName, job.Name)}
+				seen[job.Name] = true
+				if _, ok := first[job.Name]; !ok {
+					first[job.Name] = s
+					names = append(names, job.Name)
+				}
+				last[job.Name] = s
+			}
+		}
+
+		claimed := make(map[string]bool)
+		for _, from := range names {
+			old := last[from]
+			to, next := "", sighting{}
+			for _, name := range names {
+				s := first[name]
+				if claimed[name] || s.run <= old.run || s.pos != old.pos {
+					continue
+				}
+				if to == "" || s.run < next.run {
+					to, next = name, s
+				}
+			}
+			if to == "" {
+				continue
+			}
+
+			oldJob, oldOK := definitionJob(definition(wfRuns[old.run]), old.raw)
+			newJob, newOK := definitionJob(definition(wfRuns[next.run]), next.raw)
+			if !oldOK || !newOK {
+				continue
+			}
+			if oldJob.Key != newJob.Key && (oldJob.Index != newJob.Index || !slices.Equal(oldJob.Needs, newJob.Needs)) {
+				continue
+			}
+			claimed[to] = true
+			renames = append(renames, JobRename{Workflow: path, From: from, To: to, At: wfRuns[next.run].CreatedAt})
+		}
+	}
+	return renames
+}
+
+// applyJobRenames reports the jobs of the runs under their newest name,
+// following renames of renames.
+func applyJobRenames(runs []RunData, renames []JobRename) {
+	type workflowJobName struct {
+		workflow, name string
+	}
+	renamedTo := make(map[workflowJobName]string)
+	for _, r := range renames {
+		renamedTo[workflowJobName{r.Workflow, r.From}] = r.To
+	}
+	for i := range runs {
+		for j := range runs[i].Jobs {
+			job := &runs[i].Jobs[j]
+			for range renames {
+				to, ok := renamedTo[workflowJobName{runs[i].Workflow, job.Name}]
+				if !ok {
+					break
+				}
+				job.Name = to
+			}
+		}
+	}
+}
+
+// jobAliases lists the jobs whose names were rewritten by job name rules,
+// with the names they were reported under, most names first. Names that were
+// only renamed are left to the renames.
+func jobAliases(runs []RunData, renames []JobRename) []JobAlias {
+	renamed := make(map[string]bool)
+	for _, r := range renames {
+		renamed[r.From] = true
+	}
+	names := make(map[string]map[string]bool)
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			if job.RawName == "" || renamed[job.RawName] {
+				continue
+			}
+			if names[job.Name] == nil {
+				names[job.Name] = make(map[string]bool)
+			}
+			names[job.Name][job.RawName] = true
+		}
+	}
+
+	var aliases []JobAlias
+	for name, raws := range names {
+		if len(raws) == 1 && raws[name] {
+			continue
+		}
+		alias := JobAlias{Name: name}
+		for raw := range raws {
+			alias.Aliases = append(alias.Aliases, raw)
+		}
+		sort.Strings(alias.Aliases)
+		aliases = append(aliases, alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		if len(aliases[i].Aliases) != len(aliases[j].Aliases) {
+			return len(aliases[i].Aliases) > len(aliases[j].Aliases)
+		}
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases
+}
@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseJobNameRule(t *testing.T) {
+	t.Parallel()
+
+	rule, err := ParseJobNameRule(`deploy-(\w+)-\d+=>deploy-$1`)
+	assert.NoError(t, err)
+	assert.Equal(t, `deploy-(\w+)-\d+`, rule.Pattern.String())
+	assert.Equal(t, "deploy-$1", rule.Replacement)
+
+	// The replacement may be empty
+	rule, err = ParseJobNameRule(` \[skip\]=>`)
+	assert.NoError(t, err)
+	assert.Equal(t, "", rule.Replacement)
+
+	for _, spec := range []string{"", "deploy", "=>deploy", "deploy-(=>x"} {
+		_, err := ParseJobNameRule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNormalizeJobName(t *testing.T) {
+	t.Parallel()
+
+	custom, err := ParseJobNameRule(`preview-.+$=>preview`)
+	assert.NoError(t, err)
+	rules := append([]JobNameRule{custom}, DefaultJobNameRules...)
+
+	tests := []struct {
+		name     string
+		collapse bool
+		want     string
+	}{
+		{"build", false, "build"},
+		{"deploy PR 1234", false, "deploy PR <n>"},
+		{"test #2", false, "test #2"},
+		{"deploy-pr-1234", false, "deploy-pr-<n>"},
+		{"nightly 2026-03-10", false, "nightly <date>"},
+		{"preview-feature-x", false, "preview"},
+		{"test (ubuntu-latest, 18)", false, "test (ubuntu-latest, 18)"},
+		{"test (ubuntu-latest, 18)", true, "test (*)"},
+		{"test (${{ matrix.os }})", true, "test (*)"},
+		{"build (linux) / compile (arm64)", true, "build (*) / compile (*)"},
+		{"node 18", true, "node 18"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeJobName(tt.name, rules, tt.collapse), tt.name)
+	}
+}
+
+func TestParseWorkflowJobs(t *testing.T) {
+	t.Parallel()
+
+	jobs, err := parseWorkflowJobs([]byte(`
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+  test:
+    name: Unit tests
+    needs: lint
+  deploy:
+    needs: [test, lint]
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []workflowJob{
+		{Key: "lint", Name: "lint", Index: 0},
+		{Key: "test", Name: "Unit tests", Needs: []string{"lint"}, Index: 1},
+		{Key: "deploy", Name: "deploy", Needs: []string{"lint", "test"}, Index: 2},
+	}, jobs)
+
+	jobs, err = parseWorkflowJobs([]byte("on: push\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestDetectJobRenames(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	run := func(day int, sha string, names ...string) RunData {
+		r := RunData{Workflow: ".github/workflows/ci.yml", HeadSHA: sha, CreatedAt: base.Add(time.Duration(day) * 24 * time.Hour)}
+		for _, name := range names {
+			r.Jobs = append(r.Jobs, JobData{Name: name, Duration: 60000})
+		}
+		return r
+	}
+	runs := []RunData{
+		run(0, "a1", "lint", "Unit tests", "deploy"),
+		run(1, "a1", "lint", "Unit tests", "deploy"),
+		run(2, "b2", "lint", "Tests", "ship"),
+		run(3, "b2", "lint", "Tests", "ship"),
+	}
+
+	client := new(mockGitHubProvider)
+	client.On("FetchWorkflowFile", mock.Anything, "o", "r", ".github/workflows/ci.yml", "a1").Return([]byte(`
+jobs:
+  lint: {}
+  test: {name: Unit tests, needs: lint}
+  deploy: {needs: test}
+`), nil)
+	// test keeps its key; deploy becomes ship, still last and after test
+	client.On("FetchWorkflowFile", mock.Anything, "o", "r", ".github/workflows/ci.yml", "b2").Return([]byte(`
+jobs:
+  lint: {}
+  test: {name: Tests, needs: lint}
+  ship: {needs: test}
+`), nil)
+
+	renames := detectJobRenames(context.Background(), client, "o", "r", runs)
+	assert.Equal(t, []JobRename{
+		{Workflow: ".github/workflows/ci.yml", From: "Unit tests", To: "Tests", At: base.Add(48 * time.Hour)},
+		{Workflow: ".github/workflows/ci.yml", From: "deploy", To: "ship", At: base.Add(48 * time.Hour)},
+	}, renames)
+	client.AssertExpectations(t)
+
+	applyJobRenames(runs, renames)
+	assert.Equal(t, "Tests", runs[0].Jobs[1].Name)
+	assert.Equal(t, "ship", runs[1].Jobs[2].Name)
+	assert.Equal(t, "lint", runs[0].Jobs[0].Name)
+}
+
+func TestDetectJobRenamesRejectsReplacedJobs(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	runs := []RunData{
+		{Workflow: "ci.yml", HeadSHA: "a1", CreatedAt: base, Jobs: []JobData{{Name: "lint"}, {Name: "e2e"}}},
+		{Workflow: "ci.yml", HeadSHA: "b2", CreatedAt: base.Add(time.Hour), Jobs: []JobData{{Name: "lint"}, {Name: "docs"}}},
+	}
+
+	client := new(mockGitHubProvider)
+	client.On("FetchWorkflowFile", mock.Anything, "o", "r", "ci.yml", "a1").Return([]byte("jobs:\n  lint: {}\n  e2e: {needs: lint}\n"), nil)
+	client.On("FetchWorkflowFile", mock.Anything, "o", "r", "ci.yml", "b2").Return([]byte("jobs:\n  lint: {}\n  docs: {}\n"), nil)
+	assert.Empty(t, detectJobRenames(context.Background(), client, "o", "r", runs))
+
+	// Without definitions nothing confirms a rename
+	unreadable := new(mockGitHubProvider)
+	unreadable.On("FetchWorkflowFile", mock.Anything, "o", "r", "ci.yml", mock.Anything).Return([]byte(nil), errors.New("not found"))
+	assert.Empty(t, detectJobRenames(context.Background(), unreadable, "o", "r", runs))
+}
+
+func TestJobIdentityAcrossTrends(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	var runs []RunData
+	for i := range 10 {
+		conclusion := "success"
+		if i%3 == 0 {
+			conclusion = "failure"
+		}
+		runs = append(runs, RunData{
+			CreatedAt: base.Add(time.Duration(i) * time.Hour),
+			Jobs: []JobData{{
+				Name:       "deploy-pr-" + string(rune('0'+i)),
+				Conclusion: conclusion,
+				Duration:   int64(60000 * (i + 1)),
+			}},
+		})
+	}
+
+	normalizeJobNames(runs, DefaultJobNameRules, false)
+	trends := analyzeJobTrends(runs)
+	assert.Len(t, trends, 1)
+	assert.Equal(t, "deploy-pr-<n>", trends[0].Name)
+	assert.Equal(t, 10, trends[0].TotalRuns)
+
+	flaky := detectFlakyJobs(runs)
+	assert.Len(t, flaky, 1)
+	assert.Equal(t, "deploy-pr-<n>", flaky[0].Name)
+
+	regressions, _ := calculateJobChanges(runs, 0.95)
+	assert.Len(t, regressions, 1)
+	assert.Equal(t, "deploy-pr-<n>", regressions[0].Name)
+
+	aliases := jobAliases(runs, nil)
+	assert.Len(t, aliases, 1)
+	assert.Equal(t, "deploy-pr-<n>", aliases[0].Name)
+	assert.Len(t, aliases[0].Aliases, 10)
+	assert.Equal(t, "deploy-pr-0", aliases[0].Aliases[0])
+
+	// Renamed-only names aren't aliases
+	renamed := []RunData{{Jobs: []JobData{{Name: "Tests", RawName: "Unit tests"}, {Name: "Tests", RawName: "Tests"}}}}
+	assert.Empty(t, jobAliases(renamed, []JobRename{{From: "Unit tests", To: "Tests"}}))
+}
@@ -0,0 +1,293 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// Lifecycle stage keys, in display order.
+const (
+	StageFirstReview = "time_to_first_review"
+	StageApproval    = "review_to_approval"
+	StageMerge       = "approval_to_merge"
+	StageCIWait      = "ci_wait"
+	StageLeadTime    = "lead_time"
+)
+
+// LifecycleStageKeys lists the lifecycle stages in display order.
+var LifecycleStageKeys = []string{StageFirstReview, StageApproval, StageMerge, StageCIWait, StageLeadTime}
+
+// LifecycleStageNames maps stage keys to display names.
+var LifecycleStageNames = map[string]string{
+	StageFirstReview: "Time to first review",
+	StageApproval:    "Review to approval",
+	StageMerge:       "Approval to merge",
+	StageCIWait:      "CI wait blocking merge",
+	StageLeadTime:    "Commit to merge",
+}
+
+// Lifecycle holds the milestones of one pull request as unix milliseconds,
+// zero when unknown.
+type Lifecycle struct {
+	Name          string
+	URL           string
+	CommitMs      int64 // author time of the first commit
+	OpenedMs      int64
+	FirstReviewMs int64 // first submitted review of any state
+	ApprovedMs    int64 // first approval
+	ChecksDoneMs  int64 // last required check to finish
+	MergedMs      int64
+	GateCheck     string // the required check that finished last, see MergeGate
+}
+
+// LifecycleStage is the time spent in one stage of a pull request.
+type LifecycleStage struct {
+	Key        string
+	Name       string
+	DurationMs float64
+	Known      bool
+}
+
+// Stages returns the time spent in each lifecycle stage. A stage is unknown
+// when either of its milestones is.
+//
+// The CI wait is the part of approval-to-merge spent waiting for required
+// checks that were still running when the PR was approved. Lead time starts
+// at the first commit when known and at the PR opening otherwise.
+func (l Lifecycle) Stages() []LifecycleStage {
+	span := func(key string, from, to int64) LifecycleStage {
+		stage := LifecycleStage{Key: key, Name: LifecycleStageNames[key]}
+		if from == 0 || to == 0 {
+			return stage
+		}
+		stage.Known = true
+		stage.DurationMs = float64(max(to-from, 0))
+		return stage
+	}
+
+	ciWait := span(StageCIWait, l.ApprovedMs, l.ChecksDoneMs)
+	if ciWait.Known && l.MergedMs != 0 {
+		ciWait.DurationMs = min(ciWait.DurationMs, float64(max(l.MergedMs-l.ApprovedMs, 0)))
+	}
+	start := l.CommitMs
+	if start == 0 {
+		start = l.OpenedMs
+	}
+
+	return []LifecycleStage{
+		span(StageFirstReview, l.OpenedMs, l.FirstReviewMs),
+		span(StageApproval, l.FirstReviewMs, l.ApprovedMs),
+		span(StageMerge, l.ApprovedMs, l.MergedMs),
+		ciWait,
+		span(StageLeadTime, start, l.MergedMs),
+	}
+}
+
+// Stage returns the stage with the given key.
+func (l Lifecycle) Stage(key string) LifecycleStage {
+	for _, s := range l.Stages() {
+		if s.Key == key {
+			return s
+		}
+	}
+	return LifecycleStage{Key: key, Name: LifecycleStageNames[key]}
+}
+
+// LifecycleFromResult builds the lifecycle of a PR or commit result from its
+// review events and merge gate, falling back to its required jobs; false for
+// results without a pull request.
+func LifecycleFromResult(r URLResult) (Lifecycle, bool) {
+	if r.Type == "run" || (r.OpenedAtMs == nil && r.MergedAtMs == nil && len(r.ReviewEvents) == 0) {
+		return Lifecycle{}, false
+	}
+	l := Lifecycle{Name: r.DisplayName, URL: r.DisplayURL}
+	if r.FirstCommitMs != nil {
+		l.CommitMs = *r.FirstCommitMs
+	}
+	if r.OpenedAtMs != nil {
+		l.OpenedMs = *r.OpenedAtMs
+	}
+	if r.MergedAtMs != nil {
+		l.MergedMs = *r.MergedAtMs
+	}
+	for _, e := range r.ReviewEvents {
+		if e.Type == "review" {
+			l.observeReview(e.State, e.TimeMillis())
+		}
+	}
+	if gate := r.MergeGate; gate != nil && !gate.IsPending() {
+		l.ChecksDoneMs = gate.CompletedMs
+		l.GateCheck = gate.Check
+		return l, true
+	}
+	for _, job := range r.Metrics.JobTimeline {
+		if job.IsRequired && job.Status == "completed" {
+			l.ChecksDoneMs = max(l.ChecksDoneMs, job.EndTime)
+		}
+	}
+	return l, true
+}
+
+func (l *Lifecycle) observeReview(state string, ms int64) {
+	if ms == 0 {
+		return
+	}
+	if l.FirstReviewMs == 0 || ms < l.FirstReviewMs {
+		l.FirstReviewMs = ms
+	}
+	if state == "APPROVED" && (l.ApprovedMs == 0 || ms < l.ApprovedMs) {
+		l.ApprovedMs = ms
+	}
+}
+
+// firstCommitMs returns the author time of a pull request's first commit,
+// where its lead time starts; 0 when unknown. Rebases reorder commits, so it
+// is the earliest author time of any of them.
+func firstCommitMs(ctx context.Context, client githubapi.GitHubProvider, owner, repo, prNumber string) int64 {
+	commits, err := client.FetchPRCommits(ctx, owner, repo, prNumber)
+	if err != nil {
+		return 0
+	}
+	var first int64
+	for _, c := range commits {
+		if t, ok := utils.ParseTime(c.Commit.Author.Date); ok && (first == 0 || t.UnixMilli() < first) {
+			first = t.UnixMilli()
+		}
+	}
+	return first
+}
+
+// LifecycleStats summarizes one stage across pull requests. Durations are in
+// milliseconds.
+type LifecycleStats struct {
+	Key    string
+	Name   string
+	Count  int
+	MeanMs float64
+	P50Ms  float64
+	P75Ms  float64
+	P90Ms  float64
+	MaxMs  float64
+}
+
+// LifecycleAnalysis is the lifecycle of the pull requests merged into a
+// repository over a time range.
+type LifecycleAnalysis struct {
+	Owner        string
+	Repo         string
+	Base         string
+	TimeRange    TimeRange
+	PullRequests []Lifecycle // slowest lead time first
+	Stages       []LifecycleStats
+}
+
+// SummarizeLifecycles computes the per-stage distributions of lifecycles.
+func SummarizeLifecycles(lifecycles []Lifecycle) []LifecycleStats {
+	values := make(map[string][]float64)
+	for _, l := range lifecycles {
+		for _, s := range l.Stages() {
+			if s.Known {
+				values[s.Key] = append(values[s.Key], s.DurationMs)
+			}
+		}
+	}
+
+	stats := make([]LifecycleStats, 0, len(LifecycleStageKeys))
+	for _, key := range LifecycleStageKeys {
+		v := values[key]
+		st := LifecycleStats{Key: key, Name: LifecycleStageNames[key], Count: len(v)}
+		if len(v) > 0 {
+			var sum float64
+			for _, d := range v {
+				sum += d
+				st.MaxMs = max(st.MaxMs, d)
+			}
+			st.MeanMs = sum / float64(len(v))
+			st.P50Ms = calculatePercentile(v, 50)
+			st.P75Ms = calculatePercentile(v, 75)
+			st.P90Ms = calculatePercentile(v, 90)
+		}
+		stats = append(stats, st)
+	}
+	return stats
+}
+
+// AnalyzeLifecycle fetches the pull requests merged into owner/repo in the
+// last days days, optionally only those targeting base, and computes their
+// lifecycle distributions. Required checks come from the branch protection and
+// rulesets of each PR's base branch; without any every check counts.
+func AnalyzeLifecycle(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, days int, base string, reporter ProgressReporter) (*LifecycleAnalysis, error) {
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(days) * 24 * time.Hour)
+
+	if reporter != nil {
+		reporter.SetPhase("Fetching merged pull requests")
+		reporter.SetDetail(fmt.Sprintf("%s/%s, last %d days", owner, repo, days))
+	}
+	prs, err := client.FetchRecentMergedPullRequests(ctx, owner, repo, days, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+	if reporter != nil {
+		reporter.SetURLRuns(len(prs))
+		reporter.SetPhase("Fetching reviews and checks")
+	}
+
+	required := make(map[string][]RequiredCheck)
+	lifecycles := make([]Lifecycle, 0, len(prs))
+	for _, pr := range prs {
+		if reporter != nil {
+			reporter.SetDetail(fmt.Sprintf("#%d %s", pr.Number, pr.Title))
+		}
+		checks, ok := required[pr.Base.Ref]
+		if !ok {
+			checks = FetchRequiredChecks(ctx, client, owner, repo, pr.Base.Ref)
+			required[pr.Base.Ref] = checks
+		}
+
+		l := Lifecycle{Name: fmt.Sprintf("PR #%d", pr.Number), URL: pr.HTMLURL}
+		if t, ok := utils.ParseTime(pr.CreatedAt); ok {
+			l.OpenedMs = t.UnixMilli()
+		}
+		if pr.MergedAt != nil {
+			if t, ok := utils.ParseTime(*pr.MergedAt); ok {
+				l.MergedMs = t.UnixMilli()
+			}
+		}
+		l.CommitMs = firstCommitMs(ctx, client, owner, repo, strconv.Itoa(pr.Number))
+		if reviews, err := client.FetchPRReviews(ctx, owner, repo, strconv.Itoa(pr.Number)); err == nil {
+			for _, review := range reviews {
+				if t, ok := utils.ParseTime(review.SubmittedAt); ok {
+					l.observeReview(review.State, t.UnixMilli())
+				}
+			}
+		}
+		if gate := FindMergeGate(checks, FetchGateRuns(ctx, client, owner, repo, pr.Head.SHA), l.MergedMs); gate != nil && !gate.IsPending() {
+			l.ChecksDoneMs = gate.CompletedMs
+			l.GateCheck = gate.Check
+		}
+		lifecycles = append(lifecycles, l)
+		if reporter != nil {
+			reporter.ProcessRun()
+		}
+	}
+
+	sort.SliceStable(lifecycles, func(i, j int) bool {
+		return lifecycles[i].Stage(StageLeadTime).DurationMs > lifecycles[j].Stage(StageLeadTime).DurationMs
+	})
+
+	return &LifecycleAnalysis{
+		Owner:        owner,
+		Repo:         repo,
+		Base:         base,
+		TimeRange:    TimeRange{Start: startTime, End: endTime, Days: days},
+		PullRequests: lifecycles,
+		Stages:       SummarizeLifecycles(lifecycles),
+	}, nil
+}
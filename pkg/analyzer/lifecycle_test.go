@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ptrMs(t time.Time) *int64 {
+	ms := t.UnixMilli()
+	return &ms
+}
+
+func TestLifecycleStages(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return base.Add(d).UnixMilli() }
+
+	tests := []struct {
+		name string
+		l    Lifecycle
+		want map[string]time.Duration // missing keys are unknown
+	}{
+		{
+			name: "checks finishing after approval block the merge",
+			l: Lifecycle{
+				OpenedMs:      at(0),
+				FirstReviewMs: at(2 * time.Hour),
+				ApprovedMs:    at(3 * time.Hour),
+				ChecksDoneMs:  at(3*time.Hour + 20*time.Minute),
+				MergedMs:      at(4 * time.Hour),
+			},
+			want: map[string]time.Duration{
+				StageFirstReview: 2 * time.Hour,
+				StageApproval:    time.Hour,
+				StageMerge:       time.Hour,
+				StageCIWait:      20 * time.Minute,
+				StageLeadTime:    4 * time.Hour,
+			},
+		},
+		{
+			name: "checks done before approval do not block",
+			l: Lifecycle{
+				CommitMs:      at(-time.Hour),
+				OpenedMs:      at(0),
+				FirstReviewMs: at(time.Hour),
+				ApprovedMs:    at(time.Hour),
+				ChecksDoneMs:  at(30 * time.Minute),
+				MergedMs:      at(2 * time.Hour),
+			},
+			want: map[string]time.Duration{
+				StageFirstReview: time.Hour,
+				StageApproval:    0,
+				StageMerge:       time.Hour,
+				StageCIWait:      0,
+				StageLeadTime:    3 * time.Hour,
+			},
+		},
+		{
+			name: "CI wait is capped at the merge",
+			l: Lifecycle{
+				OpenedMs:     at(0),
+				ApprovedMs:   at(time.Hour),
+				ChecksDoneMs: at(3 * time.Hour),
+				MergedMs:     at(2 * time.Hour),
+			},
+			want: map[string]time.Duration{
+				StageMerge:    time.Hour,
+				StageCIWait:   time.Hour,
+				StageLeadTime: 2 * time.Hour,
+			},
+		},
+		{
+			name: "unreviewed and unmerged",
+			l:    Lifecycle{OpenedMs: at(0)},
+			want: map[string]time.Duration{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stages := tt.l.Stages()
+			assert.Len(t, stages, len(LifecycleStageKeys))
+			for i, s := range stages {
+				assert.Equal(t, LifecycleStageKeys[i], s.Key)
+				want, known := tt.want[s.Key]
+				assert.Equal(t, known, s.Known, s.Key)
+				if known {
+					assert.Equal(t, float64(want.Milliseconds()), s.DurationMs, s.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestLifecycleFromResult(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	result := URLResult{
+		Type:        "pr",
+		DisplayName: "PR #7",
+		DisplayURL:  "https://github.com/o/r/pull/7",
+		OpenedAtMs:  ptrMs(base),
+		MergedAtMs:  ptrMs(base.Add(5 * time.Hour)),
+		ReviewEvents: []ReviewEvent{
+			{Type: "comment", Time: base.Add(10 * time.Minute).Format(time.RFC3339)},
+			{Type: "review", State: "CHANGES_REQUESTED", Time: base.Add(time.Hour).Format(time.RFC3339)},
+			{Type: "review", State: "APPROVED", Time: base.Add(3 * time.Hour).Format(time.RFC3339)},
+			{Type: "review", State: "APPROVED", Time: base.Add(4 * time.Hour).Format(time.RFC3339)},
+			{Type: "merged", Time: base.Add(5 * time.Hour).Format(time.RFC3339)},
+		},
+		Metrics: FinalMetrics{Metrics: Metrics{JobTimeline: []TimelineJob{
+			{Name: "build", Status: "completed", IsRequired: true, EndTime: base.Add(3*time.Hour + 30*time.Minute).UnixMilli()},
+			{Name: "lint", Status: "completed", IsRequired: false, EndTime: base.Add(6 * time.Hour).UnixMilli()},
+		}}},
+	}
+
+	l, ok := LifecycleFromResult(result)
+	assert.True(t, ok)
+	assert.Equal(t, "PR #7", l.Name)
+	assert.Equal(t, base.Add(time.Hour).UnixMilli(), l.FirstReviewMs, "comments are not reviews")
+	assert.Equal(t, base.Add(3*time.Hour).UnixMilli(), l.ApprovedMs, "first approval")
+	assert.Equal(t, base.Add(3*time.Hour+30*time.Minute).UnixMilli(), l.ChecksDoneMs, "optional jobs are ignored")
+	assert.Equal(t, float64((30 * time.Minute).Milliseconds()), l.Stage(StageCIWait).DurationMs)
+
+	result.MergeGate = &MergeGate{Check: "security/scan", CompletedMs: base.Add(4 * time.Hour).UnixMilli()}
+	l, _ = LifecycleFromResult(result)
+	assert.Equal(t, base.Add(4*time.Hour).UnixMilli(), l.ChecksDoneMs, "the merge gate covers non-Actions checks")
+	assert.Equal(t, "security/scan", l.GateCheck)
+
+	_, ok = LifecycleFromResult(URLResult{Type: "run"})
+	assert.False(t, ok)
+	_, ok = LifecycleFromResult(URLResult{Type: "commit"})
+	assert.False(t, ok, "commit without an associated PR")
+}
+
+func TestSummarizeLifecycles(t *testing.T) {
+	t.Parallel()
+
+	var lifecycles []Lifecycle
+	for i := 1; i <= 4; i++ {
+		lifecycles = append(lifecycles, Lifecycle{OpenedMs: 1, MergedMs: 1 + int64(i)*1000})
+	}
+	stats := SummarizeLifecycles(lifecycles)
+	assert.Len(t, stats, len(LifecycleStageKeys))
+
+	lead := stats[len(stats)-1]
+	assert.Equal(t, StageLeadTime, lead.Key)
+	assert.Equal(t, 4, lead.Count)
+	assert.Equal(t, 2500.0, lead.MeanMs)
+	assert.Equal(t, 2000.0, lead.P50Ms)
+	assert.Equal(t, 3000.0, lead.P75Ms)
+	assert.Equal(t, 4000.0, lead.P90Ms)
+	assert.Equal(t, 4000.0, lead.MaxMs)
+
+	assert.Equal(t, 0, stats[0].Count, "no reviews")
+}
+
+func TestAnalyzeLifecycle(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) string { return base.Add(d).Format(time.RFC3339) }
+	merged := func(d time.Duration) *string { s := ts(d); return &s }
+
+	client := new(mockGitHubProvider)
+	pr1 := githubapi.PullRequest{Number: 1, HTMLURL: "https://github.com/o/r/pull/1", CreatedAt: ts(0), MergedAt: merged(2 * time.Hour), Head: githubapi.PRRef{SHA: "a1"}, Base: githubapi.PRRef{Ref: "main"}}
+	pr2 := githubapi.PullRequest{Number: 2, HTMLURL: "https://github.com/o/r/pull/2", CreatedAt: ts(0), MergedAt: merged(6 * time.Hour), Head: githubapi.PRRef{SHA: "b2"}, Base: githubapi.PRRef{Ref: "main"}}
+	client.On("FetchRecentMergedPullRequests", mock.Anything, "o", "r", 30, "").Return([]githubapi.PullRequest{pr1, pr2}, nil)
+	client.On("FetchBranchProtection", mock.Anything, "o", "r", "main").Return(&githubapi.BranchProtection{
+		RequiredStatusChecks: &githubapi.RequiredStatusChecks{Contexts: []string{"build"}},
+	}, nil).Once()
+	client.On("FetchBranchRules", mock.Anything, "o", "r", "main").Return([]githubapi.BranchRule{}, nil).Once()
+	client.On("FetchPRReviews", mock.Anything, "o", "r", "1").Return([]githubapi.Review{{State: "APPROVED", SubmittedAt: ts(time.Hour)}}, nil)
+	client.On("FetchPRReviews", mock.Anything, "o", "r", "2").Return([]githubapi.Review{}, nil)
+	client.On("FetchPRCommits", mock.Anything, "o", "r", "1").Return([]githubapi.PRCommit{
+		{SHA: "a0", Commit: githubapi.CommitDetails{Author: githubapi.CommitAuthor{Date: ts(-time.Hour)}}},
+		{SHA: "a1", Commit: githubapi.CommitDetails{Author: githubapi.CommitAuthor{Date: ts(-2 * time.Hour)}}},
+	}, nil)
+	client.On("FetchPRCommits", mock.Anything, "o", "r", "2").Return([]githubapi.PRCommit{}, errors.New("not found"))
+	client.On("FetchCheckRunsForCommit", mock.Anything, "o", "r", "a1").Return([]githubapi.CheckRun{
+		{Name: "docs", Status: "completed", CompletedAt: ts(3 * time.Hour)},
+	}, nil)
+	// build reports a legacy commit status
+	client.On("FetchCommitStatuses", mock.Anything, "o", "r", "a1").Return([]githubapi.CommitStatus{
+		{ID: 1, Context: "build", State: "success", CreatedAt: ts(90 * time.Minute)},
+	}, nil)
+	client.On("FetchCheckRunsForCommit", mock.Anything, "o", "r", "b2").Return([]githubapi.CheckRun{}, nil)
+	client.On("FetchCommitStatuses", mock.Anything, "o", "r", "b2").Return([]githubapi.CommitStatus{}, nil)
+
+	analysis, err := AnalyzeLifecycle(context.Background(), client, "o", "r", 30, "", nil)
+	assert.NoError(t, err)
+	client.AssertExpectations(t)
+
+	assert.Len(t, analysis.PullRequests, 2)
+	assert.Equal(t, "PR #2", analysis.PullRequests[0].Name, "slowest lead time first")
+
+	pr := analysis.PullRequests[1]
+	assert.Equal(t, float64(time.Hour.Milliseconds()), pr.Stage(StageFirstReview).DurationMs)
+	assert.Equal(t, float64((30 * time.Minute).Milliseconds()), pr.Stage(StageCIWait).DurationMs, "docs is not required")
+	assert.Equal(t, "build", pr.GateCheck)
+	assert.Equal(t, float64((4 * time.Hour).Milliseconds()), pr.Stage(StageLeadTime).DurationMs, "from the earliest authored commit")
+	assert.Equal(t, float64((6 * time.Hour).Milliseconds()), analysis.PullRequests[0].Stage(StageLeadTime).DurationMs, "from the PR opening without commits")
+
+	assert.Equal(t, 2, analysis.Stages[len(analysis.Stages)-1].Count)
+	assert.Equal(t, 1, analysis.Stages[0].Count)
+}
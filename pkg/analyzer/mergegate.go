@@ -0,0 +1,305 @@
+package analyzer
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Sources of a required check.
+const (
+	RequiredCheckSourceProtection = "branch_protection"
+	RequiredCheckSourceRuleset    = "ruleset"
+)
+
+// Span attributes of the merge gate marker emitted by TraceEmitter. Check
+// lists are newline separated.
+const (
+	AttrMergeGateCheck       = "github.merge_gate.check"
+	AttrMergeGateApp         = "github.merge_gate.app"
+	AttrMergeGateSource      = "github.merge_gate.source"
+	AttrMergeGateStatus      = "github.merge_gate.status"
+	AttrMergeGateConclusion  = "github.merge_gate.conclusion"
+	AttrMergeGateStartedMs   = "github.merge_gate.started_ms"
+	AttrMergeGateCompletedMs = "github.merge_gate.completed_ms"
+	AttrMergeGateMergedMs    = "github.merge_gate.merged_ms"
+	AttrMergeGateRequired    = "github.merge_gate.required"
+	AttrMergeGatePending     = "github.merge_gate.pending"
+	AttrMergeGateMissing     = "github.merge_gate.missing"
+)
+
+// RequiredCheck is a status check that must pass before merging into a branch.
+type RequiredCheck struct {
+	Context string
+	AppID   int64 // 0 when any app may report the check
+	Source  string
+}
+
+// FetchRequiredChecks returns the required status checks of a branch from both
+// its classic branch protection and the repository rulesets that apply to it.
+// Either API failing (no access, not configured) contributes no checks.
+func FetchRequiredChecks(ctx context.Context, client githubapi.GitHubProvider, owner, repo, branch string) []RequiredCheck {
+	var checks []RequiredCheck
+	seen := make(map[RequiredCheck]bool)
+	add := func(name string, appID *int64, source string) {
+		check := RequiredCheck{Context: name}
+		if appID != nil {
+			check.AppID = *appID
+		}
+		if name == "" || seen[check] {
+			return
+		}
+		seen[check] = true
+		check.Source = source
+		checks = append(checks, check)
+	}
+
+	if protection, err := client.FetchBranchProtection(ctx, owner, repo, branch); err == nil && protection != nil && protection.RequiredStatusChecks != nil {
+		// Checks carries the app restriction of each context when present
+		for _, check := range protection.RequiredStatusChecks.Checks {
+			add(check.Context, check.AppID, RequiredCheckSourceProtection)
+		}
+		for _, name := range protection.RequiredStatusChecks.Contexts {
+			if !hasContext(checks, name) {
+				add(name, nil, RequiredCheckSourceProtection)
+			}
+		}
+	}
+
+	if rules, err := client.FetchBranchRules(ctx, owner, repo, branch); err == nil {
+		for _, rule := range rules {
+			if rule.Type != "required_status_checks" || rule.Parameters == nil {
+				continue
+			}
+			for _, check := range rule.Parameters.RequiredStatusChecks {
+				add(check.Context, check.IntegrationID, RequiredCheckSourceRuleset)
+			}
+		}
+	}
+	return checks
+}
+
+func hasContext(checks []RequiredCheck, name string) bool {
+	for _, check := range checks {
+		if check.Context == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GitHubActionsAppID is the app ID of check runs created by GitHub Actions.
+const GitHubActionsAppID = 15368
+
+// RequiredContexts returns the distinct contexts that GitHub Actions jobs can
+// satisfy, in the form isJobRequired expects. Checks restricted to another
+// app are left out so a job sharing their name is not marked required.
+func RequiredContexts(checks []RequiredCheck) []string {
+	var contexts []string
+	seen := make(map[string]bool)
+	for _, check := range checks {
+		if check.AppID != 0 && check.AppID != GitHubActionsAppID {
+			continue
+		}
+		if !seen[check.Context] {
+			seen[check.Context] = true
+			contexts = append(contexts, check.Context)
+		}
+	}
+	return contexts
+}
+
+// Matches reports whether a check run satisfies the required check: the names
+// must be equal and, when the check is restricted to an app, the run must
+// come from that app.
+func (c RequiredCheck) Matches(run githubapi.CheckRun) bool {
+	if run.Name != c.Context {
+		return false
+	}
+	return c.AppID == 0 || (run.App != nil && run.App.ID == c.AppID)
+}
+
+// MergeGate is the required check that gated a pull request: the last one to
+// finish before the merge, or while unmerged the one still holding it up.
+// Times are unix milliseconds, zero when unknown.
+type MergeGate struct {
+	Check       string
+	App         string // slug of the app reporting the check
+	Source      string // where the check is required; empty when nothing is
+	URL         string
+	Status      string
+	Conclusion  string
+	StartedMs   int64
+	CompletedMs int64
+	MergedMs    int64
+	Required    []string // required checks, in configuration order
+	Pending     []string // required checks still running
+	Missing     []string // required checks without a qualifying check run
+}
+
+// IsPending reports whether the gate has not finished yet.
+func (g *MergeGate) IsPending() bool {
+	return g.CompletedMs == 0
+}
+
+// SlackMs is the time between the gate finishing and the merge; -1 when
+// either is unknown.
+func (g *MergeGate) SlackMs() int64 {
+	if g.CompletedMs == 0 || g.MergedMs == 0 {
+		return -1
+	}
+	return max(g.MergedMs-g.CompletedMs, 0)
+}
+
+// FetchGateRuns returns the check runs of a commit followed by its legacy
+// commit statuses as check runs, since both can satisfy required checks.
+// Either API failing leaves out its part.
+func FetchGateRuns(ctx context.Context, client githubapi.GitHubProvider, owner, repo, sha string) []githubapi.CheckRun {
+	var runs []githubapi.CheckRun
+	if checkRuns, err := client.FetchCheckRunsForCommit(ctx, owner, repo, sha); err == nil {
+		runs = checkRuns
+	}
+	if statuses, err := client.FetchCommitStatuses(ctx, owner, repo, sha); err == nil {
+		runs = append(runs, CommitStatusRuns(statuses)...)
+	}
+	return runs
+}
+
+// FindMergeGate maps required checks to the check runs of a pull request's
+// head commit and returns its merge gate. Without required checks every check
+// run counts, as for isJobRequired. For merged PRs only checks finishing
+// before mergedMs can have gated the merge. Returns nil when no check run
+// qualifies.
+func FindMergeGate(required []RequiredCheck, runs []githubapi.CheckRun, mergedMs int64) *MergeGate {
+	if len(required) == 0 {
+		seen := make(map[string]bool)
+		for _, run := range runs {
+			if !seen[run.Name] {
+				seen[run.Name] = true
+				required = append(required, RequiredCheck{Context: run.Name})
+			}
+		}
+	}
+
+	type candidate struct {
+		check RequiredCheck
+		run   githubapi.CheckRun
+		start int64
+		end   int64
+	}
+	gate := &MergeGate{MergedMs: mergedMs}
+	var done, pending []candidate
+	for _, check := range required {
+		gate.Required = append(gate.Required, check.Context)
+
+		// The most recent run of a check is the one GitHub evaluates
+		var latest *candidate
+		for _, run := range runs {
+			if !check.Matches(run) {
+				continue
+			}
+			c := candidate{check: check, run: run}
+			if t, ok := utils.ParseTime(run.StartedAt); ok {
+				c.start = t.UnixMilli()
+			}
+			if t, ok := utils.ParseTime(run.CompletedAt); ok && run.Status == "completed" {
+				c.end = t.UnixMilli()
+			}
+			if mergedMs != 0 && (c.end == 0 || c.end > mergedMs) {
+				continue // still running or re-run after the merge
+			}
+			if latest == nil || c.start > latest.start || (c.start == latest.start && c.run.ID > latest.run.ID) {
+				latest = &c
+			}
+		}
+		switch {
+		case latest == nil:
+			gate.Missing = append(gate.Missing, check.Context)
+		case latest.end == 0:
+			gate.Pending = append(gate.Pending, check.Context)
+			pending = append(pending, *latest)
+		default:
+			done = append(done, *latest)
+		}
+	}
+
+	var chosen *candidate
+	if len(pending) > 0 {
+		// The longest-running pending check is the one holding the PR up
+		sort.SliceStable(pending, func(i, j int) bool { return pending[i].start < pending[j].start })
+		chosen = &pending[0]
+	} else if len(done) > 0 {
+		sort.SliceStable(done, func(i, j int) bool { return done[i].end > done[j].end })
+		chosen = &done[0]
+	}
+	if chosen == nil {
+		return nil
+	}
+
+	gate.Check = chosen.run.Name
+	gate.Source = chosen.check.Source
+	gate.URL = chosen.run.HTMLURL
+	gate.Status = chosen.run.Status
+	gate.Conclusion = chosen.run.Conclusion
+	gate.StartedMs = chosen.start
+	gate.CompletedMs = chosen.end
+	if chosen.run.App != nil {
+		gate.App = chosen.run.App.Slug
+	}
+	return gate
+}
+
+// Attributes returns the span attributes describing the gate.
+func (g *MergeGate) Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(AttrMergeGateCheck, g.Check),
+		attribute.String(AttrMergeGateApp, g.App),
+		attribute.String(AttrMergeGateSource, g.Source),
+		attribute.String(AttrMergeGateStatus, g.Status),
+		attribute.String(AttrMergeGateConclusion, g.Conclusion),
+		attribute.String(AttrMergeGateStartedMs, strconv.FormatInt(g.StartedMs, 10)),
+		attribute.String(AttrMergeGateCompletedMs, strconv.FormatInt(g.CompletedMs, 10)),
+		attribute.String(AttrMergeGateMergedMs, strconv.FormatInt(g.MergedMs, 10)),
+		attribute.String(AttrMergeGateRequired, strings.Join(g.Required, "\n")),
+		attribute.String(AttrMergeGatePending, strings.Join(g.Pending, "\n")),
+		attribute.String(AttrMergeGateMissing, strings.Join(g.Missing, "\n")),
+	}
+}
+
+// MergeGateFromAttrs reads the gate back from span attributes; false when the
+// span is not a merge gate marker.
+func MergeGateFromAttrs(attrs map[string]string) (*MergeGate, bool) {
+	check := attrs[AttrMergeGateCheck]
+	if check == "" {
+		return nil, false
+	}
+	ms := func(key string) int64 {
+		v, _ := strconv.ParseInt(attrs[key], 10, 64)
+		return v
+	}
+	list := func(key string) []string {
+		if attrs[key] == "" {
+			return nil
+		}
+		return strings.Split(attrs[key], "\n")
+	}
+	return &MergeGate{
+		Check:       check,
+		App:         attrs[AttrMergeGateApp],
+		Source:      attrs[AttrMergeGateSource],
+		URL:         attrs["github.url"],
+		Status:      attrs[AttrMergeGateStatus],
+		Conclusion:  attrs[AttrMergeGateConclusion],
+		StartedMs:   ms(AttrMergeGateStartedMs),
+		CompletedMs: ms(AttrMergeGateCompletedMs),
+		MergedMs:    ms(AttrMergeGateMergedMs),
+		Required:    list(AttrMergeGateRequired),
+		Pending:     list(AttrMergeGatePending),
+		Missing:     list(AttrMergeGateMissing),
+	}, true
+}
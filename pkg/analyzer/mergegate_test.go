@@ -0,0 +1,236 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFetchRequiredChecks(t *testing.T) {
+	t.Parallel()
+
+	appID := int64(GitHubActionsAppID)
+	circleID := int64(18001)
+	client := new(mockGitHubProvider)
+	client.On("FetchBranchProtection", mock.Anything, "o", "r", "main").Return(&githubapi.BranchProtection{
+		RequiredStatusChecks: &githubapi.RequiredStatusChecks{
+			Contexts: []string{"build", "lint"},
+			Checks:   []githubapi.Check{{Context: "build", AppID: &appID}, {Context: "lint"}},
+		},
+	}, nil)
+	client.On("FetchBranchRules", mock.Anything, "o", "r", "main").Return([]githubapi.BranchRule{
+		{Type: "pull_request"},
+		{Type: "required_status_checks", RulesetID: 7, Parameters: &githubapi.RuleParameters{
+			RequiredStatusChecks: []githubapi.RequiredStatusCheck{{Context: "lint"}, {Context: "test", IntegrationID: &circleID}},
+		}},
+	}, nil)
+
+	checks := FetchRequiredChecks(context.Background(), client, "o", "r", "main")
+	assert.Equal(t, []RequiredCheck{
+		{Context: "build", AppID: appID, Source: RequiredCheckSourceProtection},
+		{Context: "lint", Source: RequiredCheckSourceProtection},
+		{Context: "test", AppID: circleID, Source: RequiredCheckSourceRuleset},
+	}, checks)
+	assert.Equal(t, []string{"build", "lint"}, RequiredContexts(checks), "a job named test does not satisfy the CircleCI check")
+}
+
+func TestFetchRequiredChecksRulesetsOnly(t *testing.T) {
+	t.Parallel()
+
+	client := new(mockGitHubProvider)
+	client.On("FetchBranchProtection", mock.Anything, "o", "r", "main").Return(nil, errors.New("forbidden"))
+	client.On("FetchBranchRules", mock.Anything, "o", "r", "main").Return([]githubapi.BranchRule{
+		{Type: "required_status_checks", Parameters: &githubapi.RuleParameters{
+			RequiredStatusChecks: []githubapi.RequiredStatusCheck{{Context: "build"}},
+		}},
+	}, nil)
+
+	checks := FetchRequiredChecks(context.Background(), client, "o", "r", "main")
+	assert.Equal(t, []RequiredCheck{{Context: "build", Source: RequiredCheckSourceRuleset}}, checks)
+}
+
+func TestFindMergeGate(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) string { return base.Add(d).Format(time.RFC3339) }
+	ms := func(d time.Duration) int64 { return base.Add(d).UnixMilli() }
+	actions := &githubapi.CheckRunApp{ID: 15368, Slug: "github-actions"}
+	circle := &githubapi.CheckRunApp{ID: 18001, Slug: "circleci-checks"}
+	run := func(id int64, name string, app *githubapi.CheckRunApp, start, end time.Duration) githubapi.CheckRun {
+		r := githubapi.CheckRun{ID: id, Name: name, App: app, Status: "in_progress", StartedAt: ts(start)}
+		if end != 0 {
+			r.Status, r.Conclusion, r.CompletedAt = "completed", "success", ts(end)
+		}
+		return r
+	}
+
+	runs := []githubapi.CheckRun{
+		run(1, "build", actions, 0, 20*time.Minute),
+		run(2, "test", circle, 0, 35*time.Minute),
+		run(3, "lint", actions, 0, 5*time.Minute),
+		// Re-run after the merge cannot have gated it
+		run(4, "test", circle, 2*time.Hour, 3*time.Hour),
+	}
+
+	t.Run("last required check before merge", func(t *testing.T) {
+		t.Parallel()
+		required := []RequiredCheck{{Context: "build"}, {Context: "test", AppID: 18001, Source: RequiredCheckSourceRuleset}}
+		gate := FindMergeGate(required, runs, ms(time.Hour))
+		assert.NotNil(t, gate)
+		assert.Equal(t, "test", gate.Check)
+		assert.Equal(t, "circleci-checks", gate.App)
+		assert.Equal(t, RequiredCheckSourceRuleset, gate.Source)
+		assert.Equal(t, ms(35*time.Minute), gate.CompletedMs)
+		assert.Equal(t, (25 * time.Minute).Milliseconds(), gate.SlackMs())
+		assert.Equal(t, []string{"build", "test"}, gate.Required)
+		assert.Empty(t, gate.Missing)
+	})
+
+	t.Run("app restriction", func(t *testing.T) {
+		t.Parallel()
+		required := []RequiredCheck{{Context: "build"}, {Context: "test", AppID: 15368}}
+		gate := FindMergeGate(required, runs, ms(time.Hour))
+		assert.Equal(t, "build", gate.Check)
+		assert.Equal(t, []string{"test"}, gate.Missing, "test is only reported by another app")
+	})
+
+	t.Run("unmerged PR waits on the longest pending check", func(t *testing.T) {
+		t.Parallel()
+		open := []githubapi.CheckRun{
+			run(1, "build", actions, 0, 20*time.Minute),
+			run(2, "test", circle, 10*time.Minute, 0),
+			run(3, "e2e", actions, 5*time.Minute, 0),
+		}
+		gate := FindMergeGate(nil, open, 0)
+		assert.Equal(t, "e2e", gate.Check)
+		assert.True(t, gate.IsPending())
+		assert.Equal(t, []string{"test", "e2e"}, gate.Pending)
+		assert.Equal(t, int64(-1), gate.SlackMs())
+	})
+
+	t.Run("no qualifying check run", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, FindMergeGate([]RequiredCheck{{Context: "deploy"}}, runs, ms(time.Hour)))
+		assert.Nil(t, FindMergeGate(nil, nil, 0))
+	})
+}
+
+// apiRedirect sends the requests of a githubapi.Client to a test server.
+type apiRedirect struct {
+	target *url.URL
+}
+
+func (r apiRedirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = r.target.Scheme, r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestFindMergeGateAcrossCheckRunPages(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) string { return base.Add(d).Format(time.RFC3339) }
+	run := func(id int64, name string, end time.Duration) githubapi.CheckRun {
+		return githubapi.CheckRun{ID: id, Name: name, Status: "completed", Conclusion: "success", StartedAt: ts(0), CompletedAt: ts(end)}
+	}
+
+	// A monorepo commit: the first page is full of shards, the required e2e
+	// check that finished last is on the second page
+	var first []githubapi.CheckRun
+	first = append(first, run(1, "build", 20*time.Minute))
+	for i := range 99 {
+		first = append(first, run(int64(i+2), fmt.Sprintf("shard %d", i), 10*time.Minute))
+	}
+	second := []githubapi.CheckRun{run(101, "e2e", 50*time.Minute)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		page := first
+		if req.URL.Query().Get("page") == "2" {
+			page = second
+		} else {
+			w.Header().Set("Link", `<https://api.github.com/repos/o/r/commits/abc/check-runs?per_page=100&page=2>; rel="next"`)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"total_count": len(first) + len(second), "check_runs": page})
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	client := githubapi.NewClient(githubapi.NewContext("test-token"), githubapi.WithHTTPClient(&http.Client{Transport: apiRedirect{target: target}}))
+	runs, err := client.FetchCheckRunsForCommit(context.Background(), "o", "r", "abc")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 101)
+
+	gate := FindMergeGate([]RequiredCheck{{Context: "build"}, {Context: "e2e"}}, runs, base.Add(time.Hour).UnixMilli())
+	if assert.NotNil(t, gate) {
+		assert.Equal(t, "e2e", gate.Check)
+		assert.Empty(t, gate.Missing)
+	}
+}
+
+func TestMergeGateAttrs(t *testing.T) {
+	t.Parallel()
+
+	gate := &MergeGate{
+		Check:       "test (ubuntu, 18)",
+		App:         "github-actions",
+		Source:      RequiredCheckSourceProtection,
+		Status:      "completed",
+		Conclusion:  "success",
+		StartedMs:   1000,
+		CompletedMs: 5000,
+		MergedMs:    9000,
+		Required:    []string{"build", "test (ubuntu, 18)"},
+		Missing:     []string{"docs"},
+	}
+	attrs := make(map[string]string)
+	for _, kv := range gate.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+
+	decoded, ok := MergeGateFromAttrs(attrs)
+	assert.True(t, ok)
+	assert.Equal(t, gate, decoded)
+
+	_, ok = MergeGateFromAttrs(map[string]string{"type": "marker"})
+	assert.False(t, ok)
+}
+
+func TestMergeGateMarker(t *testing.T) {
+	t.Parallel()
+
+	builder := &SpanBuilder{}
+	emitter := NewTraceEmitter(builder)
+	emitter.EmitMarkers(&RawData{
+		HeadSHA: "abc123",
+		MergeGate: &MergeGate{
+			Check:       "security/scan",
+			App:         "scanner",
+			URL:         "https://scanner.example/1",
+			Conclusion:  "success",
+			StartedMs:   1000,
+			CompletedMs: 5000,
+		},
+	}, 0)
+
+	spans := builder.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "Merge gate: security/scan", spans[0].Name())
+	assert.Equal(t, int64(5000), spans[0].StartTime().UnixMilli(), "placed where the gate finished")
+
+	gate, ok := MergeGateFromAttrs(spanAttrs(spans[0]))
+	assert.True(t, ok)
+	assert.Equal(t, "scanner", gate.App)
+	assert.Equal(t, "https://scanner.example/1", gate.URL)
+}
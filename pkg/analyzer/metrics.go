@@ -18,6 +18,7 @@ func InitializeMetrics() Metrics {
 		RunnerJobCounts: map[string]int{},
 		RunnerDurations: map[string]float64{},
 		QueueTimes:      []float64{},
+		ApprovalTimes:   []float64{},
 		BillableMs:      map[string]int64{},
 		JobTimeline:     []TimelineJob{},
 		LongestJob:      JobDuration{Name: "", Duration: 0},
@@ -98,9 +99,10 @@ func CalculateFinalMetrics(metrics Metrics, totalRuns int, jobStartTimes, jobEnd
 		avgStep = sum / float64(len(metrics.StepDurations))
 	}
 
+	// Superseded runs were cancelled by newer pushes and count for neither side
 	successRate := "0"
-	if metrics.TotalRuns > 0 {
-		successRate = formatPercent(float64(metrics.SuccessfulRuns) / float64(metrics.TotalRuns) * 100)
+	if decided := metrics.TotalRuns - metrics.SupersededRuns; decided > 0 {
+		successRate = formatPercent(float64(metrics.SuccessfulRuns) / float64(decided) * 100)
 	}
 	jobSuccessRate := "0"
 	if metrics.TotalJobs > 0 {
@@ -121,6 +123,20 @@ func CalculateFinalMetrics(metrics Metrics, totalRuns int, jobStartTimes, jobEnd
 		avgQueueTime = sum / float64(len(metrics.QueueTimes))
 	}
 
+	// Environment approval stats
+	avgApprovalTime := 0.0
+	maxApprovalTime := 0.0
+	if len(metrics.ApprovalTimes) > 0 {
+		sum := 0.0
+		for _, at := range metrics.ApprovalTimes {
+			sum += at
+			if at > maxApprovalTime {
+				maxApprovalTime = at
+			}
+		}
+		avgApprovalTime = sum / float64(len(metrics.ApprovalTimes))
+	}
+
 	// Retry rate
 	retryRate := "0"
 	if metrics.TotalRuns > 0 {
@@ -136,6 +152,8 @@ func CalculateFinalMetrics(metrics Metrics, totalRuns int, jobStartTimes, jobEnd
 		MaxConcurrency:  CalculateMaxConcurrency(jobStartTimes, jobEndTimes),
 		AvgQueueTime:    avgQueueTime,
 		MaxQueueTime:    maxQueueTime,
+		AvgApprovalTime: avgApprovalTime,
+		MaxApprovalTime: maxApprovalTime,
 		RetryRate:       retryRate,
 	}
 }
@@ -273,9 +291,10 @@ func CalculateCombinedSuccessRate(urlResults []URLResult) string {
 	totalSuccessful := 0.0
 	totalRuns := 0
 	for _, result := range urlResults {
+		decided := result.Metrics.TotalRuns - result.Metrics.SupersededRuns
 		rate := parsePercent(result.Metrics.SuccessRate)
-		totalSuccessful += float64(result.Metrics.TotalRuns) * rate / 100
-		totalRuns += result.Metrics.TotalRuns
+		totalSuccessful += float64(decided) * rate / 100
+		totalRuns += decided
 	}
 	if totalRuns == 0 {
 		return "0.0"
@@ -0,0 +1,299 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// OrgPrefix names an organization where trends take a repository, e.g.
+// "org:my-org".
+const OrgPrefix = "org:"
+
+// DefaultOrgConcurrency is how many repositories are analyzed at once. Their
+// requests share the client's rate limiter either way.
+const DefaultOrgConcurrency = 4
+
+// orgJobLimit is how many of the slowest and flakiest jobs are kept.
+const orgJobLimit = 15
+
+// OrgTrendAnalysis aggregates the trends of the repositories of an
+// organization, or of a list of repositories.
+type OrgTrendAnalysis struct {
+	Org          string // empty for a list of repositories
+	TimeRange    TimeRange
+	Repos        []RepoTrend      // most compute first
+	Errors       []RepoError      // repositories that couldn't be analyzed
+	SlowestJobs  []OrgJobTrend    // by average duration, across repositories
+	FlakiestJobs []OrgFlakyJob    // by flake rate, across repositories
+	QueueByLabel []LabelQueueTime // longest average queue first
+}
+
+// RepoTrend is the trend of one repository of an OrgTrendAnalysis.
+type RepoTrend struct {
+	Repo           string // owner/repo
+	TotalRuns      int
+	ComputeHours   float64 // runner time of all runs, estimated from the sampled runs
+	AvgDuration    float64 // seconds
+	SuccessRate    float64 // percent
+	TrendDirection string  // "improving", "stable", "degrading"
+	PercentChange  float64
+	TrendPValue    float64
+	FlakyJobs      int
+	Sampled        bool // job details were sampled
+}
+
+// RepoError is a repository an OrgTrendAnalysis couldn't analyze.
+type RepoError struct {
+	Repo  string
+	Error string
+}
+
+// OrgJobTrend is a JobTrend of one repository.
+type OrgJobTrend struct {
+	Repo string
+	JobTrend
+}
+
+// OrgFlakyJob is a FlakyJob of one repository.
+type OrgFlakyJob struct {
+	Repo string
+	FlakyJob
+}
+
+// LabelQueueTime is how long jobs waited for runners with a runs-on label
+// set, across repositories.
+type LabelQueueTime struct {
+	Labels   string // comma separated, as the job requested them
+	Jobs     int
+	Repos    int
+	AvgQueue float64 // seconds
+	P95Queue float64 // seconds
+}
+
+// ListOrgRepos lists the repositories of an organization that were pushed to
+// since the given time, as owner/repo. Archived and disabled repositories,
+// which can't run workflows, are left out.
+func ListOrgRepos(ctx context.Context, client githubapi.GitHubProvider, org string, since time.Time) ([]string, error) {
+	repos, err := client.FetchOrgRepositories(ctx, org)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list repositories of %s", org)
+	}
+	var names []string
+	for _, r := range repos {
+		if r.Archived || r.Disabled {
+			continue
+		}
+		if pushed, ok := utils.ParseTime(r.PushedAt); ok && pushed.Before(since) {
+			continue
+		}
+		names = append(names, defaultString(r.FullName, org+"/"+r.Name))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ParseRepoList parses a list of repositories, one owner/repo per line.
+// Blank lines and lines starting with # are skipped, as are repeats.
+func ParseRepoList(list string) ([]string, error) {
+	var repos []string
+	seen := make(map[string]bool)
+	for i, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		owner, repo, ok := strings.Cut(line, "/")
+		if !ok || owner == "" || repo == "" || strings.ContainsAny(repo, "/ \t") {
+			return nil, errors.Newf("line %d: invalid repository %q (want owner/repo)", i+1, line)
+		}
+		if !seen[line] {
+			seen[line] = true
+			repos = append(repos, line)
+		}
+	}
+	if len(repos) == 0 {
+		return nil, errors.New("no repositories listed")
+	}
+	return repos, nil
+}
+
+// repoTrendResult is the outcome of analyzing one repository.
+type repoTrendResult struct {
+	analysis *TrendAnalysis
+	runs     []RunData
+	err      error
+}
+
+// AnalyzeOrgTrends analyzes the trends of each repository like AnalyzeTrends,
+// at most concurrency at a time, and aggregates them. Repositories without
+// runs in the period are skipped and ones that fail are listed in Errors; it
+// fails only when no repository could be analyzed.
+func AnalyzeOrgTrends(ctx context.Context, client githubapi.GitHubProvider, org string, repos []string, days int, branch, workflow string, opts TrendOptions, concurrency int, reporter ProgressReporter) (*OrgTrendAnalysis, error) {
+	if len(repos) == 0 {
+		return nil, errors.New("no repositories to analyze")
+	}
+	if concurrency < 1 {
+		concurrency = DefaultOrgConcurrency
+	}
+	endTime := time.Now()
+
+	if reporter != nil {
+		reporter.SetURLRuns(len(repos))
+		reporter.SetPhase("Analyzing repositories")
+		reporter.SetDetail(fmt.Sprintf("0/%d repositories", len(repos)))
+	}
+
+	results := make([]repoTrendResult, len(repos))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // guards reporter and done
+	done := 0
+	for i, full := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			owner, repo, _ := strings.Cut(full, "/")
+			analysis, runs, err := analyzeRepoTrends(ctx, client, owner, repo, days, branch, workflow, opts, nil)
+			results[i] = repoTrendResult{analysis: analysis, runs: runs, err: err}
+
+			if reporter != nil {
+				mu.Lock()
+				done++
+				reporter.ProcessRun()
+				reporter.SetDetail(fmt.Sprintf("%d/%d repositories — %s", done, len(repos), full))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	analysis := aggregateOrgTrends(repos, results)
+	analysis.Org = org
+	analysis.TimeRange = TimeRange{
+		Start: endTime.Add(-time.Duration(days) * 24 * time.Hour),
+		End:   endTime,
+		Days:  days,
+	}
+	if len(analysis.Repos) == 0 {
+		if len(analysis.Errors) > 0 {
+			return nil, errors.Newf("no repository could be analyzed, e.g. %s: %s", analysis.Errors[0].Repo, analysis.Errors[0].Error)
+		}
+		return nil, errors.Wrapf(ErrNoWorkflowRuns, "in %d repositories in the last %d days", len(repos), days)
+	}
+	return analysis, nil
+}
+
+// aggregateOrgTrends combines the analyses of the repositories.
+func aggregateOrgTrends(repos []string, results []repoTrendResult) *OrgTrendAnalysis {
+	analysis := &OrgTrendAnalysis{}
+	queues := make(map[string][]float64)
+	queueRepos := make(map[string]map[string]bool)
+
+	for i, r := range results {
+		repo := repos[i]
+		if r.err != nil {
+			if !errors.Is(r.err, ErrNoWorkflowRuns) {
+				analysis.Errors = append(analysis.Errors, RepoError{Repo: repo, Error: r.err.Error()})
+			}
+			continue
+		}
+
+		a := r.analysis
+		analysis.Repos = append(analysis.Repos, RepoTrend{
+			Repo:           repo,
+			TotalRuns:      a.Summary.TotalRuns,
+			ComputeHours:   estimateComputeHours(r.runs),
+			AvgDuration:    a.Summary.AvgDuration,
+			SuccessRate:    a.Summary.AvgSuccessRate,
+			TrendDirection: a.Summary.TrendDirection,
+			PercentChange:  a.Summary.PercentChange,
+			TrendPValue:    a.Summary.TrendPValue,
+			FlakyJobs:      len(a.FlakyJobs),
+			Sampled:        a.Sampling.Enabled,
+		})
+		for _, job := range a.JobTrends {
+			analysis.SlowestJobs = append(analysis.SlowestJobs, OrgJobTrend{Repo: repo, JobTrend: job})
+		}
+		for _, job := range a.FlakyJobs {
+			analysis.FlakiestJobs = append(analysis.FlakiestJobs, OrgFlakyJob{Repo: repo, FlakyJob: job})
+		}
+
+		for _, run := range r.runs {
+			for _, job := range run.Jobs {
+				if job.StartedAt.IsZero() {
+					continue // never ran, e.g. skipped
+				}
+				labels := segmentKey(strings.Join(job.Labels, ","))
+				queues[labels] = append(queues[labels], float64(max(job.QueueTime, 0))/1000.0)
+				if queueRepos[labels] == nil {
+					queueRepos[labels] = make(map[string]bool)
+				}
+				queueRepos[labels][repo] = true
+			}
+		}
+	}
+
+	sort.SliceStable(analysis.Repos, func(i, j int) bool {
+		return analysis.Repos[i].ComputeHours > analysis.Repos[j].ComputeHours
+	})
+	sort.SliceStable(analysis.SlowestJobs, func(i, j int) bool {
+		return analysis.SlowestJobs[i].AvgDuration > analysis.SlowestJobs[j].AvgDuration
+	})
+	if len(analysis.SlowestJobs) > orgJobLimit {
+		analysis.SlowestJobs = analysis.SlowestJobs[:orgJobLimit]
+	}
+	sort.SliceStable(analysis.FlakiestJobs, func(i, j int) bool {
+		return analysis.FlakiestJobs[i].FlakeRate > analysis.FlakiestJobs[j].FlakeRate
+	})
+	if len(analysis.FlakiestJobs) > orgJobLimit {
+		analysis.FlakiestJobs = analysis.FlakiestJobs[:orgJobLimit]
+	}
+
+	for labels, q := range queues {
+		analysis.QueueByLabel = append(analysis.QueueByLabel, LabelQueueTime{
+			Labels:   labels,
+			Jobs:     len(q),
+			Repos:    len(queueRepos[labels]),
+			AvgQueue: average(q),
+			P95Queue: calculatePercentile(q, 95),
+		})
+	}
+	sort.Slice(analysis.QueueByLabel, func(i, j int) bool {
+		if analysis.QueueByLabel[i].AvgQueue != analysis.QueueByLabel[j].AvgQueue {
+			return analysis.QueueByLabel[i].AvgQueue > analysis.QueueByLabel[j].AvgQueue
+		}
+		return analysis.QueueByLabel[i].Labels < analysis.QueueByLabel[j].Labels
+	})
+	return analysis
+}
+
+// estimateComputeHours is the runner time of the runs: the job run time of
+// the runs whose jobs were fetched, scaled up to all runs.
+func estimateComputeHours(runs []RunData) float64 {
+	var ms int64
+	sampled := 0
+	for _, run := range runs {
+		if len(run.Jobs) == 0 {
+			continue
+		}
+		sampled++
+		for _, job := range run.Jobs {
+			ms += max(job.Duration, 0)
+		}
+	}
+	if sampled == 0 {
+		return 0
+	}
+	return float64(ms) / float64(time.Hour/time.Millisecond) * float64(len(runs)) / float64(sampled)
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseRepoList(t *testing.T) {
+	t.Parallel()
+
+	repos, err := ParseRepoList("# platform repos\nacme/api\n\n  acme/web  \nacme/api\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/web"}, repos)
+
+	for _, list := range []string{"", "# nothing\n", "acme\n", "acme/api/extra\n", "/api\n"} {
+		_, err := ParseRepoList(list)
+		assert.Error(t, err, list)
+	}
+}
+
+func TestListOrgRepos(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	client := new(mockGitHubProvider)
+	client.On("FetchOrgRepositories", mock.Anything, "acme").Return([]githubapi.Repository{
+		{Name: "web", FullName: "acme/web", PushedAt: "2026-03-10T00:00:00Z"},
+		{Name: "api", FullName: "acme/api", PushedAt: "2026-03-02T00:00:00Z"},
+		{Name: "legacy", FullName: "acme/legacy", Archived: true, PushedAt: "2026-03-10T00:00:00Z"},
+		{Name: "stale", FullName: "acme/stale", PushedAt: "2025-12-01T00:00:00Z"},
+		{Name: "new"}, // never pushed
+	}, nil)
+
+	repos, err := ListOrgRepos(context.Background(), client, "acme", since)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/new", "acme/web"}, repos)
+}
+
+func TestAnalyzeOrgTrends(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	run := func(id int64, ago time.Duration) githubapi.WorkflowRun {
+		created := now.Add(-ago)
+		return githubapi.WorkflowRun{
+			ID:         id,
+			RunAttempt: 1,
+			Status:     "completed",
+			Conclusion: "success",
+			CreatedAt:  created.Format(time.RFC3339),
+			UpdatedAt:  created.Add(10 * time.Minute).Format(time.RFC3339),
+			Repository: githubapi.RepoRef{Owner: githubapi.RepoOwner{Login: "acme"}, Name: "api"},
+		}
+	}
+	job := func(id int64, name string, ago time.Duration) githubapi.Job {
+		created := now.Add(-ago)
+		return githubapi.Job{
+			ID:          id,
+			RunAttempt:  1,
+			Name:        name,
+			Status:      "completed",
+			Conclusion:  "success",
+			CreatedAt:   created.Format(time.RFC3339),
+			StartedAt:   created.Add(30 * time.Second).Format(time.RFC3339),
+			CompletedAt: created.Add(30*time.Second + 30*time.Minute).Format(time.RFC3339),
+			Labels:      []string{"ubuntu-latest"},
+		}
+	}
+
+	client := new(mockGitHubProvider)
+	client.On("FetchRecentWorkflowRuns", mock.Anything, "acme", "api", 7, "", "", mock.Anything).
+		Return([]githubapi.WorkflowRun{run(1, 2*time.Hour), run(2, time.Hour)}, nil)
+	client.On("FetchRecentWorkflowRuns", mock.Anything, "acme", "docs", 7, "", "", mock.Anything).
+		Return([]githubapi.WorkflowRun{}, nil)
+	client.On("FetchRecentWorkflowRuns", mock.Anything, "acme", "secret", 7, "", "", mock.Anything).
+		Return([]githubapi.WorkflowRun(nil), errors.New("404 Not Found"))
+	client.On("FetchJobsPaginated", mock.Anything, "https://api.github.com/repos/acme/api/actions/runs/1/jobs").
+		Return([]githubapi.Job{job(11, "build", 2*time.Hour)}, nil)
+	client.On("FetchJobsPaginated", mock.Anything, "https://api.github.com/repos/acme/api/actions/runs/2/jobs").
+		Return([]githubapi.Job{job(21, "build", time.Hour)}, nil)
+
+	analysis, err := AnalyzeOrgTrends(context.Background(), client, "acme", []string{"acme/api", "acme/docs", "acme/secret"}, 7, "", "", TrendOptions{}, 2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", analysis.Org)
+	assert.Equal(t, 7, analysis.TimeRange.Days)
+
+	assert.Len(t, analysis.Repos, 1)
+	api := analysis.Repos[0]
+	assert.Equal(t, "acme/api", api.Repo)
+	assert.Equal(t, 2, api.TotalRuns)
+	assert.InDelta(t, 1.0, api.ComputeHours, 1e-9)
+	assert.Equal(t, 100.0, api.SuccessRate)
+
+	assert.Equal(t, []RepoError{{Repo: "acme/secret", Error: "failed to fetch workflow runs: 404 Not Found"}}, analysis.Errors)
+
+	assert.Len(t, analysis.SlowestJobs, 1)
+	assert.Equal(t, "acme/api", analysis.SlowestJobs[0].Repo)
+	assert.Equal(t, "build", analysis.SlowestJobs[0].Name)
+
+	assert.Equal(t, []LabelQueueTime{{Labels: "ubuntu-latest", Jobs: 2, Repos: 1, AvgQueue: 30, P95Queue: 30}}, analysis.QueueByLabel)
+
+	// Nothing to report is an error
+	_, err = AnalyzeOrgTrends(context.Background(), client, "acme", []string{"acme/docs"}, 7, "", "", TrendOptions{}, 0, nil)
+	assert.ErrorIs(t, err, ErrNoWorkflowRuns)
+	_, err = AnalyzeOrgTrends(context.Background(), client, "acme", []string{"acme/secret"}, 7, "", "", TrendOptions{}, 0, nil)
+	assert.ErrorContains(t, err, "acme/secret")
+}
+
+func TestAggregateOrgTrends(t *testing.T) {
+	t.Parallel()
+
+	result := func(runs int, flaky ...FlakyJob) repoTrendResult {
+		return repoTrendResult{
+			analysis: &TrendAnalysis{
+				Summary:   TrendSummary{TotalRuns: runs, TrendDirection: "stable"},
+				JobTrends: []JobTrend{{Name: "test", AvgDuration: float64(runs)}},
+				FlakyJobs: flaky,
+			},
+			// One sampled run with an hour of jobs, out of runs
+			runs: append([]RunData{{Jobs: []JobData{{Duration: 3600000, Labels: []string{"self-hosted", "gpu"}, StartedAt: time.Unix(1, 0), QueueTime: 120000}}}}, make([]RunData, runs-1)...),
+		}
+	}
+	analysis := aggregateOrgTrends(
+		[]string{"acme/small", "acme/big"},
+		[]repoTrendResult{
+			result(2, FlakyJob{Name: "e2e", FlakeRate: 20}),
+			result(10, FlakyJob{Name: "lint", FlakeRate: 40}),
+		},
+	)
+
+	assert.Equal(t, "acme/big", analysis.Repos[0].Repo)
+	assert.InDelta(t, 10, analysis.Repos[0].ComputeHours, 1e-9)
+	assert.InDelta(t, 2, analysis.Repos[1].ComputeHours, 1e-9)
+	assert.Equal(t, 1, analysis.Repos[0].FlakyJobs)
+
+	assert.Equal(t, "acme/big", analysis.SlowestJobs[0].Repo)
+	assert.Equal(t, "lint", analysis.FlakiestJobs[0].Name)
+	assert.Equal(t, "acme/big", analysis.FlakiestJobs[0].Repo)
+
+	assert.Equal(t, []LabelQueueTime{{Labels: "self-hosted,gpu", Jobs: 2, Repos: 2, AvgQueue: 120, P95Queue: 120}}, analysis.QueueByLabel)
+}
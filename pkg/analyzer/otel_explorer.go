@@ -22,6 +22,11 @@ type Summary struct {
 	QueueCount     int
 	RetriedRuns    int
 	BillableMs     map[string]int64 // OS name → total ms (e.g. "ubuntu", "macos", "windows")
+
+	// Waits on environment protection rules, kept out of queue time
+	AvgApprovalTimeMs float64
+	MaxApprovalTimeMs float64
+	ApprovalCount     int
 }
 
 // CalculateSummary analyzes OTel spans to produce a high-level summary.
@@ -31,7 +36,7 @@ func CalculateSummary(spans []trace.ReadOnlySpan, enricher enrichment.Enricher)
 		BillableMs: make(map[string]int64),
 	}
 
-	var totalQueueMs float64
+	var totalQueueMs, totalApprovalMs float64
 
 	for _, span := range spans {
 		attrs := make(map[string]string)
@@ -40,7 +45,7 @@ func CalculateSummary(spans []trace.ReadOnlySpan, enricher enrichment.Enricher)
 			key := string(a.Key)
 			attrs[key] = a.Value.AsString()
 			// Capture int64 attributes for billable/queue/retry
-			if strings.HasPrefix(key, "billable.") || key == "queue_time_ms" || key == "github.run_attempt" {
+			if strings.HasPrefix(key, "billable.") || key == "queue_time_ms" || key == "approval_wait_ms" || key == "github.run_attempt" {
 				if attrInts == nil {
 					attrInts = make(map[string]int64)
 				}
@@ -55,6 +60,15 @@ func CalculateSummary(spans []trace.ReadOnlySpan, enricher enrichment.Enricher)
 			continue
 		}
 
+		if hints.Category == "deploy" {
+			if ms, ok := attrInts["approval_wait_ms"]; ok && hints.Outcome != "pending" {
+				s.ApprovalCount++
+				totalApprovalMs += float64(ms)
+				s.MaxApprovalTimeMs = max(s.MaxApprovalTimeMs, float64(ms))
+			}
+			continue
+		}
+
 		if hints.IsRoot {
 			s.TotalRuns++
 			if hints.Outcome == "success" {
@@ -71,8 +85,9 @@ func CalculateSummary(spans []trace.ReadOnlySpan, enricher enrichment.Enricher)
 					s.BillableMs[osName] += ms
 				}
 			}
-		} else if !hints.IsMarker && !hints.IsLeaf {
-			// Non-root, non-marker, non-leaf = "job"-level span
+		} else if !hints.IsMarker && !hints.IsLeaf && hints.Category != "reusable_workflow" {
+			// Non-root, non-marker, non-leaf = "job"-level span; reusable
+			// workflow calls only group the jobs they ran
 			s.TotalJobs++
 			if hints.Outcome == "failure" {
 				s.FailedJobs++
@@ -91,6 +106,9 @@ func CalculateSummary(spans []trace.ReadOnlySpan, enricher enrichment.Enricher)
 	if s.QueueCount > 0 {
 		s.AvgQueueTimeMs = totalQueueMs / float64(s.QueueCount)
 	}
+	if s.ApprovalCount > 0 {
+		s.AvgApprovalTimeMs = totalApprovalMs / float64(s.ApprovalCount)
+	}
 
 	s.MaxConcurrency = CalculateConcurrency(spans, enricher)
 	return s
@@ -114,7 +132,7 @@ func CalculateConcurrency(spans []trace.ReadOnlySpan, enricher enrichment.Enrich
 		isZeroDuration := s.EndTime().Before(s.StartTime()) || s.EndTime().Equal(s.StartTime())
 		hints := enricher.Enrich(s.Name(), attrs, isZeroDuration)
 
-		if hints.Category == "" || hints.IsRoot || hints.IsMarker || hints.IsLeaf {
+		if hints.Category == "" || hints.IsRoot || hints.IsMarker || hints.IsLeaf || hints.Category == "reusable_workflow" {
 			continue
 		}
 
@@ -43,6 +43,11 @@ func (m *mockGitHubProvider) FetchPullRequest(ctx context.Context, baseURL, iden
 	return args.Get(0).(*githubapi.PullRequest), args.Error(1)
 }
 
+func (m *mockGitHubProvider) FetchRecentMergedPullRequests(ctx context.Context, owner, repo string, days int, base string) ([]githubapi.PullRequest, error) {
+	args := m.Called(ctx, owner, repo, days, base)
+	return args.Get(0).([]githubapi.PullRequest), args.Error(1)
+}
+
 func (m *mockGitHubProvider) FetchPRReviews(ctx context.Context, owner, repo, prNumber string) ([]githubapi.Review, error) {
 	args := m.Called(ctx, owner, repo, prNumber)
 	return args.Get(0).([]githubapi.Review), args.Error(1)
@@ -53,6 +58,11 @@ func (m *mockGitHubProvider) FetchPRComments(ctx context.Context, owner, repo, p
 	return args.Get(0).([]githubapi.Review), args.Error(1)
 }
 
+func (m *mockGitHubProvider) FetchPRCommits(ctx context.Context, owner, repo, prNumber string) ([]githubapi.PRCommit, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	return args.Get(0).([]githubapi.PRCommit), args.Error(1)
+}
+
 func (m *mockGitHubProvider) FetchJobsPaginated(ctx context.Context, urlValue string) ([]githubapi.Job, error) {
 	args := m.Called(ctx, urlValue)
 	return args.Get(0).([]githubapi.Job), args.Error(1)
@@ -66,6 +76,11 @@ func (m *mockGitHubProvider) FetchBranchProtection(ctx context.Context, owner, r
 	return args.Get(0).(*githubapi.BranchProtection), args.Error(1)
 }
 
+func (m *mockGitHubProvider) FetchBranchRules(ctx context.Context, owner, repo, branch string) ([]githubapi.BranchRule, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	return args.Get(0).([]githubapi.BranchRule), args.Error(1)
+}
+
 func (m *mockGitHubProvider) FetchRecentWorkflowRuns(ctx context.Context, owner, repo string, days int, branch, workflow string, onPage func(fetched, total int)) ([]githubapi.WorkflowRun, error) {
 	args := m.Called(ctx, owner, repo, days, branch, workflow, onPage)
 	return args.Get(0).([]githubapi.WorkflowRun), args.Error(1)
@@ -84,6 +99,31 @@ func (m *mockGitHubProvider) FetchCheckRunsForCommit(ctx context.Context, owner,
 	return args.Get(0).([]githubapi.CheckRun), args.Error(1)
 }
 
+func (m *mockGitHubProvider) FetchCommitStatuses(ctx context.Context, owner, repo, sha string) ([]githubapi.CommitStatus, error) {
+	args := m.Called(ctx, owner, repo, sha)
+	return args.Get(0).([]githubapi.CommitStatus), args.Error(1)
+}
+
+func (m *mockGitHubProvider) FetchDeployments(ctx context.Context, owner, repo, sha string) ([]githubapi.Deployment, error) {
+	args := m.Called(ctx, owner, repo, sha)
+	return args.Get(0).([]githubapi.Deployment), args.Error(1)
+}
+
+func (m *mockGitHubProvider) FetchDeploymentStatuses(ctx context.Context, owner, repo string, deploymentID int64) ([]githubapi.DeploymentStatus, error) {
+	args := m.Called(ctx, owner, repo, deploymentID)
+	return args.Get(0).([]githubapi.DeploymentStatus), args.Error(1)
+}
+
+func (m *mockGitHubProvider) FetchPendingDeployments(ctx context.Context, owner, repo string, runID int64) ([]githubapi.PendingDeployment, error) {
+	args := m.Called(ctx, owner, repo, runID)
+	return args.Get(0).([]githubapi.PendingDeployment), args.Error(1)
+}
+
+func (m *mockGitHubProvider) FetchRunApprovals(ctx context.Context, owner, repo string, runID int64) ([]githubapi.EnvironmentApproval, error) {
+	args := m.Called(ctx, owner, repo, runID)
+	return args.Get(0).([]githubapi.EnvironmentApproval), args.Error(1)
+}
+
 func (m *mockGitHubProvider) FetchAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]githubapi.Annotation, error) {
 	args := m.Called(ctx, owner, repo, checkRunID)
 	return args.Get(0).([]githubapi.Annotation), args.Error(1)
@@ -99,6 +139,21 @@ func (m *mockGitHubProvider) DownloadArtifact(ctx context.Context, url string) (
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *mockGitHubProvider) FetchOrgRepositories(ctx context.Context, org string) ([]githubapi.Repository, error) {
+	args := m.Called(ctx, org)
+	return args.Get(0).([]githubapi.Repository), args.Error(1)
+}
+
+func (m *mockGitHubProvider) FetchWorkflowFile(ctx context.Context, owner, repo, path, ref string) ([]byte, error) {
+	args := m.Called(ctx, owner, repo, path, ref)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockGitHubProvider) FetchJobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error) {
+	args := m.Called(ctx, owner, repo, jobID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *mockGitHubProvider) FetchWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*githubapi.WorkflowRun, error) {
 	args := m.Called(ctx, owner, repo, runID)
 	if args.Get(0) == nil {
@@ -129,14 +184,16 @@ func TestWorkflowQueueTimeSpan(t *testing.T) {
 		}
 
 		job := githubapi.Job{
-			ID:          200,
-			Name:        "Build",
-			Status:      "completed",
-			Conclusion:  "success",
-			CreatedAt:   "2026-03-18T17:47:58Z",
-			StartedAt:   "2026-03-18T17:56:49Z",
-			CompletedAt: "2026-03-18T18:47:19Z",
-			RunnerName:  "runner-1",
+			ID:              200,
+			Name:            "Build",
+			Status:          "completed",
+			Conclusion:      "success",
+			CreatedAt:       "2026-03-18T17:47:58Z",
+			StartedAt:       "2026-03-18T17:56:49Z",
+			CompletedAt:     "2026-03-18T18:47:19Z",
+			RunnerName:      "runner-1",
+			RunnerGroupName: "fleet",
+			Labels:          []string{"self-hosted", "linux"},
 		}
 
 		jobsURL := "https://api.github.com/repos/owner/repo/actions/runs/100/jobs?per_page=100"
@@ -151,7 +208,7 @@ func TestWorkflowQueueTimeSpan(t *testing.T) {
 		_, traceEvents, _, _, err := processWorkflowRun(
 			context.Background(), run, 0, 1001, earliestTime,
 			"owner", "repo", "1", 0, "https://github.com/owner/repo/pull/1", "pr",
-			nil, 0, 0, 0, mockClient, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true},
+			nil, 0, 0, 0, mockClient, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true}, nil, nil, false,
 		)
 		assert.NoError(t, err)
 
@@ -188,6 +245,16 @@ func TestWorkflowQueueTimeSpan(t *testing.T) {
 			}
 		}
 		assert.True(t, otelQueueFound, "Workflow queue OTel span not found")
+
+		var jobFound bool
+		for _, s := range spans {
+			if attrs := spanAttrs(s); attrs["type"] == "job" {
+				jobFound = true
+				assert.Equal(t, "fleet", attrs[AttrRunnerGroup])
+				assert.Equal(t, "self-hosted,linux", attrs[AttrRunnerLabels])
+			}
+		}
+		assert.True(t, jobFound, "job span not found")
 	})
 
 	t.Run("no queue span when RunStartedAt equals CreatedAt", func(t *testing.T) {
@@ -233,7 +300,7 @@ func TestWorkflowQueueTimeSpan(t *testing.T) {
 		_, traceEvents, _, _, err := processWorkflowRun(
 			context.Background(), run, 0, 1001, earliestTime,
 			"owner", "repo", "1", 0, "https://github.com/owner/repo/pull/1", "pr",
-			nil, 0, 0, 0, mockClient, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true},
+			nil, 0, 0, 0, mockClient, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true}, nil, nil, false,
 		)
 		assert.NoError(t, err)
 
@@ -287,7 +354,7 @@ func TestWorkflowQueueTimeSpan(t *testing.T) {
 		_, traceEvents, _, _, err := processWorkflowRun(
 			context.Background(), run, 0, 1001, earliestTime,
 			"owner", "repo", "1", 0, "https://github.com/owner/repo/pull/1", "pr",
-			nil, 0, 0, 0, mockClient, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true},
+			nil, 0, 0, 0, mockClient, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true}, nil, nil, false,
 		)
 		assert.NoError(t, err)
 
@@ -351,7 +418,7 @@ func TestRetriedRun(t *testing.T) {
 		_, _, _, _, err := processWorkflowRun(
 			context.Background(), run, 0, 1001, createdAt.UnixMilli(),
 			"owner", "repo", "1", 0, "https://github.com/owner/repo/pull/1", "pr",
-			nil, 0, 0, 0, mock, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true},
+			nil, 0, 0, 0, mock, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true}, nil, nil, false,
 		)
 		return err
 	}
@@ -467,7 +534,7 @@ func TestSpanBuilderGeneration(t *testing.T) {
 			ReviewEvents: reviewEvents,
 		}, 0)
 
-		_, err := buildURLResult(context.Background(), parsed, 0, "sha", "main", "PR 1", "url", reviewEvents, nil, nil, nil, 0, 0, nil, nil, 0, 0, 0, mockClient, nil, 0, builder, emitter, AnalyzeOptions{})
+		_, err := buildURLResult(context.Background(), parsed, 0, "sha", "main", "PR 1", "url", reviewEvents, nil, nil, nil, 0, 0, nil, nil, 0, 0, 0, mockClient, nil, 0, builder, emitter, AnalyzeOptions{}, nil)
 		assert.NoError(t, err)
 
 		spans := builder.Spans()
@@ -506,7 +573,7 @@ func TestSpanBuilderGeneration(t *testing.T) {
 			CommitTimeMs: &commitTimeMs,
 		}, 0)
 
-		_, err := buildURLResult(context.Background(), parsed, 0, "sha123", "main", "Commit sha123", "url", nil, nil, &commitTimeMs, nil, 0, 0, nil, nil, 0, 0, 0, mockClient, nil, 0, builder, emitter, AnalyzeOptions{})
+		_, err := buildURLResult(context.Background(), parsed, 0, "sha123", "main", "Commit sha123", "url", nil, nil, &commitTimeMs, nil, 0, 0, nil, nil, 0, 0, 0, mockClient, nil, 0, builder, emitter, AnalyzeOptions{}, nil)
 		assert.NoError(t, err)
 
 		spans := builder.Spans()
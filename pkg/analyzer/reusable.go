@@ -0,0 +1,235 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+)
+
+// SpanTypeReusableWorkflow is the span type of a reusable workflow call,
+// parenting the jobs of the called workflow.
+const SpanTypeReusableWorkflow = "reusable_workflow"
+
+// AttrReusableWorkflowUses is the `uses:` reference of a reusable workflow
+// call, e.g. "org/templates/.github/workflows/build.yml@v2".
+const AttrReusableWorkflowUses = "github.reusable_workflow.uses"
+
+// reusableJobSeparator joins the caller and callee job names of jobs run by
+// reusable workflows, e.g. "build / compile".
+const reusableJobSeparator = " / "
+
+// ReusableWorkflowCalls parses a workflow definition and returns its jobs that
+// call reusable workflows, keyed by the name the jobs appear under (the job's
+// name, or its key when unnamed), with the `uses:` reference as the value.
+func ReusableWorkflowCalls(definition []byte) (map[string]string, error) {
+	var wf struct {
+		Jobs map[string]struct {
+			Name string `yaml:"name"`
+			Uses string `yaml:"uses"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(definition, &wf); err != nil {
+		return nil, err
+	}
+	calls := make(map[string]string)
+	for key, job := range wf.Jobs {
+		if job.Uses == "" {
+			continue
+		}
+		calls[defaultString(job.Name, key)] = job.Uses
+	}
+	return calls, nil
+}
+
+// hasReusableJobs reports whether any job name looks like a reusable
+// workflow call.
+func hasReusableJobs(jobs []githubapi.Job) bool {
+	for _, job := range jobs {
+		if strings.Contains(job.Name, reusableJobSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// reusableCallPath splits a job name into the reusable workflow calls it ran
+// under, outermost first: "deploy / build / compile" ran under "deploy" and
+// "build". calls are the callers of the job's workflow; when nil the name is
+// trusted. Otherwise the outermost caller must be one of them, so jobs that
+// merely contain " / " in their name stay flat. Callers named with
+// expressions or expanded by a matrix ("build (linux)") match by prefix.
+func reusableCallPath(jobName string, calls map[string]string) []string {
+	segments := strings.Split(jobName, reusableJobSeparator)
+	if len(segments) < 2 {
+		return nil
+	}
+	if calls == nil {
+		return segments[:len(segments)-1]
+	}
+	if _, ok := reusableCaller(segments[0], calls); ok {
+		return segments[:len(segments)-1]
+	}
+	return nil
+}
+
+// reusableCaller finds the caller a job name segment was rendered from and
+// returns its `uses:` reference.
+func reusableCaller(segment string, calls map[string]string) (string, bool) {
+	if uses, ok := calls[segment]; ok {
+		return uses, true
+	}
+	for name, uses := range calls {
+		if strings.HasPrefix(segment, name+" (") {
+			return uses, true
+		}
+		if i := strings.Index(name, "${{"); i >= 0 && strings.HasPrefix(segment, name[:i]) {
+			return uses, true
+		}
+	}
+	return "", false
+}
+
+// reusablePlacement is where a job sits in the reusable workflow call tree.
+type reusablePlacement struct {
+	parent trace.SpanContext
+	name   string // the callee job name, without the caller prefix
+}
+
+// reusableCall accumulates the jobs run under one reusable workflow call.
+type reusableCall struct {
+	key         string // caller path joined by " / "
+	name        string
+	depth       int
+	start, end  time.Time
+	conclusions []string
+	pending     bool
+	sc          trace.SpanContext
+}
+
+// emitReusableWorkflows emits a span per reusable workflow call of the run,
+// nested as the calls were, spanning the jobs run under it. It returns where
+// each job of a call sits, keyed by job ID; other jobs stay under the
+// workflow span.
+func emitReusableWorkflows(jobs []githubapi.Job, run githubapi.WorkflowRun, calls map[string]string, builder *SpanBuilder, traceID trace.TraceID, wfSC trace.SpanContext) map[int64]reusablePlacement {
+	byKey := make(map[string]*reusableCall)
+	var order []*reusableCall
+	paths := make(map[int64][]string)
+	for _, job := range jobs {
+		path := reusableCallPath(job.Name, calls)
+		if len(path) == 0 {
+			continue
+		}
+		start, ok := utils.ParseTime(job.StartedAt)
+		if !ok {
+			continue
+		}
+		end, ok := utils.ParseTime(job.CompletedAt)
+		pending := job.Status != "completed" || !ok
+		if pending {
+			end = time.Now()
+		}
+		paths[job.ID] = path
+		for depth := 1; depth <= len(path); depth++ {
+			key := strings.Join(path[:depth], reusableJobSeparator)
+			call := byKey[key]
+			if call == nil {
+				call = &reusableCall{key: key, name: path[depth-1], depth: depth, start: start, end: end}
+				byKey[key] = call
+				order = append(order, call)
+			}
+			if start.Before(call.start) {
+				call.start = start
+			}
+			if end.After(call.end) {
+				call.end = end
+			}
+			call.pending = call.pending || pending
+			call.conclusions = append(call.conclusions, job.Conclusion)
+		}
+	}
+	if len(order) == 0 {
+		return nil
+	}
+
+	runURL := fmt.Sprintf("https://github.com/%s/%s/actions/runs/%d", run.Repository.Owner.Login, run.Repository.Name, run.ID)
+	// Parents are created before their children as paths grow one call at a time
+	for _, call := range order {
+		call.sc = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     githubapi.NewSpanIDFromString(fmt.Sprintf("reusable-%d-%d-%s", run.ID, run.RunAttempt, call.key)),
+			TraceFlags: trace.FlagsSampled,
+		})
+		parent := wfSC
+		if call.depth > 1 {
+			parent = byKey[call.key[:strings.LastIndex(call.key, reusableJobSeparator)]].sc
+		}
+
+		status, conclusion := "completed", aggregateConclusion(call.conclusions)
+		if call.pending {
+			status, conclusion = "in_progress", ""
+		}
+		attrs := []attribute.KeyValue{
+			attribute.String("cicd.pipeline.task.name", call.key),
+			attribute.String("cicd.pipeline.task.run.result", ghConclusionToResult(conclusion)),
+			attribute.String("type", SpanTypeReusableWorkflow),
+			attribute.String("github.status", status),
+			attribute.String("github.conclusion", conclusion),
+			attribute.String("github.url", runURL),
+		}
+		if call.depth == 1 {
+			if uses, ok := reusableCaller(call.name, calls); ok {
+				attrs = append(attrs, attribute.String(AttrReusableWorkflowUses, uses))
+			}
+		}
+		builder.Add(tracetest.SpanStub{
+			Name:        call.name,
+			SpanContext: call.sc,
+			Parent:      parent,
+			StartTime:   call.start,
+			EndTime:     call.end,
+			Attributes:  attrs,
+			Status:      ghConclusionToStatus(conclusion),
+		})
+	}
+
+	placements := make(map[int64]reusablePlacement, len(paths))
+	for _, job := range jobs {
+		path, ok := paths[job.ID]
+		if !ok {
+			continue
+		}
+		placements[job.ID] = reusablePlacement{
+			parent: byKey[strings.Join(path, reusableJobSeparator)].sc,
+			name:   strings.TrimPrefix(job.Name, strings.Join(path, reusableJobSeparator)+reusableJobSeparator),
+		}
+	}
+	return placements
+}
+
+// aggregateConclusion summarizes the conclusions of a call's jobs: the first
+// failure-like conclusion wins, then cancellation; a call whose jobs were all
+// skipped is skipped.
+func aggregateConclusion(conclusions []string) string {
+	result := "skipped"
+	for _, c := range conclusions {
+		switch c {
+		case "failure", "timed_out", "startup_failure", "action_required":
+			return "failure"
+		case "cancelled":
+			result = "cancelled"
+		case "skipped":
+		default:
+			if result == "skipped" {
+				result = "success"
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const reusableTestDefinition = `
+name: CI
+on: push
+jobs:
+  build:
+    uses: org/templates/.github/workflows/build.yml@v2
+  deploy:
+    name: Deploy ${{ inputs.env }}
+    uses: ./.github/workflows/deploy.yml
+  lint:
+    name: lint / format
+    runs-on: ubuntu-latest
+`
+
+func TestReusableWorkflowCalls(t *testing.T) {
+	t.Parallel()
+
+	calls, err := ReusableWorkflowCalls([]byte(reusableTestDefinition))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"build":                    "org/templates/.github/workflows/build.yml@v2",
+		"Deploy ${{ inputs.env }}": "./.github/workflows/deploy.yml",
+	}, calls)
+
+	_, err = ReusableWorkflowCalls([]byte("jobs: [unclosed"))
+	assert.Error(t, err)
+}
+
+func TestReusableCallPath(t *testing.T) {
+	t.Parallel()
+
+	calls, _ := ReusableWorkflowCalls([]byte(reusableTestDefinition))
+	tests := []struct {
+		name    string
+		jobName string
+		calls   map[string]string
+		want    []string
+	}{
+		{"plain job", "test", calls, nil},
+		{"caller / callee", "build / compile", calls, []string{"build"}},
+		{"nested call", "build / package / upload", calls, []string{"build", "package"}},
+		{"matrix caller", "build (linux) / compile", calls, []string{"build (linux)"}},
+		{"expression caller", "Deploy staging / apply", calls, []string{"Deploy staging"}},
+		{"slash in a plain job name", "lint / format", calls, nil},
+		{"unknown definition trusts the name", "lint / format", nil, []string{"lint"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, reusableCallPath(tt.jobName, tt.calls))
+		})
+	}
+}
+
+func TestAggregateConclusion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "success", aggregateConclusion([]string{"success", "skipped"}))
+	assert.Equal(t, "failure", aggregateConclusion([]string{"cancelled", "failure", "success"}))
+	assert.Equal(t, "cancelled", aggregateConclusion([]string{"success", "cancelled"}))
+	assert.Equal(t, "skipped", aggregateConclusion([]string{"skipped", "skipped"}))
+}
+
+func TestProcessWorkflowRunNestsReusableWorkflows(t *testing.T) {
+	t.Parallel()
+
+	run := githubapi.WorkflowRun{
+		ID: 500, RunAttempt: 1, Name: "CI", Path: ".github/workflows/ci.yml",
+		Status: "completed", Conclusion: "failure", HeadSHA: "abc123",
+		CreatedAt: "2026-03-18T17:00:00Z", UpdatedAt: "2026-03-18T17:30:00Z",
+		Repository: githubapi.RepoRef{Owner: githubapi.RepoOwner{Login: "owner"}, Name: "repo"},
+	}
+	job := func(id int64, name, start, end, conclusion string) githubapi.Job {
+		return githubapi.Job{
+			ID: id, RunAttempt: 1, Name: name, Status: "completed", Conclusion: conclusion,
+			StartedAt: "2026-03-18T" + start + "Z", CompletedAt: "2026-03-18T" + end + "Z",
+		}
+	}
+	jobs := []githubapi.Job{
+		job(501, "build / compile", "17:01:00", "17:10:00", "success"),
+		job(502, "build / package / upload", "17:10:00", "17:15:00", "failure"),
+		job(503, "lint / format", "17:01:00", "17:03:00", "success"),
+	}
+
+	m := new(mockGitHubProvider)
+	m.On("FetchJobsPaginated", mock.Anything, "https://api.github.com/repos/owner/repo/actions/runs/500/jobs?per_page=100").Return(jobs, nil)
+	m.On("FetchWorkflowFile", mock.Anything, "owner", "repo", ".github/workflows/ci.yml", "abc123").Return([]byte(reusableTestDefinition), nil)
+	m.On("FetchCheckRunsForCommit", mock.Anything, "owner", "repo", "abc123").Return([]githubapi.CheckRun{}, nil)
+	m.On("FetchRunTiming", mock.Anything, "owner", "repo", int64(500)).Return((*githubapi.RunTiming)(nil), nil)
+
+	builder := &SpanBuilder{}
+	createdAt, _ := utils.ParseTime(run.CreatedAt)
+	_, _, _, _, err := processWorkflowRun(
+		context.Background(), run, 0, 1001, createdAt.UnixMilli(),
+		"owner", "repo", "abc123", 0, "https://github.com/owner/repo/commit/abc123", "commit",
+		[]string{"lint / format"}, 0, 0, 0, m, nil, builder, NewTraceEmitter(builder), AnalyzeOptions{NoArtifacts: true}, nil, nil, false,
+	)
+	assert.NoError(t, err)
+	m.AssertExpectations(t)
+
+	roots := BuildTreeFromSpans(builder.Spans(), time.Time{}, time.Time{}, enrichment.DefaultEnricher())
+	if !assert.Len(t, roots, 1) {
+		return
+	}
+	children := map[string]*TreeNode{}
+	for _, c := range roots[0].Children {
+		children[c.Name] = c
+	}
+	assert.Contains(t, children, "lint / format 🔒", "plain jobs stay under the workflow")
+
+	build := children["build"]
+	if !assert.NotNil(t, build) {
+		return
+	}
+	assert.Equal(t, "reusable_workflow", build.Hints.Category)
+	assert.Equal(t, "org/templates/.github/workflows/build.yml@v2", build.Attrs[AttrReusableWorkflowUses])
+	assert.Equal(t, "failure", build.Attrs["github.conclusion"])
+	assert.Equal(t, time.Date(2026, 3, 18, 17, 1, 0, 0, time.UTC), build.StartTime.UTC())
+	assert.Equal(t, time.Date(2026, 3, 18, 17, 15, 0, 0, time.UTC), build.EndTime.UTC())
+
+	var names []string
+	for _, c := range build.Children {
+		names = append(names, c.Name)
+	}
+	assert.ElementsMatch(t, []string{"compile", "package"}, names)
+	for _, c := range build.Children {
+		if c.Name == "package" {
+			if assert.Len(t, c.Children, 1) {
+				upload := c.Children[0]
+				assert.Equal(t, "upload", upload.Name)
+				assert.Equal(t, "build / package / upload", upload.Attrs["cicd.pipeline.task.name"], "the full name still identifies the job")
+			}
+		}
+	}
+}
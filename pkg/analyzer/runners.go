@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Keys RunnerLanes can group jobs by.
+const (
+	RunnersByName  = "runner" // one lane per runner
+	RunnersByGroup = "group"  // one lane per runner group
+	RunnersByLabel = "label"  // one lane per runs-on label set
+)
+
+// RunnerGroupings lists the RunnerLanes keys in cycling order.
+var RunnerGroupings = []string{RunnersByName, RunnersByGroup, RunnersByLabel}
+
+// Span attributes describing the runner a job ran on.
+const (
+	AttrRunnerName   = "github.runner_name"
+	AttrRunnerGroup  = "github.runner_group_name"
+	AttrRunnerLabels = "github.runner_labels" // comma separated
+)
+
+// RunnerJob is a job on a runner lane.
+type RunnerJob struct {
+	Node   *TreeNode
+	Runner string
+	Queue  time.Duration // waiting for a runner before it started
+}
+
+// QueuedAt is when the job started waiting for a runner.
+func (j RunnerJob) QueuedAt() time.Time {
+	return j.Node.StartTime.Add(-j.Queue)
+}
+
+// RunnerLane is the swimlane of the jobs one runner, or one pool of runners,
+// ran.
+type RunnerLane struct {
+	Key            string
+	Runners        int         // distinct runners
+	Jobs           []RunnerJob // by start time
+	Start          time.Time   // first job start
+	End            time.Time   // last job end
+	Busy           time.Duration
+	Gaps           []IdleGap // between Start and End with no job running
+	MaxConcurrency int
+	Queue          time.Duration // total time the jobs waited for a runner
+}
+
+// Idle returns the time between the first and last job with no job running.
+func (l RunnerLane) Idle() time.Duration {
+	var idle time.Duration
+	for _, g := range l.Gaps {
+		idle += g.Duration()
+	}
+	return idle
+}
+
+// Utilization returns the percentage of the lane's span spent running jobs.
+func (l RunnerLane) Utilization() float64 {
+	span := l.End.Sub(l.Start)
+	if span <= 0 {
+		return 0
+	}
+	return float64(l.Busy) * 100 / float64(span)
+}
+
+// RunnerLanes regroups the job spans of the tree into swimlanes by runner
+// name, runner group or runs-on label set (see RunnerGroupings). Jobs that
+// never got a runner, e.g. skipped ones, are left out. Lanes are sorted by
+// busy time, busiest first.
+func RunnerLanes(roots []*TreeNode, groupBy string) []RunnerLane {
+	if groupBy == "" {
+		groupBy = RunnersByName
+	}
+
+	byKey := make(map[string]*RunnerLane)
+	var walk func(nodes []*TreeNode)
+	walk = func(nodes []*TreeNode) {
+		for _, n := range nodes {
+			walk(n.Children)
+			runner := n.Attrs[AttrRunnerName]
+			if n.Attrs["type"] != "job" || runner == "" {
+				continue
+			}
+			key := runner
+			switch groupBy {
+			case RunnersByGroup:
+				key = n.Attrs[AttrRunnerGroup]
+			case RunnersByLabel:
+				key = n.Attrs[AttrRunnerLabels]
+			}
+			if key == "" {
+				key = "unknown"
+			}
+			lane, ok := byKey[key]
+			if !ok {
+				lane = &RunnerLane{Key: key}
+				byKey[key] = lane
+			}
+			job := RunnerJob{Node: n, Runner: runner}
+			if ms, err := strconv.ParseInt(n.Attrs["queue_time_ms"], 10, 64); err == nil && ms > 0 {
+				job.Queue = time.Duration(ms) * time.Millisecond
+			}
+			lane.Jobs = append(lane.Jobs, job)
+		}
+	}
+	walk(roots)
+
+	lanes := make([]RunnerLane, 0, len(byKey))
+	for _, lane := range byKey {
+		summarizeLane(lane)
+		lanes = append(lanes, *lane)
+	}
+	sort.Slice(lanes, func(i, j int) bool {
+		if lanes[i].Busy != lanes[j].Busy {
+			return lanes[i].Busy > lanes[j].Busy
+		}
+		return lanes[i].Key < lanes[j].Key
+	})
+	return lanes
+}
+
+// summarizeLane orders the lane's jobs and computes its timing.
+func summarizeLane(lane *RunnerLane) {
+	sort.SliceStable(lane.Jobs, func(i, j int) bool {
+		return lane.Jobs[i].Node.StartTime.Before(lane.Jobs[j].Node.StartTime)
+	})
+
+	runners := make(map[string]bool)
+	busy := make([]IdleGap, 0, len(lane.Jobs))
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	var edges []edge
+	for _, job := range lane.Jobs {
+		n := job.Node
+		runners[job.Runner] = true
+		lane.Queue += job.Queue
+		if lane.Start.IsZero() || n.StartTime.Before(lane.Start) {
+			lane.Start = n.StartTime
+		}
+		if n.EndTime.After(lane.End) {
+			lane.End = n.EndTime
+		}
+		busy = append(busy, IdleGap{Start: n.StartTime, End: n.EndTime})
+		edges = append(edges, edge{n.StartTime, 1}, edge{n.EndTime, -1})
+	}
+	lane.Runners = len(runners)
+
+	lane.Gaps = idleGaps(lane.Start, lane.End, busy)
+	lane.Busy = lane.End.Sub(lane.Start) - lane.Idle()
+
+	// Ends sort before starts at the same instant: back-to-back jobs don't overlap
+	sort.Slice(edges, func(i, j int) bool {
+		if !edges[i].at.Equal(edges[j].at) {
+			return edges[i].at.Before(edges[j].at)
+		}
+		return edges[i].delta < edges[j].delta
+	})
+	running := 0
+	for _, e := range edges {
+		running += e.delta
+		if running > lane.MaxConcurrency {
+			lane.MaxConcurrency = running
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerLanes(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+	job := func(name, runner, group string, startMin, endMin int, queueMs string) *TreeNode {
+		return &TreeNode{
+			Name:      name,
+			StartTime: base.Add(time.Duration(startMin) * time.Minute),
+			EndTime:   base.Add(time.Duration(endMin) * time.Minute),
+			Attrs: map[string]string{
+				"type":           "job",
+				AttrRunnerName:   runner,
+				AttrRunnerGroup:  group,
+				AttrRunnerLabels: "self-hosted,linux",
+				"queue_time_ms":  queueMs,
+			},
+		}
+	}
+	roots := []*TreeNode{{
+		Name: "CI",
+		Children: []*TreeNode{
+			job("lint", "runner-1", "fleet", 0, 2, ""),
+			job("build", "runner-2", "fleet", 1, 5, ""),
+			// Waited for runner-1 to free up, then an idle gap
+			job("test", "runner-1", "fleet", 3, 4, "60000"),
+			job("skipped", "", "", 0, 0, ""),
+		},
+	}}
+
+	lanes := RunnerLanes(roots, "")
+	if !assert.Len(t, lanes, 2, "jobs without a runner are left out") {
+		return
+	}
+	assert.Equal(t, "runner-2", lanes[0].Key, "busiest first")
+	r1 := lanes[1]
+	assert.Equal(t, []string{"lint", "test"}, []string{r1.Jobs[0].Node.Name, r1.Jobs[1].Node.Name})
+	assert.Equal(t, 3*time.Minute, r1.Busy)
+	assert.Equal(t, time.Minute, r1.Idle())
+	assert.Equal(t, time.Minute, r1.Queue)
+	assert.True(t, r1.Jobs[1].QueuedAt().Equal(base.Add(2*time.Minute)))
+	assert.InDelta(t, 75.0, r1.Utilization(), 0.001)
+	assert.Equal(t, 1, r1.MaxConcurrency)
+
+	pools := RunnerLanes(roots, RunnersByGroup)
+	if assert.Len(t, pools, 1) {
+		fleet := pools[0]
+		assert.Equal(t, "fleet", fleet.Key)
+		assert.Equal(t, 2, fleet.Runners)
+		assert.Equal(t, 2, fleet.MaxConcurrency)
+		assert.Equal(t, 5*time.Minute, fleet.Busy)
+		assert.Empty(t, fleet.Gaps)
+	}
+
+	byLabel := RunnerLanes(roots, RunnersByLabel)
+	if assert.Len(t, byLabel, 1) {
+		assert.Equal(t, "self-hosted,linux", byLabel[0].Key)
+	}
+}
+
+func TestRunnerLanesBackToBack(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+	node := func(start, end int) *TreeNode {
+		return &TreeNode{
+			StartTime: base.Add(time.Duration(start) * time.Minute),
+			EndTime:   base.Add(time.Duration(end) * time.Minute),
+			Attrs:     map[string]string{"type": "job", AttrRunnerName: "r"},
+		}
+	}
+	lanes := RunnerLanes([]*TreeNode{node(0, 1), node(1, 2)}, RunnersByName)
+	if assert.Len(t, lanes, 1) {
+		assert.Equal(t, 1, lanes[0].MaxConcurrency, "a job starting as another ends does not overlap it")
+	}
+}
@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+)
+
+// Dimensions trends can be broken down by.
+const (
+	GroupByEvent     = "event"      // the event that triggered the run
+	GroupByOS        = "os"         // the OS of the runner a job ran on
+	GroupByActorType = "actor-type" // whether a person or a bot triggered the run
+)
+
+// TrendGroupings lists the dimensions trends can be broken down by.
+var TrendGroupings = []string{GroupByEvent, GroupByOS, GroupByActorType}
+
+// Actor types of RunData.
+const (
+	ActorHuman = "human"
+	ActorBot   = "bot"
+)
+
+// Runner operating systems runnerOS recognizes.
+const (
+	OSLinux   = "Linux"
+	OSMacOS   = "macOS"
+	OSWindows = "Windows"
+)
+
+// TrendSegment is the trend of the runs, or for GroupByOS the jobs, sharing a
+// value of a dimension.
+type TrendSegment struct {
+	Dimension        string // one of TrendGroupings
+	Key              string // e.g. "pull_request", "macOS" or "bot"; "unknown" when missing
+	Summary          TrendSummary
+	DurationTrend    []DataPoint
+	SuccessRateTrend []DataPoint
+}
+
+// segmentTrends breaks the runs down by each dimension, in the order given,
+// with the segments of a dimension largest first. Runs segment by their event
+// and actor type; jobs segment by the OS of their runner, measured on the
+// sampled runs only, and count as runs in that segment's summary.
+func segmentTrends(runs []RunData, dimensions []string) []TrendSegment {
+	var segments []TrendSegment
+	for _, dim := range dimensions {
+		byKey := make(map[string][]RunData)
+		for _, run := range runs {
+			switch dim {
+			case GroupByEvent:
+				key := segmentKey(run.Event)
+				byKey[key] = append(byKey[key], run)
+			case GroupByActorType:
+				key := segmentKey(run.ActorType)
+				byKey[key] = append(byKey[key], run)
+			case GroupByOS:
+				for _, job := range run.Jobs {
+					key := segmentKey(runnerOS(job.Labels))
+					byKey[key] = append(byKey[key], jobAsRun(run, job))
+				}
+			}
+		}
+
+		var dimSegments []TrendSegment
+		for key, segRuns := range byKey {
+			dimSegments = append(dimSegments, TrendSegment{
+				Dimension:        dim,
+				Key:              key,
+				Summary:          calculateTrendSummary(segRuns),
+				DurationTrend:    generateDurationTrend(segRuns),
+				SuccessRateTrend: generateSuccessRateTrend(segRuns),
+			})
+		}
+		sort.Slice(dimSegments, func(i, j int) bool {
+			if dimSegments[i].Summary.TotalRuns != dimSegments[j].Summary.TotalRuns {
+				return dimSegments[i].Summary.TotalRuns > dimSegments[j].Summary.TotalRuns
+			}
+			return dimSegments[i].Key < dimSegments[j].Key
+		})
+		segments = append(segments, dimSegments...)
+	}
+	return segments
+}
+
+// segmentKey names the segment of a missing value "unknown".
+func segmentKey(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+// jobAsRun stands a job in for a run, so run-level trends can be computed
+// over jobs.
+func jobAsRun(run RunData, job JobData) RunData {
+	return RunData{
+		ID:         run.ID,
+		HeadSHA:    run.HeadSHA,
+		Event:      run.Event,
+		Actor:      run.Actor,
+		ActorType:  run.ActorType,
+		Status:     job.Status,
+		Conclusion: job.Conclusion,
+		CreatedAt:  run.CreatedAt,
+		Duration:   job.Duration,
+	}
+}
+
+// actorType classifies who triggered a run: GitHub Apps and the usual
+// dependency bots are ActorBot, anyone else ActorHuman. It is empty when the
+// actor is unknown.
+func actorType(actor githubapi.UserInfo) string {
+	login := strings.ToLower(actor.Login)
+	switch {
+	case login == "":
+		return ""
+	case actor.Type == "Bot",
+		strings.HasSuffix(login, "[bot]"),
+		strings.HasPrefix(login, "dependabot"),
+		strings.HasPrefix(login, "renovate"):
+		return ActorBot
+	}
+	return ActorHuman
+}
+
+// runnerOS infers the OS of a job's runner from its runs-on labels: GitHub
+// hosted images (ubuntu-latest, macos-14, windows-2022) or the OS label
+// self-hosted runners carry. It is empty when no label names an OS.
+func runnerOS(labels []string) string {
+	for _, label := range labels {
+		l := strings.ToLower(label)
+		switch {
+		case l == "linux" || strings.HasPrefix(l, "ubuntu"):
+			return OSLinux
+		case l == "macos" || strings.HasPrefix(l, "macos-"):
+			return OSMacOS
+		case l == "windows" || strings.HasPrefix(l, "windows-"):
+			return OSWindows
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentTrends(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	run := func(day int, event, actorType, conclusion string, durationMin int, jobs ...JobData) RunData {
+		return RunData{
+			Event:      event,
+			ActorType:  actorType,
+			Conclusion: conclusion,
+			CreatedAt:  base.Add(time.Duration(day) * 24 * time.Hour),
+			Duration:   int64(durationMin) * 60000,
+			Jobs:       jobs,
+		}
+	}
+	job := func(label, conclusion string, durationMin int) JobData {
+		return JobData{Conclusion: conclusion, Duration: int64(durationMin) * 60000, Labels: []string{label}}
+	}
+	runs := []RunData{
+		run(0, "push", ActorHuman, "success", 10, job("ubuntu-latest", "success", 4), job("macos-14", "success", 8)),
+		run(0, "pull_request", ActorHuman, "failure", 6, job("ubuntu-latest", "failure", 5)),
+		run(1, "pull_request", ActorBot, "success", 4, job("ubuntu-latest", "success", 3)),
+		run(2, "pull_request", ActorHuman, "success", 8),
+		run(2, "", "", "success", 2),
+	}
+
+	assert.Empty(t, segmentTrends(runs, nil))
+
+	segments := segmentTrends(runs, []string{GroupByEvent, GroupByOS, GroupByActorType})
+	var keys []string
+	for _, s := range segments {
+		keys = append(keys, s.Dimension+"="+s.Key)
+	}
+	assert.Equal(t, []string{
+		"event=pull_request", "event=push", "event=unknown",
+		"os=Linux", "os=macOS",
+		"actor-type=human", "actor-type=bot", "actor-type=unknown",
+	}, keys)
+
+	pr := segments[0]
+	assert.Equal(t, 3, pr.Summary.TotalRuns)
+	assert.Equal(t, 360.0, pr.Summary.AvgDuration)
+	assert.InDelta(t, 66.7, pr.Summary.AvgSuccessRate, 0.1)
+	assert.Len(t, pr.DurationTrend, 3, "one point per day")
+
+	linux := segments[3]
+	assert.Equal(t, 3, linux.Summary.TotalRuns, "jobs count as runs of the OS")
+	assert.Equal(t, 240.0, linux.Summary.AvgDuration)
+
+	mac := segments[4]
+	assert.Equal(t, 1, mac.Summary.TotalRuns)
+	assert.Equal(t, 100.0, mac.Summary.AvgSuccessRate)
+}
+
+func TestActorType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		actor githubapi.UserInfo
+		want  string
+	}{
+		{githubapi.UserInfo{}, ""},
+		{githubapi.UserInfo{Login: "octocat", Type: "User"}, ActorHuman},
+		{githubapi.UserInfo{Login: "octocat"}, ActorHuman},
+		{githubapi.UserInfo{Login: "github-actions[bot]", Type: "Bot"}, ActorBot},
+		{githubapi.UserInfo{Login: "dependabot[bot]"}, ActorBot},
+		{githubapi.UserInfo{Login: "renovate-bot", Type: "User"}, ActorBot},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, actorType(tt.actor), tt.actor.Login)
+	}
+}
+
+func TestRunnerOS(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, OSLinux, runnerOS([]string{"ubuntu-latest"}))
+	assert.Equal(t, OSLinux, runnerOS([]string{"ubuntu-24.04-arm"}))
+	assert.Equal(t, OSLinux, runnerOS([]string{"self-hosted", "Linux", "X64"}))
+	assert.Equal(t, OSMacOS, runnerOS([]string{"macos-latest"}))
+	assert.Equal(t, OSMacOS, runnerOS([]string{"self-hosted", "macOS", "ARM64"}))
+	assert.Equal(t, OSWindows, runnerOS([]string{"windows-2022"}))
+	assert.Equal(t, "", runnerOS([]string{"self-hosted", "gpu"}))
+	assert.Equal(t, "", runnerOS(nil))
+}
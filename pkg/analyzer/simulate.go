@@ -0,0 +1,219 @@
+package analyzer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Scheduling policies SimulateRunners can replay jobs with.
+const (
+	PolicyFIFO          = "fifo"     // jobs start in the order they were queued
+	PolicyShortestFirst = "shortest" // the shortest queued job starts first
+)
+
+// SimulationPolicies lists the scheduling policies SimulateRunners supports.
+var SimulationPolicies = []string{PolicyFIFO, PolicyShortestFirst}
+
+// AnyLabel as a scenario label replays every job regardless of its labels.
+const AnyLabel = "*"
+
+// RunnerScenario is a runner pool to replay jobs against: the jobs with the
+// runs-on label and a fixed number of runners.
+type RunnerScenario struct {
+	Label   string
+	Runners int
+}
+
+// ParseRunnerScenarios parses a comma separated list of label:N pools, e.g.
+// "ubuntu-latest:4,ubuntu-latest:8,gpu:2". The same label may be listed with
+// several pool sizes to compare them.
+func ParseRunnerScenarios(spec string) ([]RunnerScenario, error) {
+	var scenarios []RunnerScenario
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		i := strings.LastIndex(part, ":")
+		if i <= 0 {
+			return nil, errors.Newf("invalid runner scenario %q (want label:N)", part)
+		}
+		n, err := strconv.Atoi(part[i+1:])
+		if err != nil || n < 1 {
+			return nil, errors.Newf("invalid runner count in %q (want a positive number)", part)
+		}
+		scenarios = append(scenarios, RunnerScenario{Label: part[:i], Runners: n})
+	}
+	if len(scenarios) == 0 {
+		return nil, errors.Newf("no runner scenarios in %q", spec)
+	}
+	return scenarios, nil
+}
+
+// QueuePercentiles summarizes queue times in seconds.
+type QueuePercentiles struct {
+	Avg float64
+	P50 float64
+	P90 float64
+	P95 float64
+	Max float64
+}
+
+// RunnerSimulation is the outcome of replaying a scenario's jobs.
+type RunnerSimulation struct {
+	Label       string
+	Runners     int
+	Policy      string
+	Jobs        int
+	Observed    QueuePercentiles // what the jobs actually waited
+	Predicted   QueuePercentiles // what they would have waited with the pool
+	BusyHours   float64          // job run time
+	RunnerHours float64          // runners provisioned over the replayed window
+	Utilization float64          // busy share of the provisioned hours, in percent
+	Cost        float64          // RunnerHours at the given price; 0 without one
+}
+
+// simJob is a job replayed by the simulator.
+type simJob struct {
+	ready    time.Time     // when it could have started: queued, after any approval
+	run      time.Duration // how long it ran
+	observed time.Duration // how long it actually waited
+}
+
+// SimulateRunners replays the jobs of the runs against each scenario's pool.
+// Each job arrives when it was queued and runs as long as it did; a fixed
+// pool of runners picks up queued jobs by policy, as GitHub would if the pool
+// had that many runners. Jobs of runs without job details, e.g. outside the
+// sample, are not replayed.
+func SimulateRunners(runs []RunData, scenarios []RunnerScenario, policy string, costPerHour float64) []RunnerSimulation {
+	if policy == "" {
+		policy = PolicyFIFO
+	}
+	var sims []RunnerSimulation
+	for _, sc := range scenarios {
+		jobs := simulationJobs(runs, sc.Label)
+		sim := RunnerSimulation{Label: sc.Label, Runners: sc.Runners, Policy: policy, Jobs: len(jobs)}
+		if len(jobs) > 0 {
+			waits, end := simulateQueue(jobs, sc.Runners, policy)
+			observed := make([]time.Duration, len(jobs))
+			var busy time.Duration
+			for i, j := range jobs {
+				observed[i] = j.observed
+				busy += j.run
+			}
+			sim.Observed = queuePercentiles(observed)
+			sim.Predicted = queuePercentiles(waits)
+			sim.BusyHours = busy.Hours()
+			sim.RunnerHours = float64(sc.Runners) * end.Sub(jobs[0].ready).Hours()
+			if sim.RunnerHours > 0 {
+				sim.Utilization = sim.BusyHours / sim.RunnerHours * 100
+			}
+			sim.Cost = sim.RunnerHours * costPerHour
+		}
+		sims = append(sims, sim)
+	}
+	return sims
+}
+
+// simulationJobs collects the completed jobs with the label, by ready time.
+func simulationJobs(runs []RunData, label string) []simJob {
+	var jobs []simJob
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			if job.StartedAt.IsZero() || job.CompletedAt.IsZero() || !hasLabel(job.Labels, label) {
+				continue
+			}
+			queue := time.Duration(max(job.QueueTime, 0)) * time.Millisecond
+			jobs = append(jobs, simJob{
+				ready:    job.StartedAt.Add(-queue),
+				run:      time.Duration(max(job.Duration, 0)) * time.Millisecond,
+				observed: queue,
+			})
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].ready.Before(jobs[j].ready) })
+	return jobs
+}
+
+// hasLabel reports whether a job with labels runs on a pool with label.
+func hasLabel(labels []string, label string) bool {
+	if label == AnyLabel {
+		return true
+	}
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// simulateQueue runs jobs, sorted by ready time, on a pool of runners. It
+// returns how long each job waited and when the last one finished.
+func simulateQueue(jobs []simJob, runners int, policy string) ([]time.Duration, time.Time) {
+	if len(jobs) == 0 {
+		return nil, time.Time{}
+	}
+	waits := make([]time.Duration, len(jobs))
+	free := make([]time.Time, max(runners, 1)) // when each runner is next idle
+	for i := range free {
+		free[i] = jobs[0].ready
+	}
+	var queued []int
+	next := 0
+	var end time.Time
+	for range jobs {
+		r := 0
+		for i := range free {
+			if free[i].Before(free[r]) {
+				r = i
+			}
+		}
+		now := free[r]
+		// An idle runner waits for the next job to arrive
+		if len(queued) == 0 && jobs[next].ready.After(now) {
+			now = jobs[next].ready
+		}
+		for next < len(jobs) && !jobs[next].ready.After(now) {
+			queued = append(queued, next)
+			next++
+		}
+
+		pick := 0
+		if policy == PolicyShortestFirst {
+			for i, j := range queued {
+				if jobs[j].run < jobs[queued[pick]].run {
+					pick = i
+				}
+			}
+		}
+		j := queued[pick]
+		queued = append(queued[:pick], queued[pick+1:]...)
+
+		waits[j] = now.Sub(jobs[j].ready)
+		free[r] = now.Add(jobs[j].run)
+		if free[r].After(end) {
+			end = free[r]
+		}
+	}
+	return waits, end
+}
+
+// queuePercentiles summarizes waits in seconds.
+func queuePercentiles(waits []time.Duration) QueuePercentiles {
+	secs := make([]float64, len(waits))
+	for i, w := range waits {
+		secs[i] = w.Seconds()
+	}
+	return QueuePercentiles{
+		Avg: average(secs),
+		P50: calculatePercentile(secs, 50),
+		P90: calculatePercentile(secs, 90),
+		P95: calculatePercentile(secs, 95),
+		Max: calculatePercentile(secs, 100),
+	}
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRunnerScenarios(t *testing.T) {
+	t.Parallel()
+
+	scenarios, err := ParseRunnerScenarios("ubuntu-latest:4, ubuntu-latest:8,self-hosted:gpu:2")
+	assert.NoError(t, err)
+	assert.Equal(t, []RunnerScenario{
+		{Label: "ubuntu-latest", Runners: 4},
+		{Label: "ubuntu-latest", Runners: 8},
+		{Label: "self-hosted:gpu", Runners: 2},
+	}, scenarios)
+
+	for _, spec := range []string{"", "ubuntu-latest", ":4", "linux:0", "linux:many"} {
+		_, err := ParseRunnerScenarios(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestSimulateQueue(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+	jobs := []simJob{
+		{ready: base, run: 10 * time.Minute},
+		{ready: base, run: time.Minute},
+		{ready: base, run: time.Minute},
+	}
+
+	waits, end := simulateQueue(jobs, 1, PolicyFIFO)
+	assert.Equal(t, []time.Duration{0, 10 * time.Minute, 11 * time.Minute}, waits)
+	assert.Equal(t, base.Add(12*time.Minute), end)
+
+	waits, end = simulateQueue(jobs, 1, PolicyShortestFirst)
+	assert.Equal(t, []time.Duration{2 * time.Minute, 0, time.Minute}, waits)
+	assert.Equal(t, base.Add(12*time.Minute), end)
+
+	waits, end = simulateQueue(jobs, 2, PolicyFIFO)
+	assert.Equal(t, []time.Duration{0, 0, time.Minute}, waits)
+	assert.Equal(t, base.Add(10*time.Minute), end)
+
+	// A runner idles until a later job arrives
+	late := []simJob{
+		{ready: base, run: time.Minute},
+		{ready: base.Add(5 * time.Minute), run: time.Minute},
+	}
+	waits, end = simulateQueue(late, 1, PolicyFIFO)
+	assert.Equal(t, []time.Duration{0, 0}, waits)
+	assert.Equal(t, base.Add(6*time.Minute), end)
+}
+
+func TestSimulateRunners(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+	job := func(label string, queueMin, runMin int) JobData {
+		started := base.Add(time.Duration(queueMin) * time.Minute)
+		return JobData{
+			StartedAt:   started,
+			CompletedAt: started.Add(time.Duration(runMin) * time.Minute),
+			Duration:    int64(runMin) * 60000,
+			QueueTime:   int64(queueMin) * 60000,
+			Labels:      []string{label},
+		}
+	}
+	runs := []RunData{
+		{Jobs: []JobData{job("Ubuntu-Latest", 0, 10), job("ubuntu-latest", 3, 1)}},
+		{Jobs: []JobData{job("ubuntu-latest", 6, 1), job("gpu", 0, 30)}},
+		{Jobs: []JobData{{Name: "skipped", Labels: []string{"ubuntu-latest"}}}},
+	}
+
+	sims := SimulateRunners(runs, []RunnerScenario{
+		{Label: "ubuntu-latest", Runners: 1},
+		{Label: "ubuntu-latest", Runners: 2},
+		{Label: AnyLabel, Runners: 4},
+		{Label: "macos", Runners: 1},
+	}, "", 0.5)
+	assert.Len(t, sims, 4)
+
+	one := sims[0]
+	assert.Equal(t, PolicyFIFO, one.Policy)
+	assert.Equal(t, 3, one.Jobs)
+	assert.Equal(t, 360.0, one.Observed.Max)
+	assert.Equal(t, 660.0, one.Predicted.Max)
+	assert.InDelta(t, 0.2, one.BusyHours, 1e-9)
+	assert.InDelta(t, 0.2, one.RunnerHours, 1e-9)
+	assert.InDelta(t, 100, one.Utilization, 1e-9)
+	assert.InDelta(t, 0.1, one.Cost, 1e-9)
+
+	two := sims[1]
+	assert.Equal(t, 60.0, two.Predicted.Max)
+	assert.InDelta(t, 2*10.0/60, two.RunnerHours, 1e-9)
+	assert.InDelta(t, 60, two.Utilization, 1e-9)
+
+	assert.Equal(t, 4, sims[2].Jobs)
+	assert.Equal(t, 0.0, sims[2].Predicted.Max)
+
+	assert.Equal(t, 0, sims[3].Jobs)
+	assert.Equal(t, 0.0, sims[3].Cost)
+}
@@ -0,0 +1,195 @@
+package analyzer
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ConfidenceInterval bounds an estimate at the trend analysis confidence
+// level.
+type ConfidenceInterval struct {
+	Low  float64
+	High float64
+}
+
+// Contains reports whether v lies within the interval.
+func (ci ConfidenceInterval) Contains(v float64) bool {
+	return v >= ci.Low && v <= ci.High
+}
+
+// bootstrapIterations is how many resamples bootstrap intervals are built
+// from.
+const bootstrapIterations = 1000
+
+// bootstrapRand returns a generator seeded from key, so that the intervals of
+// a series are the same from one run of the analysis to the next.
+func bootstrapRand(key string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// resample draws len(values) values from values with replacement into buf.
+func resample(rng *rand.Rand, values, buf []float64) []float64 {
+	buf = buf[:0]
+	for range values {
+		buf = append(buf, values[rng.Intn(len(values))])
+	}
+	return buf
+}
+
+// bootstrapCI is the percentile bootstrap interval of statistic over values.
+func bootstrapCI(values []float64, statistic func([]float64) float64, confidence float64, key string) ConfidenceInterval {
+	if len(values) == 0 {
+		return ConfidenceInterval{}
+	}
+	rng := bootstrapRand(key)
+	stats := make([]float64, bootstrapIterations)
+	buf := make([]float64, 0, len(values))
+	for i := range stats {
+		stats[i] = statistic(resample(rng, values, buf))
+	}
+	return percentileInterval(stats, confidence)
+}
+
+// bootstrapChangeCI is the percentile bootstrap interval of the percent change
+// from the mean of before to the mean of after, resampling each independently.
+func bootstrapChangeCI(before, after []float64, confidence float64, key string) ConfidenceInterval {
+	if len(before) == 0 || len(after) == 0 {
+		return ConfidenceInterval{}
+	}
+	rng := bootstrapRand(key)
+	stats := make([]float64, 0, bootstrapIterations)
+	beforeBuf := make([]float64, 0, len(before))
+	afterBuf := make([]float64, 0, len(after))
+	for range bootstrapIterations {
+		old := average(resample(rng, before, beforeBuf))
+		if old <= 0 {
+			continue
+		}
+		stats = append(stats, (average(resample(rng, after, afterBuf))-old)/old*100)
+	}
+	if len(stats) == 0 {
+		return ConfidenceInterval{}
+	}
+	return percentileInterval(stats, confidence)
+}
+
+// percentileInterval returns the central confidence share of stats.
+func percentileInterval(stats []float64, confidence float64) ConfidenceInterval {
+	sort.Float64s(stats)
+	alpha := (1 - confidence) / 2
+	lo := int(math.Floor(alpha * float64(len(stats))))
+	hi := int(math.Ceil((1-alpha)*float64(len(stats)))) - 1
+	lo = max(0, min(lo, len(stats)-1))
+	hi = max(lo, min(hi, len(stats)-1))
+	return ConfidenceInterval{Low: stats[lo], High: stats[hi]}
+}
+
+// mannWhitneyExactLimit is the largest sample size the exact distribution of
+// U is used for; larger samples, and samples with ties, use the normal
+// approximation.
+const mannWhitneyExactLimit = 20
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U test that
+// a and b come from the same distribution. It makes no assumption about the
+// shape of the distribution, which suits skewed CI durations.
+func mannWhitneyU(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type value struct {
+		v     float64
+		fromA bool
+	}
+	all := make([]value, 0, n1+n2)
+	for _, v := range a {
+		all = append(all, value{v, true})
+	}
+	for _, v := range b {
+		all = append(all, value{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+
+	// Rank with ties sharing their average rank
+	rankSumA := 0.0
+	tieTerm := 0.0 // sum of t³-t over groups of t tied values
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromA {
+				rankSumA += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+	u := rankSumA - float64(n1*(n1+1))/2
+
+	if tieTerm == 0 && n1 <= mannWhitneyExactLimit && n2 <= mannWhitneyExactLimit {
+		return mannWhitneyExactP(u, n1, n2)
+	}
+
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance) // continuity corrected
+	if z < 0 {
+		z = 0
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// mannWhitneyExactP is the two-sided p-value of u under the exact distribution
+// of U for samples of n1 and n2 values without ties.
+func mannWhitneyExactP(u float64, n1, n2 int) float64 {
+	// counts[j][k] is the number of orderings of i values of a and j values of
+	// b with U = k, built up one value of a at a time
+	counts := make([][]float64, n2+1)
+	for j := range counts {
+		counts[j] = []float64{1}
+	}
+	for i := 1; i <= n1; i++ {
+		next := make([][]float64, n2+1)
+		next[0] = []float64{1}
+		for j := 1; j <= n2; j++ {
+			next[j] = make([]float64, i*j+1)
+			// The largest value is from a, beating all j values of b, or from b
+			for k, c := range counts[j] {
+				next[j][k+j] += c
+			}
+			for k, c := range next[j-1] {
+				next[j][k] += c
+			}
+		}
+		counts = next
+	}
+
+	dist := counts[n2]
+	total := 0.0
+	for _, c := range dist {
+		total += c
+	}
+	below, above := 0.0, 0.0
+	for k, c := range dist {
+		if float64(k) <= u {
+			below += c
+		}
+		if float64(k) >= u {
+			above += c
+		}
+	}
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
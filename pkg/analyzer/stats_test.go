@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exact for small samples without ties", func(t *testing.T) {
+		// One of C(10,5) = 252 orderings separates the samples this well each way
+		p := mannWhitneyU([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10})
+		assert.InDelta(t, 2.0/252, p, 1e-12)
+		assert.Equal(t, p, mannWhitneyU([]float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}))
+		assert.Equal(t, 1.0, mannWhitneyU([]float64{1, 4}, []float64{2, 3}))
+	})
+
+	t.Run("normal approximation with ties", func(t *testing.T) {
+		assert.Equal(t, 1.0, mannWhitneyU([]float64{1, 1, 2, 2}, []float64{1, 1, 2, 2}))
+		p := mannWhitneyU([]float64{10, 10, 11, 12, 10, 11}, []float64{20, 20, 21, 22, 20, 21})
+		assert.Less(t, p, 0.01)
+	})
+
+	t.Run("normal approximation for large samples", func(t *testing.T) {
+		var a, b, odd []float64
+		for i := range 30 {
+			a = append(a, float64(2*i))
+			b = append(b, float64(2*i+30))
+			odd = append(odd, float64(2*i+1))
+		}
+		assert.Less(t, mannWhitneyU(a, b), 0.001)
+		assert.Greater(t, mannWhitneyU(a, odd), 0.5)
+	})
+
+	t.Run("empty sample", func(t *testing.T) {
+		assert.Equal(t, 1.0, mannWhitneyU(nil, []float64{1}))
+	})
+}
+
+func TestBootstrapCI(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ConfidenceInterval{}, bootstrapCI(nil, average, 0.95, "empty"))
+	assert.Equal(t, ConfidenceInterval{Low: 7, High: 7}, bootstrapCI([]float64{7, 7, 7}, average, 0.95, "constant"))
+
+	values := []float64{10, 12, 9, 30, 11, 10, 14, 8, 13, 11}
+	ci := bootstrapCI(values, average, 0.95, "job")
+	assert.True(t, ci.Contains(average(values)))
+	assert.Greater(t, ci.High, ci.Low)
+	assert.Equal(t, ci, bootstrapCI(values, average, 0.95, "job"), "intervals are reproducible")
+
+	narrow := bootstrapCI(values, average, 0.5, "job")
+	assert.GreaterOrEqual(t, narrow.Low, ci.Low)
+	assert.LessOrEqual(t, narrow.High, ci.High)
+}
+
+func TestBootstrapChangeCI(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ConfidenceInterval{Low: 100, High: 100}, bootstrapChangeCI([]float64{10, 10}, []float64{20, 20}, 0.95, "double"))
+	assert.Equal(t, ConfidenceInterval{}, bootstrapChangeCI(nil, []float64{20}, 0.95, "empty"))
+
+	ci := bootstrapChangeCI([]float64{10, 11, 9, 10, 12}, []float64{15, 16, 14, 15, 17}, 0.95, "slower")
+	assert.True(t, ci.Contains(50))
+	assert.Greater(t, ci.Low, 0.0)
+}
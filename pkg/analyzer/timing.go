@@ -0,0 +1,170 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// A gap counts as "large" when it is at least LargeGapMinDuration long and
+// takes up at least LargeGapMinFraction of its parent's duration.
+const (
+	LargeGapMinDuration = 100 * time.Millisecond
+	LargeGapMinFraction = 0.1
+)
+
+// Span attributes added to exported spans by AnnotateSpanTimings.
+const (
+	AttrSelfTimeMs   = "span.self_time_ms"
+	AttrIdleTimeMs   = "span.idle_time_ms"
+	AttrIdleGapCount = "span.idle_gap_count"
+	AttrMaxIdleGapMs = "span.max_idle_gap_ms"
+)
+
+// IdleGap is an interval inside a node during which none of its children ran.
+type IdleGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the gap.
+func (g IdleGap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// IdleTime returns the total time covered by the node's idle gaps.
+func (n *TreeNode) IdleTime() time.Duration {
+	var total time.Duration
+	for _, g := range n.Gaps {
+		total += g.Duration()
+	}
+	return total
+}
+
+// LargestGap returns the longest idle gap of the node, or false if it has none.
+func (n *TreeNode) LargestGap() (IdleGap, bool) {
+	var largest IdleGap
+	for _, g := range n.Gaps {
+		if g.Duration() > largest.Duration() {
+			largest = g
+		}
+	}
+	return largest, largest.Duration() > 0
+}
+
+// HasLargeGap reports whether the node has an idle gap worth looking at,
+// see LargeGapMinDuration and LargeGapMinFraction.
+func (n *TreeNode) HasLargeGap() bool {
+	gap, ok := n.LargestGap()
+	if !ok || gap.Duration() < LargeGapMinDuration {
+		return false
+	}
+	return float64(gap.Duration()) >= float64(n.Duration())*LargeGapMinFraction
+}
+
+// FindIdleNodes returns every node with idle gaps, sorted by total idle time
+// (longest first). A limit of 0 returns all of them.
+func FindIdleNodes(roots []*TreeNode, limit int) []*TreeNode {
+	var result []*TreeNode
+	for _, flat := range FlattenTree(roots) {
+		if flat.Node.IdleTime() > 0 {
+			result = append(result, flat.Node)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].IdleTime() > result[j].IdleTime()
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// AnnotateSpanTimings returns copies of the spans with self time and idle gap
+// attributes added. Children are resolved through parent span IDs; idle gap
+// attributes are only set on spans that have children.
+func AnnotateSpanTimings(spans []trace.ReadOnlySpan) []trace.ReadOnlySpan {
+	if len(spans) == 0 {
+		return spans
+	}
+
+	children := make(map[string][]IdleGap)
+	for _, s := range spans {
+		if !s.Parent().IsValid() {
+			continue
+		}
+		parentID := s.Parent().SpanID().String()
+		children[parentID] = append(children[parentID], IdleGap{Start: s.StartTime(), End: s.EndTime()})
+	}
+
+	stubs := tracetest.SpanStubsFromReadOnlySpans(spans)
+	for i := range stubs {
+		stub := &stubs[i]
+		duration := stub.EndTime.Sub(stub.StartTime)
+		if duration < 0 {
+			duration = 0
+		}
+
+		busy, hasChildren := children[stub.SpanContext.SpanID().String()]
+		gaps := idleGaps(stub.StartTime, stub.EndTime, busy)
+		if !hasChildren || gaps == nil {
+			stub.Attributes = append(stub.Attributes, attribute.Int64(AttrSelfTimeMs, duration.Milliseconds()))
+			continue
+		}
+
+		var idle, largest time.Duration
+		for _, g := range gaps {
+			idle += g.Duration()
+			if g.Duration() > largest {
+				largest = g.Duration()
+			}
+		}
+		stub.Attributes = append(stub.Attributes,
+			attribute.Int64(AttrSelfTimeMs, idle.Milliseconds()),
+			attribute.Int64(AttrIdleTimeMs, idle.Milliseconds()),
+			attribute.Int(AttrIdleGapCount, len(gaps)),
+			attribute.Int64(AttrMaxIdleGapMs, largest.Milliseconds()),
+		)
+	}
+	return stubs.Snapshots()
+}
+
+// idleGaps returns the parts of [start, end) not covered by any busy interval.
+// Busy intervals are clipped to the bounds; empty ones are ignored. It returns
+// nil when no busy interval has a positive duration inside the bounds.
+func idleGaps(start, end time.Time, busy []IdleGap) []IdleGap {
+	clipped := make([]IdleGap, 0, len(busy))
+	for _, b := range busy {
+		if b.Start.Before(start) {
+			b.Start = start
+		}
+		if b.End.After(end) {
+			b.End = end
+		}
+		if b.End.After(b.Start) {
+			clipped = append(clipped, b)
+		}
+	}
+	if len(clipped) == 0 {
+		return nil
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i].Start.Before(clipped[j].Start) })
+
+	gaps := []IdleGap{}
+	cursor := start
+	for _, b := range clipped {
+		if b.Start.After(cursor) {
+			gaps = append(gaps, IdleGap{Start: cursor, End: b.Start})
+		}
+		if b.End.After(cursor) {
+			cursor = b.End
+		}
+	}
+	if end.After(cursor) {
+		gaps = append(gaps, IdleGap{Start: cursor, End: end})
+	}
+	return gaps
+}
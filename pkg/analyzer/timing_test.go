@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// timingTestSpans returns a workflow (0-10s) with two jobs: build (1-4s) and
+// test (6-9s), leaving gaps at [0,1], [4,6] and [9,10].
+func timingTestSpans() []sdktrace.ReadOnlySpan {
+	return tracetest.SpanStubs{
+		makeSpanStub("workflow", 1, 0, 0, 10*time.Second),
+		makeSpanStub("build", 2, 1, time.Second, 4*time.Second),
+		makeSpanStub("test", 3, 1, 6*time.Second, 9*time.Second),
+	}.Snapshots()
+}
+
+func TestIdleGaps(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return base.Add(time.Duration(s) * time.Second) }
+
+	tests := []struct {
+		name string
+		busy []IdleGap
+		want []IdleGap
+	}{
+		{
+			name: "no children",
+			busy: nil,
+			want: nil,
+		},
+		{
+			name: "leading, middle and trailing gaps",
+			busy: []IdleGap{{at(1), at(4)}, {at(6), at(9)}},
+			want: []IdleGap{{at(0), at(1)}, {at(4), at(6)}, {at(9), at(10)}},
+		},
+		{
+			name: "overlapping children are merged",
+			busy: []IdleGap{{at(3), at(6)}, {at(1), at(4)}, {at(8), at(12)}},
+			want: []IdleGap{{at(0), at(1)}, {at(6), at(8)}},
+		},
+		{
+			name: "fully covered",
+			busy: []IdleGap{{at(-1), at(11)}},
+			want: []IdleGap{},
+		},
+		{
+			name: "zero-duration markers are ignored",
+			busy: []IdleGap{{at(5), at(5)}},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, idleGaps(at(0), at(10), tt.busy))
+		})
+	}
+}
+
+func TestBuildTreeFromSpansTimings(t *testing.T) {
+	t.Parallel()
+
+	roots := BuildTreeFromSpans(timingTestSpans(), time.Time{}, time.Time{}, enrichment.DefaultEnricher())
+	assert.Len(t, roots, 1)
+	workflow := roots[0]
+
+	assert.Equal(t, 4*time.Second, workflow.Self)
+	assert.Len(t, workflow.Gaps, 3)
+	assert.Equal(t, 4*time.Second, workflow.IdleTime())
+	largest, ok := workflow.LargestGap()
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, largest.Duration())
+	assert.True(t, workflow.HasLargeGap())
+
+	build := workflow.Children[0]
+	assert.Equal(t, 3*time.Second, build.Self)
+	assert.Empty(t, build.Gaps)
+	assert.False(t, build.HasLargeGap())
+
+	idle := FindIdleNodes(roots, 0)
+	assert.Equal(t, []*TreeNode{workflow}, idle)
+}
+
+func TestAnnotateSpanTimings(t *testing.T) {
+	t.Parallel()
+
+	annotated := AnnotateSpanTimings(timingTestSpans())
+	assert.Len(t, annotated, 3)
+
+	attrs := func(s sdktrace.ReadOnlySpan) map[attribute.Key]int64 {
+		m := map[attribute.Key]int64{}
+		for _, a := range s.Attributes() {
+			m[a.Key] = a.Value.AsInt64()
+		}
+		return m
+	}
+
+	workflow := attrs(annotated[0])
+	assert.Equal(t, int64(4000), workflow[AttrSelfTimeMs])
+	assert.Equal(t, int64(4000), workflow[AttrIdleTimeMs])
+	assert.Equal(t, int64(3), workflow[AttrIdleGapCount])
+	assert.Equal(t, int64(2000), workflow[AttrMaxIdleGapMs])
+
+	build := attrs(annotated[1])
+	assert.Equal(t, int64(3000), build[AttrSelfTimeMs])
+	assert.NotContains(t, build, attribute.Key(AttrIdleTimeMs))
+}
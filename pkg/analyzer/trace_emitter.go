@@ -97,6 +97,30 @@ func (e *TraceEmitter) EmitMarkers(data *RawData, urlIndex int) {
 		})
 	}
 
+	if gate := data.MergeGate; gate != nil && (gate.CompletedMs != 0 || gate.StartedMs != 0) {
+		// Placed where the gate finished, or where it started while pending
+		t := time.UnixMilli(firstNonZero(gate.CompletedMs, gate.StartedMs))
+		sid := githubapi.NewSpanIDFromString(fmt.Sprintf("merge-gate-%s-%s", data.HeadSHA, gate.Check))
+		attrs := []attribute.KeyValue{
+			attribute.String("type", "marker"),
+			attribute.String("github.event_type", "merge_gate"),
+			attribute.String("github.url", gate.URL),
+			attribute.String("github.event_id", fmt.Sprintf("merge_gate-%s-%s", data.HeadSHA, gate.Check)),
+			attribute.String("github.event_time", t.UTC().Format(time.RFC3339)),
+			attribute.Int("github.url_index", urlIndex),
+		}
+		e.builder.Add(tracetest.SpanStub{
+			Name: fmt.Sprintf("Merge gate: %s", gate.Check),
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				SpanID:     sid,
+				TraceFlags: trace.FlagsSampled,
+			}),
+			StartTime:  t,
+			EndTime:    t.Add(time.Millisecond),
+			Attributes: append(attrs, gate.Attributes()...),
+		})
+	}
+
 	if data.CommitPushedAtMs != nil {
 		t := time.UnixMilli(*data.CommitPushedAtMs)
 		sid := githubapi.NewSpanIDFromString(fmt.Sprintf("push-%d", *data.CommitPushedAtMs))
@@ -42,6 +42,9 @@ type TreeNode struct {
 	ScopeVersion string // instrumentation library version
 	// Resource attributes
 	ResourceAttrs map[string]string
+	// Timing breakdown, computed by BuildTreeFromSpans
+	Self time.Duration // time not covered by any child
+	Gaps []IdleGap     // intervals where no child is running
 }
 
 // Duration returns the duration of this node
@@ -49,6 +52,34 @@ func (n *TreeNode) Duration() time.Duration {
 	return n.EndTime.Sub(n.StartTime)
 }
 
+// SelfTime returns the part of this node's duration not covered by any of its
+// children. Overlapping (parallel) children are only counted once.
+func (n *TreeNode) SelfTime() time.Duration {
+	gaps := n.IdleGaps()
+	if gaps == nil {
+		if n.Duration() < 0 {
+			return 0
+		}
+		return n.Duration()
+	}
+	var self time.Duration
+	for _, g := range gaps {
+		self += g.Duration()
+	}
+	return self
+}
+
+// IdleGaps returns the intervals inside this node where none of its children
+// is running, e.g. a workflow waiting for runners between jobs. Leaves (and
+// nodes whose children are all zero-duration markers) have no gaps.
+func (n *TreeNode) IdleGaps() []IdleGap {
+	busy := make([]IdleGap, 0, len(n.Children))
+	for _, c := range n.Children {
+		busy = append(busy, IdleGap{Start: c.StartTime, End: c.EndTime})
+	}
+	return idleGaps(n.StartTime, n.EndTime, busy)
+}
+
 // BuildTreeFromSpans constructs a hierarchy of TreeNodes from OTel spans.
 // Spans are filtered and enriched using the provided enricher.
 func BuildTreeFromSpans(spans []trace.ReadOnlySpan, globalEarliest, globalLatest time.Time, enricher enrichment.Enricher) []*TreeNode {
@@ -207,6 +238,8 @@ func BuildTreeFromSpans(spans []trace.ReadOnlySpan, globalEarliest, globalLatest
 	sortTreeNodes(roots)
 	for _, node := range nodes {
 		sortTreeNodes(node.Children)
+		node.Self = node.SelfTime()
+		node.Gaps = node.IdleGaps()
 	}
 
 	return roots
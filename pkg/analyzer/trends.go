@@ -6,10 +6,12 @@ import (
 	"hash/fnv"
 	"math"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
 	"github.com/stefanpenner/otel-explorer/pkg/utils"
 )
@@ -38,6 +40,21 @@ type TrendAnalysis struct {
 	TopRegressions   []JobRegression
 	TopImprovements  []JobImprovement
 	QueueTimeStats   QueueTimeStats
+	Waste            WasteStats
+	Simulations      []RunnerSimulation
+	// Step-level breakdown of the jobs; changes have Step set
+	StepTrends          []StepTrend
+	TopStepRegressions  []JobRegression
+	TopStepImprovements []JobImprovement
+	// Breakdown by each TrendOptions.GroupBy dimension
+	Segments []TrendSegment
+	// Run volume and queue times by weekday and hour
+	QueueHeatmap QueueHeatmap
+	// Most frequent failure signatures of the sampled failed jobs
+	FailureClusters []FailureCluster
+	// Jobs tracked as one across renames and name rules
+	JobRenames []JobRename
+	JobAliases []JobAlias
 }
 
 // Changepoint identifies the approximate point in time where a job's duration shifted.
@@ -52,28 +69,41 @@ type Changepoint struct {
 	AfterAvg     float64   // average duration (seconds) after the changepoint
 	Index        int       // index of the first observation after the shift
 	TotalPoints  int       // total number of observations
+	PValue       float64   // Mann-Whitney U p-value of the durations before vs after
 }
 
-// JobRegression represents a job that got slower
+// JobRegression represents a job, or a step of one, that got slower
 type JobRegression struct {
 	Name            string
+	Step            string   // step name for step-level changes; Name is its job
 	URLs            []string // sample recent job URLs (newest first)
 	OldAvgDuration  float64
 	NewAvgDuration  float64
 	PercentIncrease float64
 	AbsoluteChange  float64
 	Changepoint     *Changepoint // nil when insufficient data
+	// Significance of the change: the Mann-Whitney U p-value of the durations
+	// of the two halves and the bootstrap interval of the percent change
+	PValue      float64
+	PercentCI   ConfidenceInterval
+	Significant bool // PValue below 1 - confidence
 }
 
-// JobImprovement represents a job that got faster
+// JobImprovement represents a job, or a step of one, that got faster
 type JobImprovement struct {
 	Name            string
+	Step            string   // step name for step-level changes; Name is its job
 	URLs            []string // sample recent job URLs (newest first)
 	OldAvgDuration  float64
 	NewAvgDuration  float64
 	PercentDecrease float64
 	AbsoluteChange  float64
 	Changepoint     *Changepoint // nil when insufficient data
+	// Significance of the change: the Mann-Whitney U p-value of the durations
+	// of the two halves and the bootstrap interval of the percent decrease
+	PValue      float64
+	PercentCI   ConfidenceInterval
+	Significant bool // PValue below 1 - confidence
 }
 
 // QueueTimeStats contains queue time analysis
@@ -84,6 +114,10 @@ type QueueTimeStats struct {
 	AvgRunTime      float64
 	MedianRunTime   float64
 	QueueTimeRatio  float64 // queue time / total time
+	// Waits on environment protection rules, kept out of queue time
+	AvgApprovalTime    float64
+	MedianApprovalTime float64
+	ApprovalCount      int
 }
 
 // TimeRange represents a time period
@@ -104,6 +138,13 @@ type TrendSummary struct {
 	TrendDescription   string // human-readable explanation of the trend
 	PercentChange      float64
 	MostFlakyJobsCount int
+	// Uncertainty of the numbers above: bootstrap intervals at the analysis
+	// confidence level and the Mann-Whitney U p-value of PercentChange
+	AvgDurationCI    ConfidenceInterval
+	MedianDurationCI ConfidenceInterval
+	SuccessRateCI    ConfidenceInterval
+	PercentChangeCI  ConfidenceInterval
+	TrendPValue      float64
 }
 
 // DataPoint represents a single data point in a trend
@@ -123,6 +164,20 @@ type JobTrend struct {
 	TotalRuns      int
 	TrendDirection string
 	DurationPoints []DataPoint
+	AvgDurationCI  ConfidenceInterval
+	SuccessRateCI  ConfidenceInterval
+}
+
+// StepTrend contains trend data for a step of a job
+type StepTrend struct {
+	Job            string
+	Name           string
+	URLs           []string // sample recent step URLs (newest first)
+	AvgDuration    float64
+	MedianDuration float64
+	TotalRuns      int
+	TrendDirection string
+	AvgDurationCI  ConfidenceInterval
 }
 
 // FlakyJob represents a job with inconsistent outcomes
@@ -141,26 +196,49 @@ type FlakyJob struct {
 type RunData struct {
 	ID         int64
 	HeadSHA    string
+	Event      string // push, pull_request, merge_group, schedule, ...
+	Actor      string // login of who triggered the run
+	ActorType  string // ActorHuman or ActorBot; empty when unknown
+	Workflow   string // path of the workflow definition
+	Name       string // name of the workflow
 	Status     string
 	Conclusion string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	Duration   int64 // milliseconds
 	Jobs       []JobData
+	Waste      Waste // set with the jobs
 }
 
 // JobData represents simplified job data
 type JobData struct {
-	ID          int64
-	Name        string
-	URL         string
-	Status      string
-	Conclusion  string
-	CreatedAt   time.Time
-	StartedAt   time.Time
-	CompletedAt time.Time
-	Duration    int64 // milliseconds
-	QueueTime   int64 // milliseconds
+	ID           int64
+	Name         string
+	URL          string
+	Status       string
+	Conclusion   string
+	CreatedAt    time.Time
+	StartedAt    time.Time
+	CompletedAt  time.Time
+	Duration     int64 // milliseconds
+	QueueTime    int64 // milliseconds, excluding ApprovalTime
+	ApprovalTime int64 // milliseconds waiting on environment protection rules
+	// runs-on labels, for replaying the job against runner pools
+	Labels []string
+	// Step timings, for step-level trends
+	Steps []StepData
+	// Why the job failed, when failures are collected
+	Failures []FailureMessage
+	// Name as GitHub reported it, when Name is normalized
+	RawName string
+}
+
+// StepData represents simplified step data
+type StepData struct {
+	Name       string
+	Number     int
+	Conclusion string
+	Duration   int64 // milliseconds
 }
 
 // TrendOptions configures the trend analysis behavior
@@ -168,6 +246,26 @@ type TrendOptions struct {
 	NoSample    bool
 	Confidence  float64 // e.g. 0.95 for 95%
 	MarginOfError float64 // e.g. 0.10 for ±10%
+	// Runner pool what-ifs replayed against the sampled jobs
+	SimulateRunners   []RunnerScenario
+	SimulationPolicy  string  // one of SimulationPolicies, default PolicyFIFO
+	RunnerCostPerHour float64 // price of a runner-hour; 0 reports runner-hours only
+	// Dimensions to break the trends down by, see TrendGroupings
+	GroupBy []string
+	// Timezone the queue heatmap's hours are in; UTC when nil
+	Timezone *time.Location
+	// Also read the logs of failed jobs for failure signatures
+	FailureLogs bool
+	// Look up the deployments of commits with long-queued jobs to keep
+	// environment approval waits out of queue time
+	ApprovalWaits bool
+	// Rewrites of job names, and whether to collapse matrix values, so that
+	// dynamic names track as one job
+	JobNameRules   []JobNameRule
+	CollapseMatrix bool
+	// Also apply DefaultJobNameRules, after JobNameRules, and follow renamed
+	// jobs, which reads workflow definitions
+	DefaultJobNameRules bool
 }
 
 // AnalyzeTrends analyzes historical trends for a repository using GitHub API.
@@ -175,6 +273,24 @@ type TrendOptions struct {
 // When opts.NoSample is false (default), job detail fetching uses statistical
 // sampling to reduce API calls. Run-level metrics use all fetched runs.
 func AnalyzeTrends(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, days int, branch, workflow string, opts TrendOptions, reporter ProgressReporter) (*TrendAnalysis, error) {
+	analysis, _, err := analyzeRepoTrends(ctx, client, owner, repo, days, branch, workflow, opts, reporter)
+	return analysis, err
+}
+
+// AnalyzeTrendsWithRuns is AnalyzeTrends that also returns the analyzed runs
+// in chronological order, with their jobs under the names they are reported
+// by. Only sampled runs have jobs.
+func AnalyzeTrendsWithRuns(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, days int, branch, workflow string, opts TrendOptions, reporter ProgressReporter) (*TrendAnalysis, []RunData, error) {
+	return analyzeRepoTrends(ctx, client, owner, repo, days, branch, workflow, opts, reporter)
+}
+
+// ErrNoWorkflowRuns is returned when a repository had no workflow runs in the
+// analyzed period.
+var ErrNoWorkflowRuns = errors.New("no workflow runs found")
+
+// analyzeRepoTrends is AnalyzeTrends, also returning the runs the analysis was
+// computed from, in chronological order.
+func analyzeRepoTrends(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, days int, branch, workflow string, opts TrendOptions, reporter ProgressReporter) (*TrendAnalysis, []RunData, error) {
 	endTime := time.Now()
 	startTime := endTime.Add(-time.Duration(days) * 24 * time.Hour)
 
@@ -219,11 +335,11 @@ func AnalyzeTrends(ctx context.Context, client githubapi.GitHubProvider, owner,
 	}
 	runs, err := client.FetchRecentWorkflowRuns(ctx, owner, repo, days, branch, workflow, onPage)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch workflow runs: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch workflow runs: %w", err)
 	}
 
 	if len(runs) == 0 {
-		return nil, fmt.Errorf("no workflow runs found for %s/%s in the last %d days", owner, repo, days)
+		return nil, nil, fmt.Errorf("%w for %s/%s in the last %d days", ErrNoWorkflowRuns, owner, repo, days)
 	}
 
 	// Convert all runs to RunData (no job fetching yet)
@@ -271,8 +387,13 @@ func AnalyzeTrends(ctx context.Context, client githubapi.GitHubProvider, owner,
 	// Fetch jobs for sampled runs
 	sampleIndices := stratifiedSampleIndices(runs, sampling.SampleSize)
 	if err := fetchJobsForRuns(ctx, client, runData, runs, sampleIndices, reporter); err != nil {
-		return nil, fmt.Errorf("failed to fetch job data: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch job data: %w", err)
+	}
+	if opts.ApprovalWaits {
+		fetchApprovalWaitsForRuns(ctx, client, owner, repo, runData, sampleIndices)
 	}
+	fetchWasteForRuns(ctx, client, runData, runs, sampleIndices)
+	fetchFailuresForRuns(ctx, client, owner, repo, runData, sampleIndices, opts.FailureLogs)
 
 	if reporter != nil {
 		reporter.SetPhase("Analyzing trends")
@@ -285,6 +406,19 @@ func AnalyzeTrends(ctx context.Context, client githubapi.GitHubProvider, owner,
 		return runData[i].CreatedAt.Before(runData[j].CreatedAt)
 	})
 
+	// Track jobs under one name across dynamic names and renames, so that
+	// every analysis below sees the same jobs
+	rules := opts.JobNameRules
+	if opts.DefaultJobNameRules {
+		rules = append(slices.Clone(rules), DefaultJobNameRules...)
+	}
+	normalizeJobNames(runData, rules, opts.CollapseMatrix)
+	var renames []JobRename
+	if opts.DefaultJobNameRules {
+		renames = detectJobRenames(ctx, client, owner, repo, runData)
+		applyJobRenames(runData, renames)
+	}
+
 	analysis := &TrendAnalysis{
 		Owner: owner,
 		Repo:  repo,
@@ -293,7 +427,9 @@ func AnalyzeTrends(ctx context.Context, client githubapi.GitHubProvider, owner,
 			End:   endTime,
 			Days:  days,
 		},
-		Sampling: sampling,
+		Sampling:   sampling,
+		JobRenames: renames,
+		JobAliases: jobAliases(runData, renames),
 	}
 
 	// Calculate summary statistics (uses all runs — run-level data)
@@ -312,27 +448,38 @@ func AnalyzeTrends(ctx context.Context, client githubapi.GitHubProvider, owner,
 	analysis.FlakyJobs = detectFlakyJobs(runData)
 	analysis.Summary.MostFlakyJobsCount = len(analysis.FlakyJobs)
 
+	// Cluster why jobs failed (uses sampled job data)
+	analysis.FailureClusters = clusterFailures(runData)
+
 	// Calculate regressions and improvements (uses sampled job data)
-	analysis.TopRegressions, analysis.TopImprovements = calculateJobChanges(runData)
+	analysis.TopRegressions, analysis.TopImprovements = calculateJobChanges(runData, confidence)
+
+	// Break jobs down into their steps (uses sampled job data)
+	analysis.StepTrends = analyzeStepTrends(runData)
+	analysis.TopStepRegressions, analysis.TopStepImprovements = calculateStepChanges(runData, confidence)
+
+	// Bound the numbers with confidence intervals (job and step intervals
+	// reflect the sample they were measured on)
+	addConfidenceIntervals(analysis, runData, confidence)
 
 	// Populate diff URLs on changepoints
-	for i, reg := range analysis.TopRegressions {
-		if reg.Changepoint != nil {
-			analysis.TopRegressions[i].Changepoint.DiffURL = fmt.Sprintf(
-				"https://github.com/%s/%s/compare/%s...%s", owner, repo, reg.Changepoint.BeforeSHA, reg.Changepoint.AfterSHA)
-		}
-	}
-	for i, imp := range analysis.TopImprovements {
-		if imp.Changepoint != nil {
-			analysis.TopImprovements[i].Changepoint.DiffURL = fmt.Sprintf(
-				"https://github.com/%s/%s/compare/%s...%s", owner, repo, imp.Changepoint.BeforeSHA, imp.Changepoint.AfterSHA)
-		}
-	}
+	linkChangepoints(owner, repo, analysis.TopRegressions, analysis.TopImprovements)
+	linkChangepoints(owner, repo, analysis.TopStepRegressions, analysis.TopStepImprovements)
 
 	// Calculate queue time statistics (uses sampled job data)
 	analysis.QueueTimeStats = calculateQueueTimeStats(runData)
+	analysis.QueueHeatmap = calculateQueueHeatmap(runData, opts.Timezone)
+
+	// Calculate wasted compute (uses sampled job data)
+	analysis.Waste = calculateWasteStats(runData)
+
+	// Break the trends down by event, runner OS or actor type
+	analysis.Segments = segmentTrends(runData, opts.GroupBy)
 
-	return analysis, nil
+	// Replay job arrivals against the requested runner pools (uses sampled job data)
+	analysis.Simulations = SimulateRunners(runData, opts.SimulateRunners, opts.SimulationPolicy, opts.RunnerCostPerHour)
+
+	return analysis, runData, nil
 }
 
 // calculateSampleSize computes the minimum sample size for a finite population
@@ -541,6 +688,11 @@ func convertRuns(runs []githubapi.WorkflowRun) []RunData {
 		rd := RunData{
 			ID:         run.ID,
 			HeadSHA:    run.HeadSHA,
+			Event:      run.Event,
+			Actor:      run.Actor.Login,
+			ActorType:  actorType(run.Actor),
+			Workflow:   run.Path,
+			Name:       run.Name,
 			Status:     run.Status,
 			Conclusion: run.Conclusion,
 			CreatedAt:  createdAt,
@@ -586,6 +738,22 @@ func fetchJobsForRuns(ctx context.Context, client githubapi.GitHubProvider, runD
 				queueTime = startedAt.Sub(createdAt).Milliseconds()
 			}
 
+			var steps []StepData
+			for _, step := range job.Steps {
+				stepStarted, _ := utils.ParseTime(step.StartedAt)
+				stepCompleted, _ := utils.ParseTime(step.CompletedAt)
+				stepDuration := int64(0)
+				if !stepStarted.IsZero() && !stepCompleted.IsZero() {
+					stepDuration = stepCompleted.Sub(stepStarted).Milliseconds()
+				}
+				steps = append(steps, StepData{
+					Name:       step.Name,
+					Number:     step.Number,
+					Conclusion: step.Conclusion,
+					Duration:   stepDuration,
+				})
+			}
+
 			runData[idx].Jobs = append(runData[idx].Jobs, JobData{
 				ID:          job.ID,
 				Name:        job.Name,
@@ -597,12 +765,116 @@ func fetchJobsForRuns(ctx context.Context, client githubapi.GitHubProvider, runD
 				CompletedAt: completedAt,
 				Duration:    duration,
 				QueueTime:   queueTime,
+				Labels:      job.Labels,
+				Steps:       steps,
 			})
 		}
 	}
 	return nil
 }
 
+// fetchWasteForRuns measures the compute sampled runs wasted: all of it for
+// cancelled runs, their timed out jobs otherwise, and the earlier attempts of
+// runs re-run after failing (best-effort).
+func fetchWasteForRuns(ctx context.Context, client githubapi.GitHubProvider, runData []RunData, runs []githubapi.WorkflowRun, indices []int) {
+	superseded := SupersededRuns(runs)
+	for _, idx := range indices {
+		run := runs[idx]
+		rd := &runData[idx]
+		if run.Status == "completed" {
+			var computeMs int64
+			for _, job := range rd.Jobs {
+				computeMs += job.Duration
+			}
+			switch {
+			case run.Conclusion == "cancelled" && superseded[run.ID] != 0:
+				rd.Waste.SupersededRuns, rd.Waste.SupersededMs = 1, computeMs
+			case run.Conclusion == "cancelled":
+				rd.Waste.CancelledRuns, rd.Waste.CancelledMs = 1, computeMs
+			default:
+				for _, job := range rd.Jobs {
+					if job.Conclusion == "timed_out" {
+						rd.Waste.TimedOutJobs++
+						rd.Waste.TimedOutMs += job.Duration
+					}
+				}
+			}
+		}
+
+		for attempt := int64(1); attempt < run.RunAttempt; attempt++ {
+			attemptJobsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/runs/%d/attempts/%d/jobs?per_page=100",
+				run.Repository.Owner.Login, run.Repository.Name, run.ID, attempt)
+			jobs, err := client.FetchJobsPaginated(ctx, attemptJobsURL)
+			if err != nil {
+				continue
+			}
+			rd.Waste.Add(attemptWaste(jobs))
+		}
+	}
+}
+
+// approvalCandidateQueue is the queue time from which a job may have waited
+// on environment protection rules. Finding out needs a deployment lookup per
+// commit, so commits whose jobs all started sooner are skipped, and only the
+// maxApprovalCommits commits with the longest queued jobs are looked up.
+const (
+	approvalCandidateQueue = time.Minute
+	maxApprovalCommits     = 25
+)
+
+// fetchApprovalWaitsForRuns splits the time sampled jobs waited on
+// environment protection rules out of their queue time (best-effort).
+func fetchApprovalWaitsForRuns(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, runData []RunData, indices []int) {
+	longestQueue := make(map[string]int64)
+	for _, idx := range indices {
+		for _, job := range runData[idx].Jobs {
+			if time.Duration(job.QueueTime)*time.Millisecond >= approvalCandidateQueue {
+				sha := runData[idx].HeadSHA
+				longestQueue[sha] = max(longestQueue[sha], job.QueueTime)
+			}
+		}
+	}
+	shas := make([]string, 0, len(longestQueue))
+	for sha := range longestQueue {
+		shas = append(shas, sha)
+	}
+	sort.Slice(shas, func(i, j int) bool {
+		if longestQueue[shas[i]] != longestQueue[shas[j]] {
+			return longestQueue[shas[i]] > longestQueue[shas[j]]
+		}
+		return shas[i] < shas[j]
+	})
+	if len(shas) > maxApprovalCommits {
+		shas = shas[:maxApprovalCommits]
+	}
+
+	byJob := make(map[int64]ApprovalWait)
+	for _, sha := range shas {
+		waits, err := FetchApprovalWaits(ctx, client, owner, repo, sha)
+		if err != nil {
+			continue
+		}
+		for _, w := range waits {
+			if w.JobID != 0 && !w.IsPending() {
+				byJob[w.JobID] = w
+			}
+		}
+	}
+	for _, idx := range indices {
+		for i := range runData[idx].Jobs {
+			job := &runData[idx].Jobs[i]
+			w, ok := byJob[job.ID]
+			if !ok {
+				continue
+			}
+			job.ApprovalTime = w.DurationMs()
+			if end := time.UnixMilli(w.EndMs); end.After(job.CreatedAt) && !end.After(job.StartedAt) {
+				job.QueueTime = job.StartedAt.Sub(end).Milliseconds()
+			}
+		}
+	}
+}
+
 // calculateTrendSummary computes summary statistics
 func calculateTrendSummary(runs []RunData) TrendSummary {
 	if len(runs) == 0 {
@@ -761,22 +1033,7 @@ func analyzeJobTrends(runs []RunData) []JobTrend {
 		medianDuration := calculateMedian(durations)
 		successRate := float64(successCount) / float64(len(jobs)) * 100
 
-		// Determine trend direction
-		trendDirection := "stable"
-		if len(durations) >= 4 {
-			midpoint := len(durations) / 2
-			firstHalf := average(durations[:midpoint])
-			secondHalf := average(durations[midpoint:])
-
-			if firstHalf > 0 {
-				percentChange := ((secondHalf - firstHalf) / firstHalf) * 100
-				if percentChange < -5 {
-					trendDirection = "improving"
-				} else if percentChange > 5 {
-					trendDirection = "degrading"
-				}
-			}
-		}
+		trendDirection := durationTrendDirection(durations)
 
 		// Collect up to 5 most recent URLs (jobs are oldest-first)
 		var urls []string
@@ -805,6 +1062,109 @@ func analyzeJobTrends(runs []RunData) []JobTrend {
 	return trends
 }
 
+// durationTrendDirection compares the average of the first and second half of
+// chronological durations: "improving", "degrading" or "stable" within 5%.
+func durationTrendDirection(durations []float64) string {
+	if len(durations) < 4 {
+		return "stable"
+	}
+	midpoint := len(durations) / 2
+	firstHalf := average(durations[:midpoint])
+	secondHalf := average(durations[midpoint:])
+	if firstHalf <= 0 {
+		return "stable"
+	}
+	percentChange := ((secondHalf - firstHalf) / firstHalf) * 100
+	if percentChange < -5 {
+		return "improving"
+	} else if percentChange > 5 {
+		return "degrading"
+	}
+	return "stable"
+}
+
+// stepKey identifies a step across runs by its job and step names.
+type stepKey struct {
+	Job  string
+	Step string
+}
+
+// stepObservations collects the durations of the steps of the jobs in
+// chronological order. Steps that didn't take time, e.g. skipped ones, are
+// left out; steps sharing a name within a job are added together.
+func stepObservations(runs []RunData) (map[stepKey][]jobObservation, []stepKey) {
+	series := make(map[stepKey][]jobObservation)
+	var keys []stepKey
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			seen := make(map[string]int) // step name -> index in its series
+			for _, step := range job.Steps {
+				if step.Duration <= 0 {
+					continue
+				}
+				key := stepKey{Job: job.Name, Step: step.Name}
+				if i, ok := seen[step.Name]; ok {
+					series[key][i].DurationSec += float64(step.Duration) / 1000.0
+					continue
+				}
+				if _, ok := series[key]; !ok {
+					keys = append(keys, key)
+				}
+				url := ""
+				if job.URL != "" {
+					url = fmt.Sprintf("%s#step:%d:1", job.URL, step.Number)
+				}
+				seen[step.Name] = len(series[key])
+				series[key] = append(series[key], jobObservation{
+					DurationSec:  float64(step.Duration) / 1000.0,
+					RunCreatedAt: run.CreatedAt,
+					HeadSHA:      run.HeadSHA,
+					JobURL:       url,
+				})
+			}
+		}
+	}
+	return series, keys
+}
+
+// analyzeStepTrends analyzes the trends of the steps of each job, slowest
+// first.
+func analyzeStepTrends(runs []RunData) []StepTrend {
+	series, keys := stepObservations(runs)
+
+	var trends []StepTrend
+	for _, key := range keys {
+		observations := series[key]
+		durations := make([]float64, len(observations))
+		for i, o := range observations {
+			durations[i] = o.DurationSec
+		}
+
+		var urls []string
+		for i := len(observations) - 1; i >= 0 && len(urls) < 5; i-- {
+			if observations[i].JobURL != "" {
+				urls = append(urls, observations[i].JobURL)
+			}
+		}
+
+		trends = append(trends, StepTrend{
+			Job:            key.Job,
+			Name:           key.Step,
+			URLs:           urls,
+			AvgDuration:    average(durations),
+			MedianDuration: calculateMedian(durations),
+			TotalRuns:      len(observations),
+			TrendDirection: durationTrendDirection(durations),
+		})
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool {
+		return trends[i].AvgDuration > trends[j].AvgDuration
+	})
+
+	return trends
+}
+
 // detectFlakyJobs identifies jobs with inconsistent outcomes
 func detectFlakyJobs(runs []RunData) []FlakyJob {
 	jobMap := make(map[string][]JobData)
@@ -981,6 +1341,10 @@ func detectChangepoint(observations []jobObservation, minSideSize int) *Changepo
 	last := observations[bestIdx-1]
 	first := observations[bestIdx]
 
+	// The split was picked to separate the two sides best, so this
+	// understates p somewhat; it is a guide rather than a test
+	pValue := mannWhitneyU(observationDurations(observations[:bestIdx]), observationDurations(observations[bestIdx:]))
+
 	return &Changepoint{
 		Date:         first.RunCreatedAt,
 		BeforeSHA:    last.HeadSHA,
@@ -991,6 +1355,7 @@ func detectChangepoint(observations []jobObservation, minSideSize int) *Changepo
 		AfterAvg:     afterAvg,
 		Index:        bestIdx,
 		TotalPoints:  n,
+		PValue:       pValue,
 	}
 }
 
@@ -1006,21 +1371,32 @@ func avgObservations(obs []jobObservation) float64 {
 	return sum / float64(len(obs))
 }
 
-// calculateJobChanges finds jobs that got significantly slower or faster
-func calculateJobChanges(runs []RunData) ([]JobRegression, []JobImprovement) {
+// observationDurations returns the DurationSec of each observation.
+func observationDurations(obs []jobObservation) []float64 {
+	durations := make([]float64, len(obs))
+	for i, o := range obs {
+		durations[i] = o.DurationSec
+	}
+	return durations
+}
+
+// calculateJobChanges finds jobs that got significantly slower or faster,
+// testing each change at the confidence level.
+func calculateJobChanges(runs []RunData, confidence float64) ([]JobRegression, []JobImprovement) {
 	if len(runs) < 4 {
 		return nil, nil // Not enough data
 	}
 
 	// Group jobs by name, preserving per-observation metadata (chronological order)
-	jobMap := make(map[string][]jobObservation)
+	jobMap := make(map[stepKey][]jobObservation)
 
 	for _, run := range runs {
 		for _, job := range run.Jobs {
 			if job.Duration <= 0 {
 				continue
 			}
-			jobMap[job.Name] = append(jobMap[job.Name], jobObservation{
+			key := stepKey{Job: job.Name}
+			jobMap[key] = append(jobMap[key], jobObservation{
 				DurationSec:  float64(job.Duration) / 1000.0,
 				RunCreatedAt: run.CreatedAt,
 				HeadSHA:      run.HeadSHA,
@@ -1029,10 +1405,32 @@ func calculateJobChanges(runs []RunData) ([]JobRegression, []JobImprovement) {
 		}
 	}
 
+	return calculateChanges(jobMap, 0, confidence)
+}
+
+// stepMinChangeSec is how many seconds a step's average has to move to count
+// as a change, so that steps taking a second or two don't flood the lists.
+const stepMinChangeSec = 5
+
+// calculateStepChanges finds the steps of jobs that got significantly slower
+// or faster, like calculateJobChanges.
+func calculateStepChanges(runs []RunData, confidence float64) ([]JobRegression, []JobImprovement) {
+	if len(runs) < 4 {
+		return nil, nil // Not enough data
+	}
+	series, _ := stepObservations(runs)
+	return calculateChanges(series, stepMinChangeSec, confidence)
+}
+
+// calculateChanges compares the first and second half of each series of
+// observations and returns the top 10 that got slower and faster by more than
+// 10% and minChange seconds. Each change is tested with Mann-Whitney U at the
+// confidence level; significant changes are listed before the rest.
+func calculateChanges(series map[stepKey][]jobObservation, minChange, confidence float64) ([]JobRegression, []JobImprovement) {
 	var regressions []JobRegression
 	var improvements []JobImprovement
 
-	for name, observations := range jobMap {
+	for key, observations := range series {
 		midpoint := len(observations) / 2
 		firstHalf := observations[:midpoint]
 		secondHalf := observations[midpoint:]
@@ -1047,7 +1445,7 @@ func calculateJobChanges(runs []RunData) ([]JobRegression, []JobImprovement) {
 		percentChange := (change / oldAvg) * 100
 
 		// Only include significant changes (>10%)
-		if math.Abs(percentChange) < 10 {
+		if math.Abs(percentChange) < 10 || math.Abs(change) < minChange {
 			continue
 		}
 
@@ -1061,36 +1459,56 @@ func calculateJobChanges(runs []RunData) ([]JobRegression, []JobImprovement) {
 
 		cp := detectChangepoint(observations, 3)
 
+		before := observationDurations(firstHalf)
+		after := observationDurations(secondHalf)
+		pValue := mannWhitneyU(before, after)
+		percentCI := bootstrapChangeCI(before, after, confidence, key.Job+"/"+key.Step)
+		significant := pValue < 1-confidence
+
 		if change > 0 {
 			// Regression (got slower)
 			regressions = append(regressions, JobRegression{
-				Name:            name,
+				Name:            key.Job,
+				Step:            key.Step,
 				URLs:            urls,
 				OldAvgDuration:  oldAvg,
 				NewAvgDuration:  newAvg,
 				PercentIncrease: percentChange,
 				AbsoluteChange:  change,
 				Changepoint:     cp,
+				PValue:          pValue,
+				PercentCI:       percentCI,
+				Significant:     significant,
 			})
 		} else {
 			// Improvement (got faster)
 			improvements = append(improvements, JobImprovement{
-				Name:            name,
+				Name:            key.Job,
+				Step:            key.Step,
 				URLs:            urls,
 				OldAvgDuration:  oldAvg,
 				NewAvgDuration:  newAvg,
 				PercentDecrease: -percentChange,
 				AbsoluteChange:  -change,
 				Changepoint:     cp,
+				PValue:          pValue,
+				PercentCI:       ConfidenceInterval{Low: -percentCI.High, High: -percentCI.Low},
+				Significant:     significant,
 			})
 		}
 	}
 
-	// Sort by percent change (worst first)
+	// Sort significant changes first, then by percent change (worst first)
 	sort.Slice(regressions, func(i, j int) bool {
+		if regressions[i].Significant != regressions[j].Significant {
+			return regressions[i].Significant
+		}
 		return regressions[i].PercentIncrease > regressions[j].PercentIncrease
 	})
 	sort.Slice(improvements, func(i, j int) bool {
+		if improvements[i].Significant != improvements[j].Significant {
+			return improvements[i].Significant
+		}
 		return improvements[i].PercentDecrease > improvements[j].PercentDecrease
 	})
 
@@ -1105,23 +1523,130 @@ func calculateJobChanges(runs []RunData) ([]JobRegression, []JobImprovement) {
 	return regressions, improvements
 }
 
+// linkChangepoints sets the compare URLs of the changepoints of the changes.
+func linkChangepoints(owner, repo string, regressions []JobRegression, improvements []JobImprovement) {
+	for i, reg := range regressions {
+		if reg.Changepoint != nil {
+			regressions[i].Changepoint.DiffURL = fmt.Sprintf(
+				"https://github.com/%s/%s/compare/%s...%s", owner, repo, reg.Changepoint.BeforeSHA, reg.Changepoint.AfterSHA)
+		}
+	}
+	for i, imp := range improvements {
+		if imp.Changepoint != nil {
+			improvements[i].Changepoint.DiffURL = fmt.Sprintf(
+				"https://github.com/%s/%s/compare/%s...%s", owner, repo, imp.Changepoint.BeforeSHA, imp.Changepoint.AfterSHA)
+		}
+	}
+}
+
+// addConfidenceIntervals bounds the summary, job and step numbers of the
+// analysis with bootstrap intervals at the confidence level, and tests the
+// summary trend. Job and step numbers are measured on the sampled runs only,
+// so resampling those runs carries the sampling uncertainty into their
+// intervals: the fewer runs sampled, the wider.
+func addConfidenceIntervals(analysis *TrendAnalysis, runs []RunData, confidence float64) {
+	var durations, successes []float64
+	jobDurations := make(map[string][]float64)
+	jobSuccesses := make(map[string][]float64)
+	for _, run := range runs {
+		if run.Duration > 0 {
+			durations = append(durations, float64(run.Duration)/1000.0)
+		}
+		successes = append(successes, successPercent(run.Conclusion))
+		for _, job := range run.Jobs {
+			if job.Duration > 0 {
+				jobDurations[job.Name] = append(jobDurations[job.Name], float64(job.Duration)/1000.0)
+			}
+			jobSuccesses[job.Name] = append(jobSuccesses[job.Name], successPercent(job.Conclusion))
+		}
+	}
+
+	s := &analysis.Summary
+	s.AvgDurationCI = bootstrapCI(durations, average, confidence, "duration")
+	s.MedianDurationCI = bootstrapCI(durations, calculateMedian, confidence, "median")
+	s.SuccessRateCI = bootstrapCI(successes, average, confidence, "success")
+	s.TrendPValue = 1
+	if len(durations) >= 4 {
+		midpoint := len(durations) / 2
+		s.PercentChangeCI = bootstrapChangeCI(durations[:midpoint], durations[midpoint:], confidence, "trend")
+		s.TrendPValue = mannWhitneyU(durations[:midpoint], durations[midpoint:])
+	}
+
+	for i, job := range analysis.JobTrends {
+		analysis.JobTrends[i].AvgDurationCI = bootstrapCI(jobDurations[job.Name], average, confidence, job.Name)
+		analysis.JobTrends[i].SuccessRateCI = bootstrapCI(jobSuccesses[job.Name], average, confidence, job.Name+" success")
+	}
+
+	series, _ := stepObservations(runs)
+	for i, step := range analysis.StepTrends {
+		key := stepKey{Job: step.Job, Step: step.Name}
+		analysis.StepTrends[i].AvgDurationCI = bootstrapCI(observationDurations(series[key]), average, confidence, step.Job+"/"+step.Name)
+	}
+}
+
+// successPercent scores a conclusion 100 for success and 0 otherwise, so that
+// the average of the scores is the success rate.
+func successPercent(conclusion string) float64 {
+	if conclusion == "success" {
+		return 100
+	}
+	return 0
+}
+
+// WasteStats is the compute wasted over the trend period, measured on the runs
+// with job details; a sample of the runs when sampling.
+type WasteStats struct {
+	Waste
+	Runs      int   // runs measured
+	ComputeMs int64 // compute of the measured runs, wasted or not
+}
+
+// Percent is the share of the measured compute that ms is.
+func (s WasteStats) Percent(ms int64) float64 {
+	if s.ComputeMs == 0 {
+		return 0
+	}
+	return float64(ms) / float64(s.ComputeMs) * 100
+}
+
+// calculateWasteStats sums the waste of the runs with job details
+func calculateWasteStats(runs []RunData) WasteStats {
+	var stats WasteStats
+	for _, run := range runs {
+		if len(run.Jobs) == 0 && run.Waste.IsZero() {
+			continue
+		}
+		stats.Runs++
+		stats.Waste.Add(run.Waste)
+		stats.ComputeMs += run.Waste.RetriedMs
+		for _, job := range run.Jobs {
+			stats.ComputeMs += job.Duration
+		}
+	}
+	return stats
+}
+
 // calculateQueueTimeStats computes queue time statistics
 func calculateQueueTimeStats(runs []RunData) QueueTimeStats {
 	var queueTimes []float64
 	var runTimes []float64
+	var approvalTimes []float64
 
 	for _, run := range runs {
 		for _, job := range run.Jobs {
 			if job.QueueTime > 0 {
 				queueTimes = append(queueTimes, float64(job.QueueTime)/1000.0)
 			}
+			if job.ApprovalTime > 0 {
+				approvalTimes = append(approvalTimes, float64(job.ApprovalTime)/1000.0)
+			}
 			if job.Duration > 0 {
 				runTimes = append(runTimes, float64(job.Duration)/1000.0)
 			}
 		}
 	}
 
-	if len(queueTimes) == 0 {
+	if len(queueTimes) == 0 && len(approvalTimes) == 0 {
 		return QueueTimeStats{}
 	}
 
@@ -1140,5 +1665,9 @@ func calculateQueueTimeStats(runs []RunData) QueueTimeStats {
 		AvgRunTime:      avgRun,
 		MedianRunTime:   calculateMedian(runTimes),
 		QueueTimeRatio:  queueRatio,
+
+		AvgApprovalTime:    average(approvalTimes),
+		MedianApprovalTime: calculateMedian(approvalTimes),
+		ApprovalCount:      len(approvalTimes),
 	}
 }
@@ -5,10 +5,46 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
 	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// fixtureStart is the time the span and tree fixtures are offset from.
+var fixtureStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func makeTreeNode(name string, hints enrichment.SpanHints, attrs map[string]string, start, end time.Duration, children ...*TreeNode) *TreeNode {
+	return &TreeNode{
+		Name:      name,
+		Attrs:     attrs,
+		Hints:     hints,
+		StartTime: fixtureStart.Add(start),
+		EndTime:   fixtureStart.Add(end),
+		Children:  children,
+	}
+}
+
+// makeSpanStub returns a span of trace 1; a zero parent makes it a root.
+func makeSpanStub(name string, id, parent byte, start, end time.Duration, attrs ...attribute.KeyValue) tracetest.SpanStub {
+	ctx := func(id byte) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{id}})
+	}
+	s := tracetest.SpanStub{
+		Name:        name,
+		SpanContext: ctx(id),
+		StartTime:   fixtureStart.Add(start),
+		EndTime:     fixtureStart.Add(end),
+		Attributes:  attrs,
+	}
+	if parent != 0 {
+		s.Parent = ctx(parent)
+	}
+	return s
+}
+
 func makeRunData(conclusion string, durationMs int64, createdAt time.Time, jobs []JobData) RunData {
 	return RunData{
 		ID:         1,
@@ -185,7 +221,7 @@ func TestCalculateJobChanges(t *testing.T) {
 	t.Parallel()
 
 	t.Run("too few runs", func(t *testing.T) {
-		regressions, improvements := calculateJobChanges([]RunData{{}, {}})
+		regressions, improvements := calculateJobChanges([]RunData{{}, {}}, 0.95)
 		assert.Nil(t, regressions)
 		assert.Nil(t, improvements)
 	})
@@ -197,7 +233,7 @@ func TestCalculateJobChanges(t *testing.T) {
 			{Jobs: []JobData{{Name: "build", Duration: 20000}}},
 			{Jobs: []JobData{{Name: "build", Duration: 20000}}},
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Len(t, regressions, 1)
 		assert.Equal(t, "build", regressions[0].Name)
 		assert.Greater(t, regressions[0].PercentIncrease, 10.0)
@@ -211,13 +247,43 @@ func TestCalculateJobChanges(t *testing.T) {
 			{Jobs: []JobData{{Name: "build", Duration: 10000}}},
 			{Jobs: []JobData{{Name: "build", Duration: 10000}}},
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Empty(t, regressions)
 		assert.Len(t, improvements, 1)
 		assert.Equal(t, "build", improvements[0].Name)
 		assert.Greater(t, improvements[0].PercentDecrease, 10.0)
 	})
 
+	t.Run("tests significance", func(t *testing.T) {
+		var runs []RunData
+		for i := range 12 {
+			duration := int64(10000 + i*100)
+			if i >= 6 {
+				duration += 10000
+			}
+			runs = append(runs, RunData{Jobs: []JobData{{Name: "build", Duration: duration}}})
+		}
+		regressions, _ := calculateJobChanges(runs, 0.95)
+		assert.Len(t, regressions, 1)
+		assert.True(t, regressions[0].Significant)
+		assert.Less(t, regressions[0].PValue, 0.05)
+		assert.True(t, regressions[0].PercentCI.Contains(regressions[0].PercentIncrease))
+		if assert.NotNil(t, regressions[0].Changepoint) {
+			assert.Less(t, regressions[0].Changepoint.PValue, 0.05)
+		}
+
+		// Two runs a side can't tell a change from noise
+		few := []RunData{
+			{Jobs: []JobData{{Name: "build", Duration: 10000}}},
+			{Jobs: []JobData{{Name: "build", Duration: 10000}}},
+			{Jobs: []JobData{{Name: "build", Duration: 20000}}},
+			{Jobs: []JobData{{Name: "build", Duration: 20000}}},
+		}
+		regressions, _ = calculateJobChanges(few, 0.95)
+		assert.Len(t, regressions, 1)
+		assert.False(t, regressions[0].Significant)
+	})
+
 	t.Run("ignores small changes", func(t *testing.T) {
 		runs := []RunData{
 			{Jobs: []JobData{{Name: "build", Duration: 10000}}},
@@ -225,12 +291,101 @@ func TestCalculateJobChanges(t *testing.T) {
 			{Jobs: []JobData{{Name: "build", Duration: 10500}}},
 			{Jobs: []JobData{{Name: "build", Duration: 10500}}},
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Empty(t, regressions)
 		assert.Empty(t, improvements)
 	})
 }
 
+func TestAnalyzeStepTrends(t *testing.T) {
+	t.Parallel()
+
+	job := func(install int64) JobData {
+		return JobData{
+			Name: "test",
+			URL:  "https://github.com/o/r/actions/runs/1/job/2",
+			Steps: []StepData{
+				{Name: "Checkout", Number: 1, Duration: 2000},
+				{Name: "Install dependencies", Number: 2, Duration: install},
+				{Name: "Run script", Number: 3, Duration: 1000},
+				{Name: "Run script", Number: 4, Duration: 3000},
+				{Name: "Deploy", Number: 5, Conclusion: "skipped"},
+			},
+		}
+	}
+	runs := []RunData{
+		{Jobs: []JobData{job(10000)}},
+		{Jobs: []JobData{job(10000)}},
+		{Jobs: []JobData{job(30000)}},
+		{Jobs: []JobData{job(30000)}},
+	}
+
+	trends := analyzeStepTrends(runs)
+	assert.Len(t, trends, 3, "skipped steps are left out")
+
+	install := trends[0]
+	assert.Equal(t, "test", install.Job)
+	assert.Equal(t, "Install dependencies", install.Name)
+	assert.Equal(t, 20.0, install.AvgDuration)
+	assert.Equal(t, 4, install.TotalRuns)
+	assert.Equal(t, "degrading", install.TrendDirection)
+	assert.Equal(t, "https://github.com/o/r/actions/runs/1/job/2#step:2:1", install.URLs[0])
+
+	script := trends[1]
+	assert.Equal(t, "Run script", script.Name)
+	assert.Equal(t, 4.0, script.AvgDuration, "steps sharing a name add up")
+	assert.Equal(t, "stable", script.TrendDirection)
+}
+
+func TestCalculateStepChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("too few runs", func(t *testing.T) {
+		regressions, improvements := calculateStepChanges([]RunData{{}, {}}, 0.95)
+		assert.Nil(t, regressions)
+		assert.Nil(t, improvements)
+	})
+
+	t.Run("finds the step that changed within a job", func(t *testing.T) {
+		base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		var runs []RunData
+		for i := range 6 {
+			install, setup, checkout := int64(30000), int64(60000), int64(1000)
+			if i >= 3 {
+				install, setup, checkout = 60000, 40000, 2000
+			}
+			runs = append(runs, RunData{
+				CreatedAt: base.Add(time.Duration(i) * 24 * time.Hour),
+				HeadSHA:   fmt.Sprintf("sha%d", i),
+				Jobs: []JobData{{
+					Name:     "test",
+					Duration: install + setup + checkout,
+					Steps: []StepData{
+						{Name: "Checkout", Number: 1, Duration: checkout},
+						{Name: "Set up toolchain", Number: 2, Duration: setup},
+						{Name: "Install dependencies", Number: 3, Duration: install},
+					},
+				}},
+			})
+		}
+
+		regressions, improvements := calculateStepChanges(runs, 0.95)
+		assert.Len(t, regressions, 1, "a second more for checkout is too small to report")
+		assert.Equal(t, "test", regressions[0].Name)
+		assert.Equal(t, "Install dependencies", regressions[0].Step)
+		assert.Equal(t, 100.0, regressions[0].PercentIncrease)
+		if assert.NotNil(t, regressions[0].Changepoint) {
+			assert.Equal(t, 3, regressions[0].Changepoint.Index)
+			assert.Equal(t, base.Add(3*24*time.Hour), regressions[0].Changepoint.Date)
+			assert.Equal(t, "sha3", regressions[0].Changepoint.AfterSHA)
+		}
+
+		assert.Len(t, improvements, 1)
+		assert.Equal(t, "Set up toolchain", improvements[0].Step)
+		assert.Equal(t, 20.0, improvements[0].AbsoluteChange)
+	})
+}
+
 func TestCalculateQueueTimeStats(t *testing.T) {
 	t.Parallel()
 
@@ -252,6 +407,19 @@ func TestCalculateQueueTimeStats(t *testing.T) {
 		assert.Greater(t, stats.QueueTimeRatio, 0.0)
 		assert.Less(t, stats.QueueTimeRatio, 100.0)
 	})
+
+	t.Run("keeps approval waits out of queue time", func(t *testing.T) {
+		runs := []RunData{
+			{Jobs: []JobData{
+				{QueueTime: 5000, ApprovalTime: 600000, Duration: 60000},
+				{QueueTime: 3000, Duration: 30000},
+			}},
+		}
+		stats := calculateQueueTimeStats(runs)
+		assert.Equal(t, 4.0, stats.AvgQueueTime)
+		assert.Equal(t, 600.0, stats.AvgApprovalTime)
+		assert.Equal(t, 1, stats.ApprovalCount)
+	})
 }
 
 func TestStatisticalFunctions(t *testing.T) {
@@ -456,7 +624,7 @@ func TestCalculateJobChanges_ChronologicalOrder(t *testing.T) {
 				{Name: "build", Duration: 30000, Conclusion: "success"},
 			}),
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Len(t, regressions, 1, "should detect one regression")
 		assert.Equal(t, "build", regressions[0].Name)
 		assert.Greater(t, regressions[0].PercentIncrease, 10.0,
@@ -487,7 +655,7 @@ func TestCalculateJobChanges_ChronologicalOrder(t *testing.T) {
 				{Name: "test", Duration: 15000, Conclusion: "success"},
 			}),
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Empty(t, regressions, "no regressions expected when jobs got faster")
 		assert.Len(t, improvements, 1, "should detect one improvement")
 		assert.Equal(t, "test", improvements[0].Name)
@@ -520,7 +688,7 @@ func TestCalculateJobChanges_ChronologicalOrder(t *testing.T) {
 				{Name: "lint", Duration: 8000, Conclusion: "success"},
 			}),
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Len(t, regressions, 1, "should detect build as a regression")
 		assert.Equal(t, "build", regressions[0].Name)
 		assert.Len(t, improvements, 1, "should detect lint as an improvement")
@@ -553,7 +721,7 @@ func TestCalculateJobChanges_ChronologicalOrder(t *testing.T) {
 				{Name: "deploy", Duration: 17000, Conclusion: "success"},
 			}),
 		}
-		regressions, improvements := calculateJobChanges(runs)
+		regressions, improvements := calculateJobChanges(runs, 0.95)
 		assert.Len(t, regressions, 1)
 		assert.Equal(t, "deploy", regressions[0].Name)
 		assert.Greater(t, regressions[0].PercentIncrease, 100.0,
@@ -1035,7 +1203,7 @@ func TestCalculateJobChanges_Changepoint(t *testing.T) {
 				}},
 			}
 		}
-		regressions, _ := calculateJobChanges(runs)
+		regressions, _ := calculateJobChanges(runs, 0.95)
 		assert.Len(t, regressions, 1)
 		assert.NotNil(t, regressions[0].Changepoint, "changepoint should be populated with 8 observations")
 		assert.Equal(t, 4, regressions[0].Changepoint.Index)
@@ -1059,7 +1227,7 @@ func TestCalculateJobChanges_Changepoint(t *testing.T) {
 				}},
 			}
 		}
-		_, improvements := calculateJobChanges(runs)
+		_, improvements := calculateJobChanges(runs, 0.95)
 		assert.Len(t, improvements, 1)
 		assert.NotNil(t, improvements[0].Changepoint, "changepoint should be populated with 8 observations")
 		assert.Equal(t, 4, improvements[0].Changepoint.Index)
@@ -1074,7 +1242,7 @@ func TestCalculateJobChanges_Changepoint(t *testing.T) {
 			{CreatedAt: now.Add(-2 * time.Hour), Jobs: []JobData{{Name: "build", Duration: 25000}}},
 			{CreatedAt: now.Add(-1 * time.Hour), Jobs: []JobData{{Name: "build", Duration: 25000}}},
 		}
-		regressions, _ := calculateJobChanges(runs)
+		regressions, _ := calculateJobChanges(runs, 0.95)
 		assert.Len(t, regressions, 1)
 		assert.Nil(t, regressions[0].Changepoint, "changepoint should be nil with only 4 observations")
 	})
@@ -1135,16 +1303,21 @@ func TestConvertRuns(t *testing.T) {
 			{
 				ID:         42,
 				HeadSHA:    "abc123",
+				Event:      "pull_request",
 				Status:     "completed",
 				Conclusion: "success",
 				CreatedAt:  "2026-01-15T10:00:00Z",
 				UpdatedAt:  "2026-01-15T10:05:00Z",
+				Actor:      githubapi.UserInfo{Login: "dependabot[bot]", Type: "Bot"},
 			},
 		}
 		result := convertRuns(runs)
 		assert.Len(t, result, 1)
 		assert.Equal(t, int64(42), result[0].ID)
 		assert.Equal(t, "abc123", result[0].HeadSHA)
+		assert.Equal(t, "pull_request", result[0].Event)
+		assert.Equal(t, "dependabot[bot]", result[0].Actor)
+		assert.Equal(t, ActorBot, result[0].ActorType)
 		assert.Equal(t, "success", result[0].Conclusion)
 		assert.Equal(t, int64(300000), result[0].Duration) // 5 minutes in ms
 		assert.Empty(t, result[0].Jobs)
@@ -11,6 +11,7 @@ type Metrics struct {
 	SuccessfulRuns  int
 	FailedRuns      int
 	RetriedRuns     int
+	SupersededRuns  int // cancelled by a newer run; neither successful nor failed
 	TotalJobs       int
 	FailedJobs      int
 	TotalSteps      int
@@ -22,12 +23,14 @@ type Metrics struct {
 	RunnerJobCounts map[string]int
 	RunnerDurations map[string]float64
 	QueueTimes      []float64
+	ApprovalTimes   []float64 // waits on environment protection rules, kept out of QueueTimes
 	BillableMs      map[string]int64
 	TotalDuration   float64
 	LongestJob      JobDuration
 	ShortestJob     JobDuration
 	JobTimeline     []TimelineJob
 	PendingJobs     []PendingJob
+	Waste           Waste
 }
 
 type StepDuration struct {
@@ -69,6 +72,8 @@ type FinalMetrics struct {
 	MaxConcurrency  int
 	AvgQueueTime    float64
 	MaxQueueTime    float64
+	AvgApprovalTime float64
+	MaxApprovalTime float64
 	RetryRate       string
 }
 
@@ -121,6 +126,10 @@ type URLResult struct {
 	MergedAtMs             *int64
 	CommitTimeMs           *int64
 	CommitPushedAtMs       *int64
+	OpenedAtMs             *int64
+	FirstCommitMs          *int64 // author time of the PR's first commit
+	MergeGate              *MergeGate
+	WorkflowChains         []WorkflowChain // runs linked by workflow_run triggers
 	AllCommitRunsCount     int
 	AllCommitRunsComputeMs int64
 }
@@ -0,0 +1,235 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// Waste is the compute spent on work whose result was thrown away, as job
+// run time (started to completed) in milliseconds.
+type Waste struct {
+	SupersededRuns  int // cancelled because a newer run of the workflow started on the branch
+	SupersededMs    int64
+	CancelledRuns   int // cancelled for any other reason, e.g. by hand
+	CancelledMs     int64
+	RetriedAttempts int // failed attempts that were re-run
+	RetriedMs       int64
+	TimedOutJobs    int // jobs of otherwise kept runs that hit their timeout
+	TimedOutMs      int64
+	BillableMs      int64 // billable time of superseded and cancelled runs, when known
+}
+
+// TotalMs is the compute wasted across all categories.
+func (w Waste) TotalMs() int64 {
+	return w.SupersededMs + w.CancelledMs + w.RetriedMs + w.TimedOutMs
+}
+
+// IsZero reports whether nothing was wasted.
+func (w Waste) IsZero() bool {
+	return w == Waste{}
+}
+
+// Add accumulates other into w.
+func (w *Waste) Add(other Waste) {
+	w.SupersededRuns += other.SupersededRuns
+	w.SupersededMs += other.SupersededMs
+	w.CancelledRuns += other.CancelledRuns
+	w.CancelledMs += other.CancelledMs
+	w.RetriedAttempts += other.RetriedAttempts
+	w.RetriedMs += other.RetriedMs
+	w.TimedOutJobs += other.TimedOutJobs
+	w.TimedOutMs += other.TimedOutMs
+	w.BillableMs += other.BillableMs
+}
+
+// supersedeSlack is how long after a run was cancelled a newer run may have
+// been created and still be the one that cancelled it. With
+// `cancel-in-progress` the older run is cancelled as soon as the newer one is
+// queued; a push long after a manual cancellation did not supersede anything.
+const supersedeSlack = time.Minute
+
+// SupersededRuns finds the cancelled runs that a newer run of the same
+// workflow on the same branch replaced, as `concurrency: cancel-in-progress`
+// does. It returns the ID of the newer run keyed by the cancelled run's ID.
+func SupersededRuns(runs []githubapi.WorkflowRun) map[int64]int64 {
+	superseded := make(map[int64]int64)
+	for _, run := range runs {
+		if run.Conclusion != "cancelled" || run.HeadBranch == "" {
+			continue
+		}
+		created, ok := utils.ParseTime(run.CreatedAt)
+		if !ok {
+			continue
+		}
+		ended, ok := utils.ParseTime(run.UpdatedAt)
+		if !ok {
+			ended = created
+		}
+
+		var newerID int64
+		var newerAt time.Time
+		for _, other := range runs {
+			if other.ID == run.ID || other.HeadBranch != run.HeadBranch || !sameWorkflow(other, run) {
+				continue
+			}
+			t, ok := utils.ParseTime(other.CreatedAt)
+			if !ok || !t.After(created) || t.After(ended.Add(supersedeSlack)) {
+				continue
+			}
+			if newerID == 0 || t.Before(newerAt) {
+				newerID, newerAt = other.ID, t
+			}
+		}
+		if newerID != 0 {
+			superseded[run.ID] = newerID
+		}
+	}
+	return superseded
+}
+
+// jobRunMs is how long a completed job ran.
+func jobRunMs(job githubapi.Job) int64 {
+	start, ok := utils.ParseTime(job.StartedAt)
+	if !ok {
+		return 0
+	}
+	end, ok := utils.ParseTime(job.CompletedAt)
+	if !ok || !end.After(start) {
+		return 0
+	}
+	return end.Sub(start).Milliseconds()
+}
+
+// runWaste is the compute a completed run wasted given the jobs of its latest
+// attempt. A cancelled run wasted all of it; otherwise only its timed out jobs
+// did.
+func runWaste(run githubapi.WorkflowRun, jobs []githubapi.Job, superseded bool) Waste {
+	var w Waste
+	if run.Status != "completed" {
+		return w
+	}
+	var computeMs int64
+	for _, job := range jobs {
+		computeMs += jobRunMs(job)
+	}
+	switch {
+	case run.Conclusion == "cancelled" && superseded:
+		w.SupersededRuns, w.SupersededMs = 1, computeMs
+	case run.Conclusion == "cancelled":
+		w.CancelledRuns, w.CancelledMs = 1, computeMs
+	default:
+		for _, job := range jobs {
+			if job.Conclusion == "timed_out" {
+				w.TimedOutJobs++
+				w.TimedOutMs += jobRunMs(job)
+			}
+		}
+	}
+	return w
+}
+
+// attemptWaste is the compute of an earlier attempt of a run that was re-run.
+// Attempts re-run after succeeding, e.g. to check for flakiness, are not
+// waste.
+func attemptWaste(jobs []githubapi.Job) Waste {
+	if len(jobs) == 0 {
+		return Waste{}
+	}
+	w := Waste{RetriedAttempts: 1}
+	var conclusions []string
+	for _, job := range jobs {
+		conclusions = append(conclusions, job.Conclusion)
+		w.RetriedMs += jobRunMs(job)
+	}
+	if c := aggregateConclusion(conclusions); c == "success" || c == "skipped" {
+		return Waste{}
+	}
+	return w
+}
+
+// Bounds of the superseded runs looked up for one pull request, so that
+// long-lived ones stay cheap: the branch history goes back at most
+// supersededDays, and only the newest maxSupersededRuns superseded runs are
+// measured.
+const (
+	supersededDays    = 14
+	maxSupersededRuns = 30
+)
+
+// FetchSupersededWaste measures the runs of earlier pushes to a pull
+// request's branch that newer pushes cancelled, the newest maxSupersededRuns
+// of them. headRuns are the runs of the head commit and branchRuns those of
+// the branch since the pull request opened. Runs of workflows and events the
+// head commit did not run are left out, as a fork's branch may share its name
+// with a branch of the base repository. Job and timing lookups are
+// best-effort.
+func FetchSupersededWaste(ctx context.Context, client githubapi.GitHubProvider, owner, repo string, headRuns, branchRuns []githubapi.WorkflowRun) Waste {
+	var waste Waste
+	if len(headRuns) == 0 {
+		return waste
+	}
+
+	seen := make(map[int64]bool, len(headRuns))
+	all := append([]githubapi.WorkflowRun{}, headRuns...)
+	for _, run := range headRuns {
+		seen[run.ID] = true
+	}
+	for _, run := range branchRuns {
+		if !seen[run.ID] {
+			seen[run.ID] = true
+			all = append(all, run)
+		}
+	}
+
+	headSHA := headRuns[0].HeadSHA
+	superseded := SupersededRuns(all)
+	var candidates []githubapi.WorkflowRun
+	for _, run := range all {
+		if _, ok := superseded[run.ID]; ok && run.HeadSHA != headSHA && ranOnHead(run, headRuns) {
+			candidates = append(candidates, run)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].CreatedAt > candidates[j].CreatedAt })
+	if len(candidates) > maxSupersededRuns {
+		candidates = candidates[:maxSupersededRuns]
+	}
+
+	for _, run := range candidates {
+		jobsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/runs/%d/jobs?per_page=100", owner, repo, run.ID)
+		jobs, err := client.FetchJobsPaginated(ctx, jobsURL)
+		if err != nil {
+			continue
+		}
+		w := runWaste(run, jobs, true)
+		if timing, err := client.FetchRunTiming(ctx, owner, repo, run.ID); err == nil && timing != nil {
+			for _, billable := range timing.Billable {
+				w.BillableMs += billable.TotalMs
+			}
+		}
+		waste.Add(w)
+	}
+	return waste
+}
+
+// ranOnHead reports whether the head commit ran the run's workflow for the
+// same event.
+func ranOnHead(run githubapi.WorkflowRun, headRuns []githubapi.WorkflowRun) bool {
+	for _, head := range headRuns {
+		if head.Event == run.Event && sameWorkflow(head, run) {
+			return true
+		}
+	}
+	return false
+}
+
+// daysSince is the lookback in days for FetchRecentWorkflowRuns to include
+// runs created at t, with a day to spare.
+func daysSince(t time.Time) int {
+	return int(math.Ceil(time.Since(t).Hours()/24)) + 1
+}
@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/githubapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func wasteRun(id int64, sha, conclusion, created, updated string) githubapi.WorkflowRun {
+	return githubapi.WorkflowRun{
+		ID: id, WorkflowID: 7, Name: "CI", Event: "pull_request",
+		HeadBranch: "feature", HeadSHA: sha,
+		Status: "completed", Conclusion: conclusion,
+		CreatedAt: "2026-03-18T" + created + "Z",
+		UpdatedAt: "2026-03-18T" + updated + "Z",
+	}
+}
+
+func wasteJob(conclusion, started, completed string) githubapi.Job {
+	return githubapi.Job{
+		Conclusion:  conclusion,
+		StartedAt:   "2026-03-18T" + started + "Z",
+		CompletedAt: "2026-03-18T" + completed + "Z",
+	}
+}
+
+func TestSupersededRuns(t *testing.T) {
+	t.Parallel()
+
+	runs := []githubapi.WorkflowRun{
+		wasteRun(1, "a", "cancelled", "17:00:00", "17:05:00"),
+		wasteRun(2, "b", "cancelled", "17:05:00", "17:06:00"),
+		wasteRun(3, "c", "success", "17:06:00", "17:20:00"),
+		// Cancelled by hand long before the next push
+		wasteRun(4, "d", "cancelled", "18:00:00", "18:01:00"),
+		wasteRun(5, "e", "success", "19:00:00", "19:10:00"),
+	}
+	other := wasteRun(6, "f", "success", "17:01:00", "17:10:00")
+	other.WorkflowID = 8
+	runs = append(runs, other)
+
+	superseded := SupersededRuns(runs)
+	assert.Equal(t, map[int64]int64{1: 2, 2: 3}, superseded,
+		"the earliest newer run of the same workflow supersedes; other workflows and late pushes do not")
+}
+
+func TestRunWaste(t *testing.T) {
+	t.Parallel()
+
+	jobs := []githubapi.Job{
+		wasteJob("success", "17:00:00", "17:02:00"),
+		wasteJob("cancelled", "17:00:00", "17:03:00"),
+		wasteJob("skipped", "", ""),
+	}
+	cancelled := wasteRun(1, "a", "cancelled", "17:00:00", "17:03:00")
+
+	w := runWaste(cancelled, jobs, true)
+	assert.Equal(t, 1, w.SupersededRuns)
+	assert.Equal(t, (5 * time.Minute).Milliseconds(), w.SupersededMs)
+	assert.Zero(t, w.CancelledRuns)
+
+	w = runWaste(cancelled, jobs, false)
+	assert.Equal(t, 1, w.CancelledRuns)
+	assert.Equal(t, (5 * time.Minute).Milliseconds(), w.CancelledMs)
+
+	failed := wasteRun(2, "b", "failure", "17:00:00", "17:30:00")
+	w = runWaste(failed, []githubapi.Job{
+		wasteJob("success", "17:00:00", "17:02:00"),
+		wasteJob("timed_out", "17:00:00", "17:30:00"),
+	}, false)
+	assert.Equal(t, Waste{TimedOutJobs: 1, TimedOutMs: (30 * time.Minute).Milliseconds()}, w)
+
+	running := cancelled
+	running.Status = "in_progress"
+	assert.True(t, runWaste(running, jobs, true).IsZero())
+}
+
+func TestAttemptWaste(t *testing.T) {
+	t.Parallel()
+
+	w := attemptWaste([]githubapi.Job{
+		wasteJob("success", "17:00:00", "17:02:00"),
+		wasteJob("failure", "17:00:00", "17:04:00"),
+	})
+	assert.Equal(t, Waste{RetriedAttempts: 1, RetriedMs: (6 * time.Minute).Milliseconds()}, w)
+
+	assert.True(t, attemptWaste([]githubapi.Job{wasteJob("success", "17:00:00", "17:02:00")}).IsZero(),
+		"re-running a passing attempt wastes nothing")
+	assert.True(t, attemptWaste(nil).IsZero())
+}
+
+func TestWasteAdd(t *testing.T) {
+	t.Parallel()
+
+	w := Waste{SupersededRuns: 1, SupersededMs: 100, TimedOutJobs: 1, TimedOutMs: 50}
+	w.Add(Waste{CancelledRuns: 1, CancelledMs: 10, RetriedAttempts: 2, RetriedMs: 20, BillableMs: 60000})
+	assert.Equal(t, int64(180), w.TotalMs())
+	assert.Equal(t, int64(60000), w.BillableMs, "billable time is not part of the total")
+	assert.False(t, w.IsZero())
+}
+
+func TestFetchSupersededWaste(t *testing.T) {
+	t.Parallel()
+
+	head := wasteRun(3, "c", "success", "17:06:00", "17:20:00")
+	branch := []githubapi.WorkflowRun{
+		wasteRun(1, "a", "cancelled", "17:00:00", "17:05:00"),
+		wasteRun(2, "b", "cancelled", "17:05:00", "17:06:00"),
+		head,
+	}
+	// A workflow the head commit did not run
+	push := wasteRun(4, "b", "cancelled", "17:05:00", "17:06:00")
+	push.Event = "push"
+	branch = append(branch, push, wasteRun(5, "c", "success", "17:07:00", "17:10:00"))
+
+	m := new(mockGitHubProvider)
+	m.On("FetchJobsPaginated", mock.Anything, "https://api.github.com/repos/o/r/actions/runs/1/jobs?per_page=100").
+		Return([]githubapi.Job{wasteJob("cancelled", "17:00:00", "17:05:00")}, nil)
+	m.On("FetchJobsPaginated", mock.Anything, "https://api.github.com/repos/o/r/actions/runs/2/jobs?per_page=100").
+		Return([]githubapi.Job{wasteJob("cancelled", "17:05:00", "17:06:00")}, nil)
+	m.On("FetchRunTiming", mock.Anything, "o", "r", int64(1)).
+		Return(&githubapi.RunTiming{Billable: map[string]githubapi.BillableOS{"UBUNTU": {TotalMs: 360000}}}, nil)
+	m.On("FetchRunTiming", mock.Anything, "o", "r", int64(2)).Return(nil, assert.AnError)
+
+	w := FetchSupersededWaste(context.Background(), m, "o", "r", []githubapi.WorkflowRun{head}, branch)
+	m.AssertExpectations(t)
+	assert.Equal(t, Waste{
+		SupersededRuns: 2,
+		SupersededMs:   (6 * time.Minute).Milliseconds(),
+		BillableMs:     360000,
+	}, w)
+}
+
+func TestFetchSupersededWasteMeasuresNewestRuns(t *testing.T) {
+	t.Parallel()
+
+	head := wasteRun(100, "head", "success", "17:50:00", "17:55:00")
+	branch := []githubapi.WorkflowRun{head}
+	// Each cancelled by the next push
+	for i := 1; i <= maxSupersededRuns+2; i++ {
+		created, cancelled := fmt.Sprintf("17:%02d:00", i), fmt.Sprintf("17:%02d:00", i+1)
+		if i == maxSupersededRuns+2 {
+			cancelled = "17:50:00"
+		}
+		branch = append(branch, wasteRun(int64(i), fmt.Sprintf("sha%d", i), "cancelled", created, cancelled))
+	}
+
+	m := new(mockGitHubProvider)
+	m.On("FetchJobsPaginated", mock.Anything, mock.Anything).Return([]githubapi.Job{}, nil)
+	m.On("FetchRunTiming", mock.Anything, "o", "r", mock.Anything).Return(nil, assert.AnError)
+
+	FetchSupersededWaste(context.Background(), m, "o", "r", []githubapi.WorkflowRun{head}, branch)
+	m.AssertNumberOfCalls(t, "FetchJobsPaginated", maxSupersededRuns)
+	m.AssertNotCalled(t, "FetchJobsPaginated", mock.Anything, "https://api.github.com/repos/o/r/actions/runs/1/jobs?per_page=100")
+	m.AssertCalled(t, "FetchJobsPaginated", mock.Anything, fmt.Sprintf("https://api.github.com/repos/o/r/actions/runs/%d/jobs?per_page=100", maxSupersededRuns+2))
+}
+
+func TestSuccessRateExcludesSupersededRuns(t *testing.T) {
+	t.Parallel()
+
+	metrics := InitializeMetrics()
+	metrics.TotalRuns = 4
+	metrics.SuccessfulRuns = 2
+	metrics.FailedRuns = 1
+	metrics.SupersededRuns = 1
+
+	final := CalculateFinalMetrics(metrics, 4, nil, nil)
+	assert.Equal(t, "66.7", final.SuccessRate)
+}
+
+func TestCalculateWasteStats(t *testing.T) {
+	t.Parallel()
+
+	stats := calculateWasteStats([]RunData{
+		{Jobs: []JobData{{Duration: 60000}, {Duration: 30000}}, Waste: Waste{TimedOutJobs: 1, TimedOutMs: 30000}},
+		{Jobs: []JobData{{Duration: 10000}}, Waste: Waste{RetriedAttempts: 1, RetriedMs: 100000}},
+		{}, // not sampled
+	})
+	assert.Equal(t, 2, stats.Runs)
+	assert.Equal(t, int64(200000), stats.ComputeMs, "retried attempts count towards compute")
+	assert.Equal(t, int64(130000), stats.TotalMs())
+	assert.InDelta(t, 65.0, stats.Percent(stats.TotalMs()), 0.001)
+}
@@ -0,0 +1,50 @@
+package enrichment
+
+// ChecksEnricher recognizes check runs and commit statuses reported by CI
+// outside GitHub Actions (CircleCI, Buildkite, Vercel, ...).
+type ChecksEnricher struct{}
+
+// Enrich produces SpanHints for spans with type ∈ {check_run, commit_status}.
+// Returns empty hints (Category=="") for any other span.
+func (e *ChecksEnricher) Enrich(name string, attrs map[string]string, isZeroDuration bool) SpanHints {
+	spanType := attrs["type"]
+	if spanType != "check_run" && spanType != "commit_status" {
+		return SpanHints{}
+	}
+
+	h := SpanHints{
+		Category:    "check",
+		URL:         attrs["github.url"],
+		IsRequired:  attrs["github.is_required"] == "true",
+		IsRoot:      true,
+		BarChar:     "█",
+		Detail:      attrs["github.app"],
+		VCSRevision: attrs["vcs.revision"],
+	}
+
+	h.Icon = "☑️"
+	if spanType == "commit_status" {
+		h.Icon = "📌"
+	}
+
+	switch attrs["github.status"] {
+	case "queued", "in_progress", "pending":
+		h.Outcome = "pending"
+		h.Color = "blue"
+		return h
+	}
+	switch attrs["github.conclusion"] {
+	case "success", "neutral":
+		h.Outcome = "success"
+		h.Color = "green"
+	case "failure", "timed_out", "action_required", "startup_failure":
+		h.Outcome = "failure"
+		h.Color = "red"
+	case "skipped", "cancelled", "stale":
+		h.Outcome = "skipped"
+		h.Color = "gray"
+	default:
+		h.Color = "gray"
+	}
+	return h
+}
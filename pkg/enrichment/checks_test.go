@@ -0,0 +1,63 @@
+package enrichment
+
+import "testing"
+
+func TestChecksEnricher_CheckRun(t *testing.T) {
+	e := &ChecksEnricher{}
+	attrs := map[string]string{
+		"type":               "check_run",
+		"github.app":         "CircleCI Checks",
+		"github.status":      "completed",
+		"github.conclusion":  "failure",
+		"github.url":         "https://circleci.com/gh/o/r/2",
+		"github.is_required": "true",
+	}
+	h := e.Enrich("test", attrs, false)
+
+	if h.Category != "check" {
+		t.Errorf("expected category 'check', got %q", h.Category)
+	}
+	if !h.IsRoot {
+		t.Error("expected IsRoot=true for check run")
+	}
+	if h.Outcome != "failure" || h.Color != "red" {
+		t.Errorf("expected failure/red, got %q/%q", h.Outcome, h.Color)
+	}
+	if h.Detail != "CircleCI Checks" {
+		t.Errorf("expected app as detail, got %q", h.Detail)
+	}
+	if h.URL != "https://circleci.com/gh/o/r/2" {
+		t.Errorf("unexpected URL %q", h.URL)
+	}
+	if !h.IsRequired {
+		t.Error("expected IsRequired=true")
+	}
+}
+
+func TestChecksEnricher_PendingStatus(t *testing.T) {
+	e := &ChecksEnricher{}
+	h := e.Enrich("Vercel", map[string]string{
+		"type":          "commit_status",
+		"github.status": "in_progress",
+	}, false)
+
+	if h.Category != "check" {
+		t.Errorf("expected category 'check', got %q", h.Category)
+	}
+	if h.Icon != "📌" {
+		t.Errorf("expected icon '📌', got %q", h.Icon)
+	}
+	if h.Outcome != "pending" || h.Color != "blue" {
+		t.Errorf("expected pending/blue, got %q/%q", h.Outcome, h.Color)
+	}
+}
+
+func TestChecksEnricher_IgnoresOtherSpans(t *testing.T) {
+	e := &ChecksEnricher{}
+	if h := e.Enrich("build", map[string]string{"type": "job"}, false); h.Category != "" {
+		t.Errorf("expected empty category for job, got %q", h.Category)
+	}
+	if h := DefaultEnricher().Enrich("lint", map[string]string{"type": "check_run", "cicd.pipeline.task.name": "lint"}, false); h.Category != "check" {
+		t.Errorf("expected checks to win over CICD in the default chain, got %q", h.Category)
+	}
+}
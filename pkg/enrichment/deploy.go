@@ -0,0 +1,50 @@
+package enrichment
+
+import "strings"
+
+// DeployEnricher recognizes the time jobs spend waiting on environment
+// protection rules (required reviewers, wait timers) before they deploy.
+type DeployEnricher struct{}
+
+// Enrich produces SpanHints for spans with type == approval_wait.
+// Returns empty hints (Category=="") for any other span.
+func (e *DeployEnricher) Enrich(name string, attrs map[string]string, isZeroDuration bool) SpanHints {
+	if attrs["type"] != "approval_wait" {
+		return SpanHints{}
+	}
+
+	h := SpanHints{
+		Category:    "deploy",
+		URL:         attrs["github.url"],
+		User:        attrs["github.approval.user"],
+		IsLeaf:      true,
+		Icon:        "🛡️",
+		BarChar:     "░",
+		Environment: attrs["github.environment"],
+	}
+
+	switch attrs["github.approval.state"] {
+	case "approved":
+		h.Outcome = "success"
+		h.Color = "green"
+		h.Detail = "approved"
+		if h.User != "" {
+			h.Detail = "approved by @" + h.User
+		}
+	case "rejected":
+		h.Outcome = "failure"
+		h.Color = "red"
+		h.Detail = "rejected"
+		if h.User != "" {
+			h.Detail = "rejected by @" + h.User
+		}
+	default:
+		h.Outcome = "pending"
+		h.Color = "yellow"
+		h.Detail = "waiting for approval"
+		if reviewers := attrs["github.approval.reviewers"]; reviewers != "" {
+			h.Detail = "waiting for " + strings.ReplaceAll(reviewers, ",", ", ")
+		}
+	}
+	return h
+}
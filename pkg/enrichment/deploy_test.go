@@ -0,0 +1,75 @@
+package enrichment
+
+import "testing"
+
+func TestDeployEnricher_Approved(t *testing.T) {
+	e := &DeployEnricher{}
+	attrs := map[string]string{
+		"type":                  "approval_wait",
+		"github.environment":    "production",
+		"github.approval.state": "approved",
+		"github.approval.user":  "octocat",
+		"github.url":            "https://github.com/o/r/actions/runs/1",
+	}
+	h := e.Enrich("🛡️ Approval: production", attrs, false)
+
+	if h.Category != "deploy" {
+		t.Errorf("expected category 'deploy', got %q", h.Category)
+	}
+	if !h.IsLeaf {
+		t.Error("expected IsLeaf=true for approval wait")
+	}
+	if h.Outcome != "success" || h.Color != "green" {
+		t.Errorf("expected success/green, got %q/%q", h.Outcome, h.Color)
+	}
+	if h.Detail != "approved by @octocat" {
+		t.Errorf("unexpected detail %q", h.Detail)
+	}
+	if h.Environment != "production" {
+		t.Errorf("expected environment 'production', got %q", h.Environment)
+	}
+	if h.User != "octocat" {
+		t.Errorf("expected user 'octocat', got %q", h.User)
+	}
+}
+
+func TestDeployEnricher_Waiting(t *testing.T) {
+	e := &DeployEnricher{}
+	h := e.Enrich("🛡️ Approval: production", map[string]string{
+		"type":                      "approval_wait",
+		"github.approval.state":     "waiting",
+		"github.approval.reviewers": "octocat,org/release",
+	}, false)
+
+	if h.Outcome != "pending" || h.Color != "yellow" {
+		t.Errorf("expected pending/yellow, got %q/%q", h.Outcome, h.Color)
+	}
+	if h.Detail != "waiting for octocat, org/release" {
+		t.Errorf("unexpected detail %q", h.Detail)
+	}
+}
+
+func TestDeployEnricher_Rejected(t *testing.T) {
+	e := &DeployEnricher{}
+	h := e.Enrich("🛡️ Approval: production", map[string]string{
+		"type":                  "approval_wait",
+		"github.approval.state": "rejected",
+	}, false)
+
+	if h.Outcome != "failure" || h.Color != "red" {
+		t.Errorf("expected failure/red, got %q/%q", h.Outcome, h.Color)
+	}
+	if h.Detail != "rejected" {
+		t.Errorf("unexpected detail %q", h.Detail)
+	}
+}
+
+func TestDeployEnricher_IgnoresOtherSpans(t *testing.T) {
+	e := &DeployEnricher{}
+	if h := e.Enrich("build", map[string]string{"type": "job"}, false); h.Category != "" {
+		t.Errorf("expected empty category for job, got %q", h.Category)
+	}
+	if h := DefaultEnricher().Enrich("wait", map[string]string{"type": "approval_wait"}, false); h.Category != "deploy" {
+		t.Errorf("expected deploy in the default chain, got %q", h.Category)
+	}
+}
@@ -55,7 +55,8 @@ func (c *ChainEnricher) Enrich(name string, attrs map[string]string, isZeroDurat
 	return SpanHints{}
 }
 
-// DefaultEnricher returns the default enricher chain: GHA first, CICD second, then Generic.
+// DefaultEnricher returns the default enricher chain: GHA first, then third-party
+// checks, deployment approvals, CICD, and Generic.
 func DefaultEnricher() *ChainEnricher {
-	return NewChainEnricher(&GHAEnricher{}, &CICDEnricher{}, &GenericEnricher{})
+	return NewChainEnricher(&GHAEnricher{}, &ChecksEnricher{}, &DeployEnricher{}, &CICDEnricher{}, &GenericEnricher{})
 }
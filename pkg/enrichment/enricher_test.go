@@ -57,6 +57,29 @@ func TestGHAEnricher_Job(t *testing.T) {
 	}
 }
 
+func TestGHAEnricher_ReusableWorkflow(t *testing.T) {
+	e := &GHAEnricher{}
+	attrs := map[string]string{
+		"type":                          "reusable_workflow",
+		"github.conclusion":             "success",
+		"github.reusable_workflow.uses": "org/templates/.github/workflows/build.yml@v2",
+	}
+	h := e.Enrich("build", attrs, false)
+
+	if h.Category != "reusable_workflow" {
+		t.Errorf("expected category 'reusable_workflow', got %q", h.Category)
+	}
+	if h.IsRoot || h.IsLeaf {
+		t.Error("expected an intermediate span")
+	}
+	if h.Icon != "🧩" {
+		t.Errorf("expected icon '🧩', got %q", h.Icon)
+	}
+	if h.Detail != "org/templates/.github/workflows/build.yml@v2" {
+		t.Errorf("expected uses as detail, got %q", h.Detail)
+	}
+}
+
 func TestGHAEnricher_Step(t *testing.T) {
 	e := &GHAEnricher{}
 	attrs := map[string]string{
@@ -252,3 +275,30 @@ func TestGenericEnricher_EmptyArtifactName(t *testing.T) {
 		t.Errorf("expected empty GroupKey for empty artifact name, got %q", h.GroupKey)
 	}
 }
+
+func TestGHAEnricher_MarkerMergeGate(t *testing.T) {
+	e := &GHAEnricher{}
+	attrs := map[string]string{
+		"type":                         "marker",
+		"github.event_type":            "merge_gate",
+		"github.event_id":              "merge_gate-abc123-build",
+		"github.merge_gate.check":      "build",
+		"github.merge_gate.conclusion": "failure",
+	}
+	h := e.Enrich("Merge gate: build", attrs, true)
+
+	if !h.IsMarker {
+		t.Error("expected IsMarker=true")
+	}
+	if h.Icon != "◇ " {
+		t.Errorf("expected icon '◇ ', got %q", h.Icon)
+	}
+	if h.Outcome != "failure" {
+		t.Errorf("expected outcome 'failure', got %q", h.Outcome)
+	}
+
+	attrs["github.merge_gate.conclusion"] = ""
+	if h := e.Enrich("Merge gate: build", attrs, true); h.Outcome != "pending" {
+		t.Errorf("expected outcome 'pending' while running, got %q", h.Outcome)
+	}
+}
@@ -4,11 +4,11 @@ package enrichment
 // matching the current hardcoded behavior.
 type GHAEnricher struct{}
 
-// Enrich produces SpanHints for GHA spans (type ∈ {workflow, job, step, marker}).
+// Enrich produces SpanHints for GHA spans (type ∈ {workflow, reusable_workflow, job, step, marker}).
 // Returns empty hints (Category=="") if the span is not a GHA span.
 func (e *GHAEnricher) Enrich(name string, attrs map[string]string, isZeroDuration bool) SpanHints {
 	spanType := attrs["type"]
-	if spanType != "workflow" && spanType != "job" && spanType != "step" && spanType != "marker" {
+	if spanType != "workflow" && spanType != "reusable_workflow" && spanType != "job" && spanType != "step" && spanType != "marker" {
 		return SpanHints{}
 	}
 
@@ -45,6 +45,10 @@ func (e *GHAEnricher) Enrich(name string, attrs map[string]string, isZeroDuratio
 		h.IsRoot = true
 		h.Icon = "📋"
 		h.BarChar = "█"
+	case "reusable_workflow":
+		h.Icon = "🧩"
+		h.BarChar = "█"
+		h.Detail = attrs["github.reusable_workflow.uses"]
 	case "job":
 		h.Icon = "⚙️"
 		h.BarChar = "█"
@@ -77,6 +81,20 @@ func (h *SpanHints) enrichMarker(attrs map[string]string) {
 		h.BarChar = "✓"
 		h.Color = "green"
 		h.Outcome = "success"
+	case "merge_gate":
+		h.Icon = "◇ "
+		h.BarChar = "◇"
+		switch attrs["github.merge_gate.conclusion"] {
+		case "success", "neutral", "skipped":
+			h.Color = "green"
+			h.Outcome = "success"
+		case "":
+			h.Color = "blue"
+			h.Outcome = "pending"
+		default:
+			h.Color = "red"
+			h.Outcome = "failure"
+		}
 	case "comment", "commented", "COMMENTED":
 		h.Icon = "● "
 		h.BarChar = "●"
@@ -0,0 +1,351 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Names of the metrics exported by TrendMetrics. Pipeline run durations follow
+// the CI/CD semantic conventions; the rest extend them to tasks (jobs).
+const (
+	MetricRunDuration      = "cicd.pipeline.run.duration"
+	MetricRunSuccessRatio  = "cicd.pipeline.run.success_ratio"
+	MetricTaskDuration     = "cicd.pipeline.task.run.duration"
+	MetricTaskQueueTime    = "cicd.pipeline.task.run.queue_time"
+	MetricTaskFailureRatio = "cicd.pipeline.task.run.failure_ratio"
+)
+
+// Bucket boundaries, in seconds, of the duration and queue time histograms.
+var (
+	durationBounds  = []float64{60, 120, 300, 600, 900, 1800, 3600, 7200, 14400}
+	queueTimeBounds = []float64{5, 10, 30, 60, 120, 300, 600, 1800, 3600}
+)
+
+// metricsScope names the instrumentation scope of the exported metrics.
+const metricsScope = "github.com/stefanpenner/otel-explorer/pkg/export/otel"
+
+// TrendMetrics converts the runs of a trend analysis (as returned by
+// analyzer.AnalyzeTrendsWithRuns) to OTLP metrics. Runs and jobs are
+// aggregated per UTC day, so each data point carries the historical time of
+// the runs it covers: histograms are delta sums over the day, ratios are
+// gauges at the end of the day. Job metrics only cover the sampled runs, and
+// failure ratios only the jobs the analysis found flaky.
+func TrendMetrics(analysis *analyzer.TrendAnalysis, runs []analyzer.RunData) *metricspb.ResourceMetrics {
+	end := analysis.TimeRange.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	flaky := make(map[string]bool)
+	for _, job := range analysis.FlakyJobs {
+		flaky[job.Name] = true
+	}
+
+	runDurations := newHistograms(durationBounds)
+	taskDurations := newHistograms(durationBounds)
+	queueTimes := newHistograms(queueTimeBounds)
+	successes := newRatios()
+	failures := newRatios()
+
+	for _, run := range runs {
+		if run.CreatedAt.IsZero() || run.Status != "completed" {
+			continue
+		}
+		day := dayOf(run.CreatedAt)
+		pipeline := pipelineName(run)
+		if run.Duration > 0 {
+			runDurations.add(day, msToSeconds(run.Duration), pipeline, "cicd.pipeline.result", cicdResult(run.Conclusion))
+		}
+		if run.Conclusion == "success" || run.Conclusion == "failure" {
+			successes.add(day, run.Conclusion == "success", pipeline)
+		}
+
+		for _, job := range run.Jobs {
+			if job.Status != "completed" || job.Conclusion == "skipped" {
+				continue
+			}
+			if job.Duration > 0 {
+				taskDurations.add(day, msToSeconds(job.Duration), pipeline,
+					"cicd.pipeline.task.name", job.Name,
+					"cicd.pipeline.task.run.result", cicdResult(job.Conclusion))
+			}
+			if !job.StartedAt.IsZero() {
+				queueTimes.add(day, msToSeconds(max(job.QueueTime, 0)), pipeline, "cicd.pipeline.task.name", job.Name)
+			}
+			if flaky[job.Name] {
+				failures.add(day, job.Conclusion == "failure", pipeline, "cicd.pipeline.task.name", job.Name)
+			}
+		}
+	}
+
+	return &metricspb.ResourceMetrics{
+		Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+			stringAttr("service.name", "otel-explorer"),
+			stringAttr("vcs.provider.name", "github"),
+			stringAttr("vcs.owner.name", analysis.Owner),
+			stringAttr("vcs.repository.name", analysis.Repo),
+			stringAttr("vcs.repository.url.full", fmt.Sprintf("https://github.com/%s/%s", analysis.Owner, analysis.Repo)),
+		}},
+		ScopeMetrics: []*metricspb.ScopeMetrics{{
+			Scope: &commonpb.InstrumentationScope{Name: metricsScope},
+			Metrics: []*metricspb.Metric{
+				runDurations.metric(MetricRunDuration, "Duration of workflow runs", end),
+				successes.metric(MetricRunSuccessRatio, "Share of completed workflow runs that succeeded, per day", end),
+				taskDurations.metric(MetricTaskDuration, "Duration of jobs", end),
+				queueTimes.metric(MetricTaskQueueTime, "Time jobs waited for a runner", end),
+				failures.metric(MetricTaskFailureRatio, "Share of runs of flaky jobs that failed, per day", end),
+			},
+		}},
+	}
+}
+
+// pipelineName is the cicd.pipeline.name of a run: its workflow's name, or
+// the path of its definition.
+func pipelineName(run analyzer.RunData) string {
+	if run.Name != "" {
+		return run.Name
+	}
+	return run.Workflow
+}
+
+// cicdResult maps a GitHub conclusion to a cicd.pipeline.result value.
+func cicdResult(conclusion string) string {
+	switch conclusion {
+	case "success", "failure":
+		return conclusion
+	case "cancelled":
+		return "cancellation"
+	case "skipped":
+		return "skip"
+	case "timed_out":
+		return "timeout"
+	}
+	return "error" // startup_failure, action_required, stale, ...
+}
+
+func dayOf(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func msToSeconds(ms int64) float64 {
+	return float64(ms) / 1000.0
+}
+
+// series identifies the data points of one day and attribute set.
+type series struct {
+	day   time.Time
+	attrs string // key=value pairs joined by \x00
+}
+
+func newSeries(day time.Time, pipeline string, kv []string) series {
+	return series{day: day, attrs: strings.Join(append([]string{"cicd.pipeline.name", pipeline}, kv...), "\x00")}
+}
+
+// attributes returns the attributes of the series.
+func (s series) attributes() []*commonpb.KeyValue {
+	kv := strings.Split(s.attrs, "\x00")
+	attrs := make([]*commonpb.KeyValue, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		attrs = append(attrs, stringAttr(kv[i], kv[i+1]))
+	}
+	return attrs
+}
+
+// window returns the start and end time of the data point of the series' day,
+// which ends at end for the current day.
+func (s series) window(end time.Time) (uint64, uint64) {
+	until := s.day.Add(24 * time.Hour)
+	if until.After(end) {
+		until = end
+	}
+	return uint64(s.day.UnixNano()), uint64(until.UnixNano())
+}
+
+// sortedSeries returns the keys of m by day, then attributes.
+func sortedSeries[V any](m map[series]V) []series {
+	keys := make([]series, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].day.Equal(keys[j].day) {
+			return keys[i].day.Before(keys[j].day)
+		}
+		return keys[i].attrs < keys[j].attrs
+	})
+	return keys
+}
+
+// histograms accumulates a delta histogram per series.
+type histograms struct {
+	bounds []float64
+	points map[series]*metricspb.HistogramDataPoint
+}
+
+func newHistograms(bounds []float64) *histograms {
+	return &histograms{bounds: bounds, points: make(map[series]*metricspb.HistogramDataPoint)}
+}
+
+func (h *histograms) add(day time.Time, value float64, pipeline string, kv ...string) {
+	key := newSeries(day, pipeline, kv)
+	p, ok := h.points[key]
+	if !ok {
+		lo, hi := value, value
+		p = &metricspb.HistogramDataPoint{
+			ExplicitBounds: h.bounds,
+			BucketCounts:   make([]uint64, len(h.bounds)+1),
+			Sum:            new(float64),
+			Min:            &lo,
+			Max:            &hi,
+		}
+		h.points[key] = p
+	}
+	p.Count++
+	*p.Sum += value
+	*p.Min = min(*p.Min, value)
+	*p.Max = max(*p.Max, value)
+	p.BucketCounts[sort.SearchFloat64s(h.bounds, value)]++
+}
+
+func (h *histograms) metric(name, description string, end time.Time) *metricspb.Metric {
+	var points []*metricspb.HistogramDataPoint
+	for _, key := range sortedSeries(h.points) {
+		p := h.points[key]
+		p.Attributes = key.attributes()
+		p.StartTimeUnixNano, p.TimeUnixNano = key.window(end)
+		points = append(points, p)
+	}
+	return &metricspb.Metric{
+		Name:        name,
+		Description: description,
+		Unit:        "s",
+		Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+			DataPoints:             points,
+		}},
+	}
+}
+
+// ratios accumulates a share of hits per series.
+type ratios struct {
+	hits, totals map[series]int
+}
+
+func newRatios() *ratios {
+	return &ratios{hits: make(map[series]int), totals: make(map[series]int)}
+}
+
+func (r *ratios) add(day time.Time, hit bool, pipeline string, kv ...string) {
+	key := newSeries(day, pipeline, kv)
+	r.totals[key]++
+	if hit {
+		r.hits[key]++
+	}
+}
+
+func (r *ratios) metric(name, description string, end time.Time) *metricspb.Metric {
+	var points []*metricspb.NumberDataPoint
+	for _, key := range sortedSeries(r.totals) {
+		start, at := key.window(end)
+		points = append(points, &metricspb.NumberDataPoint{
+			Attributes:        key.attributes(),
+			StartTimeUnixNano: start,
+			TimeUnixNano:      at,
+			Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: float64(r.hits[key]) / float64(r.totals[key])},
+		})
+	}
+	return &metricspb.Metric{
+		Name:        name,
+		Description: description,
+		Unit:        "1",
+		Data:        &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: points}},
+	}
+}
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+// MetricsExporter sends OTLP metrics to a collector over OTLP/HTTP, or writes
+// them as OTLP JSON.
+type MetricsExporter struct {
+	url    string    // OTLP/HTTP metrics endpoint
+	w      io.Writer // set for stdout mode
+	client *http.Client
+}
+
+// NewMetricsExporter returns an exporter posting to the OTLP/HTTP endpoint, a
+// host:port (plain HTTP, path /v1/metrics) or a full URL.
+func NewMetricsExporter(endpoint string) (*MetricsExporter, error) {
+	if endpoint == "" {
+		return nil, errors.New("OTLP metrics endpoint required")
+	}
+	url := endpoint
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	if scheme, rest, _ := strings.Cut(url, "://"); !strings.Contains(rest, "/") {
+		url = scheme + "://" + rest + "/v1/metrics"
+	}
+	return &MetricsExporter{url: url, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// NewStdoutMetricsExporter returns an exporter writing the metrics to w as
+// OTLP JSON.
+func NewStdoutMetricsExporter(w io.Writer) *MetricsExporter {
+	return &MetricsExporter{w: w}
+}
+
+// Export sends the metrics.
+func (e *MetricsExporter) Export(ctx context.Context, metrics ...*metricspb.ResourceMetrics) error {
+	req := &collectormetrics.ExportMetricsServiceRequest{ResourceMetrics: metrics}
+	if e.w != nil {
+		data, err := protojson.MarshalOptions{Multiline: true}.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode metrics")
+		}
+		_, err = fmt.Fprintln(e.w, string(data))
+		return err
+	}
+
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode metrics")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create metrics request")
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrapf(err, "failed to export metrics to %s", e.url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Newf("failed to export metrics to %s: %s %s", e.url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var exportResp collectormetrics.ExportMetricsServiceResponse
+	if data, err := io.ReadAll(resp.Body); err == nil && proto.Unmarshal(data, &exportResp) == nil {
+		if ps := exportResp.GetPartialSuccess(); ps.GetRejectedDataPoints() > 0 {
+			return errors.Newf("collector rejected %d data points: %s", ps.GetRejectedDataPoints(), ps.GetErrorMessage())
+		}
+	}
+	return nil
+}
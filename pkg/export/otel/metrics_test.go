@@ -0,0 +1,214 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// trendFixture returns an analysis and its runs: three runs over two days,
+// the second day's with a flaky job.
+func trendFixture() (*analyzer.TrendAnalysis, []analyzer.RunData) {
+	day1 := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	job := func(name, conclusion string, created time.Time, queue, duration time.Duration) analyzer.JobData {
+		return analyzer.JobData{
+			Name:       name,
+			Status:     "completed",
+			Conclusion: conclusion,
+			CreatedAt:  created,
+			StartedAt:  created.Add(queue),
+			QueueTime:  queue.Milliseconds(),
+			Duration:   duration.Milliseconds(),
+		}
+	}
+	runs := []analyzer.RunData{
+		{Name: "CI", Workflow: ".github/workflows/ci.yml", Status: "completed", Conclusion: "success", CreatedAt: day1, Duration: (10 * time.Minute).Milliseconds(),
+			Jobs: []analyzer.JobData{job("test", "success", day1, 30*time.Second, 8*time.Minute)}},
+		{Name: "CI", Workflow: ".github/workflows/ci.yml", Status: "completed", Conclusion: "failure", CreatedAt: day2, Duration: (20 * time.Minute).Milliseconds(),
+			Jobs: []analyzer.JobData{job("test", "failure", day2, 2*time.Minute, 15*time.Minute)}},
+		{Name: "CI", Workflow: ".github/workflows/ci.yml", Status: "completed", Conclusion: "success", CreatedAt: day2.Add(time.Hour), Duration: (5 * time.Minute).Milliseconds()},
+		{Name: "CI", Status: "in_progress", CreatedAt: day2.Add(2 * time.Hour)},
+	}
+	analysis := &analyzer.TrendAnalysis{
+		Owner:     "acme",
+		Repo:      "api",
+		TimeRange: analyzer.TimeRange{End: day2.Add(3 * time.Hour)},
+		FlakyJobs: []analyzer.FlakyJob{{Name: "test"}},
+	}
+	return analysis, runs
+}
+
+func findMetric(t *testing.T, rm *metricspb.ResourceMetrics, name string) *metricspb.Metric {
+	t.Helper()
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+	t.Fatalf("metric %s not exported", name)
+	return nil
+}
+
+func attrMap(attrs []*commonpb.KeyValue) map[string]string {
+	m := make(map[string]string)
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value.GetStringValue()
+	}
+	return m
+}
+
+func TestTrendMetrics(t *testing.T) {
+	t.Parallel()
+
+	analysis, runs := trendFixture()
+	rm := TrendMetrics(analysis, runs)
+
+	res := attrMap(rm.Resource.Attributes)
+	assert.Equal(t, "otel-explorer", res["service.name"])
+	assert.Equal(t, "api", res["vcs.repository.name"])
+	assert.Equal(t, "https://github.com/acme/api", res["vcs.repository.url.full"])
+
+	day1 := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	// One data point per day and result, at the day the runs happened
+	runDuration := findMetric(t, rm, MetricRunDuration).GetHistogram()
+	assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, runDuration.AggregationTemporality)
+	require.Len(t, runDuration.DataPoints, 3)
+	first := runDuration.DataPoints[0]
+	assert.Equal(t, map[string]string{"cicd.pipeline.name": "CI", "cicd.pipeline.result": "success"}, attrMap(first.Attributes))
+	assert.Equal(t, uint64(day1.UnixNano()), first.StartTimeUnixNano)
+	assert.Equal(t, uint64(day2.UnixNano()), first.TimeUnixNano)
+	assert.Equal(t, uint64(1), first.Count)
+	assert.Equal(t, 600.0, first.GetSum())
+	assert.Equal(t, uint64(1), first.BucketCounts[3]) // (300, 600]
+	// The current day ends at the end of the analysis
+	assert.Equal(t, uint64(analysis.TimeRange.End.UnixNano()), runDuration.DataPoints[2].TimeUnixNano)
+
+	ratio := findMetric(t, rm, MetricRunSuccessRatio).GetGauge()
+	require.Len(t, ratio.DataPoints, 2)
+	assert.Equal(t, 1.0, ratio.DataPoints[0].GetAsDouble())
+	assert.Equal(t, 0.5, ratio.DataPoints[1].GetAsDouble())
+
+	taskDuration := findMetric(t, rm, MetricTaskDuration).GetHistogram()
+	require.Len(t, taskDuration.DataPoints, 2)
+	assert.Equal(t, map[string]string{
+		"cicd.pipeline.name":            "CI",
+		"cicd.pipeline.task.name":       "test",
+		"cicd.pipeline.task.run.result": "failure",
+	}, attrMap(taskDuration.DataPoints[1].Attributes))
+
+	queue := findMetric(t, rm, MetricTaskQueueTime).GetHistogram()
+	require.Len(t, queue.DataPoints, 2)
+	assert.Equal(t, 120.0, queue.DataPoints[1].GetMax())
+
+	failures := findMetric(t, rm, MetricTaskFailureRatio).GetGauge()
+	require.Len(t, failures.DataPoints, 2)
+	assert.Equal(t, 0.0, failures.DataPoints[0].GetAsDouble())
+	assert.Equal(t, 1.0, failures.DataPoints[1].GetAsDouble())
+}
+
+func TestCICDResult(t *testing.T) {
+	t.Parallel()
+
+	for conclusion, want := range map[string]string{
+		"success":         "success",
+		"failure":         "failure",
+		"cancelled":       "cancellation",
+		"skipped":         "skip",
+		"timed_out":       "timeout",
+		"startup_failure": "error",
+	} {
+		assert.Equal(t, want, cicdResult(conclusion), conclusion)
+	}
+}
+
+func TestMetricsExporterOTLP(t *testing.T) {
+	t.Parallel()
+
+	// An in-process OTLP/HTTP metrics receiver
+	var got collectormetrics.ExportMetricsServiceRequest
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		contentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		if err := proto.Unmarshal(body, &got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, _ := proto.Marshal(&collectormetrics.ExportMetricsServiceResponse{})
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(resp)
+	}))
+	defer server.Close()
+
+	analysis, runs := trendFixture()
+	exporter, err := NewMetricsExporter(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	require.NoError(t, exporter.Export(context.Background(), TrendMetrics(analysis, runs)))
+
+	assert.Equal(t, "application/x-protobuf", contentType)
+	require.Len(t, got.ResourceMetrics, 1)
+	assert.Len(t, got.ResourceMetrics[0].ScopeMetrics[0].Metrics, 5)
+	assert.True(t, proto.Equal(TrendMetrics(analysis, runs), got.ResourceMetrics[0]))
+}
+
+func TestMetricsExporterErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/partial") {
+			resp, _ := proto.Marshal(&collectormetrics.ExportMetricsServiceResponse{
+				PartialSuccess: &collectormetrics.ExportMetricsPartialSuccess{RejectedDataPoints: 2, ErrorMessage: "too old"},
+			})
+			_, _ = w.Write(resp)
+			return
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	analysis, runs := trendFixture()
+	exporter, err := NewMetricsExporter(server.URL)
+	require.NoError(t, err)
+	assert.ErrorContains(t, exporter.Export(context.Background(), TrendMetrics(analysis, runs)), "401")
+
+	exporter, err = NewMetricsExporter(server.URL + "/partial")
+	require.NoError(t, err)
+	assert.ErrorContains(t, exporter.Export(context.Background(), TrendMetrics(analysis, runs)), "rejected 2 data points: too old")
+
+	_, err = NewMetricsExporter("")
+	assert.Error(t, err)
+}
+
+func TestStdoutMetricsExporter(t *testing.T) {
+	t.Parallel()
+
+	analysis, runs := trendFixture()
+	var buf bytes.Buffer
+	require.NoError(t, NewStdoutMetricsExporter(&buf).Export(context.Background(), TrendMetrics(analysis, runs)))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Contains(t, buf.String(), MetricRunDuration)
+	assert.Contains(t, buf.String(), `"timeUnixNano"`)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/cockroachdb/errors"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
@@ -49,8 +50,10 @@ func NewGRPCExporter(ctx context.Context, endpoint string) (*Exporter, error) {
 	return &Exporter{exporter: exporter}, nil
 }
 
+// Export sends the spans, annotated with self time and idle gap attributes
+// (see analyzer.AnnotateSpanTimings).
 func (e *Exporter) Export(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
-	return e.exporter.ExportSpans(ctx, spans)
+	return e.exporter.ExportSpans(ctx, analyzer.AnnotateSpanTimings(spans))
 }
 
 func (e *Exporter) Finish(ctx context.Context) error {
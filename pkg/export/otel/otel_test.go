@@ -131,6 +131,17 @@ func TestStdoutExporter(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "self time attribute added",
+			spans: func(t *testing.T) []sdktrace.ReadOnlySpan {
+				return makeSpans(t, "timed-span")
+			},
+			checkFunc: func(t *testing.T, output []byte) {
+				if !bytes.Contains(output, []byte("span.self_time_ms")) {
+					t.Errorf("output missing attribute key 'span.self_time_ms' in: %s", output)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,6 +134,8 @@ type WorkflowRun struct {
 	RunAttempt   int64   `json:"run_attempt"`
 	Name         string  `json:"name"`
 	Path         string  `json:"path"`
+	WorkflowID   int64   `json:"workflow_id"`
+	Event        string  `json:"event"`
 	Status       string  `json:"status"`
 	Conclusion   string  `json:"conclusion"`
 	CreatedAt    string  `json:"created_at"`
@@ -142,6 +144,8 @@ type WorkflowRun struct {
 	HeadSHA      string  `json:"head_sha"`
 	HeadBranch   string  `json:"head_branch"`
 	Repository   RepoRef `json:"repository"`
+	// Who triggered the run; its type tells people from bots
+	Actor UserInfo `json:"actor"`
 }
 
 type RepoRef struct {
@@ -169,6 +173,9 @@ type Job struct {
 	RunnerName  string `json:"runner_name"`
 	HTMLURL     string `json:"html_url"`
 	Steps       []Step `json:"steps"`
+	// Runner pool the job ran in: its runs-on labels and runner group
+	Labels          []string `json:"labels"`
+	RunnerGroupName string   `json:"runner_group_name"`
 }
 
 type Step struct {
@@ -183,6 +190,8 @@ type Step struct {
 type PullRequest struct {
 	Number       int       `json:"number"`
 	Title        string    `json:"title"`
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    string    `json:"created_at"`
 	Head         PRRef     `json:"head"`
 	Base         PRRef     `json:"base"`
 	MergedAt     *string   `json:"merged_at"`
@@ -209,6 +218,7 @@ type Review struct {
 type UserInfo struct {
 	Login string `json:"login"`
 	Name  string `json:"name"`
+	Type  string `json:"type"` // "User", "Bot" or "Organization"
 }
 
 type CommitDetails struct {
@@ -216,6 +226,12 @@ type CommitDetails struct {
 	Author    CommitAuthor `json:"author"`
 }
 
+// PRCommit is one commit of a pull request.
+type PRCommit struct {
+	SHA    string        `json:"sha"`
+	Commit CommitDetails `json:"commit"`
+}
+
 type CommitResponse struct {
 	Commit CommitDetails `json:"commit"`
 	Stats  CommitStats   `json:"stats"`
@@ -243,15 +259,28 @@ type RepoMeta struct {
 	DefaultBranch string `json:"default_branch"`
 }
 
+// Repository is a repository listed for an organization.
+type Repository struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"` // owner/repo
+	Archived bool   `json:"archived"`
+	Disabled bool   `json:"disabled"`
+	PushedAt string `json:"pushed_at"`
+}
+
 type PullAssociated struct {
 	Number int    `json:"number"`
 	Title  string `json:"title"`
 	Base   struct {
 		Ref string `json:"ref"`
 	} `json:"base"`
-	MergedAt *string   `json:"merged_at"`
-	MergedBy *UserInfo `json:"merged_by"`
-	HTMLURL  string    `json:"html_url"`
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+	MergedAt  *string   `json:"merged_at"`
+	MergedBy  *UserInfo `json:"merged_by"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt string    `json:"created_at"`
 }
 
 type GitHubError struct {
@@ -274,6 +303,24 @@ type Check struct {
 	AppID   *int64 `json:"app_id,omitempty"`
 }
 
+// BranchRule is a rule from a repository ruleset that applies to a branch.
+type BranchRule struct {
+	Type       string          `json:"type"`
+	RulesetID  int64           `json:"ruleset_id"`
+	Parameters *RuleParameters `json:"parameters,omitempty"`
+}
+
+// RuleParameters holds the parameters of a required_status_checks rule.
+type RuleParameters struct {
+	RequiredStatusChecks []RequiredStatusCheck `json:"required_status_checks,omitempty"`
+}
+
+// RequiredStatusCheck is a status check a ruleset requires before merging.
+type RequiredStatusCheck struct {
+	Context       string `json:"context"`
+	IntegrationID *int64 `json:"integration_id,omitempty"`
+}
+
 type rateLimiter struct {
 	mu        sync.Mutex
 	remaining int
@@ -630,6 +677,85 @@ func (c *Client) FetchRecentWorkflowRuns(ctx context.Context, owner, repo string
 	return runs, nil
 }
 
+// FetchRecentMergedPullRequests fetches the pull requests of a repository merged
+// in the last N days, optionally only those targeting base.
+func (c *Client) FetchRecentMergedPullRequests(ctx context.Context, owner, repo string, days int, base string) ([]PullRequest, error) {
+	ctx, span := getTracer().Start(ctx, "FetchRecentMergedPullRequests", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.Int("days", days),
+		attribute.String("github.base", base),
+	))
+	defer span.End()
+
+	since := time.Now().AddDate(0, 0, -days)
+
+	params := url.Values{}
+	params.Set("state", "closed")
+	params.Set("sort", "updated")
+	params.Set("direction", "desc")
+	params.Set("per_page", "100")
+	if base != "" {
+		params.Set("base", base)
+	}
+
+	var merged []PullRequest
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?%s", owner, repo, params.Encode())
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var page []struct {
+			PullRequest
+			UpdatedAt string `json:"updated_at"`
+		}
+		if err := decodeJSON(resp, &page); err != nil {
+			return nil, err
+		}
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+		for _, pr := range page {
+			// Sorted by last update: a PR merged in the window was updated in it too
+			if t, err := time.Parse(time.RFC3339, pr.UpdatedAt); err == nil && t.Before(since) {
+				nextURL = ""
+				break
+			}
+			if pr.MergedAt == nil {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, *pr.MergedAt); err == nil && !t.Before(since) {
+				merged = append(merged, pr.PullRequest)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// FetchOrgRepositories lists the repositories of an organization the token
+// can see.
+func (c *Client) FetchOrgRepositories(ctx context.Context, org string) ([]Repository, error) {
+	ctx, span := getTracer().Start(ctx, "FetchOrgRepositories", trace.WithAttributes(
+		attribute.String("github.org", org),
+	))
+	defer span.End()
+
+	var all []Repository
+	nextURL := fmt.Sprintf("https://api.github.com/orgs/%s/repos?type=all&per_page=100", url.PathEscape(org))
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var data []Repository
+		if err := decodeJSON(resp, &data); err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
 func (c *Client) FetchRepository(ctx context.Context, baseURL string) (*RepoMeta, error) {
 	ctx, span := getTracer().Start(ctx, "FetchRepository", trace.WithAttributes(
 		attribute.String("github.baseURL", baseURL),
@@ -730,6 +856,33 @@ func (c *Client) FetchPRComments(ctx context.Context, owner, repo, prNumber stri
 	return fetchCommentsPaginated(ctx, c, commentsURL)
 }
 
+// FetchPRCommits returns the commits of a pull request, oldest first. The API
+// lists at most 250.
+func (c *Client) FetchPRCommits(ctx context.Context, owner, repo, prNumber string) ([]PRCommit, error) {
+	ctx, span := getTracer().Start(ctx, "FetchPRCommits", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.String("github.prNumber", prNumber),
+	))
+	defer span.End()
+
+	var all []PRCommit
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%s/commits?per_page=100", owner, repo, prNumber)
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var page []PRCommit
+		if err := decodeJSON(resp, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
 func (c *Client) FetchJobsPaginated(ctx context.Context, urlValue string) ([]Job, error) {
 	ctx, span := getTracer().Start(ctx, "FetchJobsPaginated", trace.WithAttributes(
 		attribute.String("github.url", urlValue),
@@ -900,6 +1053,32 @@ func (c *Client) FetchBranchProtection(ctx context.Context, owner, repo, branch
 	return &BranchProtection{RequiredStatusChecks: &protection}, nil
 }
 
+// FetchBranchRules returns the active ruleset rules that apply to a branch.
+func (c *Client) FetchBranchRules(ctx context.Context, owner, repo, branch string) ([]BranchRule, error) {
+	ctx, span := getTracer().Start(ctx, "FetchBranchRules", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.String("github.branch", branch),
+	))
+	defer span.End()
+
+	var all []BranchRule
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/rules/branches/%s?per_page=100", owner, repo, url.PathEscape(branch))
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var data []BranchRule
+		if err := decodeJSON(resp, &data); err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
 // RunTiming represents the billing timing for a workflow run.
 type RunTiming struct {
 	Billable map[string]BillableOS `json:"billable"`
@@ -912,10 +1091,96 @@ type BillableOS struct {
 
 // CheckRun represents a GitHub check run.
 type CheckRun struct {
-	ID         int64  `json:"id"`
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	Conclusion string `json:"conclusion"`
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	Status      string       `json:"status"`
+	Conclusion  string       `json:"conclusion"`
+	StartedAt   string       `json:"started_at"`
+	CompletedAt string       `json:"completed_at"`
+	HTMLURL     string       `json:"html_url"`
+	DetailsURL  string       `json:"details_url"`
+	App         *CheckRunApp `json:"app,omitempty"`
+}
+
+// CheckRunApp is the GitHub App that created a check run.
+type CheckRunApp struct {
+	ID   int64  `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// CommitStatus is one state reported through the legacy commit status API.
+type CommitStatus struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"state"` // error, failure, pending or success
+	Context     string    `json:"context"`
+	Description string    `json:"description"`
+	TargetURL   string    `json:"target_url"`
+	CreatedAt   string    `json:"created_at"`
+	UpdatedAt   string    `json:"updated_at"`
+	Creator     *UserInfo `json:"creator,omitempty"`
+}
+
+// Deployment is a deployment of a commit to an environment. Jobs targeting
+// an environment create one when they reach it.
+type Deployment struct {
+	ID          int64     `json:"id"`
+	SHA         string    `json:"sha"`
+	Ref         string    `json:"ref"`
+	Task        string    `json:"task"`
+	Environment string    `json:"environment"`
+	Description string    `json:"description"`
+	Creator     *UserInfo `json:"creator,omitempty"`
+	CreatedAt   string    `json:"created_at"`
+	UpdatedAt   string    `json:"updated_at"`
+}
+
+// DeploymentStatus is one state of a deployment. Deployments by Actions
+// jobs link the job through LogURL.
+type DeploymentStatus struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"state"` // waiting, queued, in_progress, success, failure, error or inactive
+	Environment string    `json:"environment"`
+	Description string    `json:"description"`
+	LogURL      string    `json:"log_url"`
+	TargetURL   string    `json:"target_url"`
+	Creator     *UserInfo `json:"creator,omitempty"`
+	CreatedAt   string    `json:"created_at"`
+	UpdatedAt   string    `json:"updated_at"`
+}
+
+// EnvironmentRef names an environment in deployment reviews.
+type EnvironmentRef struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// DeploymentReviewer is a user or team that can approve a pending deployment.
+type DeploymentReviewer struct {
+	Type     string `json:"type"` // User or Team
+	Reviewer struct {
+		Login string `json:"login"` // users
+		Slug  string `json:"slug"`  // teams
+		Name  string `json:"name"`
+	} `json:"reviewer"`
+}
+
+// PendingDeployment is an environment a waiting run needs approval for.
+type PendingDeployment struct {
+	Environment           EnvironmentRef       `json:"environment"`
+	WaitTimer             int                  `json:"wait_timer"` // minutes
+	WaitTimerStartedAt    string               `json:"wait_timer_started_at"`
+	CurrentUserCanApprove bool                 `json:"current_user_can_approve"`
+	Reviewers             []DeploymentReviewer `json:"reviewers"`
+}
+
+// EnvironmentApproval is a review of a run's pending deployments.
+type EnvironmentApproval struct {
+	State        string           `json:"state"` // approved or rejected
+	Comment      string           `json:"comment"`
+	Environments []EnvironmentRef `json:"environments"`
+	User         UserInfo         `json:"user"`
 }
 
 // Annotation represents a check run annotation.
@@ -965,18 +1230,144 @@ func (c *Client) FetchCheckRunsForCommit(ctx context.Context, owner, repo, sha s
 	))
 	defer span.End()
 
-	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s/check-runs?per_page=100", owner, repo, sha)
+	var all []CheckRun
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s/check-runs?per_page=100", owner, repo, sha)
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			CheckRuns []CheckRun `json:"check_runs"`
+		}
+		if err := decodeJSON(resp, &result); err != nil {
+			return nil, err
+		}
+		all = append(all, result.CheckRuns...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
+// FetchCommitStatuses returns every status reported for a commit, newest first.
+func (c *Client) FetchCommitStatuses(ctx context.Context, owner, repo, sha string) ([]CommitStatus, error) {
+	ctx, span := getTracer().Start(ctx, "FetchCommitStatuses", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.String("github.sha", sha),
+	))
+	defer span.End()
+
+	var all []CommitStatus
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s/statuses?per_page=100", owner, repo, sha)
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var data []CommitStatus
+		if err := decodeJSON(resp, &data); err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
+// FetchDeployments lists the deployments of a commit, newest first.
+func (c *Client) FetchDeployments(ctx context.Context, owner, repo, sha string) ([]Deployment, error) {
+	ctx, span := getTracer().Start(ctx, "FetchDeployments", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.String("github.sha", sha),
+	))
+	defer span.End()
+
+	var all []Deployment
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/deployments?sha=%s&per_page=100", owner, repo, sha)
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var data []Deployment
+		if err := decodeJSON(resp, &data); err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
+// FetchDeploymentStatuses lists the states of a deployment, newest first.
+func (c *Client) FetchDeploymentStatuses(ctx context.Context, owner, repo string, deploymentID int64) ([]DeploymentStatus, error) {
+	ctx, span := getTracer().Start(ctx, "FetchDeploymentStatuses", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.Int64("github.deployment_id", deploymentID),
+	))
+	defer span.End()
+
+	var all []DeploymentStatus
+	nextURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/deployments/%d/statuses?per_page=100", owner, repo, deploymentID)
+	for nextURL != "" {
+		resp, err := fetchWithAuth(ctx, c, nextURL, "")
+		if err != nil {
+			return nil, err
+		}
+		var data []DeploymentStatus
+		if err := decodeJSON(resp, &data); err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
+// FetchPendingDeployments lists the environments a waiting run needs
+// approval for and who can approve them.
+func (c *Client) FetchPendingDeployments(ctx context.Context, owner, repo string, runID int64) ([]PendingDeployment, error) {
+	ctx, span := getTracer().Start(ctx, "FetchPendingDeployments", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.Int64("github.run_id", runID),
+	))
+	defer span.End()
+
+	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/runs/%d/pending_deployments", owner, repo, runID)
 	resp, err := fetchWithAuth(ctx, c, endpoint, "")
 	if err != nil {
 		return nil, err
 	}
-	var result struct {
-		CheckRuns []CheckRun `json:"check_runs"`
+	var pending []PendingDeployment
+	if err := decodeJSON(resp, &pending); err != nil {
+		return nil, err
 	}
-	if err := decodeJSON(resp, &result); err != nil {
+	return pending, nil
+}
+
+// FetchRunApprovals returns the review history of a run's deployments.
+func (c *Client) FetchRunApprovals(ctx context.Context, owner, repo string, runID int64) ([]EnvironmentApproval, error) {
+	ctx, span := getTracer().Start(ctx, "FetchRunApprovals", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.Int64("github.run_id", runID),
+	))
+	defer span.End()
+
+	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/runs/%d/approvals", owner, repo, runID)
+	resp, err := fetchWithAuth(ctx, c, endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+	var approvals []EnvironmentApproval
+	if err := decodeJSON(resp, &approvals); err != nil {
 		return nil, err
 	}
-	return result.CheckRuns, nil
+	return approvals, nil
 }
 
 func (c *Client) FetchAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]Annotation, error) {
@@ -1021,6 +1412,48 @@ func (c *Client) ListArtifacts(ctx context.Context, owner, repo string, runID in
 	return result.Artifacts, nil
 }
 
+// FetchWorkflowFile returns the raw contents of a workflow definition (e.g.
+// .github/workflows/ci.yml) at the given ref.
+func (c *Client) FetchWorkflowFile(ctx context.Context, owner, repo, path, ref string) ([]byte, error) {
+	ctx, span := getTracer().Start(ctx, "FetchWorkflowFile", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.String("github.path", path),
+	))
+	defer span.End()
+
+	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s", owner, repo, path, url.QueryEscape(ref))
+	resp, err := fetchWithAuth(ctx, c, endpoint, "application/vnd.github.raw")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// FetchJobLogs returns the plain-text log of a job. Logs expire with the
+// repository's retention period, after which the request fails.
+func (c *Client) FetchJobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error) {
+	ctx, span := getTracer().Start(ctx, "FetchJobLogs", trace.WithAttributes(
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.Int64("github.job_id", jobID),
+	))
+	defer span.End()
+
+	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID)
+	resp, err := fetchWithAuth(ctx, c, endpoint, "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
 func (c *Client) DownloadArtifact(ctx context.Context, downloadURL string) ([]byte, error) {
 	ctx, span := getTracer().Start(ctx, "DownloadArtifact", trace.WithAttributes(
 		attribute.String("github.url", downloadURL),
@@ -0,0 +1,50 @@
+package githubapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redirectTransport sends every request to a test server instead of the
+// GitHub API, keeping its path and query.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestFetchCheckRunsForCommitFollowsPages(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/o/r/commits/abc/check-runs", r.URL.Path)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"total_count": 2, "check_runs": [{"id": 2, "name": "required-e2e"}]}`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/o/r/commits/abc/check-runs?per_page=100&page=2>; rel="next", <https://api.github.com/repos/o/r/commits/abc/check-runs?per_page=100&page=2>; rel="last"`)
+		fmt.Fprint(w, `{"total_count": 2, "check_runs": [{"id": 1, "name": "lint"}]}`)
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	client := NewClient(NewContext("test-token"), WithHTTPClient(&http.Client{Transport: redirectTransport{target: target}}))
+
+	runs, err := client.FetchCheckRunsForCommit(context.Background(), "o", "r", "abc")
+	assert.NoError(t, err)
+	if assert.Len(t, runs, 2) {
+		assert.Equal(t, "lint", runs[0].Name)
+		assert.Equal(t, "required-e2e", runs[1].Name)
+	}
+}
@@ -9,17 +9,28 @@ type GitHubProvider interface {
 	FetchWorkflowRuns(ctx context.Context, baseURL, headSHA string, branch, event string) ([]WorkflowRun, error)
 	FetchRecentWorkflowRuns(ctx context.Context, owner, repo string, days int, branch, workflow string, onPage func(fetched, total int)) ([]WorkflowRun, error)
 	FetchRepository(ctx context.Context, baseURL string) (*RepoMeta, error)
+	FetchOrgRepositories(ctx context.Context, org string) ([]Repository, error)
 	FetchCommitAssociatedPRs(ctx context.Context, owner, repo, sha string) ([]PullAssociated, error)
 	FetchCommit(ctx context.Context, baseURL, sha string) (*CommitResponse, error)
 	FetchPullRequest(ctx context.Context, baseURL, identifier string) (*PullRequest, error)
+	FetchRecentMergedPullRequests(ctx context.Context, owner, repo string, days int, base string) ([]PullRequest, error)
 	FetchPRReviews(ctx context.Context, owner, repo, prNumber string) ([]Review, error)
 	FetchPRComments(ctx context.Context, owner, repo, prNumber string) ([]Review, error)
+	FetchPRCommits(ctx context.Context, owner, repo, prNumber string) ([]PRCommit, error)
 	FetchJobsPaginated(ctx context.Context, urlValue string) ([]Job, error)
 	FetchBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error)
+	FetchBranchRules(ctx context.Context, owner, repo, branch string) ([]BranchRule, error)
 	FetchRunTiming(ctx context.Context, owner, repo string, runID int64) (*RunTiming, error)
 	FetchCheckRunsForCommit(ctx context.Context, owner, repo, sha string) ([]CheckRun, error)
+	FetchCommitStatuses(ctx context.Context, owner, repo, sha string) ([]CommitStatus, error)
+	FetchDeployments(ctx context.Context, owner, repo, sha string) ([]Deployment, error)
+	FetchDeploymentStatuses(ctx context.Context, owner, repo string, deploymentID int64) ([]DeploymentStatus, error)
+	FetchPendingDeployments(ctx context.Context, owner, repo string, runID int64) ([]PendingDeployment, error)
+	FetchRunApprovals(ctx context.Context, owner, repo string, runID int64) ([]EnvironmentApproval, error)
 	FetchAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]Annotation, error)
 	ListArtifacts(ctx context.Context, owner, repo string, runID int64) ([]Artifact, error)
 	DownloadArtifact(ctx context.Context, url string) ([]byte, error)
+	FetchWorkflowFile(ctx context.Context, owner, repo, path, ref string) ([]byte, error)
+	FetchJobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error)
 	FetchWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*WorkflowRun, error)
 }
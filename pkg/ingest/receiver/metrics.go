@@ -0,0 +1,271 @@
+package receiver
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanNameDimension is the dimension of the span name, which is not an
+// attribute.
+const SpanNameDimension = "span.name"
+
+// DefaultMetricsDimensions break the RED metrics down by service, span name
+// and CI/CD task (job).
+var DefaultMetricsDimensions = []string{"service.name", SpanNameDimension, "cicd.pipeline.task.name"}
+
+// DefaultMetricsBuckets are the duration histogram bucket boundaries, in
+// seconds, spanning requests to CI jobs.
+var DefaultMetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 600, 1800, 3600}
+
+// MetricsOptions configures the RED metrics derived from received spans.
+type MetricsOptions struct {
+	// Span attributes (or resource attributes, e.g. service.name) the metrics
+	// are broken down by, plus SpanNameDimension. Defaults to
+	// DefaultMetricsDimensions.
+	Dimensions []string
+	// Upper bounds of the duration histogram buckets in seconds, increasing.
+	// Defaults to DefaultMetricsBuckets.
+	Buckets []float64
+}
+
+// openMetricsContentType is the content type of the OpenMetrics text format.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Metric family names.
+const (
+	spansFamily    = "otel_explorer_spans"
+	errorsFamily   = "otel_explorer_span_errors"
+	durationFamily = "otel_explorer_span_duration_seconds"
+)
+
+// invalidLabelChars matches the characters OpenMetrics label names can't hold.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// labelName converts an attribute key to a label name, e.g. service.name to
+// service_name.
+func labelName(key string) string {
+	name := invalidLabelChars.ReplaceAllString(key, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// REDMetrics aggregates the rate, errors and duration of spans per
+// combination of dimension values. It is safe for concurrent use.
+type REDMetrics struct {
+	dimensions []string
+	labels     []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*redSeries
+}
+
+// redSeries holds the metrics of one combination of dimension values.
+type redSeries struct {
+	values       []string
+	count        uint64
+	errors       uint64
+	sum          float64  // seconds
+	bucketCounts []uint64 // per bucket, not cumulative; the last is +Inf
+}
+
+// NewREDMetrics returns empty RED metrics for the options.
+func NewREDMetrics(opts MetricsOptions) (*REDMetrics, error) {
+	dimensions := opts.Dimensions
+	if len(dimensions) == 0 {
+		dimensions = DefaultMetricsDimensions
+	}
+	buckets := opts.Buckets
+	if len(buckets) == 0 {
+		buckets = DefaultMetricsBuckets
+	}
+
+	m := &REDMetrics{
+		dimensions: dimensions,
+		buckets:    buckets,
+		series:     make(map[string]*redSeries),
+	}
+	seen := make(map[string]string)
+	for _, d := range dimensions {
+		if d == "" {
+			return nil, fmt.Errorf("empty metrics dimension")
+		}
+		label := labelName(d)
+		if label == "le" || strings.HasPrefix(label, "__") {
+			return nil, fmt.Errorf("metrics dimension %q has the reserved label name %s", d, label)
+		}
+		if other, ok := seen[label]; ok {
+			return nil, fmt.Errorf("metrics dimensions %q and %q have the same label %s", other, d, label)
+		}
+		seen[label] = d
+		m.labels = append(m.labels, label)
+	}
+	for i, b := range buckets {
+		if i > 0 && b <= buckets[i-1] {
+			return nil, fmt.Errorf("metrics buckets must be increasing: %v after %v", b, buckets[i-1])
+		}
+	}
+	return m, nil
+}
+
+// ParseMetricsBuckets parses comma separated bucket boundaries in seconds,
+// e.g. "0.1,1,10,60".
+func ParseMetricsBuckets(s string) ([]float64, error) {
+	var buckets []float64
+	for _, part := range strings.Split(s, ",") {
+		b, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || b <= 0 {
+			return nil, fmt.Errorf("invalid bucket boundary %q: must be a positive number of seconds", part)
+		}
+		buckets = append(buckets, b)
+	}
+	sort.Float64s(buckets)
+	return buckets, nil
+}
+
+// Observe adds the spans to the metrics.
+func (m *REDMetrics) Observe(spans []sdktrace.ReadOnlySpan) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, span := range spans {
+		values := make([]string, len(m.dimensions))
+		for i, d := range m.dimensions {
+			values[i] = dimensionValue(span, d)
+		}
+		key := strings.Join(values, "\x00")
+		s, ok := m.series[key]
+		if !ok {
+			s = &redSeries{values: values, bucketCounts: make([]uint64, len(m.buckets)+1)}
+			m.series[key] = s
+		}
+
+		seconds := max(span.EndTime().Sub(span.StartTime()).Seconds(), 0)
+		s.count++
+		if span.Status().Code == codes.Error {
+			s.errors++
+		}
+		s.sum += seconds
+		s.bucketCounts[sort.SearchFloat64s(m.buckets, seconds)]++
+	}
+}
+
+// dimensionValue is the value of a dimension for a span: its name, or the
+// attribute of the span or else of its resource.
+func dimensionValue(span sdktrace.ReadOnlySpan, dimension string) string {
+	if dimension == SpanNameDimension {
+		return span.Name()
+	}
+	key := attribute.Key(dimension)
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	if v, ok := span.Resource().Set().Value(key); ok {
+		return v.Emit()
+	}
+	return ""
+}
+
+// WriteOpenMetrics writes the metrics in the OpenMetrics text format.
+func (m *REDMetrics) WriteOpenMetrics(w io.Writer) error {
+	m.mu.Lock()
+	keys := make([]string, 0, len(m.series))
+	for k := range m.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	series := make([]redSeries, len(keys))
+	for i, k := range keys {
+		series[i] = *m.series[k]
+		series[i].bucketCounts = append([]uint64(nil), series[i].bucketCounts...)
+	}
+	m.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# TYPE %s counter\n# HELP %s Spans received.\n", spansFamily, spansFamily)
+	for _, s := range series {
+		fmt.Fprintf(&b, "%s_total%s %d\n", spansFamily, m.labelSet(s.values), s.count)
+	}
+	fmt.Fprintf(&b, "# TYPE %s counter\n# HELP %s Spans received with an error status.\n", errorsFamily, errorsFamily)
+	for _, s := range series {
+		fmt.Fprintf(&b, "%s_total%s %d\n", errorsFamily, m.labelSet(s.values), s.errors)
+	}
+	fmt.Fprintf(&b, "# TYPE %s histogram\n# UNIT %s seconds\n# HELP %s Duration of received spans.\n", durationFamily, durationFamily, durationFamily)
+	for _, s := range series {
+		var cumulative uint64
+		for i, count := range s.bucketCounts {
+			cumulative += count
+			le := "+Inf"
+			if i < len(m.buckets) {
+				le = formatFloat(m.buckets[i])
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", durationFamily, m.labelSet(s.values, "le", le), cumulative)
+		}
+		fmt.Fprintf(&b, "%s_count%s %d\n", durationFamily, m.labelSet(s.values), s.count)
+		fmt.Fprintf(&b, "%s_sum%s %s\n", durationFamily, m.labelSet(s.values), formatFloat(s.sum))
+	}
+	b.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelSet renders the labels of a series, followed by extra name/value
+// pairs.
+func (m *REDMetrics) labelSet(values []string, extra ...string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	write := func(name, value string) {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", name, escapeLabelValue(value))
+	}
+	for i, label := range m.labels {
+		write(label, values[i])
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		write(extra[i], extra[i+1])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
+
+func formatFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+func (r *Receiver) handleMetrics(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", openMetricsContentType)
+	if err := r.metrics.WriteOpenMetrics(w); err != nil {
+		log.Printf("failed to write metrics: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package receiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stefanpenner/otel-explorer/pkg/ingest/otlpfile"
+)
+
+// ciTestSpansJSON holds three newline-delimited spans of a CI job from the
+// "ci" service: two successful test steps (2s and 20s) and a failed one (45s).
+const ciTestSpansJSON = `{"Name":"run tests","SpanContext":{"TraceID":"0af7651916cd43dd8448eb211c80319c","SpanID":"b7ad6b7169203331","TraceFlags":"01"},"Parent":{"TraceID":"","SpanID":""},"SpanKind":1,"StartTime":"2024-01-15T10:00:00Z","EndTime":"2024-01-15T10:00:02Z","Attributes":[{"Key":"cicd.pipeline.task.name","Value":{"Type":"STRING","Value":"test \"unit\""}}],"Events":null,"Links":null,"Status":{"Code":"Ok","Description":""},"Resource":[{"Key":"service.name","Value":{"Type":"STRING","Value":"ci"}}]}
+{"Name":"run tests","SpanContext":{"TraceID":"0af7651916cd43dd8448eb211c80319c","SpanID":"00f067aa0ba902b7","TraceFlags":"01"},"Parent":{"TraceID":"","SpanID":""},"SpanKind":1,"StartTime":"2024-01-15T10:00:00Z","EndTime":"2024-01-15T10:00:20Z","Attributes":[{"Key":"cicd.pipeline.task.name","Value":{"Type":"STRING","Value":"test \"unit\""}}],"Events":null,"Links":null,"Status":{"Code":"Unset","Description":""},"Resource":[{"Key":"service.name","Value":{"Type":"STRING","Value":"ci"}}]}
+{"Name":"run tests","SpanContext":{"TraceID":"0af7651916cd43dd8448eb211c80319c","SpanID":"53995c3f42cd8ad8","TraceFlags":"01"},"Parent":{"TraceID":"","SpanID":""},"SpanKind":1,"StartTime":"2024-01-15T10:00:00Z","EndTime":"2024-01-15T10:00:45Z","Attributes":[{"Key":"cicd.pipeline.task.name","Value":{"Type":"STRING","Value":"test \"unit\""}}],"Events":null,"Links":null,"Status":{"Code":"Error","Description":""},"Resource":[{"Key":"service.name","Value":{"Type":"STRING","Value":"ci"}}]}`
+
+func TestREDMetricsOpenMetrics(t *testing.T) {
+	m, err := NewREDMetrics(MetricsOptions{Buckets: []float64{5, 30}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans, err := otlpfile.Parse(strings.NewReader(ciTestSpansJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Observe(spans)
+
+	var b strings.Builder
+	if err := m.WriteOpenMetrics(&b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+
+	labels := `service_name="ci",span_name="run tests",cicd_pipeline_task_name="test \"unit\""`
+	for _, want := range []string{
+		"# TYPE otel_explorer_spans counter\n",
+		"otel_explorer_spans_total{" + labels + "} 3\n",
+		"otel_explorer_span_errors_total{" + labels + "} 1\n",
+		"# TYPE otel_explorer_span_duration_seconds histogram\n",
+		"# UNIT otel_explorer_span_duration_seconds seconds\n",
+		"otel_explorer_span_duration_seconds_bucket{" + labels + `,le="5.0"} 1` + "\n",
+		"otel_explorer_span_duration_seconds_bucket{" + labels + `,le="30.0"} 2` + "\n",
+		"otel_explorer_span_duration_seconds_bucket{" + labels + `,le="+Inf"} 3` + "\n",
+		"otel_explorer_span_duration_seconds_count{" + labels + "} 3\n",
+		"otel_explorer_span_duration_seconds_sum{" + labels + "} 67.0\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if !strings.HasSuffix(got, "# EOF\n") {
+		t.Errorf("exposition must end with # EOF, got:\n%s", got)
+	}
+}
+
+func TestREDMetricsDimensions(t *testing.T) {
+	m, err := NewREDMetrics(MetricsOptions{Dimensions: []string{"service.name", "deployment.environment"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans, err := otlpfile.Parse(strings.NewReader(ciTestSpansJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Observe(spans)
+
+	var b strings.Builder
+	if err := m.WriteOpenMetrics(&b); err != nil {
+		t.Fatal(err)
+	}
+	// Missing attributes have empty values; other dimensions are dropped
+	want := `otel_explorer_spans_total{service_name="ci",deployment_environment=""} 3`
+	if !strings.Contains(b.String(), want) {
+		t.Errorf("missing %q in:\n%s", want, b.String())
+	}
+
+	invalid := []MetricsOptions{
+		{Dimensions: []string{"service.name", "service_name"}},
+		{Dimensions: []string{""}},
+		{Dimensions: []string{"le"}},
+		{Dimensions: []string{"__name__"}},
+		{Dimensions: []string{"..internal"}},
+		{Buckets: []float64{1, 1}},
+	}
+	for _, opts := range invalid {
+		if _, err := NewREDMetrics(opts); err == nil {
+			t.Errorf("NewREDMetrics(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestParseMetricsBuckets(t *testing.T) {
+	buckets, err := ParseMetricsBuckets("60, 0.5,10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 3 || buckets[0] != 0.5 || buckets[2] != 60 {
+		t.Errorf("buckets = %v, want [0.5 10 60]", buckets)
+	}
+
+	for _, s := range []string{"", "1,x", "0", "-1,2"} {
+		if _, err := ParseMetricsBuckets(s); err == nil {
+			t.Errorf("ParseMetricsBuckets(%q) should fail", s)
+		}
+	}
+}
+
+func TestMetricsEndpointCountsReceivedSpans(t *testing.T) {
+	r := New(":0")
+	handler := setupMux(r)
+
+	post := httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader(twoSpansJSON))
+	post.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), post)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Errorf("Content-Type = %q, want application/openmetrics-text", ct)
+	}
+	want := `otel_explorer_spans_total{service_name="",span_name="span-a",cicd_pipeline_task_name=""} 1`
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("missing %q in:\n%s", want, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rec.Code)
+	}
+}
@@ -1,5 +1,6 @@
 // Package receiver implements an OTLP/HTTP receiver that accepts spans
 // via the standard /v1/traces endpoint and feeds them into the analyzer.
+// RED metrics derived from the spans are served on /metrics.
 package receiver
 
 import (
@@ -23,13 +24,29 @@ type Receiver struct {
 	spans  []sdktrace.ReadOnlySpan
 	server *http.Server
 	addr   string
+
+	// RED metrics of the received spans, served on /metrics
+	metrics *REDMetrics
 }
 
 // New creates a new OTLP/HTTP receiver listening on the given address.
 func New(addr string) *Receiver {
+	metrics, _ := NewREDMetrics(MetricsOptions{})
 	return &Receiver{
-		addr: addr,
+		addr:    addr,
+		metrics: metrics,
+	}
+}
+
+// SetMetricsOptions configures the dimensions and buckets of the /metrics
+// endpoint. It must be called before spans are received.
+func (r *Receiver) SetMetricsOptions(opts MetricsOptions) error {
+	metrics, err := NewREDMetrics(opts)
+	if err != nil {
+		return err
 	}
+	r.metrics = metrics
+	return nil
 }
 
 // Start begins listening for OTLP/HTTP traces.
@@ -37,6 +54,7 @@ func New(addr string) *Receiver {
 func (r *Receiver) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/v1/traces", r.handleTraces)
+	mux.HandleFunc("/metrics", r.handleMetrics)
 	// Health check
 	mux.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -134,6 +152,7 @@ func (r *Receiver) handleTraces(w http.ResponseWriter, req *http.Request) {
 	r.mu.Lock()
 	r.spans = append(r.spans, spans...)
 	r.mu.Unlock()
+	r.metrics.Observe(spans)
 
 	// Return OTLP ExportTraceServiceResponse (empty JSON object)
 	w.Header().Set("Content-Type", "application/json")
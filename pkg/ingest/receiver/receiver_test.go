@@ -22,6 +22,7 @@ const twoSpansJSON = `{"Name":"span-a","SpanContext":{"TraceID":"0af7651916cd43d
 func setupMux(r *Receiver) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/v1/traces", r.handleTraces)
+	mux.HandleFunc("/metrics", r.handleMetrics)
 	mux.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// aggregateRowLimit caps the number of groups printed in the aggregate report.
+const aggregateRowLimit = 50
+
+// OutputAggregate prints the span aggregation table: one row per group with
+// count, total, self time, p50/p95/max and error rate.
+func OutputAggregate(w io.Writer, groups []analyzer.SpanGroup, groupBy, sortBy string) error {
+	if groupBy == "" {
+		groupBy = analyzer.AggregateByName
+	}
+	if sortBy == "" {
+		sortBy = "total"
+	}
+
+	spanCount := 0
+	for _, g := range groups {
+		spanCount += g.Count
+	}
+
+	styledSection(w, fmt.Sprintf("Span Aggregation by %s", groupBy))
+	fmt.Fprintf(w, "  %s\n\n", dimStyle.Render(fmt.Sprintf("%d spans in %d groups, sorted by %s", spanCount, len(groups), sortBy)))
+
+	if len(groups) == 0 {
+		fmt.Fprintf(w, "  %s\n", dimStyle.Render(fmt.Sprintf("No spans have a %q value.", groupBy)))
+		return nil
+	}
+
+	limit := aggregateRowLimit
+	if len(groups) < limit {
+		limit = len(groups)
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col == 0 {
+				return lipgloss.NewStyle()
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers(groupBy, "Count", "Total", "Self", "p50", "p95", "Max", "Errors")
+
+	for _, g := range groups[:limit] {
+		key := g.Key
+		if len(key) > 48 {
+			key = key[:45] + "..."
+		}
+		errRate := dimStyle.Render("0%")
+		if g.Errors > 0 {
+			errRate = failureStyle.Render(fmt.Sprintf("%.1f%%", g.ErrorRate()))
+		}
+		t.Row(
+			key,
+			fmt.Sprintf("%d", g.Count),
+			utils.HumanizeTime(g.Total.Seconds()),
+			utils.HumanizeTime(g.Self.Seconds()),
+			utils.HumanizeTime(g.P50.Seconds()),
+			utils.HumanizeTime(g.P95.Seconds()),
+			utils.HumanizeTime(g.Max.Seconds()),
+			errRate,
+		)
+	}
+
+	fmt.Fprintln(w, t)
+
+	if len(groups) > limit {
+		fmt.Fprintf(w, "\n... and %d more groups\n", len(groups)-limit)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// resultChains returns the results that have workflow_run chains.
+func resultChains(urlResults []analyzer.URLResult) []analyzer.URLResult {
+	var chained []analyzer.URLResult
+	for _, result := range urlResults {
+		if len(result.WorkflowChains) > 0 {
+			chained = append(chained, result)
+		}
+	}
+	return chained
+}
+
+// chainLatency describes the end-to-end latency of a chain.
+func chainLatency(c analyzer.WorkflowChain) string {
+	if c.IsPending() {
+		return "still running"
+	}
+	from := "commit"
+	if c.CommitMs == 0 {
+		from = "first run"
+	}
+	return fmt.Sprintf("%s %s to end", utils.HumanizeTime(float64(c.EndToEndMs())/1000), from)
+}
+
+// writeStyledChains prints the Workflow Chains section of the styled report.
+func writeStyledChains(w io.Writer, results []analyzer.URLResult) {
+	styledSection(w, "Workflow Chains")
+	for _, result := range results {
+		for _, c := range result.WorkflowChains {
+			fmt.Fprintf(w, "  %s %s  %s\n",
+				dimStyle.Render(fmt.Sprintf("[%d]", result.URLIndex+1)),
+				valueStyle.Render(utils.MakeClickableLink(c.Runs[0].URL, c.Name())),
+				numStyle.Render(chainLatency(c)))
+			for _, r := range c.Runs[1:] {
+				duration := "running"
+				if r.EndMs != 0 {
+					duration = utils.HumanizeTime(float64(r.EndMs-r.StartMs) / 1000)
+				}
+				fmt.Fprintf(w, "      %s %s  %s\n",
+					labelStyle.Render("↳"),
+					valueStyle.Render(utils.MakeClickableLink(r.URL, r.Name)),
+					dimStyle.Render(duration))
+			}
+		}
+	}
+}
+
+// writeMarkdownChains prints the Workflow Chains table of the markdown report.
+func writeMarkdownChains(w io.Writer, results []analyzer.URLResult) {
+	fmt.Fprintln(w, "## Workflow Chains")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "| Source | Chain | Runs | End-to-end |")
+	fmt.Fprintln(w, "| --- | --- | ---: | ---: |")
+	for _, result := range results {
+		for _, c := range result.WorkflowChains {
+			fmt.Fprintf(w, "| %s | %s | %d | %s |\n",
+				markdownLink(result.DisplayURL, result.DisplayName),
+				markdownLink(c.Runs[0].URL, c.Name()),
+				len(c.Runs),
+				chainLatency(c))
+		}
+	}
+	fmt.Fprintln(w, "")
+}
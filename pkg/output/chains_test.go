@@ -0,0 +1,47 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowChainSections(t *testing.T) {
+	t.Parallel()
+
+	results, combined, start, end, spans := reportTestInput()
+
+	var md bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&md, results, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, md.String(), "## Workflow Chains")
+	assert.Contains(t, md.String(), "| [PR #42](https://github.com/o/r/pull/42) | [CI → Deploy preview](https://github.com/o/r/actions/runs/1) | 2 | 6m 40s first run to end |")
+
+	var styled bytes.Buffer
+	assert.NoError(t, OutputStyledResults(&styled, results, combined, nil, start, end, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, styled.String(), "Workflow Chains")
+	assert.Contains(t, styled.String(), "Deploy preview")
+
+	chained := results[0]
+	chained.WorkflowChains = []analyzer.WorkflowChain{{
+		ID:       1,
+		CommitMs: 1000,
+		Runs: []analyzer.ChainRun{
+			{RunID: 1, Name: "Build", URL: "https://github.com/o/r/actions/runs/1", Status: "completed", StartMs: 61000, EndMs: 121000},
+			{RunID: 2, Name: "Deploy", Status: "in_progress", StartMs: 122000, TriggeredBy: 1},
+		},
+	}}
+	var pending bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&pending, []analyzer.URLResult{chained}, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, pending.String(), "| [Build → Deploy](https://github.com/o/r/actions/runs/1) | 2 | still running |")
+
+	chained.WorkflowChains[0].Runs[1].EndMs = 181000
+	assert.Equal(t, "3m commit to end", chainLatency(chained.WorkflowChains[0]))
+
+	chained.WorkflowChains = nil
+	var none bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&none, []analyzer.URLResult{chained}, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.NotContains(t, none.String(), "## Workflow Chains")
+}
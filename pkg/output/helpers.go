@@ -2,10 +2,19 @@ package output
 
 import (
 	"sort"
+	"time"
 
 	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"go.opentelemetry.io/otel/sdk/trace"
 )
 
+// idleGapLimit caps the number of spans listed in the Idle Gaps section.
+const idleGapLimit = 10
+
+// baselineLimit caps the number of spans listed in the Baseline section.
+const baselineLimit = 15
+
 func sortReviewEvents(events []analyzer.ReviewEvent) {
 	sort.Slice(events, func(i, j int) bool {
 		return events[i].TimeMillis() < events[j].TimeMillis()
@@ -36,3 +45,15 @@ func requiredEmoji(isRequired bool) string {
 	}
 	return ""
 }
+
+// idleNodes returns the spans with the most idle time between their children.
+func idleNodes(spans []trace.ReadOnlySpan, globalEarliestTime, globalLatestTime int64, enricher enrichment.Enricher) []*analyzer.TreeNode {
+	roots := analyzer.BuildTreeFromSpans(spans, time.UnixMilli(globalEarliestTime), time.UnixMilli(globalLatestTime), enricher)
+	return analyzer.FindIdleNodes(roots, idleGapLimit)
+}
+
+// baselineNodes returns the spans ranked against a --baseline, outliers first.
+func baselineNodes(spans []trace.ReadOnlySpan, globalEarliestTime, globalLatestTime int64, enricher enrichment.Enricher) []*analyzer.TreeNode {
+	roots := analyzer.BuildTreeFromSpans(spans, time.UnixMilli(globalEarliestTime), time.UnixMilli(globalLatestTime), enricher)
+	return analyzer.FindBaselineNodes(roots, baselineLimit)
+}
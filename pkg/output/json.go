@@ -0,0 +1,694 @@
+package output
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ReportSchemaVersion is the version of the --output=json report layout.
+// It is bumped on any change that removes, renames or retypes a field;
+// adding optional fields keeps the version.
+const ReportSchemaVersion = 1
+
+// ReportSchema is the JSON Schema (draft 2020-12) describing Report.
+//
+//go:embed report.schema.json
+var ReportSchema []byte
+
+// Report is the machine-readable form of a single-run analysis. Durations are
+// in milliseconds, timestamps are RFC 3339 in UTC and percentages are 0-100.
+type Report struct {
+	SchemaVersion int                `json:"schema_version"`
+	Start         time.Time          `json:"start"`
+	End           time.Time          `json:"end"`
+	Combined      ReportCombined     `json:"combined"`
+	Results       []ReportResult     `json:"results"`
+	PendingJobs   []ReportPendingJob `json:"pending_jobs"`
+	Tree          []ReportNode       `json:"tree"`
+}
+
+// ReportCombined holds metrics across all inputs.
+type ReportCombined struct {
+	TotalRuns      int              `json:"total_runs"`
+	TotalJobs      int              `json:"total_jobs"`
+	TotalSteps     int              `json:"total_steps"`
+	SuccessRate    float64          `json:"success_rate"`
+	JobSuccessRate float64          `json:"job_success_rate"`
+	MaxConcurrency int              `json:"max_concurrency"`
+	BillableMs     map[string]int64 `json:"billable_ms"`
+	Jobs           []ReportJob      `json:"jobs"`
+}
+
+// ReportResult is the analysis of one input URL.
+type ReportResult struct {
+	URLIndex       int                 `json:"url_index"`
+	Type           string              `json:"type"`
+	Owner          string              `json:"owner"`
+	Repo           string              `json:"repo"`
+	Identifier     string              `json:"identifier"`
+	DisplayName    string              `json:"display_name"`
+	DisplayURL     string              `json:"display_url"`
+	Branch         string              `json:"branch,omitempty"`
+	HeadSHA        string              `json:"head_sha,omitempty"`
+	EarliestTime   *time.Time          `json:"earliest_time"`
+	CommitTime     *time.Time          `json:"commit_time"`
+	CommitPushedAt *time.Time          `json:"commit_pushed_at"`
+	MergedAt       *time.Time          `json:"merged_at"`
+	AllCommitRuns  ReportCommitRuns    `json:"all_commit_runs"`
+	Metrics        ReportMetrics       `json:"metrics"`
+	ReviewEvents   []ReportReviewEvent `json:"review_events"`
+	PendingJobs    []ReportPendingJob  `json:"pending_jobs"`
+	Lifecycle      *ReportLifecycle    `json:"lifecycle,omitempty"`
+	MergeGate      *ReportMergeGate    `json:"merge_gate,omitempty"`
+	WorkflowChains []ReportChain       `json:"workflow_chains,omitempty"`
+	Waste          *ReportWaste        `json:"waste,omitempty"`
+}
+
+// ReportLifecycle holds the pull request milestones and the time spent in
+// each stage between them, null when unknown.
+type ReportLifecycle struct {
+	Commit      *time.Time          `json:"commit"`
+	Opened      *time.Time          `json:"opened"`
+	FirstReview *time.Time          `json:"first_review"`
+	Approved    *time.Time          `json:"approved"`
+	ChecksDone  *time.Time          `json:"checks_done"`
+	Merged      *time.Time          `json:"merged"`
+	StagesMs    map[string]*float64 `json:"stages_ms"`
+}
+
+// ReportMergeGate is the required check that gated a pull request's merge.
+type ReportMergeGate struct {
+	Check         string     `json:"check"`
+	App           string     `json:"app"`
+	Source        string     `json:"source"`
+	URL           string     `json:"url"`
+	Status        string     `json:"status"`
+	Conclusion    string     `json:"conclusion"`
+	Started       *time.Time `json:"started"`
+	Completed     *time.Time `json:"completed"`
+	BeforeMergeMs *int64     `json:"before_merge_ms"`
+	Required      []string   `json:"required"`
+	Pending       []string   `json:"pending"`
+	Missing       []string   `json:"missing"`
+}
+
+// ReportChain is a set of workflow runs linked by workflow_run triggers.
+type ReportChain struct {
+	Name       string           `json:"name"`
+	Commit     *time.Time       `json:"commit"`
+	Start      *time.Time       `json:"start"`
+	End        *time.Time       `json:"end"`
+	EndToEndMs *int64           `json:"end_to_end_ms"`
+	Conclusion string           `json:"conclusion"`
+	Runs       []ReportChainRun `json:"runs"`
+}
+
+// ReportChainRun is one workflow run of a chain.
+type ReportChainRun struct {
+	RunID       int64      `json:"run_id"`
+	Name        string     `json:"name"`
+	URL         string     `json:"url"`
+	Event       string     `json:"event"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	Start       *time.Time `json:"start"`
+	End         *time.Time `json:"end"`
+	TriggeredBy *int64     `json:"triggered_by"`
+}
+
+// ReportWaste is the compute spent on runs and jobs whose results were thrown
+// away.
+type ReportWaste struct {
+	TotalMs         int64 `json:"total_ms"`
+	SupersededRuns  int   `json:"superseded_runs"`
+	SupersededMs    int64 `json:"superseded_ms"`
+	CancelledRuns   int   `json:"cancelled_runs"`
+	CancelledMs     int64 `json:"cancelled_ms"`
+	RetriedAttempts int   `json:"retried_attempts"`
+	RetriedMs       int64 `json:"retried_ms"`
+	TimedOutJobs    int   `json:"timed_out_jobs"`
+	TimedOutMs      int64 `json:"timed_out_ms"`
+	BillableMs      int64 `json:"billable_ms"`
+}
+
+// ReportCommitRuns counts every workflow run for the head commit, including
+// runs outside the analyzed PR or commit.
+type ReportCommitRuns struct {
+	Count     int   `json:"count"`
+	ComputeMs int64 `json:"compute_ms"`
+}
+
+// ReportMetrics is the per-URL metrics block.
+type ReportMetrics struct {
+	TotalRuns         int                  `json:"total_runs"`
+	SuccessfulRuns    int                  `json:"successful_runs"`
+	FailedRuns        int                  `json:"failed_runs"`
+	RetriedRuns       int                  `json:"retried_runs"`
+	SupersededRuns    int                  `json:"superseded_runs"`
+	TotalJobs         int                  `json:"total_jobs"`
+	FailedJobs        int                  `json:"failed_jobs"`
+	TotalSteps        int                  `json:"total_steps"`
+	FailedSteps       int                  `json:"failed_steps"`
+	SuccessRate       float64              `json:"success_rate"`
+	JobSuccessRate    float64              `json:"job_success_rate"`
+	RetryRate         float64              `json:"retry_rate"`
+	MaxConcurrency    int                  `json:"max_concurrency"`
+	TotalDurationMs   float64              `json:"total_duration_ms"`
+	AvgJobDurationMs  float64              `json:"avg_job_duration_ms"`
+	AvgStepDurationMs float64              `json:"avg_step_duration_ms"`
+	AvgQueueTimeMs    float64              `json:"avg_queue_time_ms"`
+	MaxQueueTimeMs    float64              `json:"max_queue_time_ms"`
+	AvgApprovalTimeMs float64              `json:"avg_approval_time_ms"`
+	MaxApprovalTimeMs float64              `json:"max_approval_time_ms"`
+	LongestJob        *ReportNamedDuration `json:"longest_job"`
+	ShortestJob       *ReportNamedDuration `json:"shortest_job"`
+	BillableMs        map[string]int64     `json:"billable_ms"`
+	Runners           []ReportRunner       `json:"runners"`
+	Jobs              []ReportJob          `json:"jobs"`
+	Steps             []ReportStep         `json:"steps"`
+}
+
+// ReportNamedDuration is a job name with its duration.
+type ReportNamedDuration struct {
+	Name       string  `json:"name"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// ReportRunner is the work done on one runner.
+type ReportRunner struct {
+	Name       string  `json:"name"`
+	Jobs       int     `json:"jobs"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// ReportJob is one job on the timeline.
+type ReportJob struct {
+	Name       string    `json:"name"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	DurationMs float64   `json:"duration_ms"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	URL        string    `json:"url"`
+	IsRequired bool      `json:"is_required"`
+	// Set on combined jobs only
+	URLIndex   *int   `json:"url_index,omitempty"`
+	SourceName string `json:"source_name,omitempty"`
+	SourceURL  string `json:"source_url,omitempty"`
+}
+
+// ReportStep is one step duration.
+type ReportStep struct {
+	Name       string  `json:"name"`
+	Job        string  `json:"job"`
+	DurationMs float64 `json:"duration_ms"`
+	URL        string  `json:"url"`
+}
+
+// ReportReviewEvent is a PR review, comment or merge.
+type ReportReviewEvent struct {
+	Type     string     `json:"type"`
+	State    string     `json:"state,omitempty"`
+	Time     *time.Time `json:"time"`
+	Reviewer string     `json:"reviewer,omitempty"`
+	MergedBy string     `json:"merged_by,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	PRNumber int        `json:"pr_number,omitempty"`
+	PRTitle  string     `json:"pr_title,omitempty"`
+}
+
+// ReportPendingJob is a job that had not finished when the data was fetched.
+type ReportPendingJob struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	StartedAt  string `json:"started_at,omitempty"`
+	URL        string `json:"url"`
+	IsRequired bool   `json:"is_required"`
+	SourceName string `json:"source_name,omitempty"`
+	SourceURL  string `json:"source_url,omitempty"`
+}
+
+// ReportNode is one span of the enriched tree.
+type ReportNode struct {
+	Name       string            `json:"name"`
+	SpanID     string            `json:"span_id,omitempty"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	URLIndex   int               `json:"url_index"`
+	Start      time.Time         `json:"start"`
+	End        time.Time         `json:"end"`
+	DurationMs float64           `json:"duration_ms"`
+	SelfMs     float64           `json:"self_ms"`
+	Hints      ReportHints       `json:"hints"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Resource   map[string]string `json:"resource,omitempty"`
+	Scope      *ReportScope      `json:"scope,omitempty"`
+	Events     []ReportEvent     `json:"events,omitempty"`
+	Links      []ReportLink      `json:"links,omitempty"`
+	IdleGaps   []ReportGap       `json:"idle_gaps,omitempty"`
+	Children   []ReportNode      `json:"children,omitempty"`
+}
+
+// ReportHints is the enrichment output for a span. Presentation-only hints
+// (icon, colour, bar character) are left out.
+type ReportHints struct {
+	Category    string `json:"category"`
+	Outcome     string `json:"outcome,omitempty"`
+	URL         string `json:"url,omitempty"`
+	User        string `json:"user,omitempty"`
+	EventType   string `json:"event_type,omitempty"`
+	IsRequired  bool   `json:"is_required,omitempty"`
+	IsMarker    bool   `json:"is_marker,omitempty"`
+	IsRoot      bool   `json:"is_root,omitempty"`
+	IsLeaf      bool   `json:"is_leaf,omitempty"`
+	VCSBranch   string `json:"vcs_branch,omitempty"`
+	VCSRevision string `json:"vcs_revision,omitempty"`
+	RunID       string `json:"run_id,omitempty"`
+	Detail      string `json:"detail,omitempty"`
+	ServiceName string `json:"service_name,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// ReportScope is the instrumentation scope of a span.
+type ReportScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ReportEvent is a span event.
+type ReportEvent struct {
+	Name       string            `json:"name"`
+	Time       time.Time         `json:"time"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// ReportLink is a link to another span.
+type ReportLink struct {
+	TraceID    string            `json:"trace_id"`
+	SpanID     string            `json:"span_id"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// ReportGap is an interval inside a span where none of its children ran.
+type ReportGap struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	DurationMs float64   `json:"duration_ms"`
+}
+
+// OutputJSON writes the analysis as a Report.
+func OutputJSON(w io.Writer, urlResults []analyzer.URLResult, combined analyzer.CombinedMetrics, globalEarliestTime, globalLatestTime int64, spans []trace.ReadOnlySpan, enricher enrichment.Enricher) error {
+	report := BuildReport(urlResults, combined, globalEarliestTime, globalLatestTime, spans, enricher)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// BuildReport assembles the Report for the given analysis.
+func BuildReport(urlResults []analyzer.URLResult, combined analyzer.CombinedMetrics, globalEarliestTime, globalLatestTime int64, spans []trace.ReadOnlySpan, enricher enrichment.Enricher) Report {
+	start := time.UnixMilli(globalEarliestTime)
+	end := time.UnixMilli(globalLatestTime)
+
+	report := Report{
+		SchemaVersion: ReportSchemaVersion,
+		Start:         start.UTC(),
+		End:           end.UTC(),
+		Combined: ReportCombined{
+			TotalRuns:      combined.TotalRuns,
+			TotalJobs:      combined.TotalJobs,
+			TotalSteps:     combined.TotalSteps,
+			SuccessRate:    parsePercent(combined.SuccessRate),
+			JobSuccessRate: parsePercent(combined.JobSuccessRate),
+			MaxConcurrency: combined.MaxConcurrency,
+			BillableMs:     map[string]int64{},
+			Jobs:           []ReportJob{},
+		},
+		Results:     []ReportResult{},
+		PendingJobs: []ReportPendingJob{},
+		Tree:        []ReportNode{},
+	}
+
+	for _, job := range combined.JobTimeline {
+		rj := reportJob(job.TimelineJob)
+		urlIndex := job.URLIndex
+		rj.URLIndex = &urlIndex
+		rj.SourceName = job.SourceName
+		rj.SourceURL = job.SourceURL
+		report.Combined.Jobs = append(report.Combined.Jobs, rj)
+	}
+
+	for _, result := range urlResults {
+		for os, ms := range result.Metrics.BillableMs {
+			report.Combined.BillableMs[os] += ms
+		}
+		report.Results = append(report.Results, reportResult(result))
+	}
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].URLIndex < report.Results[j].URLIndex
+	})
+
+	for _, job := range collectPending(urlResults) {
+		pj := reportPendingJob(job.PendingJob)
+		pj.SourceName = job.SourceName
+		pj.SourceURL = job.SourceURL
+		report.PendingJobs = append(report.PendingJobs, pj)
+	}
+
+	if len(spans) > 0 {
+		for _, root := range analyzer.BuildTreeFromSpans(spans, start, end, enricher) {
+			report.Tree = append(report.Tree, reportNode(root))
+		}
+	}
+	return report
+}
+
+func reportResult(result analyzer.URLResult) ReportResult {
+	m := result.Metrics
+	rr := ReportResult{
+		URLIndex:       result.URLIndex,
+		Type:           result.Type,
+		Owner:          result.Owner,
+		Repo:           result.Repo,
+		Identifier:     result.Identifier,
+		DisplayName:    result.DisplayName,
+		DisplayURL:     result.DisplayURL,
+		Branch:         result.BranchName,
+		HeadSHA:        result.HeadSHA,
+		CommitTime:     millisPtr(result.CommitTimeMs),
+		CommitPushedAt: millisPtr(result.CommitPushedAtMs),
+		MergedAt:       millisPtr(result.MergedAtMs),
+		AllCommitRuns: ReportCommitRuns{
+			Count:     result.AllCommitRunsCount,
+			ComputeMs: result.AllCommitRunsComputeMs,
+		},
+		Metrics: ReportMetrics{
+			TotalRuns:         m.TotalRuns,
+			SuccessfulRuns:    m.SuccessfulRuns,
+			FailedRuns:        m.FailedRuns,
+			RetriedRuns:       m.RetriedRuns,
+			SupersededRuns:    m.SupersededRuns,
+			TotalJobs:         m.TotalJobs,
+			FailedJobs:        m.FailedJobs,
+			TotalSteps:        m.TotalSteps,
+			FailedSteps:       m.FailedSteps,
+			SuccessRate:       parsePercent(m.SuccessRate),
+			JobSuccessRate:    parsePercent(m.JobSuccessRate),
+			RetryRate:         parsePercent(m.RetryRate),
+			MaxConcurrency:    m.MaxConcurrency,
+			TotalDurationMs:   m.TotalDuration,
+			AvgJobDurationMs:  m.AvgJobDuration,
+			AvgStepDurationMs: m.AvgStepDuration,
+			AvgQueueTimeMs:    m.AvgQueueTime,
+			MaxQueueTimeMs:    m.MaxQueueTime,
+			AvgApprovalTimeMs: m.AvgApprovalTime,
+			MaxApprovalTimeMs: m.MaxApprovalTime,
+			LongestJob:        namedDuration(m.LongestJob),
+			ShortestJob:       namedDuration(m.ShortestJob),
+			BillableMs:        map[string]int64{},
+			Runners:           []ReportRunner{},
+			Jobs:              []ReportJob{},
+			Steps:             []ReportStep{},
+		},
+		ReviewEvents: []ReportReviewEvent{},
+		PendingJobs:  []ReportPendingJob{},
+	}
+	if result.EarliestTime > 0 && result.EarliestTime != math.MaxInt64 {
+		rr.EarliestTime = millisPtr(&result.EarliestTime)
+	}
+
+	for os, ms := range m.BillableMs {
+		rr.Metrics.BillableMs[os] = ms
+	}
+	for name, count := range m.RunnerJobCounts {
+		rr.Metrics.Runners = append(rr.Metrics.Runners, ReportRunner{Name: name, Jobs: count, DurationMs: m.RunnerDurations[name]})
+	}
+	sort.Slice(rr.Metrics.Runners, func(i, j int) bool {
+		return rr.Metrics.Runners[i].Name < rr.Metrics.Runners[j].Name
+	})
+	for _, job := range m.JobTimeline {
+		rr.Metrics.Jobs = append(rr.Metrics.Jobs, reportJob(job))
+	}
+	for _, step := range m.StepDurations {
+		rr.Metrics.Steps = append(rr.Metrics.Steps, ReportStep{Name: step.Name, Job: step.JobName, DurationMs: step.Duration, URL: step.URL})
+	}
+	for _, event := range result.ReviewEvents {
+		rr.ReviewEvents = append(rr.ReviewEvents, ReportReviewEvent{
+			Type:     event.Type,
+			State:    event.State,
+			Time:     parseTimePtr(event.Time),
+			Reviewer: event.Reviewer,
+			MergedBy: event.MergedBy,
+			URL:      event.URL,
+			PRNumber: event.PRNumber,
+			PRTitle:  event.PRTitle,
+		})
+	}
+	for _, job := range m.PendingJobs {
+		rr.PendingJobs = append(rr.PendingJobs, reportPendingJob(job))
+	}
+	if l, ok := analyzer.LifecycleFromResult(result); ok {
+		rr.Lifecycle = reportLifecycle(l)
+	}
+	if result.MergeGate != nil {
+		rr.MergeGate = reportMergeGate(result.MergeGate)
+	}
+	for _, c := range result.WorkflowChains {
+		rr.WorkflowChains = append(rr.WorkflowChains, reportChain(c))
+	}
+	if waste := m.Waste; !waste.IsZero() {
+		rr.Waste = &ReportWaste{
+			TotalMs:         waste.TotalMs(),
+			SupersededRuns:  waste.SupersededRuns,
+			SupersededMs:    waste.SupersededMs,
+			CancelledRuns:   waste.CancelledRuns,
+			CancelledMs:     waste.CancelledMs,
+			RetriedAttempts: waste.RetriedAttempts,
+			RetriedMs:       waste.RetriedMs,
+			TimedOutJobs:    waste.TimedOutJobs,
+			TimedOutMs:      waste.TimedOutMs,
+			BillableMs:      waste.BillableMs,
+		}
+	}
+	return rr
+}
+
+func reportLifecycle(l analyzer.Lifecycle) *ReportLifecycle {
+	milestone := func(ms int64) *time.Time {
+		if ms == 0 {
+			return nil
+		}
+		return millisPtr(&ms)
+	}
+	rl := &ReportLifecycle{
+		Commit:      milestone(l.CommitMs),
+		Opened:      milestone(l.OpenedMs),
+		FirstReview: milestone(l.FirstReviewMs),
+		Approved:    milestone(l.ApprovedMs),
+		ChecksDone:  milestone(l.ChecksDoneMs),
+		Merged:      milestone(l.MergedMs),
+		StagesMs:    map[string]*float64{},
+	}
+	for _, s := range l.Stages() {
+		rl.StagesMs[s.Key] = nil
+		if s.Known {
+			d := s.DurationMs
+			rl.StagesMs[s.Key] = &d
+		}
+	}
+	return rl
+}
+
+func reportMergeGate(g *analyzer.MergeGate) *ReportMergeGate {
+	milestone := func(ms int64) *time.Time {
+		if ms == 0 {
+			return nil
+		}
+		return millisPtr(&ms)
+	}
+	rg := &ReportMergeGate{
+		Check:      g.Check,
+		App:        g.App,
+		Source:     g.Source,
+		URL:        g.URL,
+		Status:     g.Status,
+		Conclusion: g.Conclusion,
+		Started:    milestone(g.StartedMs),
+		Completed:  milestone(g.CompletedMs),
+		Required:   append([]string{}, g.Required...),
+		Pending:    append([]string{}, g.Pending...),
+		Missing:    append([]string{}, g.Missing...),
+	}
+	if slack := g.SlackMs(); slack >= 0 {
+		rg.BeforeMergeMs = &slack
+	}
+	return rg
+}
+
+func reportChain(c analyzer.WorkflowChain) ReportChain {
+	milestone := func(ms int64) *time.Time {
+		if ms == 0 {
+			return nil
+		}
+		return millisPtr(&ms)
+	}
+	rc := ReportChain{
+		Name:       c.Name(),
+		Commit:     milestone(c.CommitMs),
+		Start:      milestone(c.StartMs()),
+		End:        milestone(c.EndMs()),
+		Conclusion: c.Conclusion(),
+		Runs:       []ReportChainRun{},
+	}
+	if d := c.EndToEndMs(); d >= 0 {
+		rc.EndToEndMs = &d
+	}
+	for _, r := range c.Runs {
+		run := ReportChainRun{
+			RunID:      r.RunID,
+			Name:       r.Name,
+			URL:        r.URL,
+			Event:      r.Event,
+			Status:     r.Status,
+			Conclusion: r.Conclusion,
+			Start:      milestone(r.StartMs),
+			End:        milestone(r.EndMs),
+		}
+		if r.TriggeredBy != 0 {
+			triggeredBy := r.TriggeredBy
+			run.TriggeredBy = &triggeredBy
+		}
+		rc.Runs = append(rc.Runs, run)
+	}
+	return rc
+}
+
+func reportJob(job analyzer.TimelineJob) ReportJob {
+	return ReportJob{
+		Name:       job.Name,
+		Start:      time.UnixMilli(job.StartTime).UTC(),
+		End:        time.UnixMilli(job.EndTime).UTC(),
+		DurationMs: float64(job.EndTime - job.StartTime),
+		Status:     job.Status,
+		Conclusion: job.Conclusion,
+		URL:        job.URL,
+		IsRequired: job.IsRequired,
+	}
+}
+
+func reportPendingJob(job analyzer.PendingJob) ReportPendingJob {
+	return ReportPendingJob{
+		Name:       job.Name,
+		Status:     job.Status,
+		StartedAt:  job.StartedAt,
+		URL:        job.URL,
+		IsRequired: job.IsRequired,
+	}
+}
+
+func reportNode(n *analyzer.TreeNode) ReportNode {
+	node := ReportNode{
+		Name:       n.Name,
+		SpanID:     n.SpanID,
+		TraceID:    n.TraceID,
+		URLIndex:   n.URLIndex,
+		Start:      n.StartTime.UTC(),
+		End:        n.EndTime.UTC(),
+		DurationMs: millis(n.Duration()),
+		SelfMs:     millis(n.Self),
+		Hints: ReportHints{
+			Category:    n.Hints.Category,
+			Outcome:     n.Hints.Outcome,
+			URL:         n.Hints.URL,
+			User:        n.Hints.User,
+			EventType:   n.Hints.EventType,
+			IsRequired:  n.Hints.IsRequired,
+			IsMarker:    n.Hints.IsMarker,
+			IsRoot:      n.Hints.IsRoot,
+			IsLeaf:      n.Hints.IsLeaf,
+			VCSBranch:   n.Hints.VCSBranch,
+			VCSRevision: n.Hints.VCSRevision,
+			RunID:       n.Hints.RunID,
+			Detail:      n.Hints.Detail,
+			ServiceName: n.Hints.ServiceName,
+			Environment: n.Hints.Environment,
+		},
+		Attributes: n.Attrs,
+		Resource:   n.ResourceAttrs,
+	}
+	if len(node.Attributes) == 0 {
+		node.Attributes = nil
+	}
+	if len(node.Resource) == 0 {
+		node.Resource = nil
+	}
+	if n.ScopeName != "" {
+		node.Scope = &ReportScope{Name: n.ScopeName, Version: n.ScopeVersion}
+	}
+	for _, e := range n.Events {
+		node.Events = append(node.Events, ReportEvent{Name: e.Name, Time: e.Time.UTC(), Attributes: nonEmpty(e.Attrs)})
+	}
+	for _, l := range n.Links {
+		node.Links = append(node.Links, ReportLink{TraceID: l.TraceID, SpanID: l.SpanID, Attributes: nonEmpty(l.Attrs)})
+	}
+	for _, g := range n.Gaps {
+		node.IdleGaps = append(node.IdleGaps, ReportGap{Start: g.Start.UTC(), End: g.End.UTC(), DurationMs: millis(g.Duration())})
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, reportNode(child))
+	}
+	return node
+}
+
+// namedDuration returns nil for the unset longest/shortest job, whose
+// duration is 0 or +Inf.
+func namedDuration(job analyzer.JobDuration) *ReportNamedDuration {
+	if job.Name == "" || math.IsInf(job.Duration, 0) {
+		return nil
+	}
+	return &ReportNamedDuration{Name: job.Name, DurationMs: job.Duration}
+}
+
+func parsePercent(value string) float64 {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func parseTimePtr(value string) *time.Time {
+	t, ok := utils.ParseTime(value)
+	if !ok {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+func millisPtr(ms *int64) *time.Time {
+	if ms == nil {
+		return nil
+	}
+	t := time.UnixMilli(*ms).UTC()
+	return &t
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func nonEmpty(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,296 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// reportTestInput returns a fixed single-PR analysis: one workflow run with
+// a build job (two steps) and a test job, one review and one pending job. The
+// PR was opened an hour before the run and build gated its merge.
+func reportTestInput() ([]analyzer.URLResult, analyzer.CombinedMetrics, int64, int64, []sdktrace.ReadOnlySpan) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return base.Add(time.Duration(s) * time.Second) }
+	ms := func(s int) int64 { return at(s).UnixMilli() }
+	merged := ms(600)
+	opened := ms(-3600)
+
+	metrics := analyzer.InitializeMetrics()
+	metrics.TotalRuns = 1
+	metrics.SuccessfulRuns = 1
+	metrics.TotalJobs = 2
+	metrics.FailedJobs = 1
+	metrics.TotalSteps = 2
+	metrics.TotalDuration = 300000
+	metrics.JobDurations = []float64{120000, 150000}
+	metrics.JobNames = []string{"build", "test"}
+	metrics.StepDurations = []analyzer.StepDuration{
+		{Name: "✅ Checkout", Duration: 10000, URL: "https://github.com/o/r/actions/runs/1/job/2#step:1", JobName: "build"},
+		{Name: "✅ Compile", Duration: 100000, URL: "https://github.com/o/r/actions/runs/1/job/2#step:2", JobName: "build"},
+	}
+	metrics.RunnerJobCounts = map[string]int{"runner-b": 1, "runner-a": 1}
+	metrics.RunnerDurations = map[string]float64{"runner-b": 150000, "runner-a": 120000}
+	metrics.QueueTimes = []float64{5000}
+	metrics.BillableMs = map[string]int64{"UBUNTU": 300000}
+	metrics.LongestJob = analyzer.JobDuration{Name: "test", Duration: 150000}
+	metrics.ShortestJob = analyzer.JobDuration{Name: "build", Duration: 120000}
+	metrics.JobTimeline = []analyzer.TimelineJob{
+		{Name: "build", StartTime: ms(10), EndTime: ms(130), Status: "completed", Conclusion: "success", URL: "https://github.com/o/r/actions/runs/1/job/2", IsRequired: true},
+		{Name: "test", StartTime: ms(140), EndTime: ms(290), Status: "completed", Conclusion: "failure", URL: "https://github.com/o/r/actions/runs/1/job/3"},
+	}
+	metrics.PendingJobs = []analyzer.PendingJob{{Name: "deploy", Status: "queued", URL: "https://github.com/o/r/actions/runs/1/job/4"}}
+
+	events := []analyzer.JobEvent{{Ts: ms(10), Type: "start"}, {Ts: ms(140), Type: "start"}}
+	ends := []analyzer.JobEvent{{Ts: ms(130), Type: "end"}, {Ts: ms(290), Type: "end"}}
+	final := analyzer.CalculateFinalMetrics(metrics, 1, events, ends)
+
+	results := []analyzer.URLResult{{
+		Owner:        "o",
+		Repo:         "r",
+		Identifier:   "42",
+		Type:         "pr",
+		BranchName:   "feature",
+		HeadSHA:      "abc123",
+		DisplayName:  "PR #42",
+		DisplayURL:   "https://github.com/o/r/pull/42",
+		Metrics:      final,
+		EarliestTime: ms(0),
+		MergedAtMs:   &merged,
+		OpenedAtMs:   &opened,
+		MergeGate: &analyzer.MergeGate{
+			Check:       "build",
+			App:         "github-actions",
+			Source:      analyzer.RequiredCheckSourceProtection,
+			URL:         "https://github.com/o/r/actions/runs/1/job/2",
+			Status:      "completed",
+			Conclusion:  "success",
+			StartedMs:   ms(10),
+			CompletedMs: ms(130),
+			MergedMs:    merged,
+			Required:    []string{"build"},
+		},
+		WorkflowChains: []analyzer.WorkflowChain{{
+			ID: 1,
+			Runs: []analyzer.ChainRun{
+				{RunID: 1, RunAttempt: 1, Name: "CI", URL: "https://github.com/o/r/actions/runs/1", Event: "pull_request", Status: "completed", Conclusion: "success", StartMs: ms(0), EndMs: ms(290)},
+				{RunID: 5, RunAttempt: 1, Name: "Deploy preview", URL: "https://github.com/o/r/actions/runs/5", Event: "workflow_run", Status: "completed", Conclusion: "success", StartMs: ms(295), EndMs: ms(400), TriggeredBy: 1},
+			},
+		}},
+		ReviewEvents: []analyzer.ReviewEvent{
+			{Type: "review", State: "APPROVED", Time: base.Add(500 * time.Second).Format(time.RFC3339), Reviewer: "alice", URL: "https://github.com/o/r/pull/42#review"},
+		},
+		AllCommitRunsCount:     3,
+		AllCommitRunsComputeMs: 450000,
+	}}
+	combined := analyzer.CalculateCombinedMetrics(results, 1, events, ends)
+
+	spans := tracetest.SpanStubs{
+		makeSpanStub("CI", 1, 0, at(0), at(300), attribute.String("type", "workflow"), attribute.String("github.conclusion", "failure")),
+		makeSpanStub("build", 2, 1, at(10), at(130), attribute.String("type", "job"), attribute.String("github.conclusion", "success")),
+		makeSpanStub("Checkout", 3, 2, at(10), at(20), attribute.String("type", "step"), attribute.String("github.conclusion", "success")),
+		makeSpanStub("Compile", 4, 2, at(30), at(130), attribute.String("type", "step"), attribute.String("github.conclusion", "success")),
+		makeSpanStub("test", 5, 1, at(140), at(290), attribute.String("type", "job"), attribute.String("github.conclusion", "failure")),
+	}.Snapshots()
+
+	return results, combined, ms(0), ms(300), spans
+}
+
+func TestOutputJSONGolden(t *testing.T) {
+	t.Parallel()
+
+	results, combined, start, end, spans := reportTestInput()
+	var buf bytes.Buffer
+	assert.NoError(t, OutputJSON(&buf, results, combined, start, end, spans, enrichment.DefaultEnricher()))
+
+	golden := filepath.Join("testdata", "report.golden.json")
+	if *update {
+		assert.NoError(t, os.WriteFile(golden, buf.Bytes(), 0644))
+	}
+	want, err := os.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), buf.String(), "report changed; if intended, bump ReportSchemaVersion when fields were removed or retyped and rerun with -update")
+}
+
+func TestOutputJSONMatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	var schema map[string]any
+	assert.NoError(t, json.Unmarshal(ReportSchema, &schema))
+	assert.Equal(t, float64(ReportSchemaVersion), schema["properties"].(map[string]any)["schema_version"].(map[string]any)["const"])
+
+	t.Run("full report", func(t *testing.T) {
+		results, combined, start, end, spans := reportTestInput()
+		var buf bytes.Buffer
+		assert.NoError(t, OutputJSON(&buf, results, combined, start, end, spans, enrichment.DefaultEnricher()))
+		var doc any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		assert.Empty(t, validateSchema(schema, schema, doc, "$"))
+	})
+
+	t.Run("empty analysis", func(t *testing.T) {
+		metrics := analyzer.CalculateFinalMetrics(analyzer.InitializeMetrics(), 0, nil, nil)
+		results := []analyzer.URLResult{{Type: "commit", Metrics: metrics}}
+		var buf bytes.Buffer
+		assert.NoError(t, OutputJSON(&buf, results, analyzer.CombinedMetrics{SuccessRate: "0", JobSuccessRate: "0"}, 0, 0, nil, enrichment.DefaultEnricher()))
+		var doc any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		assert.Empty(t, validateSchema(schema, schema, doc, "$"))
+	})
+}
+
+func TestBuildReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset shortest job is null rather than +Inf", func(t *testing.T) {
+		metrics := analyzer.CalculateFinalMetrics(analyzer.InitializeMetrics(), 0, nil, nil)
+		assert.True(t, math.IsInf(metrics.ShortestJob.Duration, 1))
+
+		report := BuildReport([]analyzer.URLResult{{Metrics: metrics}}, analyzer.CombinedMetrics{}, 0, 0, nil, enrichment.DefaultEnricher())
+		assert.Nil(t, report.Results[0].Metrics.ShortestJob)
+		assert.Nil(t, report.Results[0].Metrics.LongestJob)
+	})
+
+	t.Run("billable time is summed across inputs", func(t *testing.T) {
+		a := analyzer.URLResult{URLIndex: 1, Metrics: analyzer.FinalMetrics{Metrics: analyzer.Metrics{BillableMs: map[string]int64{"UBUNTU": 100}}}}
+		b := analyzer.URLResult{URLIndex: 0, Metrics: analyzer.FinalMetrics{Metrics: analyzer.Metrics{BillableMs: map[string]int64{"UBUNTU": 50, "MACOS": 10}}}}
+
+		report := BuildReport([]analyzer.URLResult{a, b}, analyzer.CombinedMetrics{}, 0, 0, nil, enrichment.DefaultEnricher())
+		assert.Equal(t, map[string]int64{"UBUNTU": 150, "MACOS": 10}, report.Combined.BillableMs)
+		assert.Equal(t, 0, report.Results[0].URLIndex)
+	})
+}
+
+// validateSchema checks doc against the subset of JSON Schema used by
+// report.schema.json and returns one message per violation.
+func validateSchema(root, schema map[string]any, doc any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")]
+		if def == nil {
+			return []string{fmt.Sprintf("%s: unknown $ref %s", path, ref)}
+		}
+		return validateSchema(root, def.(map[string]any), doc, path)
+	}
+
+	var errs []string
+	if c, ok := schema["const"]; ok && c != doc {
+		errs = append(errs, fmt.Sprintf("%s: want %v, got %v", path, c, doc))
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, v := range enum {
+			found = found || v == doc
+		}
+		if !found {
+			errs = append(errs, fmt.Sprintf("%s: %v not in %v", path, doc, enum))
+		}
+	}
+	if typ, ok := schema["type"]; ok && !schemaTypeMatches(typ, doc) {
+		return append(errs, fmt.Sprintf("%s: want type %v, got %T", path, typ, doc))
+	}
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if n, ok := doc.(float64); ok && n < minimum {
+			errs = append(errs, fmt.Sprintf("%s: %v below minimum %v", path, n, minimum))
+		}
+	}
+
+	switch v := doc.(type) {
+	case map[string]any:
+		for _, req := range asSlice(schema["required"]) {
+			if _, ok := v[req.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing %s", path, req))
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := props[k]; ok {
+				errs = append(errs, validateSchema(root, prop.(map[string]any), v[k], path+"."+k)...)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					errs = append(errs, fmt.Sprintf("%s: unexpected field %s", path, k))
+				}
+			case map[string]any:
+				errs = append(errs, validateSchema(root, extra, v[k], path+"."+k)...)
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				errs = append(errs, validateSchema(root, items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return errs
+}
+
+func schemaTypeMatches(typ any, doc any) bool {
+	for _, t := range asSlice(typ) {
+		switch t {
+		case "object":
+			if _, ok := doc.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := doc.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := doc.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := doc.(bool); ok {
+				return true
+			}
+		case "null":
+			if doc == nil {
+				return true
+			}
+		case "number":
+			if _, ok := doc.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := doc.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func asSlice(v any) []any {
+	switch s := v.(type) {
+	case []any:
+		return s
+	case nil:
+		return nil
+	default:
+		return []any{s}
+	}
+}
@@ -0,0 +1,159 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// lifecycleSlowestLimit caps the pull requests listed by OutputLifecycle.
+const lifecycleSlowestLimit = 10
+
+// resultLifecycles returns the PR lifecycles of the results that have one.
+func resultLifecycles(urlResults []analyzer.URLResult) []analyzer.Lifecycle {
+	var lifecycles []analyzer.Lifecycle
+	for _, result := range urlResults {
+		if l, ok := analyzer.LifecycleFromResult(result); ok {
+			lifecycles = append(lifecycles, l)
+		}
+	}
+	return lifecycles
+}
+
+// stageDuration formats a lifecycle stage, "—" when unknown.
+func stageDuration(s analyzer.LifecycleStage) string {
+	if !s.Known {
+		return "—"
+	}
+	return utils.HumanizeTime(s.DurationMs / 1000)
+}
+
+// writeStyledLifecycle prints the Lifecycle section of the styled report.
+func writeStyledLifecycle(w io.Writer, lifecycles []analyzer.Lifecycle) {
+	styledSection(w, "Lifecycle")
+	for _, l := range lifecycles {
+		fmt.Fprintf(w, "\n  %s\n", subheaderStyle.Render(utils.MakeClickableLink(l.URL, l.Name)))
+		for _, s := range l.Stages() {
+			fmt.Fprintf(w, "    %s %s\n",
+				labelStyle.Render(fmt.Sprintf("%-24s", s.Name)),
+				numStyle.Render(stageDuration(s)))
+		}
+	}
+}
+
+// writeMarkdownLifecycle prints the Lifecycle table of the markdown report.
+func writeMarkdownLifecycle(w io.Writer, lifecycles []analyzer.Lifecycle) {
+	fmt.Fprintln(w, "## Lifecycle")
+	fmt.Fprintln(w, "")
+	header := []string{"PR"}
+	align := []string{"---"}
+	for _, key := range analyzer.LifecycleStageKeys {
+		header = append(header, analyzer.LifecycleStageNames[key])
+		align = append(align, "---:")
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(w, "| %s |\n", strings.Join(align, " | "))
+	for _, l := range lifecycles {
+		row := []string{markdownLink(l.URL, l.Name)}
+		for _, s := range l.Stages() {
+			row = append(row, stageDuration(s))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | "))
+	}
+	fmt.Fprintln(w, "")
+}
+
+// OutputLifecycle displays the lifecycle distributions of a repository's
+// merged pull requests.
+func OutputLifecycle(w io.Writer, analysis *analyzer.LifecycleAnalysis, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(analysis)
+	}
+
+	width := 80
+	contentWidth := width - 4
+	headerLine := func(content string) string {
+		pad := contentWidth - lipgloss.Width(content)
+		if pad < 0 {
+			pad = 0
+		}
+		return borderStyle.Render("│") + " " + content + strings.Repeat(" ", pad) + " " + borderStyle.Render("│")
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, borderStyle.Render("╭"+strings.Repeat("─", width-2)+"╮"))
+	fmt.Fprintln(w, headerLine(titleStyle.Render(fmt.Sprintf("PR Lifecycle: %s/%s", analysis.Owner, analysis.Repo))))
+	periodText := labelStyle.Render("Period: ") +
+		valueStyle.Render(fmt.Sprintf("%s to %s",
+			analysis.TimeRange.Start.Format("Jan 02, 2006"),
+			analysis.TimeRange.End.Format("Jan 02, 2006"))) +
+		labelStyle.Render(fmt.Sprintf(" (%d days)", analysis.TimeRange.Days))
+	fmt.Fprintln(w, headerLine(periodText))
+	prText := labelStyle.Render("Merged PRs: ") + numStyle.Render(fmt.Sprintf("%d", len(analysis.PullRequests)))
+	if analysis.Base != "" {
+		prText += labelStyle.Render(" into ") + valueStyle.Render(analysis.Base)
+	}
+	fmt.Fprintln(w, headerLine(prText))
+	fmt.Fprintln(w, borderStyle.Render("╰"+strings.Repeat("─", width-2)+"╯"))
+
+	if len(analysis.PullRequests) == 0 {
+		return nil
+	}
+
+	trendSection(w, "Stage Distributions")
+	fmt.Fprintln(w)
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col == 0 {
+				return lipgloss.NewStyle()
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers("Stage", "PRs", "p50", "p75", "p90", "Mean", "Max")
+	humanize := func(ms float64) string { return utils.HumanizeTime(ms / 1000) }
+	for _, s := range analysis.Stages {
+		if s.Count == 0 {
+			t.Row(s.Name, "0", "—", "—", "—", "—", "—")
+			continue
+		}
+		t.Row(s.Name, fmt.Sprintf("%d", s.Count), humanize(s.P50Ms), humanize(s.P75Ms), humanize(s.P90Ms), humanize(s.MeanMs), humanize(s.MaxMs))
+	}
+	fmt.Fprintln(w, t.Render())
+
+	trendSection(w, "Slowest Lead Times")
+	fmt.Fprintln(w)
+	slowest := analysis.PullRequests
+	if len(slowest) > lifecycleSlowestLimit {
+		slowest = slowest[:lifecycleSlowestLimit]
+	}
+	for i, l := range slowest {
+		var parts []string
+		for _, s := range l.Stages() {
+			if s.Key != analyzer.StageLeadTime && s.Known {
+				parts = append(parts, fmt.Sprintf("%s %s", strings.ToLower(s.Name), stageDuration(s)))
+			}
+		}
+		if l.GateCheck != "" {
+			parts = append(parts, "gated by "+l.GateCheck)
+		}
+		fmt.Fprintf(w, "  %s  %s %s  %s\n",
+			dimStyle.Render(fmt.Sprintf("%2d.", i+1)),
+			numStyle.Render(stageDuration(l.Stage(analyzer.StageLeadTime))),
+			valueStyle.Render(utils.MakeClickableLink(l.URL, l.Name)),
+			dimStyle.Render(strings.Join(parts, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleSections(t *testing.T) {
+	t.Parallel()
+
+	results, combined, start, end, spans := reportTestInput()
+
+	var md bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&md, results, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, md.String(), "## Lifecycle")
+	assert.Contains(t, md.String(), "| PR | Time to first review | Review to approval | Approval to merge | CI wait blocking merge | Commit to merge |")
+	assert.Contains(t, md.String(), "| [PR #42](https://github.com/o/r/pull/42) | 1h 8m 20s | 0s | 1m 40s | 0s | 1h 10m |")
+
+	var styled bytes.Buffer
+	assert.NoError(t, OutputStyledResults(&styled, results, combined, nil, start, end, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, styled.String(), "Lifecycle")
+	assert.Contains(t, styled.String(), "CI wait blocking merge")
+
+	run := results[0]
+	run.Type = "run"
+	var none bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&none, []analyzer.URLResult{run}, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.NotContains(t, none.String(), "## Lifecycle")
+}
+
+func TestOutputLifecycle(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	prs := []analyzer.Lifecycle{
+		{Name: "PR #2", URL: "https://github.com/o/r/pull/2", OpenedMs: base.UnixMilli(), MergedMs: base.Add(26 * time.Hour).UnixMilli()},
+		{Name: "PR #1", URL: "https://github.com/o/r/pull/1", OpenedMs: base.UnixMilli(), FirstReviewMs: base.Add(time.Hour).UnixMilli(), MergedMs: base.Add(2 * time.Hour).UnixMilli(), GateCheck: "build"},
+	}
+	analysis := &analyzer.LifecycleAnalysis{
+		Owner:        "o",
+		Repo:         "r",
+		TimeRange:    analyzer.TimeRange{Start: base.AddDate(0, 0, -30), End: base, Days: 30},
+		PullRequests: prs,
+		Stages:       analyzer.SummarizeLifecycles(prs),
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, OutputLifecycle(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "PR Lifecycle: o/r")
+	assert.Contains(t, out, "Stage Distributions")
+	assert.Contains(t, out, "Commit to merge")
+	assert.Contains(t, out, "26h")
+	assert.Contains(t, out, "time to first review 1h")
+	assert.Contains(t, out, "gated by build")
+
+	buf.Reset()
+	assert.NoError(t, OutputLifecycle(&buf, analysis, "json"))
+	var decoded analyzer.LifecycleAnalysis
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.PullRequests, 2)
+	assert.Equal(t, 2, decoded.Stages[len(decoded.Stages)-1].Count)
+}
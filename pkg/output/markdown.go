@@ -97,6 +97,22 @@ func OutputCombinedResultsMarkdown(w io.Writer, urlResults []analyzer.URLResult,
 		fmt.Fprintln(w, "")
 	}
 
+	if lifecycles := resultLifecycles(urlResults); len(lifecycles) > 0 {
+		writeMarkdownLifecycle(w, lifecycles)
+	}
+
+	if gated := resultMergeGates(urlResults); len(gated) > 0 {
+		writeMarkdownMergeGates(w, gated)
+	}
+
+	if chained := resultChains(urlResults); len(chained) > 0 {
+		writeMarkdownChains(w, chained)
+	}
+
+	if wasted := resultWastes(urlResults); len(wasted) > 0 {
+		writeMarkdownWaste(w, wasted)
+	}
+
 	if len(urlResults) > 0 {
 		fmt.Fprintln(w, "## Slowest Jobs")
 		fmt.Fprintln(w, "")
@@ -142,6 +158,50 @@ func OutputCombinedResultsMarkdown(w io.Writer, urlResults []analyzer.URLResult,
 		}
 	}
 
+	if idle := idleNodes(spans, globalEarliestTime, globalLatestTime, enricher); len(idle) > 0 {
+		fmt.Fprintln(w, "## Idle Gaps")
+		fmt.Fprintln(w, "")
+		fmt.Fprintln(w, "| Span | Duration | Self | Gaps | Largest gap |")
+		fmt.Fprintln(w, "| --- | ---: | ---: | ---: | ---: |")
+		for _, node := range idle {
+			gap, _ := node.LargestGap()
+			fmt.Fprintf(w, "| %s | %s | %s | %d | %s at +%s |\n",
+				node.Name,
+				utils.HumanizeTime(node.Duration().Seconds()),
+				utils.HumanizeTime(node.Self.Seconds()),
+				len(node.Gaps),
+				utils.HumanizeTime(gap.Duration().Seconds()),
+				utils.HumanizeTime(gap.Start.Sub(node.StartTime).Seconds()),
+			)
+		}
+		fmt.Fprintln(w, "")
+	}
+
+	if ranked := baselineNodes(spans, globalEarliestTime, globalLatestTime, enricher); len(ranked) > 0 {
+		rank, _ := analyzer.BaselineRankFromAttrs(ranked[0].Attrs)
+		fmt.Fprintf(w, "## Baseline vs `%s`\n", rank.Branch)
+		fmt.Fprintln(w, "")
+		fmt.Fprintln(w, "| Span | Duration | Percentile | Baseline p50 | Baseline p95 | Runs | Outlier |")
+		fmt.Fprintln(w, "| --- | ---: | ---: | ---: | ---: | ---: | :---: |")
+		for _, node := range ranked {
+			rank, _ := analyzer.BaselineRankFromAttrs(node.Attrs)
+			outlier := ""
+			if rank.Outlier {
+				outlier = "⚠️"
+			}
+			fmt.Fprintf(w, "| %s | %s | p%.0f | %s | %s | %d | %s |\n",
+				node.Name,
+				utils.HumanizeTime(node.Duration().Seconds()),
+				rank.Percentile,
+				utils.HumanizeTime(rank.P50().Seconds()),
+				utils.HumanizeTime(rank.P95().Seconds()),
+				rank.Samples,
+				outlier,
+			)
+		}
+		fmt.Fprintln(w, "")
+	}
+
 	// Commit aggregates
 	commitAggregates := []CommitAggregate{}
 	for _, result := range urlResults {
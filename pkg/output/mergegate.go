@@ -0,0 +1,103 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// resultMergeGates returns the results that have a merge gate.
+func resultMergeGates(urlResults []analyzer.URLResult) []analyzer.URLResult {
+	var gated []analyzer.URLResult
+	for _, result := range urlResults {
+		if result.MergeGate != nil {
+			gated = append(gated, result)
+		}
+	}
+	return gated
+}
+
+// mergeGateTiming describes when the gate finished relative to the merge.
+func mergeGateTiming(g *analyzer.MergeGate) string {
+	switch {
+	case g.IsPending():
+		return "still running"
+	case g.MergedMs == 0:
+		return "finished, not merged"
+	}
+	return utils.HumanizeTime(float64(g.SlackMs())/1000) + " before merge"
+}
+
+// mergeGateSource names where the gate check is required.
+func mergeGateSource(g *analyzer.MergeGate) string {
+	switch g.Source {
+	case analyzer.RequiredCheckSourceProtection:
+		return "branch protection"
+	case analyzer.RequiredCheckSourceRuleset:
+		return "ruleset"
+	}
+	return "no required checks"
+}
+
+// writeStyledMergeGates prints the Merge Gate section of the styled report.
+func writeStyledMergeGates(w io.Writer, results []analyzer.URLResult) {
+	styledSection(w, "Merge Gate")
+	for _, result := range results {
+		g := result.MergeGate
+		check := g.Check
+		if g.App != "" {
+			check += " (" + g.App + ")"
+		}
+		link := g.URL
+		if link == "" {
+			link = result.DisplayURL
+		}
+		duration := "—"
+		if !g.IsPending() && g.StartedMs != 0 {
+			duration = utils.HumanizeTime(float64(g.CompletedMs-g.StartedMs) / 1000)
+		}
+		fmt.Fprintf(w, "  %s %s  %s  %s %s\n",
+			dimStyle.Render(fmt.Sprintf("[%d]", result.URLIndex+1)),
+			valueStyle.Render(utils.MakeClickableLink(link, check)),
+			numStyle.Render(duration),
+			labelStyle.Render(mergeGateTiming(g)),
+			dimStyle.Render(fmt.Sprintf("· %d required via %s", len(g.Required), mergeGateSource(g))))
+		if len(g.Missing) > 0 {
+			fmt.Fprintf(w, "      %s %s\n", labelStyle.Render("not reported:"), valueStyle.Render(strings.Join(g.Missing, ", ")))
+		}
+	}
+}
+
+// writeMarkdownMergeGates prints the Merge Gate table of the markdown report.
+func writeMarkdownMergeGates(w io.Writer, results []analyzer.URLResult) {
+	fmt.Fprintln(w, "## Merge Gate")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "| PR | Gate check | App | Required via | Finished | Not reported |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | ---: | --- |")
+	for _, result := range results {
+		g := result.MergeGate
+		check := g.Check
+		if g.URL != "" {
+			check = markdownLink(g.URL, g.Check)
+		}
+		app := "—"
+		if g.App != "" {
+			app = g.App
+		}
+		missing := "—"
+		if len(g.Missing) > 0 {
+			missing = strings.Join(g.Missing, ", ")
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n",
+			markdownLink(result.DisplayURL, result.DisplayName),
+			check,
+			app,
+			mergeGateSource(g),
+			mergeGateTiming(g),
+			missing)
+	}
+	fmt.Fprintln(w, "")
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeGateSections(t *testing.T) {
+	t.Parallel()
+
+	results, combined, start, end, spans := reportTestInput()
+
+	var md bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&md, results, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, md.String(), "## Merge Gate")
+	assert.Contains(t, md.String(), "| [PR #42](https://github.com/o/r/pull/42) | [build](https://github.com/o/r/actions/runs/1/job/2) | github-actions | branch protection | 7m 50s before merge | — |")
+
+	var styled bytes.Buffer
+	assert.NoError(t, OutputStyledResults(&styled, results, combined, nil, start, end, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, styled.String(), "Merge Gate")
+	assert.Contains(t, styled.String(), "7m 50s before merge")
+	assert.Contains(t, styled.String(), "1 required via branch protection")
+
+	pending := results[0]
+	pending.MergeGate = &analyzer.MergeGate{Check: "e2e", StartedMs: 1, Required: []string{"build", "e2e", "docs"}, Pending: []string{"e2e"}, Missing: []string{"docs"}}
+	var open bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&open, []analyzer.URLResult{pending}, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, open.String(), "| [PR #42](https://github.com/o/r/pull/42) | e2e | — | no required checks | still running | docs |")
+
+	pending.MergeGate = nil
+	var none bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&none, []analyzer.URLResult{pending}, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.NotContains(t, none.String(), "## Merge Gate")
+}
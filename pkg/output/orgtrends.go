@@ -0,0 +1,190 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// orgRepoLimit caps the repositories listed by OutputOrgTrends.
+const orgRepoLimit = 20
+
+// OutputOrgTrends displays the trends of the repositories of an organization
+// or of a list of repositories.
+func OutputOrgTrends(w io.Writer, analysis *analyzer.OrgTrendAnalysis, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(analysis)
+	}
+
+	title := "Organization Trend Analysis"
+	if analysis.Org != "" {
+		title += ": " + analysis.Org
+	}
+	trendSection(w, title)
+	fmt.Fprintf(w, "  %s %s %s\n",
+		labelStyle.Render("Period:"),
+		valueStyle.Render(fmt.Sprintf("%s to %s",
+			analysis.TimeRange.Start.Format("Jan 02, 2006"),
+			analysis.TimeRange.End.Format("Jan 02, 2006"))),
+		labelStyle.Render(fmt.Sprintf("(%d days)", analysis.TimeRange.Days)))
+	totalRuns, totalHours := 0, 0.0
+	for _, r := range analysis.Repos {
+		totalRuns += r.TotalRuns
+		totalHours += r.ComputeHours
+	}
+	fmt.Fprintf(w, "  %s %s %s\n",
+		labelStyle.Render("Repositories:"),
+		numStyle.Render(fmt.Sprintf("%d", len(analysis.Repos))),
+		dimStyle.Render(fmt.Sprintf("· %s · ~%.1f runner hours", countNoun(totalRuns, "run"), totalHours)))
+
+	trendSection(w, "Repositories by Compute")
+	renderOrgRepos(w, analysis.Repos)
+
+	if len(analysis.SlowestJobs) > 0 {
+		trendSection(w, "Slowest Jobs")
+		renderOrgSlowestJobs(w, analysis.SlowestJobs)
+	}
+
+	if len(analysis.FlakiestJobs) > 0 {
+		trendSection(w, "Flakiest Jobs")
+		renderOrgFlakiestJobs(w, analysis.FlakiestJobs)
+	}
+
+	if len(analysis.QueueByLabel) > 0 {
+		trendSection(w, "Queue Time by Runner Label")
+		renderOrgQueueByLabel(w, analysis.QueueByLabel)
+	}
+
+	if len(analysis.Errors) > 0 {
+		trendSection(w, "Skipped Repositories")
+		fmt.Fprintln(w)
+		for _, e := range analysis.Errors {
+			fmt.Fprintf(w, "  %s %s %s\n", failureStyle.Render("✗"), valueStyle.Render(e.Repo), dimStyle.Render(e.Error))
+		}
+	}
+
+	return nil
+}
+
+// orgTable returns a table with a left-aligned first column and the rest
+// right-aligned.
+func orgTable(headers ...string) *table.Table {
+	return table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col == 0 {
+				return lipgloss.NewStyle()
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers(headers...)
+}
+
+// orgTrend renders a trend direction with its percent change.
+func orgTrend(direction string, percent float64) string {
+	switch direction {
+	case "improving":
+		return successStyle.Render(fmt.Sprintf("✓ %+.1f%%", percent))
+	case "degrading":
+		return failureStyle.Render(fmt.Sprintf("⚠ %+.1f%%", percent))
+	}
+	return dimStyle.Render(fmt.Sprintf("→ %+.1f%%", percent))
+}
+
+func renderOrgRepos(w io.Writer, repos []analyzer.RepoTrend) {
+	fmt.Fprintln(w)
+	t := orgTable("Repository", "Runs", "Runner Hours", "Avg Duration", "Success Rate", "Flaky Jobs", "Trend")
+	sampled := false
+	for i, r := range repos {
+		if i == orgRepoLimit {
+			break
+		}
+		hours := fmt.Sprintf("%.1f", r.ComputeHours)
+		if r.Sampled {
+			hours = "~" + hours
+			sampled = true
+		}
+		flaky := fmt.Sprintf("%d", r.FlakyJobs)
+		if r.FlakyJobs > 0 {
+			flaky = warningStyle.Render(flaky)
+		}
+		t.Row(
+			r.Repo,
+			fmt.Sprintf("%d", r.TotalRuns),
+			hours,
+			utils.HumanizeTime(r.AvgDuration),
+			colorForSuccessRate(r.SuccessRate).Render(fmt.Sprintf("%.1f%%", r.SuccessRate)),
+			flaky,
+			orgTrend(r.TrendDirection, r.PercentChange),
+		)
+	}
+	fmt.Fprintln(w, t)
+	if len(repos) > orgRepoLimit {
+		fmt.Fprintf(w, "\n... and %d more repositories\n", len(repos)-orgRepoLimit)
+	}
+	if sampled {
+		fmt.Fprintf(w, "\n  %s\n", dimStyle.Render("~ runner hours estimated from the runs whose job details were sampled"))
+	}
+}
+
+func renderOrgSlowestJobs(w io.Writer, jobs []analyzer.OrgJobTrend) {
+	fmt.Fprintln(w)
+	t := orgTable("Job", "Repository", "Avg Duration", "Median", "Runs", "Success Rate")
+	for _, job := range jobs {
+		t.Row(
+			linkName(job.Name, job.URLs, 40),
+			dimStyle.Render(job.Repo),
+			utils.HumanizeTime(job.AvgDuration),
+			utils.HumanizeTime(job.MedianDuration),
+			fmt.Sprintf("%d", job.TotalRuns),
+			fmt.Sprintf("%.1f%%", job.SuccessRate),
+		)
+	}
+	fmt.Fprintln(w, t)
+}
+
+func renderOrgFlakiestJobs(w io.Writer, jobs []analyzer.OrgFlakyJob) {
+	fmt.Fprintln(w)
+	t := orgTable("Job", "Repository", "Total Runs", "Failures", "Flake Rate")
+	for _, job := range jobs {
+		flakeRateColor := utils.YellowText
+		if job.FlakeRate > 30 {
+			flakeRateColor = utils.RedText
+		}
+		t.Row(
+			linkName(job.Name, job.URLs, 40),
+			dimStyle.Render(job.Repo),
+			fmt.Sprintf("%d", job.TotalRuns),
+			fmt.Sprintf("%d", job.FailureCount),
+			flakeRateColor(fmt.Sprintf("%.1f%%", job.FlakeRate)),
+		)
+	}
+	fmt.Fprintln(w, t)
+}
+
+func renderOrgQueueByLabel(w io.Writer, labels []analyzer.LabelQueueTime) {
+	fmt.Fprintln(w)
+	t := orgTable("Runner Labels", "Jobs", "Repositories", "Avg Queue", "P95 Queue")
+	for _, l := range labels {
+		t.Row(
+			strings.ReplaceAll(l.Labels, ",", ", "),
+			fmt.Sprintf("%d", l.Jobs),
+			fmt.Sprintf("%d", l.Repos),
+			utils.HumanizeTime(l.AvgQueue),
+			utils.HumanizeTime(l.P95Queue),
+		)
+	}
+	fmt.Fprintln(w, t)
+}
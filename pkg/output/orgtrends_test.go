@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputOrgTrends(t *testing.T) {
+	t.Parallel()
+
+	analysis := &analyzer.OrgTrendAnalysis{
+		Org: "acme",
+		TimeRange: analyzer.TimeRange{
+			Start: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			Days:  30,
+		},
+		Repos: []analyzer.RepoTrend{
+			{Repo: "acme/api", TotalRuns: 120, ComputeHours: 42.5, AvgDuration: 600, SuccessRate: 92, TrendDirection: "degrading", PercentChange: 12.3, FlakyJobs: 2, Sampled: true},
+			{Repo: "acme/web", TotalRuns: 30, ComputeHours: 3, AvgDuration: 120, SuccessRate: 100, TrendDirection: "stable"},
+		},
+		Errors:       []analyzer.RepoError{{Repo: "acme/secret", Error: "failed to fetch workflow runs: 404 Not Found"}},
+		SlowestJobs:  []analyzer.OrgJobTrend{{Repo: "acme/api", JobTrend: analyzer.JobTrend{Name: "e2e", AvgDuration: 900, TotalRuns: 40, SuccessRate: 90}}},
+		FlakiestJobs: []analyzer.OrgFlakyJob{{Repo: "acme/api", FlakyJob: analyzer.FlakyJob{Name: "integration", TotalRuns: 40, FailureCount: 10, FlakeRate: 25}}},
+		QueueByLabel: []analyzer.LabelQueueTime{{Labels: "self-hosted,gpu", Jobs: 12, Repos: 2, AvgQueue: 300, P95Queue: 900}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, OutputOrgTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Organization Trend Analysis: acme")
+	assert.Contains(t, out, "150 runs · ~45.5 runner hours")
+	assert.Contains(t, out, "Repositories by Compute")
+	assert.Contains(t, out, "~42.5")
+	assert.Contains(t, out, "⚠ +12.3%")
+	assert.Contains(t, out, "~ runner hours estimated")
+	assert.Contains(t, out, "Slowest Jobs")
+	assert.Contains(t, out, "Flakiest Jobs")
+	assert.Contains(t, out, "integration")
+	assert.Contains(t, out, "Queue Time by Runner Label")
+	assert.Contains(t, out, "self-hosted, gpu")
+	assert.Contains(t, out, "Skipped Repositories")
+	assert.Contains(t, out, "404 Not Found")
+
+	var js bytes.Buffer
+	assert.NoError(t, OutputOrgTrends(&js, analysis, "json"))
+	assert.Contains(t, js.String(), `"ComputeHours": 42.5`)
+	assert.Contains(t, js.String(), `"Repo": "acme/api"`)
+}
@@ -0,0 +1,132 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// runnerBarWidth is the width of a swimlane bar in columns.
+const runnerBarWidth = 60
+
+// OutputRunners prints the jobs regrouped into one swimlane per runner (or
+// runner pool): a bar of when it was busy and idle over the whole run, its
+// utilization and concurrency, and each job with how long it waited.
+func OutputRunners(w io.Writer, lanes []analyzer.RunnerLane, groupBy string, start, end time.Time) error {
+	if groupBy == "" {
+		groupBy = analyzer.RunnersByName
+	}
+
+	jobs := 0
+	runners := 0
+	for _, lane := range lanes {
+		jobs += len(lane.Jobs)
+		runners += lane.Runners
+	}
+
+	styledSection(w, fmt.Sprintf("Runner Swimlanes by %s", groupBy))
+	fmt.Fprintf(w, "  %s\n", dimStyle.Render(fmt.Sprintf("%s on %s in %s",
+		countNoun(jobs, "job"), countNoun(runners, "runner"), countNoun(len(lanes), "lane"))))
+
+	if len(lanes) == 0 {
+		fmt.Fprintf(w, "\n  %s\n", dimStyle.Render("No jobs have runner information."))
+		return nil
+	}
+
+	for _, lane := range lanes {
+		stats := []string{
+			countNoun(len(lane.Jobs), "job"),
+			"busy " + utils.HumanizeTime(lane.Busy.Seconds()),
+			"idle " + utils.HumanizeTime(lane.Idle().Seconds()),
+			fmt.Sprintf("%.0f%% utilized", lane.Utilization()),
+		}
+		if groupBy != analyzer.RunnersByName {
+			stats = append(stats, countNoun(lane.Runners, "runner"), fmt.Sprintf("max %d concurrent", lane.MaxConcurrency))
+		}
+		if lane.Queue > 0 {
+			stats = append(stats, "waited "+utils.HumanizeTime(lane.Queue.Seconds()))
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  %s  %s\n", valueStyle.Render(lane.Key), dimStyle.Render(strings.Join(stats, " · ")))
+		fmt.Fprintf(w, "  %s%s%s\n", borderStyle.Render("│"), runnerBar(lane, start, end, runnerBarWidth), borderStyle.Render("│"))
+		for _, job := range lane.Jobs {
+			line := fmt.Sprintf("    %s %s  %s",
+				dimStyle.Render("+"+utils.HumanizeTime(job.Node.StartTime.Sub(start).Seconds())),
+				job.Node.Name,
+				numStyle.Render(utils.HumanizeTime(job.Node.Duration().Seconds())))
+			if job.Queue > 0 {
+				line += dimStyle.Render("  waited " + utils.HumanizeTime(job.Queue.Seconds()))
+			}
+			if groupBy != analyzer.RunnersByName {
+				line += dimStyle.Render("  on " + job.Runner)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  %s\n", dimStyle.Render("█ running  ░ idle between jobs  ▒ job waiting for a runner"))
+	return nil
+}
+
+// runnerBar draws a lane over start..end: jobs running, idle gaps between its
+// first and last job, and jobs waiting for a runner while it was idle.
+func runnerBar(lane analyzer.RunnerLane, start, end time.Time, width int) string {
+	total := end.Sub(start)
+	if total <= 0 {
+		return strings.Repeat(" ", width)
+	}
+	col := func(t time.Time) int {
+		return clampInt(int(float64(t.Sub(start))/float64(total)*float64(width)), 0, width-1)
+	}
+
+	cells := make([]rune, width)
+	failed := make([]bool, width)
+	for i := range cells {
+		cells[i] = ' '
+	}
+	fill := func(from, to time.Time, r rune) {
+		for i := col(from); i <= col(to); i++ {
+			cells[i] = r
+		}
+	}
+	for _, g := range lane.Gaps {
+		fill(g.Start, g.End, '░')
+	}
+	for _, job := range lane.Jobs {
+		if job.Queue > 0 {
+			for i := col(job.QueuedAt()); i <= col(job.Node.StartTime); i++ {
+				if cells[i] != '█' {
+					cells[i] = '▒'
+				}
+			}
+		}
+	}
+	for _, job := range lane.Jobs {
+		fill(job.Node.StartTime, job.Node.EndTime, '█')
+		if job.Node.Hints.Outcome == "failure" {
+			for i := col(job.Node.StartTime); i <= col(job.Node.EndTime); i++ {
+				failed[i] = true
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, r := range cells {
+		style := dimStyle
+		switch {
+		case r == '█' && failed[i]:
+			style = failureStyle
+		case r == '█':
+			style = successStyle
+		case r == '▒':
+			style = warningStyle
+		}
+		b.WriteString(style.Render(string(r)))
+	}
+	return b.String()
+}
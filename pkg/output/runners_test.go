@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputRunners(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+	job := func(name, runner string, startMin, endMin int, queueMs string) *analyzer.TreeNode {
+		return &analyzer.TreeNode{
+			Name:      name,
+			StartTime: start.Add(time.Duration(startMin) * time.Minute),
+			EndTime:   start.Add(time.Duration(endMin) * time.Minute),
+			Attrs: map[string]string{
+				"type":                   "job",
+				analyzer.AttrRunnerName:  runner,
+				analyzer.AttrRunnerGroup: "fleet",
+				"queue_time_ms":          queueMs,
+			},
+		}
+	}
+	roots := []*analyzer.TreeNode{
+		job("lint", "runner-1", 0, 2, ""),
+		job("test", "runner-1", 3, 4, "60000"),
+		job("build", "runner-2", 1, 5, ""),
+	}
+	end := start.Add(5 * time.Minute)
+
+	var byRunner bytes.Buffer
+	assert.NoError(t, OutputRunners(&byRunner, analyzer.RunnerLanes(roots, ""), "", start, end))
+	out := byRunner.String()
+	assert.Contains(t, out, "Runner Swimlanes by runner")
+	assert.Contains(t, out, "3 jobs on 2 runners in 2 lanes")
+	assert.Contains(t, out, "2 jobs · busy 3m · idle 1m · 75% utilized · waited 1m")
+	assert.Contains(t, out, "test  1m  waited 1m")
+	assert.Contains(t, out, "░")
+
+	var byGroup bytes.Buffer
+	assert.NoError(t, OutputRunners(&byGroup, analyzer.RunnerLanes(roots, analyzer.RunnersByGroup), analyzer.RunnersByGroup, start, end))
+	assert.Contains(t, byGroup.String(), "2 runners · max 2 concurrent")
+	assert.Contains(t, byGroup.String(), "on runner-2")
+
+	var none bytes.Buffer
+	assert.NoError(t, OutputRunners(&none, nil, "", start, end))
+	assert.Contains(t, none.String(), "No jobs have runner information.")
+}
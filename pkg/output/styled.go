@@ -72,13 +72,14 @@ func OutputStyledResults(w io.Writer, urlResults []analyzer.URLResult, combined
 	line3 := buildLineAligned(contentWidth, leftStyled3, leftPlain3, rightStyled3, rightPlain3)
 
 	// Aggregate enrichment metrics across URL results
-	var totalQueueTimes []float64
+	var totalQueueTimes, totalApprovalTimes []float64
 	var totalRetriedRuns, totalRunCount int
 	totalBillable := map[string]int64{}
 	totalRunnerJobs := map[string]int{}
 	totalRunnerDur := map[string]float64{}
 	for _, result := range urlResults {
 		totalQueueTimes = append(totalQueueTimes, result.Metrics.QueueTimes...)
+		totalApprovalTimes = append(totalApprovalTimes, result.Metrics.ApprovalTimes...)
 		totalRetriedRuns += result.Metrics.RetriedRuns
 		totalRunCount += result.Metrics.TotalRuns
 		for os, ms := range result.Metrics.BillableMs {
@@ -98,10 +99,11 @@ func OutputStyledResults(w io.Writer, urlResults []analyzer.URLResult, combined
 	fmt.Fprintln(w, line2)
 	fmt.Fprintln(w, line3)
 
-	// Line 4: Queue time + Retry rate (conditional)
+	// Line 4: Queue time + Approval time + Retry rate (conditional)
 	hasQueueData := len(totalQueueTimes) > 0
+	hasApprovalData := len(totalApprovalTimes) > 0
 	hasRetryData := totalRetriedRuns > 0
-	if hasQueueData || hasRetryData {
+	if hasQueueData || hasApprovalData || hasRetryData {
 		var parts4 []string
 		if hasQueueData {
 			avgQ := 0.0
@@ -117,6 +119,20 @@ func OutputStyledResults(w io.Writer, urlResults []analyzer.URLResult, combined
 			maxQStr := utils.HumanizeTime(maxQ / 1000)
 			parts4 = append(parts4, labelStyle.Render("Queue: avg ")+numStyle.Render(avgQStr)+labelStyle.Render(" / max ")+numStyle.Render(maxQStr))
 		}
+		if hasApprovalData {
+			avgA := 0.0
+			maxA := 0.0
+			for _, at := range totalApprovalTimes {
+				avgA += at
+				if at > maxA {
+					maxA = at
+				}
+			}
+			avgA /= float64(len(totalApprovalTimes))
+			avgAStr := utils.HumanizeTime(avgA / 1000)
+			maxAStr := utils.HumanizeTime(maxA / 1000)
+			parts4 = append(parts4, labelStyle.Render("Approval: avg ")+numStyle.Render(avgAStr)+labelStyle.Render(" / max ")+numStyle.Render(maxAStr))
+		}
 		if hasRetryData {
 			retryPct := fmt.Sprintf("%.0f%%", float64(totalRetriedRuns)/float64(totalRunCount)*100)
 			retryDetail := fmt.Sprintf("(%d/%d runs)", totalRetriedRuns, totalRunCount)
@@ -310,6 +326,26 @@ func OutputStyledResults(w io.Writer, urlResults []analyzer.URLResult, combined
 		}
 	}
 
+	// ── Lifecycle ─────────────────────────────────────────────────────
+	if lifecycles := resultLifecycles(urlResults); len(lifecycles) > 0 {
+		writeStyledLifecycle(w, lifecycles)
+	}
+
+	// ── Merge Gate ────────────────────────────────────────────────────
+	if gated := resultMergeGates(urlResults); len(gated) > 0 {
+		writeStyledMergeGates(w, gated)
+	}
+
+	// ── Workflow Chains ───────────────────────────────────────────────
+	if chained := resultChains(urlResults); len(chained) > 0 {
+		writeStyledChains(w, chained)
+	}
+
+	// ── Waste ─────────────────────────────────────────────────────────
+	if wasted := resultWastes(urlResults); len(wasted) > 0 {
+		writeStyledWaste(w, wasted)
+	}
+
 	// ── Slowest Jobs ──────────────────────────────────────────────────
 	allJobs := append([]analyzer.CombinedTimelineJob{}, combined.JobTimeline...)
 	analyzer.SortCombinedJobsByDuration(allJobs)
@@ -363,6 +399,47 @@ func OutputStyledResults(w io.Writer, urlResults []analyzer.URLResult, combined
 		}
 	}
 
+	// ── Idle Gaps ─────────────────────────────────────────────────────
+	if idle := idleNodes(spans, globalEarliestTime, globalLatestTime, enricher); len(idle) > 0 {
+		styledSection(w, "Idle Gaps")
+		for i, node := range idle {
+			gap, _ := node.LargestGap()
+			detail := fmt.Sprintf("%d gaps, largest %s at +%s, of %s",
+				len(node.Gaps),
+				utils.HumanizeTime(gap.Duration().Seconds()),
+				utils.HumanizeTime(gap.Start.Sub(node.StartTime).Seconds()),
+				utils.HumanizeTime(node.Duration().Seconds()))
+			fmt.Fprintf(w, "    %s  %s idle %s  %s\n",
+				dimStyle.Render(fmt.Sprintf("%d.", i+1)),
+				numStyle.Render(utils.HumanizeTime(node.IdleTime().Seconds())),
+				valueStyle.Render(node.Name),
+				dimStyle.Render("("+detail+")"))
+		}
+	}
+
+	// ── Baseline ──────────────────────────────────────────────────────
+	if ranked := baselineNodes(spans, globalEarliestTime, globalLatestTime, enricher); len(ranked) > 0 {
+		styledSection(w, "Baseline")
+		for i, node := range ranked {
+			rank, _ := analyzer.BaselineRankFromAttrs(node.Attrs)
+			detail := fmt.Sprintf("p50 %s, p95 %s, %d runs on %s",
+				utils.HumanizeTime(rank.P50().Seconds()),
+				utils.HumanizeTime(rank.P95().Seconds()),
+				rank.Samples, rank.Branch)
+			flag := ""
+			if rank.Outlier {
+				flag = " ⚠ outlier"
+			}
+			fmt.Fprintf(w, "    %s  %s p%.0f  %s%s  %s\n",
+				dimStyle.Render(fmt.Sprintf("%d.", i+1)),
+				numStyle.Render(utils.HumanizeTime(node.Duration().Seconds())),
+				rank.Percentile,
+				valueStyle.Render(node.Name),
+				flag,
+				dimStyle.Render("("+detail+")"))
+		}
+	}
+
 	// ── Pipeline Timelines ────────────────────────────────────────────
 	styledSection(w, "Pipeline Timelines")
 	RenderOTelTimeline(w, spans, time.UnixMilli(globalEarliestTime), time.UnixMilli(globalLatestTime), enricher)
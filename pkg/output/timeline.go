@@ -316,6 +316,9 @@ func renderNode(w io.Writer, node *SpanNode, depth int, globalStart time.Time, t
 	}
 
 	durationDisplay := fmt.Sprintf("(%s)", utils.HumanizeTime(duration.Seconds()))
+	if rank, ok := analyzer.BaselineRankFromAttrs(node.Attrs); ok {
+		durationDisplay = fmt.Sprintf("(%s, %s)", utils.HumanizeTime(duration.Seconds()), rank.Label())
+	}
 	if h.IsMarker {
 		durationDisplay = ""
 	}
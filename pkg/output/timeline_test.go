@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
 	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel/attribute"
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestRenderOTelTimelineDeduplication(t *testing.T) {
@@ -146,6 +148,24 @@ func (m *mockReadOnlySpan) DroppedEventsCount() int       { return 0 }
 func (m *mockReadOnlySpan) DroppedLinksCount() int        { return 0 }
 func (m *mockReadOnlySpan) ChildSpans() []sdktrace.ReadOnlySpan { return nil }
 
+// makeSpanStub returns a span of trace ab; a zero parent makes it a root.
+func makeSpanStub(name string, id, parent byte, start, end time.Time, attrs ...attribute.KeyValue) tracetest.SpanStub {
+	ctx := func(id byte) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{0xab}, SpanID: trace.SpanID{id}})
+	}
+	s := tracetest.SpanStub{
+		Name:        name,
+		SpanContext: ctx(id),
+		StartTime:   start,
+		EndTime:     end,
+		Attributes:  attrs,
+	}
+	if parent != 0 {
+		s.Parent = ctx(parent)
+	}
+	return s
+}
+
 func countOccurrences(s, substr string) int {
 	count := 0
 	for {
@@ -211,3 +231,48 @@ func TestJobSpanRequiredEmoji(t *testing.T) {
 		assert.NotContains(t, output, "🔒")
 	})
 }
+
+func TestBaselineAnnotations(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	span := &mockReadOnlySpan{
+		name:      "build",
+		startTime: now,
+		endTime:   now.Add(9 * time.Minute),
+		spanID:    trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		attrs: []attribute.KeyValue{
+			attribute.String("type", "job"),
+			attribute.String("github.status", "completed"),
+			attribute.String("github.conclusion", "success"),
+			attribute.String("baseline.branch", "main"),
+			attribute.String("baseline.percentile", "97.5"),
+			attribute.String("baseline.outlier", "true"),
+			attribute.String("baseline.p50_ms", "240000"),
+			attribute.String("baseline.p95_ms", "300000"),
+			attribute.String("baseline.samples", "20"),
+		},
+	}
+	spans := []sdktrace.ReadOnlySpan{span}
+	start, end := now.UnixMilli(), now.Add(9*time.Minute).UnixMilli()
+
+	t.Run("Timeline shows percentile rank", func(t *testing.T) {
+		var buf bytes.Buffer
+		RenderOTelTimeline(&buf, spans, now, now.Add(9*time.Minute), enrichment.DefaultEnricher())
+		assert.Contains(t, buf.String(), "build (9m, p98 vs main ⚠)")
+	})
+
+	t.Run("Markdown lists ranked spans", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := OutputCombinedResultsMarkdown(&buf, nil, analyzer.CombinedMetrics{}, nil, start, end, "", false, spans, enrichment.DefaultEnricher())
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "## Baseline vs `main`")
+		assert.Contains(t, buf.String(), "| build | 9m | p98 | 4m | 5m | 20 | ⚠️ |")
+	})
+
+	t.Run("Stdout lists ranked spans", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := OutputStyledResults(&buf, nil, analyzer.CombinedMetrics{}, nil, start, end, spans, enrichment.DefaultEnricher())
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Baseline")
+		assert.Contains(t, buf.String(), "20 runs on main")
+	})
+}
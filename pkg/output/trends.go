@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
@@ -108,9 +110,14 @@ func OutputTrends(w io.Writer, analysis *analyzer.TrendAnalysis, format string)
 	}
 	fmt.Fprintln(w, botBorder)
 
+	confidence := analysis.Sampling.Confidence
+	if confidence <= 0 {
+		confidence = 0.95
+	}
+
 	// Summary statistics
 	trendSection(w, "Summary Statistics")
-	renderTrendSummary(w, analysis.Summary)
+	renderTrendSummary(w, analysis.Summary, confidence)
 
 	// Duration trend chart
 	if len(analysis.DurationTrend) > 0 {
@@ -124,28 +131,75 @@ func OutputTrends(w io.Writer, analysis *analyzer.TrendAnalysis, format string)
 		renderSuccessRateChart(w, analysis.SuccessRateTrend)
 	}
 
+	// Breakdowns by dimension
+	for i := 0; i < len(analysis.Segments); {
+		j := i
+		for j < len(analysis.Segments) && analysis.Segments[j].Dimension == analysis.Segments[i].Dimension {
+			j++
+		}
+		trendSection(w, "Trends by "+analysis.Segments[i].Dimension)
+		renderSegments(w, analysis.Segments[i:j], analysis.Sampling)
+		i = j
+	}
+
 	// Top jobs by duration
 	if len(analysis.JobTrends) > 0 {
 		trendSection(w, "Job Performance Summary")
-		renderJobTrends(w, analysis.JobTrends)
+		renderJobTrends(w, analysis.JobTrends, confidence)
+	}
+
+	// Top steps by duration
+	if len(analysis.StepTrends) > 0 {
+		trendSection(w, "Step Performance Summary")
+		renderStepTrends(w, analysis.StepTrends, confidence)
 	}
 
 	// Queue time analysis
-	if analysis.QueueTimeStats.AvgQueueTime > 0 {
+	if analysis.QueueTimeStats.AvgQueueTime > 0 || analysis.QueueTimeStats.ApprovalCount > 0 {
 		trendSection(w, "Queue Time Analysis")
 		renderQueueTimeStats(w, analysis.QueueTimeStats)
 	}
 
+	// When runs queue up
+	if analysis.QueueHeatmap.MaxRuns > 0 {
+		trendSection(w, "Queue Time Heatmap")
+		renderQueueHeatmap(w, analysis.QueueHeatmap, analysis.Sampling)
+	}
+
+	// Wasted compute
+	if analysis.Waste.TotalMs() > 0 {
+		trendSection(w, "Waste")
+		renderWasteStats(w, analysis.Waste)
+	}
+
+	// Runner pool what-ifs
+	if len(analysis.Simulations) > 0 {
+		trendSection(w, "Runner Simulation")
+		renderRunnerSimulations(w, analysis.Simulations, analysis.Sampling)
+	}
+
 	// Top regressions
 	if len(analysis.TopRegressions) > 0 {
 		trendSection(w, "Top Performance Regressions")
-		renderRegressions(w, analysis.TopRegressions)
+		renderRegressions(w, analysis.TopRegressions, "Jobs", confidence)
+	}
+
+	// Top step regressions
+	if len(analysis.TopStepRegressions) > 0 {
+		trendSection(w, "Top Step Regressions")
+		renderRegressions(w, analysis.TopStepRegressions, "Steps", confidence)
 	}
 
 	// Top improvements
 	if len(analysis.TopImprovements) > 0 {
 		trendSection(w, "Top Performance Improvements")
-		renderImprovements(w, analysis.TopImprovements)
+		renderImprovements(w, analysis.TopImprovements, "Jobs", confidence)
+	}
+
+	// Top step improvements
+	if len(analysis.TopStepImprovements) > 0 {
+		trendSection(w, "Top Step Improvements")
+		renderImprovements(w, analysis.TopStepImprovements, "Steps", confidence)
 	}
 
 	// Flaky jobs
@@ -154,6 +208,18 @@ func OutputTrends(w io.Writer, analysis *analyzer.TrendAnalysis, format string)
 		renderFlakyJobs(w, analysis.FlakyJobs)
 	}
 
+	// Why jobs failed
+	if len(analysis.FailureClusters) > 0 {
+		trendSection(w, "Top Failure Signatures")
+		renderFailureClusters(w, analysis.FailureClusters, analysis.Sampling)
+	}
+
+	// Which names jobs are reported under
+	if len(analysis.JobRenames) > 0 || len(analysis.JobAliases) > 0 {
+		trendSection(w, "Job Identities")
+		renderJobIdentities(w, analysis.JobRenames, analysis.JobAliases)
+	}
+
 	// Legend
 	trendSection(w, "Legend")
 	renderLegend(w)
@@ -161,7 +227,7 @@ func OutputTrends(w io.Writer, analysis *analyzer.TrendAnalysis, format string)
 	return nil
 }
 
-func renderTrendSummary(w io.Writer, summary analyzer.TrendSummary) {
+func renderTrendSummary(w io.Writer, summary analyzer.TrendSummary, confidence float64) {
 	fmt.Fprintln(w)
 
 	t := table.New().
@@ -176,12 +242,12 @@ func renderTrendSummary(w io.Writer, summary analyzer.TrendSummary) {
 			}
 			return lipgloss.NewStyle().Align(lipgloss.Right)
 		}).
-		Headers("Metric", "Value")
+		Headers("Metric", "Value", ciLabel(confidence))
 
-	t.Row("Average Duration", utils.HumanizeTime(summary.AvgDuration))
-	t.Row("Median Duration", utils.HumanizeTime(summary.MedianDuration))
-	t.Row("95th Percentile", utils.HumanizeTime(summary.P95Duration))
-	t.Row("Average Success Rate", colorForSuccessRate(summary.AvgSuccessRate).Render(fmt.Sprintf("%.1f%%", summary.AvgSuccessRate)))
+	t.Row("Average Duration", utils.HumanizeTime(summary.AvgDuration), durationCI(summary.AvgDurationCI))
+	t.Row("Median Duration", utils.HumanizeTime(summary.MedianDuration), durationCI(summary.MedianDurationCI))
+	t.Row("95th Percentile", utils.HumanizeTime(summary.P95Duration), "")
+	t.Row("Average Success Rate", colorForSuccessRate(summary.AvgSuccessRate).Render(fmt.Sprintf("%.1f%%", summary.AvgSuccessRate)), percentCI(summary.SuccessRateCI, false))
 
 	// Trend direction with color
 	trendDisplay := summary.TrendDirection
@@ -193,10 +259,15 @@ func renderTrendSummary(w io.Writer, summary analyzer.TrendSummary) {
 	case "stable":
 		trendDisplay = dimStyle.Render("→ Stable") + " " + dimStyle.Render(fmt.Sprintf("(%.1f%%)", summary.PercentChange))
 	}
-	t.Row("Trend Direction", trendDisplay)
+	trendSignificant := summary.TrendPValue < 1-confidence
+	trendCI := percentCI(summary.PercentChangeCI, true)
+	if trendCI != "" {
+		trendCI += dimStyle.Render(", " + formatPValue(summary.TrendPValue))
+	}
+	t.Row("Trend Direction", trendDisplay, trendCI)
 
 	if summary.MostFlakyJobsCount > 0 {
-		t.Row("Flaky Jobs Detected", warningStyle.Render(fmt.Sprintf("%d", summary.MostFlakyJobsCount)))
+		t.Row("Flaky Jobs Detected", warningStyle.Render(fmt.Sprintf("%d", summary.MostFlakyJobsCount)), "")
 	}
 
 	fmt.Fprintln(w, t)
@@ -204,6 +275,108 @@ func renderTrendSummary(w io.Writer, summary analyzer.TrendSummary) {
 	if summary.TrendDescription != "" {
 		fmt.Fprintf(w, "\n  %s\n", dimStyle.Render(summary.TrendDescription))
 	}
+	if summary.TrendDirection != "stable" && summary.TrendDirection != "" && !trendSignificant {
+		fmt.Fprintf(w, "  %s\n", warningStyle.Render(fmt.Sprintf(
+			"This change is not statistically significant at %.0f%% confidence (%s) and may be noise.", confidence*100, formatPValue(summary.TrendPValue))))
+	}
+}
+
+// ciLabel is the column heading for confidence intervals at confidence.
+func ciLabel(confidence float64) string {
+	return fmt.Sprintf("%.0f%% CI", confidence*100)
+}
+
+// durationCI renders an interval of durations in seconds; empty when unknown.
+func durationCI(ci analyzer.ConfidenceInterval) string {
+	if ci == (analyzer.ConfidenceInterval{}) {
+		return ""
+	}
+	return dimStyle.Render(utils.HumanizeTime(ci.Low) + " – " + utils.HumanizeTime(ci.High))
+}
+
+// percentCI renders an interval of percentages, signed for changes; empty
+// when unknown.
+func percentCI(ci analyzer.ConfidenceInterval, signed bool) string {
+	if ci == (analyzer.ConfidenceInterval{}) {
+		return ""
+	}
+	format := "%.1f%% – %.1f%%"
+	if signed {
+		format = "%+.1f%% – %+.1f%%"
+	}
+	return dimStyle.Render(fmt.Sprintf(format, ci.Low, ci.High))
+}
+
+// formatPValue renders a p-value, flooring tiny ones.
+func formatPValue(p float64) string {
+	if p < 0.001 {
+		return "p<0.001"
+	}
+	return fmt.Sprintf("p=%.3f", p)
+}
+
+// segmentChartHeight is the height of the per-segment duration charts.
+const segmentChartHeight = 6
+
+// renderSegments renders the segments of one dimension: a summary table
+// comparing them, then each one's duration trend.
+func renderSegments(w io.Writer, segments []analyzer.TrendSegment, sampling analyzer.SamplingInfo) {
+	fmt.Fprintln(w)
+
+	unit := "Runs"
+	if segments[0].Dimension == analyzer.GroupByOS {
+		unit = "Jobs"
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col == 0 {
+				return lipgloss.NewStyle()
+			}
+			if col == 6 {
+				return lipgloss.NewStyle().Align(lipgloss.Center)
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers("Segment", unit, "Avg Duration", "Median", "95th Percentile", "Success Rate", "Trend")
+
+	for _, seg := range segments {
+		s := seg.Summary
+		trend := dimStyle.Render(fmt.Sprintf("→ %+.1f%%", s.PercentChange))
+		switch s.TrendDirection {
+		case "improving":
+			trend = successStyle.Render(fmt.Sprintf("✓ %+.1f%%", s.PercentChange))
+		case "degrading":
+			trend = failureStyle.Render(fmt.Sprintf("⚠ %+.1f%%", s.PercentChange))
+		}
+		t.Row(
+			seg.Key,
+			fmt.Sprintf("%d", s.TotalRuns),
+			utils.HumanizeTime(s.AvgDuration),
+			utils.HumanizeTime(s.MedianDuration),
+			utils.HumanizeTime(s.P95Duration),
+			colorForSuccessRate(s.AvgSuccessRate).Render(fmt.Sprintf("%.1f%%", s.AvgSuccessRate)),
+			trend,
+		)
+	}
+
+	fmt.Fprintln(w, t)
+	if segments[0].Dimension == analyzer.GroupByOS && sampling.Enabled {
+		fmt.Fprintf(w, "\n  %s Jobs of the %d sampled runs only. Use --no-sample to include every run.\n", dimStyle.Render("i"), sampling.SampleSize)
+	}
+
+	for _, seg := range segments {
+		if len(seg.DurationTrend) < 2 {
+			continue
+		}
+		fmt.Fprintf(w, "\n  %s %s\n\n", valueStyle.Render(seg.Key), dimStyle.Render("average duration per day"))
+		fmt.Fprint(w, generateASCIIChart(seg.DurationTrend, 40, segmentChartHeight, "seconds"))
+	}
 }
 
 func renderDurationChart(w io.Writer, points []analyzer.DataPoint) {
@@ -228,7 +401,7 @@ func renderSuccessRateChart(w io.Writer, points []analyzer.DataPoint) {
 	fmt.Fprint(w, chart)
 }
 
-func renderJobTrends(w io.Writer, trends []analyzer.JobTrend) {
+func renderJobTrends(w io.Writer, trends []analyzer.JobTrend, confidence float64) {
 	// Show top 10 jobs
 	limit := 10
 	if len(trends) < limit {
@@ -247,12 +420,12 @@ func renderJobTrends(w io.Writer, trends []analyzer.JobTrend) {
 			if col == 0 {
 				return lipgloss.NewStyle()
 			}
-			if col == 4 {
+			if col == 5 {
 				return lipgloss.NewStyle().Align(lipgloss.Center)
 			}
 			return lipgloss.NewStyle().Align(lipgloss.Right)
 		}).
-		Headers("Job Name", "Avg Duration", "Median", "Success Rate", "Trend")
+		Headers("Job Name", "Avg Duration", ciLabel(confidence), "Median", "Success Rate", "Trend")
 
 	for i := 0; i < limit; i++ {
 		job := trends[i]
@@ -271,6 +444,7 @@ func renderJobTrends(w io.Writer, trends []analyzer.JobTrend) {
 		t.Row(
 			linkName(job.Name, job.URLs, 48),
 			utils.HumanizeTime(job.AvgDuration),
+			durationCI(job.AvgDurationCI),
 			utils.HumanizeTime(job.MedianDuration),
 			fmt.Sprintf("%.1f%%", job.SuccessRate),
 			trendColor(trendIcon),
@@ -284,6 +458,66 @@ func renderJobTrends(w io.Writer, trends []analyzer.JobTrend) {
 	}
 }
 
+func renderStepTrends(w io.Writer, trends []analyzer.StepTrend, confidence float64) {
+	limit := min(10, len(trends))
+
+	fmt.Fprintf(w, "\nTop %d Steps by Average Duration:\n\n", limit)
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col <= 1 {
+				return lipgloss.NewStyle()
+			}
+			if col == 6 {
+				return lipgloss.NewStyle().Align(lipgloss.Center)
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers("Step Name", "Job", "Avg Duration", ciLabel(confidence), "Median", "Runs", "Trend")
+
+	for _, step := range trends[:limit] {
+		trendIcon := "→"
+		trendColor := utils.BlueText
+		switch step.TrendDirection {
+		case "improving":
+			trendIcon = "✓"
+			trendColor = utils.GreenText
+		case "degrading":
+			trendIcon = "⚠"
+			trendColor = utils.RedText
+		}
+
+		t.Row(
+			linkName(step.Name, step.URLs, 40),
+			linkName(step.Job, nil, 30),
+			utils.HumanizeTime(step.AvgDuration),
+			durationCI(step.AvgDurationCI),
+			utils.HumanizeTime(step.MedianDuration),
+			fmt.Sprintf("%d", step.TotalRuns),
+			trendColor(trendIcon),
+		)
+	}
+
+	fmt.Fprintln(w, t)
+
+	if len(trends) > limit {
+		fmt.Fprintf(w, "\n... and %d more steps\n", len(trends)-limit)
+	}
+}
+
+// changeName names a job, or a step within its job, that changed.
+func changeName(job, step string) string {
+	if step == "" {
+		return job
+	}
+	return job + " / " + step
+}
+
 func renderFlakyJobs(w io.Writer, flakyJobs []analyzer.FlakyJob) {
 	fmt.Fprintf(w, "\n  %s Found %d flaky jobs (>10%% failure rate):\n\n",
 		warningStyle.Render("!"),
@@ -453,6 +687,10 @@ func renderQueueTimeStats(w io.Writer, stats analyzer.QueueTimeStats) {
 		ratioStyle = warningStyle
 	}
 	t.Row("Queue Time Ratio", ratioStyle.Render(fmt.Sprintf("%.1f%%", stats.QueueTimeRatio)))
+	if stats.ApprovalCount > 0 {
+		t.Row("Average Approval Wait", utils.HumanizeTime(stats.AvgApprovalTime))
+		t.Row("Median Approval Wait", utils.HumanizeTime(stats.MedianApprovalTime))
+	}
 
 	fmt.Fprintln(w, t)
 
@@ -469,6 +707,163 @@ func renderQueueTimeStats(w io.Writer, stats analyzer.QueueTimeStats) {
 	}
 }
 
+// heatmapDays orders the heatmap rows Monday first.
+var heatmapDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+func renderQueueHeatmap(w io.Writer, heatmap analyzer.QueueHeatmap, sampling analyzer.SamplingInfo) {
+	fmt.Fprintf(w, "\n  %s\n", dimStyle.Render("Hours in "+heatmap.Timezone))
+
+	renderHeatmapGrid(w, "Runs started", heatmap, func(c analyzer.HeatmapCell) float64 { return float64(c.Runs) }, float64(heatmap.MaxRuns))
+	fmt.Fprintf(w, "  %s\n", dimStyle.Render(fmt.Sprintf("·· none  ░ ▒ ▓ █ up to %s", countNoun(heatmap.MaxRuns, "run"))))
+
+	if heatmap.MaxQueue <= 0 {
+		return
+	}
+	renderHeatmapGrid(w, "Average queue time", heatmap, func(c analyzer.HeatmapCell) float64 { return c.AvgQueue }, heatmap.MaxQueue)
+	fmt.Fprintf(w, "  %s\n", dimStyle.Render("·· no queued jobs  ░ ▒ ▓ █ up to "+utils.HumanizeTime(heatmap.MaxQueue)))
+	if sampling.Enabled {
+		fmt.Fprintf(w, "  %s\n", dimStyle.Render("Queue times are from the jobs of sampled runs; use --no-sample for all of them."))
+	}
+
+	if len(heatmap.Worst) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n  %s Worst windows:\n\n", warningStyle.Render("!"))
+	for _, win := range heatmap.Worst {
+		fmt.Fprintf(w, "     %s  avg %s  p95 %s  %s\n",
+			valueStyle.Render(fmt.Sprintf("%s %02d:00–%02d:00", win.Weekday.String()[:3], win.Hour, (win.Hour+1)%24)),
+			failureStyle.Render(utils.HumanizeTime(win.AvgQueue)),
+			numStyle.Render(utils.HumanizeTime(win.P95Queue)),
+			dimStyle.Render("over "+countNoun(win.Jobs, "job")))
+	}
+	fmt.Fprintf(w, "\n  %s Schedule cron workflows away from these windows.\n", subheaderStyle.Render("i"))
+}
+
+// renderHeatmapGrid draws one weekday × hour grid, shading each cell by its
+// value relative to maxValue.
+func renderHeatmapGrid(w io.Writer, title string, heatmap analyzer.QueueHeatmap, value func(analyzer.HeatmapCell) float64, maxValue float64) {
+	fmt.Fprintf(w, "\n  %s\n", labelStyle.Render(title))
+
+	var header strings.Builder
+	for hour := 0; hour < 24; hour += 3 {
+		fmt.Fprintf(&header, "%-6s", fmt.Sprintf("%02d", hour))
+	}
+	fmt.Fprintf(w, "       %s\n", dimStyle.Render(strings.TrimRight(header.String(), " ")))
+
+	for _, day := range heatmapDays {
+		var row strings.Builder
+		for hour := 0; hour < 24; hour++ {
+			row.WriteString(heatShade(value(heatmap.Cells[day][hour]), maxValue))
+		}
+		fmt.Fprintf(w, "  %s  %s\n", labelStyle.Render(day.String()[:3]), row.String())
+	}
+}
+
+// heatShade renders a two-column heatmap cell for v out of maxValue.
+func heatShade(v, maxValue float64) string {
+	if v <= 0 || maxValue <= 0 {
+		return dimStyle.Render("··")
+	}
+	switch ratio := v / maxValue; {
+	case ratio <= 0.25:
+		return successStyle.Render("░░")
+	case ratio <= 0.5:
+		return warningStyle.Render("▒▒")
+	case ratio <= 0.75:
+		return warningStyle.Render("▓▓")
+	default:
+		return failureStyle.Render("██")
+	}
+}
+
+func renderWasteStats(w io.Writer, stats analyzer.WasteStats) {
+	fmt.Fprintln(w)
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col == 0 {
+				return lipgloss.NewStyle()
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers("Category", "Count", "Compute", "Share")
+
+	for _, r := range wasteRows(stats.Waste) {
+		t.Row(r.Label, r.Count, utils.HumanizeTime(float64(r.Ms)/1000), fmt.Sprintf("%.1f%%", stats.Percent(r.Ms)))
+	}
+	total := stats.TotalMs()
+	t.Row(labelStyle.Render("Total"), "", utils.HumanizeTime(float64(total)/1000), fmt.Sprintf("%.1f%%", stats.Percent(total)))
+
+	fmt.Fprintln(w, t)
+	fmt.Fprintf(w, "\n  %s Share of the compute of %d runs with job details.\n", dimStyle.Render("i"), stats.Runs)
+}
+
+func renderRunnerSimulations(w io.Writer, sims []analyzer.RunnerSimulation, sampling analyzer.SamplingInfo) {
+	fmt.Fprintln(w)
+
+	withCost := false
+	for _, s := range sims {
+		if s.Cost > 0 {
+			withCost = true
+		}
+	}
+
+	headers := []string{"Label", "Runners", "Jobs", "Queue p50", "Queue p95", "Queue Max", "Observed p95", "Utilization", "Runner-Hours"}
+	if withCost {
+		headers = append(headers, "Cost")
+	}
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return labelStyle.Bold(true)
+			}
+			if col == 0 {
+				return lipgloss.NewStyle()
+			}
+			return lipgloss.NewStyle().Align(lipgloss.Right)
+		}).
+		Headers(headers...)
+
+	for _, s := range sims {
+		p95Style := successStyle
+		if s.Predicted.P95 > s.Observed.P95 {
+			p95Style = failureStyle
+		}
+		row := []string{
+			s.Label,
+			fmt.Sprintf("%d", s.Runners),
+			fmt.Sprintf("%d", s.Jobs),
+			utils.HumanizeTime(s.Predicted.P50),
+			p95Style.Render(utils.HumanizeTime(s.Predicted.P95)),
+			utils.HumanizeTime(s.Predicted.Max),
+			utils.HumanizeTime(s.Observed.P95),
+			fmt.Sprintf("%.1f%%", s.Utilization),
+			fmt.Sprintf("%.1f", s.RunnerHours),
+		}
+		if withCost {
+			row = append(row, fmt.Sprintf("%.2f", s.Cost))
+		}
+		t.Row(row...)
+	}
+
+	fmt.Fprintln(w, t)
+	policy := analyzer.PolicyFIFO
+	if len(sims) > 0 && sims[0].Policy != "" {
+		policy = sims[0].Policy
+	}
+	fmt.Fprintf(w, "\n  %s Jobs replayed from when they were queued, %s scheduling; runner-hours keep the pool up for the whole replay.\n", dimStyle.Render("i"), policy)
+	if sampling.Enabled {
+		fmt.Fprintf(w, "  %s Only sampled runs are replayed, so contention is understated. Use --no-sample for a full replay.\n", warningStyle.Render("!"))
+	}
+}
+
 // shortSHA safely truncates a SHA to 8 characters.
 func shortSHA(sha string) string {
 	if len(sha) > 8 {
@@ -477,8 +872,8 @@ func shortSHA(sha string) string {
 	return sha
 }
 
-func renderRegressions(w io.Writer, regressions []analyzer.JobRegression) {
-	fmt.Fprintf(w, "\n  %s Jobs that got significantly slower (>10%% increase):\n\n", failureStyle.Render("!"))
+func renderRegressions(w io.Writer, regressions []analyzer.JobRegression, noun string, confidence float64) {
+	fmt.Fprintf(w, "\n  %s %s that got significantly slower (>10%% increase):\n\n", failureStyle.Render("!"), noun)
 
 	t := table.New().
 		Border(lipgloss.RoundedBorder()).
@@ -492,26 +887,35 @@ func renderRegressions(w io.Writer, regressions []analyzer.JobRegression) {
 			}
 			return lipgloss.NewStyle().Align(lipgloss.Right)
 		}).
-		Headers("Job Name", "Was", "Now", "Change")
+		Headers(strings.TrimSuffix(noun, "s")+" Name", "Was", "Now", "Change", ciLabel(confidence), "Significance")
 
+	insignificant := 0
 	for _, reg := range regressions {
+		change := failureStyle.Render(fmt.Sprintf("+%.1f%%", reg.PercentIncrease))
+		if !reg.Significant {
+			change = dimStyle.Render(fmt.Sprintf("~+%.1f%%", reg.PercentIncrease))
+			insignificant++
+		}
 		t.Row(
-			linkName(reg.Name, reg.URLs, 58),
+			linkName(changeName(reg.Name, reg.Step), reg.URLs, 58),
 			utils.HumanizeTime(reg.OldAvgDuration),
 			utils.HumanizeTime(reg.NewAvgDuration),
-			failureStyle.Render(fmt.Sprintf("+%.1f%%", reg.PercentIncrease)),
+			change,
+			percentCI(reg.PercentCI, true),
+			dimStyle.Render(formatPValue(reg.PValue)),
 		)
 	}
 
 	fmt.Fprintln(w, t)
+	renderInsignificantNote(w, insignificant, confidence)
 
-	// Render changepoint details for regressions that have them
+	// Render changepoint details for significant regressions that have them
 	for _, reg := range regressions {
-		if reg.Changepoint == nil {
+		if reg.Changepoint == nil || !reg.Significant {
 			continue
 		}
 		cp := reg.Changepoint
-		fmt.Fprintf(w, "\n  %s %s\n", labelStyle.Render("Changepoint:"), valueStyle.Render(reg.Name))
+		fmt.Fprintf(w, "\n  %s %s\n", labelStyle.Render("Changepoint:"), valueStyle.Render(changeName(reg.Name, reg.Step)))
 		fmt.Fprintf(w, "     %s  %s\n", labelStyle.Render("Date:    "), valueStyle.Render(cp.Date.Format("Jan 02, 2006 15:04")))
 		fmt.Fprintf(w, "     %s  %s %s %s\n",
 			labelStyle.Render("Duration:"),
@@ -539,18 +943,18 @@ func renderRegressions(w io.Writer, regressions []analyzer.JobRegression) {
 		}
 		fmt.Fprintf(w, "     %s  %s\n",
 			labelStyle.Render("Position:"),
-			dimStyle.Render(fmt.Sprintf("observation %d of %d", cp.Index, cp.TotalPoints)))
+			dimStyle.Render(fmt.Sprintf("observation %d of %d, %s", cp.Index, cp.TotalPoints, formatPValue(cp.PValue))))
 	}
 
-	fmt.Fprintf(w, "\n  %s Investigate these jobs for:\n", subheaderStyle.Render("i"))
+	fmt.Fprintf(w, "\n  %s Investigate these %s for:\n", subheaderStyle.Render("i"), strings.ToLower(noun))
 	fmt.Fprintf(w, "     %s Recent code changes that may have added overhead\n", dimStyle.Render("•"))
 	fmt.Fprintf(w, "     %s Missing or invalid caches\n", dimStyle.Render("•"))
 	fmt.Fprintf(w, "     %s Resource contention or runner performance issues\n", dimStyle.Render("•"))
 	fmt.Fprintf(w, "     %s Dependencies that need updating or optimization\n", dimStyle.Render("•"))
 }
 
-func renderImprovements(w io.Writer, improvements []analyzer.JobImprovement) {
-	fmt.Fprintf(w, "\n  %s Jobs that got significantly faster (>10%% decrease):\n\n", successStyle.Render("✓"))
+func renderImprovements(w io.Writer, improvements []analyzer.JobImprovement, noun string, confidence float64) {
+	fmt.Fprintf(w, "\n  %s %s that got significantly faster (>10%% decrease):\n\n", successStyle.Render("✓"), noun)
 
 	t := table.New().
 		Border(lipgloss.RoundedBorder()).
@@ -564,26 +968,35 @@ func renderImprovements(w io.Writer, improvements []analyzer.JobImprovement) {
 			}
 			return lipgloss.NewStyle().Align(lipgloss.Right)
 		}).
-		Headers("Job Name", "Was", "Now", "Change")
+		Headers(strings.TrimSuffix(noun, "s")+" Name", "Was", "Now", "Change", ciLabel(confidence), "Significance")
 
+	insignificant := 0
 	for _, imp := range improvements {
+		change := successStyle.Render(fmt.Sprintf("-%.1f%%", imp.PercentDecrease))
+		if !imp.Significant {
+			change = dimStyle.Render(fmt.Sprintf("~-%.1f%%", imp.PercentDecrease))
+			insignificant++
+		}
 		t.Row(
-			linkName(imp.Name, imp.URLs, 58),
+			linkName(changeName(imp.Name, imp.Step), imp.URLs, 58),
 			utils.HumanizeTime(imp.OldAvgDuration),
 			utils.HumanizeTime(imp.NewAvgDuration),
-			successStyle.Render(fmt.Sprintf("-%.1f%%", imp.PercentDecrease)),
+			change,
+			percentCI(analyzer.ConfidenceInterval{Low: -imp.PercentCI.High, High: -imp.PercentCI.Low}, true),
+			dimStyle.Render(formatPValue(imp.PValue)),
 		)
 	}
 
 	fmt.Fprintln(w, t)
+	renderInsignificantNote(w, insignificant, confidence)
 
-	// Render changepoint details for improvements that have them
+	// Render changepoint details for significant improvements that have them
 	for _, imp := range improvements {
-		if imp.Changepoint == nil {
+		if imp.Changepoint == nil || !imp.Significant {
 			continue
 		}
 		cp := imp.Changepoint
-		fmt.Fprintf(w, "\n  %s %s\n", labelStyle.Render("Changepoint:"), valueStyle.Render(imp.Name))
+		fmt.Fprintf(w, "\n  %s %s\n", labelStyle.Render("Changepoint:"), valueStyle.Render(changeName(imp.Name, imp.Step)))
 		fmt.Fprintf(w, "     %s  %s\n", labelStyle.Render("Date:    "), valueStyle.Render(cp.Date.Format("Jan 02, 2006 15:04")))
 		fmt.Fprintf(w, "     %s  %s %s %s\n",
 			labelStyle.Render("Duration:"),
@@ -611,8 +1024,52 @@ func renderImprovements(w io.Writer, improvements []analyzer.JobImprovement) {
 		}
 		fmt.Fprintf(w, "     %s  %s\n",
 			labelStyle.Render("Position:"),
-			dimStyle.Render(fmt.Sprintf("observation %d of %d", cp.Index, cp.TotalPoints)))
+			dimStyle.Render(fmt.Sprintf("observation %d of %d, %s", cp.Index, cp.TotalPoints, formatPValue(cp.PValue))))
+	}
+}
+
+// renderInsignificantNote explains the changes marked ~ in a changes table.
+func renderInsignificantNote(w io.Writer, n int, confidence float64) {
+	if n == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n  %s %s not significant at %.0f%% confidence (Mann-Whitney U) and may be noise\n",
+		dimStyle.Render("~"), countNoun(n, "change"), confidence*100)
+}
+
+func renderFailureClusters(w io.Writer, clusters []analyzer.FailureCluster, sampling analyzer.SamplingInfo) {
+	scope := "failed jobs"
+	if sampling.Enabled {
+		scope = "failed jobs of sampled runs"
+	}
+	fmt.Fprintf(w, "\n  %s Most common reasons %s failed:\n", failureStyle.Render("✗"), scope)
+
+	for i, c := range clusters {
+		links, _ := formatSampleLinks(c.URLs)
+		fmt.Fprintf(w, "\n  %s %s %s\n",
+			numStyle.Render(fmt.Sprintf("%2d.", i+1)),
+			valueStyle.Render(c.Signature),
+			failureStyle.Render(fmt.Sprintf("×%d", c.Count)))
+		if c.Example != "" && c.Example != c.Signature {
+			fmt.Fprintf(w, "      %s %s\n", labelStyle.Render("e.g."), dimStyle.Render(firstLine(c.Example, 100)))
+		}
+		seen := c.FirstSeen.Format("Jan 02")
+		if last := c.LastSeen.Format("Jan 02"); last != seen {
+			seen += " – " + last
+		}
+		fmt.Fprintf(w, "      %s%s\n",
+			dimStyle.Render(fmt.Sprintf("in %s · from %s · %s", strings.Join(c.Jobs, ", "), c.Source, seen)),
+			links)
+	}
+}
+
+// firstLine returns the first line of s, truncated to n bytes.
+func firstLine(s string, n int) string {
+	line, _, _ := strings.Cut(s, "\n")
+	if len(line) > n {
+		line = line[:n-3] + "..."
 	}
+	return line
 }
 
 func renderLegend(w io.Writer) {
@@ -621,3 +1078,43 @@ func renderLegend(w io.Writer) {
 	fmt.Fprintf(w, "     %s  Job got slower (>5%% regression)\n", failureStyle.Render("⚠"))
 	fmt.Fprintf(w, "     %s  Job performance is stable (<5%% change)\n", dimStyle.Render("→"))
 }
+
+// jobAliasLimit is how many jobs with aliases are listed, and
+// jobAliasNames how many of the names of each.
+const (
+	jobAliasLimit = 10
+	jobAliasNames = 3
+)
+
+// renderJobIdentities lists the renames followed and the names folded into
+// one job, so that reported job names can be traced back to GitHub's.
+func renderJobIdentities(w io.Writer, renames []analyzer.JobRename, aliases []analyzer.JobAlias) {
+	if len(renames) > 0 {
+		fmt.Fprintf(w, "\n  %s Renamed jobs, reported under their new name:\n", subheaderStyle.Render("↻"))
+		for _, r := range renames {
+			fmt.Fprintf(w, "     %s %s %s %s\n",
+				dimStyle.Render(r.From),
+				dimStyle.Render("→"),
+				valueStyle.Render(r.To),
+				dimStyle.Render(fmt.Sprintf("since %s · %s", r.At.Format("Jan 02"), path.Base(r.Workflow))))
+		}
+	}
+
+	if len(aliases) > 0 {
+		fmt.Fprintf(w, "\n  %s Job names tracked as one job:\n", subheaderStyle.Render("≡"))
+		for i, a := range aliases {
+			if i == jobAliasLimit {
+				fmt.Fprintf(w, "     %s\n", dimStyle.Render(fmt.Sprintf("... and %d more", len(aliases)-i)))
+				break
+			}
+			names := strings.Join(a.Aliases[:min(len(a.Aliases), jobAliasNames)], ", ")
+			if len(a.Aliases) > jobAliasNames {
+				names += ", …"
+			}
+			fmt.Fprintf(w, "     %s %s %s\n",
+				valueStyle.Render(a.Name),
+				dimStyle.Render("←"),
+				dimStyle.Render(fmt.Sprintf("%s: %s", countNoun(len(a.Aliases), "name"), names)))
+		}
+	}
+}
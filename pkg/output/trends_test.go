@@ -0,0 +1,235 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendsRunnerSimulationSection(t *testing.T) {
+	t.Parallel()
+
+	analysis := &analyzer.TrendAnalysis{
+		Sampling: analyzer.SamplingInfo{Enabled: true},
+		Simulations: []analyzer.RunnerSimulation{{
+			Label:       "ubuntu-latest",
+			Runners:     2,
+			Policy:      analyzer.PolicyShortestFirst,
+			Jobs:        12,
+			Observed:    analyzer.QueuePercentiles{P95: 300},
+			Predicted:   analyzer.QueuePercentiles{P50: 30, P95: 90, Max: 120},
+			RunnerHours: 4,
+			Utilization: 62.5,
+			Cost:        1.92,
+		}},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Runner Simulation")
+	assert.Contains(t, out, "ubuntu-latest")
+	assert.Contains(t, out, "62.5%")
+	assert.Contains(t, out, "1.92")
+	assert.Contains(t, out, "shortest scheduling")
+	assert.Contains(t, out, "--no-sample")
+}
+
+func TestTrendsStepSections(t *testing.T) {
+	t.Parallel()
+
+	analysis := &analyzer.TrendAnalysis{
+		StepTrends: []analyzer.StepTrend{{
+			Job:            "test",
+			Name:           "Install dependencies",
+			AvgDuration:    45,
+			MedianDuration: 40,
+			TotalRuns:      12,
+			TrendDirection: "degrading",
+		}},
+		TopStepRegressions: []analyzer.JobRegression{{
+			Name:            "test",
+			Step:            "Install dependencies",
+			OldAvgDuration:  30,
+			NewAvgDuration:  60,
+			PercentIncrease: 100,
+			Changepoint:     &analyzer.Changepoint{BeforeAvg: 30, AfterAvg: 60, Index: 3, TotalPoints: 6},
+		}},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Step Performance Summary")
+	assert.Contains(t, out, "Top 1 Steps by Average Duration")
+	assert.Contains(t, out, "Top Step Regressions")
+	assert.Contains(t, out, "Steps that got significantly slower")
+	assert.Contains(t, out, "test / Install dependencies")
+	assert.Contains(t, out, "+100.0%")
+	assert.Contains(t, out, "Investigate these steps for")
+	assert.NotContains(t, out, "Top Performance Regressions")
+}
+
+func TestTrendsSignificance(t *testing.T) {
+	t.Parallel()
+
+	cp := &analyzer.Changepoint{BeforeAvg: 30, AfterAvg: 60, Index: 6, TotalPoints: 12, PValue: 0.0004}
+	analysis := &analyzer.TrendAnalysis{
+		Sampling: analyzer.SamplingInfo{Confidence: 0.9},
+		Summary: analyzer.TrendSummary{
+			AvgDuration:     100,
+			AvgDurationCI:   analyzer.ConfidenceInterval{Low: 90, High: 110},
+			TrendDirection:  "degrading",
+			PercentChange:   8,
+			PercentChangeCI: analyzer.ConfidenceInterval{Low: -2, High: 18},
+			TrendPValue:     0.31,
+		},
+		TopRegressions: []analyzer.JobRegression{
+			{Name: "build", OldAvgDuration: 30, NewAvgDuration: 60, PercentIncrease: 100, Changepoint: cp,
+				PValue: 0.002, PercentCI: analyzer.ConfidenceInterval{Low: 80, High: 120}, Significant: true},
+			{Name: "lint", OldAvgDuration: 10, NewAvgDuration: 12, PercentIncrease: 20, Changepoint: cp,
+				PValue: 0.4, PercentCI: analyzer.ConfidenceInterval{Low: -5, High: 45}},
+		},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "90% CI")
+	assert.Contains(t, out, "1m 30s – 1m 50s")
+	assert.Contains(t, out, "-2.0% – +18.0%, p=0.310")
+	assert.Contains(t, out, "not statistically significant at 90% confidence")
+	assert.Contains(t, out, "+80.0% – +120.0%")
+	assert.Contains(t, out, "p=0.002")
+	assert.Contains(t, out, "~+20.0%")
+	assert.Contains(t, out, "1 change not significant at 90% confidence")
+	assert.Contains(t, out, "Changepoint: build")
+	assert.NotContains(t, out, "Changepoint: lint")
+	assert.Contains(t, out, "observation 6 of 12, p<0.001")
+
+	var js bytes.Buffer
+	assert.NoError(t, OutputTrends(&js, analysis, "json"))
+	assert.Contains(t, js.String(), `"Significant": false`)
+	assert.Contains(t, js.String(), `"PValue": 0.002`)
+}
+
+func TestTrendsSegmentSections(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2026, 3, 10+d, 0, 0, 0, 0, time.UTC) }
+	analysis := &analyzer.TrendAnalysis{
+		Sampling: analyzer.SamplingInfo{Enabled: true, SampleSize: 80},
+		Segments: []analyzer.TrendSegment{
+			{Dimension: analyzer.GroupByEvent, Key: "pull_request",
+				Summary:       analyzer.TrendSummary{TotalRuns: 40, AvgDuration: 300, AvgSuccessRate: 90, TrendDirection: "degrading", PercentChange: 12.5},
+				DurationTrend: []analyzer.DataPoint{{Timestamp: day(0), Value: 280}, {Timestamp: day(1), Value: 320}}},
+			{Dimension: analyzer.GroupByEvent, Key: "merge_group",
+				Summary: analyzer.TrendSummary{TotalRuns: 12, AvgDuration: 600, AvgSuccessRate: 100, TrendDirection: "stable"}},
+			{Dimension: analyzer.GroupByOS, Key: "macOS",
+				Summary: analyzer.TrendSummary{TotalRuns: 30, AvgDuration: 900, AvgSuccessRate: 95, TrendDirection: "improving", PercentChange: -8}},
+		},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Trends by event")
+	assert.Contains(t, out, "pull_request")
+	assert.Contains(t, out, "merge_group")
+	assert.Contains(t, out, "⚠ +12.5%")
+	assert.Contains(t, out, "average duration per day")
+	assert.Contains(t, out, "Trends by os")
+	assert.Contains(t, out, "Jobs of the 80 sampled runs only")
+	assert.Contains(t, out, "✓ -8.0%")
+}
+
+func TestTrendsQueueHeatmapSection(t *testing.T) {
+	t.Parallel()
+
+	var heatmap analyzer.QueueHeatmap
+	heatmap.Timezone = "Europe/Berlin"
+	heatmap.Cells[time.Monday][9] = analyzer.HeatmapCell{Runs: 12, Jobs: 40, AvgQueue: 240, P95Queue: 600}
+	heatmap.Cells[time.Sunday][3] = analyzer.HeatmapCell{Runs: 1}
+	heatmap.MaxRuns = 12
+	heatmap.MaxQueue = 240
+	heatmap.Worst = []analyzer.HeatmapWindow{{Weekday: time.Monday, Hour: 9, Jobs: 40, AvgQueue: 240, P95Queue: 600}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, &analyzer.TrendAnalysis{QueueHeatmap: heatmap}, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Queue Time Heatmap")
+	assert.Contains(t, out, "Hours in Europe/Berlin")
+	assert.Contains(t, out, "00    03    06    09    12    15    18    21")
+	assert.Contains(t, out, "Runs started")
+	assert.Contains(t, out, "Average queue time")
+	assert.Contains(t, out, "Mon 09:00–10:00")
+	assert.Contains(t, out, "over 40 jobs")
+	assert.Contains(t, out, "Schedule cron workflows away")
+
+	var js bytes.Buffer
+	assert.NoError(t, OutputTrends(&js, &analyzer.TrendAnalysis{QueueHeatmap: heatmap}, "json"))
+	assert.Contains(t, js.String(), `"Timezone": "Europe/Berlin"`)
+	assert.Contains(t, js.String(), `"AvgQueue": 240`)
+
+	var none bytes.Buffer
+	assert.NoError(t, OutputTrends(&none, &analyzer.TrendAnalysis{}, "terminal"))
+	assert.NotContains(t, none.String(), "Queue Time Heatmap")
+}
+
+func TestTrendsFailureClustersSection(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	analysis := &analyzer.TrendAnalysis{
+		Sampling: analyzer.SamplingInfo{Enabled: true},
+		FailureClusters: []analyzer.FailureCluster{{
+			Signature: "dial tcp <ip>:<n>: connect: connection refused",
+			Example:   "dial tcp 10.0.0.1:5432: connect: connection refused",
+			Source:    analyzer.FailureFromAnnotation,
+			Count:     3,
+			Jobs:      []string{"e2e", "test"},
+			FirstSeen: base,
+			LastSeen:  base.Add(48 * time.Hour),
+			URLs:      []string{"https://github.com/o/r/actions/runs/3/job/31"},
+		}},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Top Failure Signatures")
+	assert.Contains(t, out, "failed jobs of sampled runs")
+	assert.Contains(t, out, "dial tcp <ip>:<n>: connect: connection refused")
+	assert.Contains(t, out, "×3")
+	assert.Contains(t, out, "10.0.0.1:5432")
+	assert.Contains(t, out, "in e2e, test · from annotation")
+
+	var none bytes.Buffer
+	assert.NoError(t, OutputTrends(&none, &analyzer.TrendAnalysis{}, "terminal"))
+	assert.NotContains(t, none.String(), "Top Failure Signatures")
+}
+
+func TestTrendsJobIdentitiesSection(t *testing.T) {
+	t.Parallel()
+
+	analysis := &analyzer.TrendAnalysis{
+		JobRenames: []analyzer.JobRename{{
+			Workflow: ".github/workflows/ci.yml",
+			From:     "Unit tests",
+			To:       "Tests",
+			At:       time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC),
+		}},
+		JobAliases: []analyzer.JobAlias{{
+			Name:    "deploy-pr-<n>",
+			Aliases: []string{"deploy-pr-101", "deploy-pr-102", "deploy-pr-103", "deploy-pr-104"},
+		}},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Job Identities")
+	assert.Contains(t, out, "Unit tests → Tests since Mar 12 · ci.yml")
+	assert.Contains(t, out, "deploy-pr-<n> ← 4 names: deploy-pr-101, deploy-pr-102, deploy-pr-103, …")
+
+	var none bytes.Buffer
+	assert.NoError(t, OutputTrends(&none, &analyzer.TrendAnalysis{}, "terminal"))
+	assert.NotContains(t, none.String(), "Job Identities")
+}
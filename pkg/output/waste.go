@@ -0,0 +1,93 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// wasteRow is one category of wasted compute.
+type wasteRow struct {
+	Label string
+	Count string // e.g. "2 runs"
+	Ms    int64
+}
+
+// wasteRows lists the categories that wasted any compute.
+func wasteRows(waste analyzer.Waste) []wasteRow {
+	all := []wasteRow{
+		{"Superseded", countNoun(waste.SupersededRuns, "run"), waste.SupersededMs},
+		{"Cancelled", countNoun(waste.CancelledRuns, "run"), waste.CancelledMs},
+		{"Retried", countNoun(waste.RetriedAttempts, "attempt"), waste.RetriedMs},
+		{"Timed out", countNoun(waste.TimedOutJobs, "job"), waste.TimedOutMs},
+	}
+	var rows []wasteRow
+	for _, r := range all {
+		if r.Ms > 0 {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// countNoun formats a count with its noun, e.g. "1 run" or "2 runs".
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// resultWastes returns the results that wasted compute.
+func resultWastes(urlResults []analyzer.URLResult) []analyzer.URLResult {
+	var wasted []analyzer.URLResult
+	for _, result := range urlResults {
+		if result.Metrics.Waste.TotalMs() > 0 {
+			wasted = append(wasted, result)
+		}
+	}
+	return wasted
+}
+
+// writeStyledWaste prints the Waste section of the styled report.
+func writeStyledWaste(w io.Writer, results []analyzer.URLResult) {
+	styledSection(w, "Waste")
+	for _, result := range results {
+		waste := result.Metrics.Waste
+		fmt.Fprintf(w, "  %s %s  %s\n",
+			dimStyle.Render(fmt.Sprintf("[%d]", result.URLIndex+1)),
+			valueStyle.Render(utils.MakeClickableLink(result.DisplayURL, result.DisplayName)),
+			numStyle.Render(utils.HumanizeTime(float64(waste.TotalMs())/1000)+" wasted"))
+		for _, r := range wasteRows(waste) {
+			fmt.Fprintf(w, "      %-10s %10s  %s\n",
+				labelStyle.Render(r.Label),
+				numStyle.Render(utils.HumanizeTime(float64(r.Ms)/1000)),
+				dimStyle.Render(r.Count))
+		}
+		if waste.BillableMs > 0 {
+			fmt.Fprintf(w, "      %s\n", dimStyle.Render(fmt.Sprintf("%s billable in cancelled runs",
+				utils.HumanizeTime(float64(waste.BillableMs)/1000))))
+		}
+	}
+}
+
+// writeMarkdownWaste prints the Waste table of the markdown report.
+func writeMarkdownWaste(w io.Writer, results []analyzer.URLResult) {
+	fmt.Fprintln(w, "## Waste")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "| Source | Superseded | Cancelled | Retried | Timed out | Total |")
+	fmt.Fprintln(w, "| --- | ---: | ---: | ---: | ---: | ---: |")
+	for _, result := range results {
+		waste := result.Metrics.Waste
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n",
+			markdownLink(result.DisplayURL, result.DisplayName),
+			utils.HumanizeTime(float64(waste.SupersededMs)/1000),
+			utils.HumanizeTime(float64(waste.CancelledMs)/1000),
+			utils.HumanizeTime(float64(waste.RetriedMs)/1000),
+			utils.HumanizeTime(float64(waste.TimedOutMs)/1000),
+			utils.HumanizeTime(float64(waste.TotalMs())/1000))
+	}
+	fmt.Fprintln(w, "")
+}
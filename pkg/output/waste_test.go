@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWasteSections(t *testing.T) {
+	t.Parallel()
+
+	results, combined, start, end, spans := reportTestInput()
+
+	var none bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&none, results, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.NotContains(t, none.String(), "## Waste")
+
+	wasted := results[0]
+	wasted.Metrics.Waste = analyzer.Waste{
+		SupersededRuns: 2, SupersededMs: 240000,
+		RetriedAttempts: 1, RetriedMs: 60000,
+		BillableMs: 300000,
+	}
+
+	var md bytes.Buffer
+	assert.NoError(t, OutputCombinedResultsMarkdown(&md, []analyzer.URLResult{wasted}, combined, nil, start, end, "", false, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, md.String(), "## Waste")
+	assert.Contains(t, md.String(), "| [PR #42](https://github.com/o/r/pull/42) | 4m | 0s | 1m | 0s | 5m |")
+
+	var styled bytes.Buffer
+	assert.NoError(t, OutputStyledResults(&styled, []analyzer.URLResult{wasted}, combined, nil, start, end, spans, enrichment.DefaultEnricher()))
+	assert.Contains(t, styled.String(), "5m wasted")
+	assert.Contains(t, styled.String(), "2 runs")
+	assert.Contains(t, styled.String(), "1 attempt")
+	assert.Contains(t, styled.String(), "5m billable in cancelled runs")
+	assert.NotContains(t, styled.String(), "Timed out")
+}
+
+func TestTrendsWasteSection(t *testing.T) {
+	t.Parallel()
+
+	analysis := &analyzer.TrendAnalysis{
+		Waste: analyzer.WasteStats{
+			Waste:     analyzer.Waste{CancelledRuns: 1, CancelledMs: 60000, TimedOutJobs: 2, TimedOutMs: 120000},
+			Runs:      10,
+			ComputeMs: 600000,
+		},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, OutputTrends(&buf, analysis, "terminal"))
+	out := buf.String()
+	assert.Contains(t, out, "Waste")
+	assert.Contains(t, out, "10.0%")
+	assert.Contains(t, out, "30.0%")
+	assert.Contains(t, out, "compute of 10 runs with job details")
+}
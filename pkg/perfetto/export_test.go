@@ -194,6 +194,56 @@ func TestWriteTrace(t *testing.T) {
 	assert.True(t, markerFound, "marker instant event 'Review: APPROVED' not found")
 }
 
+func TestWriteTraceRunnerLanes(t *testing.T) {
+	globalStart := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	job := func(name string, jobID int64, runner string) trace.ReadOnlySpan {
+		return (&tracetest.SpanStub{
+			Name:      name,
+			StartTime: globalStart,
+			EndTime:   globalStart.Add(time.Minute),
+			Attributes: []attribute.KeyValue{
+				attribute.String("type", "job"),
+				attribute.Int64("github.run_id", 12345),
+				attribute.Int64("github.job_id", jobID),
+				attribute.String(analyzer.AttrRunnerName, runner),
+				attribute.String(analyzer.AttrRunnerGroup, "Default"),
+			},
+		}).Snapshot()
+	}
+
+	tempFile := "test_runner_trace.pftrace"
+	defer os.Remove(tempFile)
+
+	var buf bytes.Buffer
+	spans := []trace.ReadOnlySpan{job("build", 1, "runner-1"), job("test", 2, "runner-1"), job("skipped", 3, "")}
+	require.NoError(t, WriteTrace(&buf, nil, analyzer.CombinedMetrics{}, nil, globalStart.UnixMilli(), tempFile, false, spans))
+
+	data, err := os.ReadFile(tempFile)
+	require.NoError(t, err)
+
+	tracks := map[string]uint64{}
+	slices := map[uint64]int{}
+	for _, pkt := range extractSubmessages(data, 1) {
+		for _, desc := range extractSubmessages(pkt, 60) {
+			name, _ := extractStringField(desc, 2)
+			uuid, _ := extractVarintField(desc, 1)
+			tracks[name] = uuid
+		}
+		for _, te := range extractSubmessages(pkt, 11) {
+			if eventType, _ := extractVarintField(te, 9); eventType == typeSliceBegin {
+				uuid, _ := extractVarintField(te, 11)
+				slices[uuid]++
+			}
+		}
+	}
+
+	assert.Contains(t, tracks, "Runners")
+	if assert.Contains(t, tracks, "runner-1 (Default)") {
+		assert.Equal(t, 2, slices[tracks["runner-1 (Default)"]], "both jobs sit on the runner's lane")
+	}
+}
+
 func TestWriteTraceWithLegacyEvents(t *testing.T) {
 	globalStart := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
 
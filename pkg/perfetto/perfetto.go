@@ -14,6 +14,9 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
+// runnersPid is the process grouping the per-runner swimlane tracks.
+const runnersPid = 997
+
 // trackKey identifies a track by its process/thread pair.
 type trackKey struct {
 	pid, tid int
@@ -221,6 +224,22 @@ func WriteTrace(w io.Writer, urlResults []analyzer.URLResult, combined analyzer.
 			annotations: annotations,
 			isInstant:   isMarker,
 		})
+
+		// Jobs are repeated on a swimlane per runner, grouped under one process
+		if runner, _ := attrs[analyzer.AttrRunnerName].(string); spanType == "job" && runner != "" {
+			laneName := runner
+			if group, _ := attrs[analyzer.AttrRunnerGroup].(string); group != "" {
+				laneName += " (" + group + ")"
+			}
+			getProcessTrack(runnersPid, "Runners")
+			events = append(events, spanEvent{
+				trackUUID:   getThreadTrack(runnersPid, int(makeUUID("runner", runner)%2147483647), laneName),
+				startNs:     uint64(startNs),
+				endNs:       uint64(endNs),
+				name:        name,
+				annotations: annotations,
+			})
+		}
 	}
 
 	// Process legacy TraceEvents (skip metadata, convert data events)
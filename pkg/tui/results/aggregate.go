@@ -0,0 +1,422 @@
+package results
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// aggregateKeyCandidates are the grouping keys offered by the aggregate view,
+// in cycling order. Attribute keys are only offered when some span carries them.
+var aggregateKeyCandidates = []string{
+	analyzer.AggregateByName,
+	"cicd.pipeline.task.name",
+	"http.route",
+	"db.statement",
+	"rpc.method",
+	"service.name",
+}
+
+// aggregateNumWidth is the width of each numeric column in the aggregate table.
+const aggregateNumWidth = 9
+
+// availableAggregateKeys returns the candidate keys present in the tree.
+func availableAggregateKeys(roots []*analyzer.TreeNode) []string {
+	present := map[string]bool{analyzer.AggregateByName: true}
+	var walk func(nodes []*analyzer.TreeNode)
+	walk = func(nodes []*analyzer.TreeNode) {
+		for _, n := range nodes {
+			for _, k := range aggregateKeyCandidates {
+				if n.Attrs[k] != "" {
+					present[k] = true
+				}
+			}
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+
+	var keys []string
+	for _, k := range aggregateKeyCandidates {
+		if present[k] {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// toggleAggregate opens or closes the aggregate view.
+func (m *Model) toggleAggregate() {
+	m.showAggregate = !m.showAggregate
+	if m.showAggregate {
+		if m.aggGroupBy == "" {
+			m.aggGroupBy = analyzer.AggregateByName
+		}
+		if m.aggSort == "" {
+			m.aggSort = "total"
+		}
+		m.rebuildAggregate()
+	}
+}
+
+// rebuildAggregate regroups and sorts spans for the current key and column.
+func (m *Model) rebuildAggregate() {
+	m.aggGroups = analyzer.AggregateSpans(m.roots, m.aggGroupBy)
+	_ = analyzer.SortSpanGroups(m.aggGroups, m.aggSort)
+	m.aggDrilled = false
+	m.aggSpanCursor = 0
+	if m.aggCursor >= len(m.aggGroups) {
+		m.aggCursor = len(m.aggGroups) - 1
+	}
+	if m.aggCursor < 0 {
+		m.aggCursor = 0
+	}
+}
+
+// cycleAggregateSort advances to the next sort column, keeping the cursor on
+// the same group.
+func (m *Model) cycleAggregateSort() {
+	idx := 0
+	for i, f := range analyzer.AggregateSortFields {
+		if f == m.aggSort {
+			idx = i
+		}
+	}
+	m.aggSort = analyzer.AggregateSortFields[(idx+1)%len(analyzer.AggregateSortFields)]
+
+	var curKey string
+	if m.aggCursor < len(m.aggGroups) {
+		curKey = m.aggGroups[m.aggCursor].Key
+	}
+	_ = analyzer.SortSpanGroups(m.aggGroups, m.aggSort)
+	for i, g := range m.aggGroups {
+		if g.Key == curKey {
+			m.aggCursor = i
+		}
+	}
+}
+
+// cycleAggregateKey advances to the next grouping key present in the data.
+func (m *Model) cycleAggregateKey() {
+	keys := availableAggregateKeys(m.roots)
+	idx := 0
+	for i, k := range keys {
+		if k == m.aggGroupBy {
+			idx = i
+		}
+	}
+	m.aggGroupBy = keys[(idx+1)%len(keys)]
+	m.aggCursor = 0
+	m.rebuildAggregate()
+}
+
+// aggregateRowCount returns how many rows the current aggregate list has.
+func (m Model) aggregateRowCount() int {
+	if m.aggDrilled && m.aggCursor < len(m.aggGroups) {
+		return len(m.aggGroups[m.aggCursor].Nodes)
+	}
+	return len(m.aggGroups)
+}
+
+// updateAggregate handles key presses while the aggregate view is shown.
+func (m Model) updateAggregate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	drilled := m.aggDrilled
+	cursor := &m.aggCursor
+	if drilled {
+		cursor = &m.aggSpanCursor
+	}
+	rows := m.aggregateRowCount()
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Help):
+		m.showHelpModal = true
+
+	case key.Matches(msg, m.keys.Aggregate):
+		m.showAggregate = false
+
+	case key.Matches(msg, m.keys.Up):
+		if *cursor > 0 {
+			*cursor--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if *cursor < rows-1 {
+			*cursor++
+		}
+
+	case key.Matches(msg, m.keys.PageUp):
+		*cursor = max(0, *cursor-m.aggregatePageSize()/2)
+
+	case key.Matches(msg, m.keys.PageDown):
+		*cursor = max(0, min(rows-1, *cursor+m.aggregatePageSize()/2))
+
+	case key.Matches(msg, m.keys.Sort):
+		if !drilled {
+			m.cycleAggregateSort()
+		}
+
+	case key.Matches(msg, m.keys.AggregateBy):
+		if !drilled {
+			m.cycleAggregateKey()
+		}
+
+	case key.Matches(msg, m.keys.Enter), key.Matches(msg, m.keys.Right):
+		if !drilled {
+			if m.aggCursor < len(m.aggGroups) {
+				m.aggDrilled = true
+				m.aggSpanCursor = 0
+			}
+			return m, nil
+		}
+		// Jump from an individual span back to the tree view
+		nodes := m.aggGroups[m.aggCursor].Nodes
+		if m.aggSpanCursor < len(nodes) && m.revealNode(nodes[m.aggSpanCursor]) {
+			m.showAggregate = false
+		}
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Left):
+		if drilled {
+			m.aggDrilled = false
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Back) {
+			m.showAggregate = false
+		}
+	}
+	return m, nil
+}
+
+// revealNode expands the ancestors of the tree item built from node and moves
+// the cursor onto it. It reports whether the item was found.
+func (m *Model) revealNode(node *analyzer.TreeNode) bool {
+	var target *TreeItem
+	var walk func(items []*TreeItem)
+	walk = func(items []*TreeItem) {
+		for _, item := range items {
+			if item.sourceNode == node && target == nil {
+				target = item
+			}
+			walk(item.Children)
+		}
+	}
+	walk(m.treeItems)
+	if target == nil {
+		return false
+	}
+	return m.revealItem(target)
+}
+
+// revealItem expands the ancestors of target, clears any search filter and
+// moves the cursor to it.
+func (m *Model) revealItem(target *TreeItem) bool {
+	parentOf := make(map[string]string)
+	var walk func(items []*TreeItem)
+	walk = func(items []*TreeItem) {
+		for _, item := range items {
+			parentOf[item.ID] = item.ParentID
+			walk(item.Children)
+		}
+	}
+	walk(m.treeItems)
+
+	for id := target.ParentID; id != ""; id = parentOf[id] {
+		m.expandedState[id] = true
+	}
+	m.searchQuery = ""
+	m.searchMatchIDs = nil
+	m.searchAncIDs = nil
+	m.rebuildItems()
+	m.recalculateChartBounds()
+	for i, item := range m.visibleItems {
+		if item.ID == target.ID {
+			m.cursor = i
+			m.selectionStart = -1
+			return true
+		}
+	}
+	return false
+}
+
+// aggregatePageSize returns the number of table rows that fit on screen.
+func (m Model) aggregatePageSize() int {
+	// Reuses the flame graph layout: same header and footer
+	return m.flameRowCapacity() - 1 // column header row
+}
+
+// renderAggregateView renders the aggregate table in place of the tree and timeline.
+func (m Model) renderAggregateView(width, height int) string {
+	var b strings.Builder
+
+	totalWidth := width - horizontalPad*2
+	if totalWidth < 1 {
+		totalWidth = 80
+	}
+	contentWidth := totalWidth - 2
+	innerWidth := contentWidth - 2
+	pageSize := max(1, m.aggregatePageSize())
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(m.renderAggregateTitle(contentWidth))
+	b.WriteString("\n")
+	blankLine := BorderStyle.Render("│") + strings.Repeat(" ", contentWidth) + BorderStyle.Render("│")
+	b.WriteString(blankLine)
+	b.WriteString("\n")
+
+	row := func(content string) string {
+		pad := innerWidth - lipgloss.Width(content)
+		if pad < 0 {
+			content = ansi.Truncate(content, innerWidth, "…")
+			pad = 0
+		}
+		return BorderStyle.Render("│") + " " + content + strings.Repeat(" ", pad) + " " + BorderStyle.Render("│") + "\n"
+	}
+
+	var lines []string
+	var header string
+	cursor := m.aggCursor
+	if m.aggDrilled && m.aggCursor < len(m.aggGroups) {
+		cursor = m.aggSpanCursor
+		header, lines = m.aggregateSpanLines(innerWidth)
+	} else {
+		header, lines = m.aggregateGroupLines(innerWidth)
+	}
+	b.WriteString(row(HeaderCountStyle.Render(header)))
+
+	start := 0
+	if len(lines) > pageSize {
+		start = max(0, min(cursor-pageSize/2, len(lines)-pageSize))
+	}
+	for i := 0; i < pageSize; i++ {
+		idx := start + i
+		if idx >= len(lines) {
+			b.WriteString(blankLine + "\n")
+			continue
+		}
+		line := lines[idx]
+		if idx == cursor {
+			line = SelectedStyle.Render(ansi.Strip(line) + strings.Repeat(" ", max(0, innerWidth-lipgloss.Width(line))))
+		}
+		b.WriteString(row(line))
+	}
+
+	b.WriteString(m.renderFooter())
+
+	if m.showHelpModal {
+		return placeModalCentered(m.renderHelpModal(), width, height)
+	}
+	return addHorizontalPadding(b.String(), horizontalPad)
+}
+
+// renderAggregateTitle renders the line above the table: grouping key and sort.
+func (m Model) renderAggregateTitle(contentWidth int) string {
+	text := HeaderStyle.Render("Aggregate by "+m.aggGroupBy) +
+		HeaderCountStyle.Render(fmt.Sprintf(" · %d groups · sort:%s", len(m.aggGroups), m.aggSort))
+	if m.aggDrilled && m.aggCursor < len(m.aggGroups) {
+		g := m.aggGroups[m.aggCursor]
+		text += HeaderCountStyle.Render(" · ") + BreadcrumbActiveStyle.Render(g.Key) +
+			HeaderCountStyle.Render(fmt.Sprintf(" (%d spans)", g.Count))
+	}
+	text = ansi.Truncate(text, contentWidth-2, "…")
+	pad := max(0, contentWidth-1-lipgloss.Width(text))
+	return BorderStyle.Render("│") + " " + text + strings.Repeat(" ", pad) + BorderStyle.Render("│")
+}
+
+// aggregateGroupLines renders one line per group with its statistics.
+func (m Model) aggregateGroupLines(width int) (string, []string) {
+	// Column labels keyed by the sort field they display
+	cols := []struct{ field, label string }{
+		{"count", "count"}, {"total", "total"}, {"self", "self"},
+		{"p50", "p50"}, {"p95", "p95"}, {"max", "max"}, {"errors", "err%"},
+	}
+	keyWidth := max(10, width-len(cols)*aggregateNumWidth)
+
+	var h strings.Builder
+	keyLabel := m.aggGroupBy
+	if m.aggSort == "key" {
+		keyLabel += "▴"
+	}
+	h.WriteString(padRight(keyLabel, keyLabel, keyWidth))
+	for _, c := range cols {
+		label := c.label
+		if c.field == m.aggSort {
+			label += "▾"
+		}
+		h.WriteString(fmt.Sprintf("%*s", aggregateNumWidth, label))
+	}
+
+	lines := make([]string, 0, len(m.aggGroups))
+	for _, g := range m.aggGroups {
+		key := ansi.Truncate(g.Key, keyWidth-1, "…")
+		errCell := fmt.Sprintf("%*s", aggregateNumWidth, fmt.Sprintf("%.0f%%", g.ErrorRate()))
+		if g.Errors > 0 {
+			errCell = FailureStyle.Render(errCell)
+		}
+		lines = append(lines, padRight(key, key, keyWidth)+
+			fmt.Sprintf("%*d", aggregateNumWidth, g.Count)+
+			aggregateDuration(g.Total)+
+			aggregateDuration(g.Self)+
+			aggregateDuration(g.P50)+
+			aggregateDuration(g.P95)+
+			aggregateDuration(g.Max)+
+			errCell)
+	}
+	return h.String(), lines
+}
+
+// aggregateSpanLines renders the individual spans of the drilled-down group.
+func (m Model) aggregateSpanLines(width int) (string, []string) {
+	g := m.aggGroups[m.aggCursor]
+	nameWidth := max(10, width-3*aggregateNumWidth-2)
+
+	header := "  " + padRight("span", "span", nameWidth) +
+		fmt.Sprintf("%*s%*s%*s", aggregateNumWidth, "duration", aggregateNumWidth, "self", aggregateNumWidth, "start")
+
+	lines := make([]string, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		item := TreeItem{Hints: n.Hints}
+		name := ansi.Truncate(n.Name, nameWidth-1, "…")
+		offset := "-"
+		if !m.globalStart.IsZero() {
+			offset = "+" + utils.HumanizeTime(n.StartTime.Sub(m.globalStart).Seconds())
+		}
+		lines = append(lines, getStyledStatusIcon(item)+" "+padRight(name, name, nameWidth)+
+			aggregateDuration(n.Duration())+
+			aggregateDuration(n.SelfTime())+
+			fmt.Sprintf("%*s", aggregateNumWidth, offset))
+	}
+	return header, lines
+}
+
+// aggregateDuration right-aligns a humanized duration in a numeric column.
+func aggregateDuration(d time.Duration) string {
+	return fmt.Sprintf("%*s", aggregateNumWidth, utils.HumanizeTime(d.Seconds()))
+}
+
+// renderAggregateBreadcrumb shows the selected group (and span when drilled in).
+func (m Model) renderAggregateBreadcrumb(totalWidth int) string {
+	contentWidth := max(10, totalWidth-4)
+	var bc string
+	if m.aggCursor < len(m.aggGroups) {
+		g := m.aggGroups[m.aggCursor]
+		if m.aggDrilled && m.aggSpanCursor < len(g.Nodes) {
+			bc = BreadcrumbStyle.Render(g.Key) + BreadcrumbSepStyle.Render(" › ") +
+				BreadcrumbActiveStyle.Render(g.Nodes[m.aggSpanCursor].Name)
+		} else {
+			bc = BreadcrumbActiveStyle.Render(g.Key)
+		}
+	}
+	bc = ansi.Truncate(bc, contentWidth, "…")
+	pad := max(0, contentWidth-lipgloss.Width(bc))
+	return BorderStyle.Render("│") + " " + bc + strings.Repeat(" ", pad) + " " + BorderStyle.Render("│")
+}
@@ -0,0 +1,82 @@
+package results
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateView(t *testing.T) {
+	t.Parallel()
+
+	press := func(m Model, k string) Model {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		return next.(Model)
+	}
+
+	t.Run("A toggles the aggregate table", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "A")
+		assert.True(t, m.showAggregate)
+		assert.Equal(t, "name", m.aggGroupBy)
+		assert.Equal(t, "total", m.aggSort)
+		// CI, build, test, Checkout, Build
+		assert.Len(t, m.aggGroups, 5)
+		assert.Equal(t, "CI", m.aggGroups[0].Key)
+		assert.Contains(t, m.View(), "Aggregate by name")
+
+		m = press(m, "A")
+		assert.False(t, m.showAggregate)
+	})
+
+	t.Run("s cycles the sort column", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "A")
+		m = press(m, "s")
+		assert.Equal(t, "self", m.aggSort)
+		assert.Equal(t, "CI", m.aggGroups[m.aggCursor].Key, "cursor stays on the same group")
+	})
+
+	t.Run("b only offers keys present in the data", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "A")
+		m = press(m, "b")
+		assert.Equal(t, "name", m.aggGroupBy)
+	})
+
+	t.Run("drills into a group and jumps to a span", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "A")
+		for m.aggGroups[m.aggCursor].Key != "Checkout" {
+			m = press(m, "j")
+		}
+		m = press(m, "enter")
+		assert.True(t, m.aggDrilled)
+		assert.Contains(t, m.View(), "Checkout")
+
+		m = press(m, "enter")
+		assert.False(t, m.showAggregate)
+		assert.Equal(t, "Checkout", m.visibleItems[m.cursor].Name)
+	})
+
+	t.Run("esc leaves the drill-down before closing", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "A")
+		m = press(m, "enter")
+		m = press(m, "esc")
+		assert.True(t, m.showAggregate)
+		assert.False(t, m.aggDrilled)
+		m = press(m, "esc")
+		assert.False(t, m.showAggregate)
+	})
+}
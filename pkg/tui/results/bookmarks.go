@@ -0,0 +1,194 @@
+package results
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bookmarkIndex returns the index of the bookmark on itemID, or -1.
+func (m *Model) bookmarkIndex(itemID string) int {
+	for i, b := range m.bookmarks {
+		if b.ItemID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// startBookmark opens the bookmark modal with a name prompt for the item
+// under the cursor, prefilled with its current bookmark name or item name.
+func (m *Model) startBookmark() {
+	if m.cursor >= len(m.visibleItems) {
+		return
+	}
+	item := m.visibleItems[m.cursor]
+	m.bookmarkTarget = item.ID
+	m.bookmarkInput = item.Name
+	if i := m.bookmarkIndex(item.ID); i >= 0 {
+		m.bookmarkInput = m.bookmarks[i].Name
+	}
+	m.bookmarkNaming = true
+	m.showBookmarks = true
+}
+
+// commitBookmark adds or renames the bookmark for the prompted item.
+func (m *Model) commitBookmark() {
+	item := m.findItemByID(m.bookmarkTarget)
+	if item == nil {
+		return
+	}
+	name := strings.TrimSpace(m.bookmarkInput)
+	if name == "" {
+		name = item.Name
+	}
+	if i := m.bookmarkIndex(item.ID); i >= 0 {
+		m.bookmarks[i].Name = name
+		m.bookmarkCursor = i
+		return
+	}
+	m.bookmarks = append(m.bookmarks, Bookmark{Name: name, ItemID: item.ID, SpanID: item.SpanID})
+	m.bookmarkCursor = len(m.bookmarks) - 1
+}
+
+// jumpToBookmark reveals the bookmarked item in the tree and moves the
+// cursor to it. Falls back to the span ID when the item ID changed.
+func (m *Model) jumpToBookmark(b Bookmark) bool {
+	target := m.findItemByID(b.ItemID)
+	if target == nil && b.SpanID != "" {
+		var walk func(items []*TreeItem)
+		walk = func(items []*TreeItem) {
+			for _, item := range items {
+				if target != nil {
+					return
+				}
+				if item.SpanID == b.SpanID {
+					target = item
+					return
+				}
+				walk(item.Children)
+			}
+		}
+		walk(m.treeItems)
+	}
+	if target == nil {
+		return false
+	}
+	return m.revealItem(target)
+}
+
+// updateBookmarks handles keys while the bookmark modal is open.
+func (m Model) updateBookmarks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.bookmarkNaming {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.bookmarkNaming = false
+			if len(m.bookmarks) == 0 {
+				m.showBookmarks = false
+			}
+		case tea.KeyEnter:
+			m.commitBookmark()
+			m.bookmarkNaming = false
+			m.showBookmarks = false
+		case tea.KeyBackspace:
+			if r := []rune(m.bookmarkInput); len(r) > 0 {
+				m.bookmarkInput = string(r[:len(r)-1])
+			}
+		case tea.KeySpace:
+			m.bookmarkInput += " "
+		case tea.KeyRunes:
+			m.bookmarkInput += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Bookmarks):
+		m.showBookmarks = false
+
+	case key.Matches(msg, m.keys.Up):
+		if m.bookmarkCursor > 0 {
+			m.bookmarkCursor--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if m.bookmarkCursor < len(m.bookmarks)-1 {
+			m.bookmarkCursor++
+		}
+
+	case key.Matches(msg, m.keys.Enter):
+		if m.bookmarkCursor < len(m.bookmarks) {
+			m.jumpToBookmark(m.bookmarks[m.bookmarkCursor])
+			m.showBookmarks = false
+		}
+
+	case msg.String() == "d":
+		if m.bookmarkCursor < len(m.bookmarks) {
+			m.bookmarks = append(m.bookmarks[:m.bookmarkCursor:m.bookmarkCursor], m.bookmarks[m.bookmarkCursor+1:]...)
+			if m.bookmarkCursor >= len(m.bookmarks) && m.bookmarkCursor > 0 {
+				m.bookmarkCursor--
+			}
+		}
+
+	case msg.String() == "r":
+		if m.bookmarkCursor < len(m.bookmarks) {
+			b := m.bookmarks[m.bookmarkCursor]
+			m.bookmarkTarget = b.ItemID
+			m.bookmarkInput = b.Name
+			m.bookmarkNaming = true
+		}
+	}
+	return m, nil
+}
+
+// renderBookmarkModal renders the bookmark list, or the name prompt.
+func (m Model) renderBookmarkModal() string {
+	var b strings.Builder
+	title := " Bookmarks "
+
+	if m.bookmarkNaming {
+		title = " Bookmark "
+		b.WriteString(ModalGroupLabelStyle.Render("Name: "))
+		b.WriteString(ModalValueStyle.Render(m.bookmarkInput))
+		b.WriteString(TimeCursorStyle.Render("▏"))
+		b.WriteString("\n\n")
+		b.WriteString(FooterStyle.Render("enter save • esc cancel"))
+		return overlayFloatingTitle(ModalStyle.Render(b.String()), title)
+	}
+
+	if len(m.bookmarks) == 0 {
+		b.WriteString(HeaderCountStyle.Render("No bookmarks yet. Press M on a span to add one."))
+	}
+	for i, bm := range m.bookmarks {
+		name := bm.Name
+		where := ""
+		if item := m.findItemByID(bm.ItemID); item != nil {
+			if item.Name != bm.Name {
+				where = item.Name
+			}
+		} else {
+			where = "missing"
+		}
+		line := fmt.Sprintf("%d. %s", i+1, name)
+		if i == m.bookmarkCursor {
+			line = SelectedStyle.Render(line)
+		} else {
+			line = ModalValueStyle.Render(line)
+		}
+		if where != "" {
+			line += "  " + HeaderCountStyle.Render(where)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(FooterStyle.Render("enter jump • r rename • d delete • esc close"))
+	return overlayFloatingTitle(ModalStyle.Render(lipgloss.NewStyle().Width(48).Render(b.String())), title)
+}
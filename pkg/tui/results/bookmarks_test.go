@@ -0,0 +1,80 @@
+package results
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func pressSpecial(m Model, t tea.KeyType) Model {
+	newModel, _ := m.Update(tea.KeyMsg{Type: t})
+	return newModel.(Model)
+}
+
+func TestBookmarks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("M names a bookmark for the item under the cursor", func(t *testing.T) {
+		m := createTestModel()
+		m.expandAll()
+		m.cursor = findVisibleIndex(&m, "url-group/0/CI/0/build/0")
+
+		m = pressKey(m, "M")
+		assert.True(t, m.bookmarkNaming)
+		assert.Equal(t, "build", m.bookmarkInput)
+		assert.Contains(t, m.View(), "Name:")
+
+		for range "build" {
+			m = pressSpecial(m, tea.KeyBackspace)
+		}
+		m = pressKey(m, "slow")
+		m = pressSpecial(m, tea.KeySpace)
+		m = pressKey(m, "job")
+		m = pressSpecial(m, tea.KeyEnter)
+
+		assert.False(t, m.showBookmarks)
+		assert.Equal(t, []Bookmark{{Name: "slow job", ItemID: "url-group/0/CI/0/build/0"}}, m.bookmarks)
+	})
+
+	t.Run("bookmarking the same item again renames it", func(t *testing.T) {
+		m := createTestModel()
+		m.cursor = findVisibleIndex(&m, "url-group/0/CI/0")
+		m = pressKey(m, "M")
+		m = pressSpecial(m, tea.KeyEnter)
+		m = pressKey(m, "M")
+		m = pressKey(m, "!")
+		m = pressSpecial(m, tea.KeyEnter)
+
+		assert.Len(t, m.bookmarks, 1)
+		assert.Equal(t, "CI!", m.bookmarks[0].Name)
+	})
+
+	t.Run("enter jumps to a bookmark inside a collapsed subtree", func(t *testing.T) {
+		m := createTestModel()
+		m.bookmarks = []Bookmark{{Name: "checkout", ItemID: "url-group/0/CI/0/build/0/Checkout/0"}}
+		assert.Equal(t, -1, findVisibleIndex(&m, "url-group/0/CI/0/build/0/Checkout/0"))
+
+		m = pressKey(m, "'")
+		assert.True(t, m.showBookmarks)
+		assert.Contains(t, m.View(), "checkout")
+		m = pressSpecial(m, tea.KeyEnter)
+
+		assert.False(t, m.showBookmarks)
+		assert.Equal(t, "url-group/0/CI/0/build/0/Checkout/0", m.visibleItems[m.cursor].ID)
+	})
+
+	t.Run("d deletes the selected bookmark", func(t *testing.T) {
+		m := createTestModel()
+		m.bookmarks = []Bookmark{
+			{Name: "one", ItemID: "url-group/0/CI/0"},
+			{Name: "two", ItemID: "url-group/0/CI/0/build/0"},
+		}
+		m = pressKey(m, "'")
+		m = pressSpecial(m, tea.KeyDown)
+		m = pressKey(m, "d")
+
+		assert.Equal(t, []Bookmark{{Name: "one", ItemID: "url-group/0/CI/0"}}, m.bookmarks)
+		assert.Equal(t, 0, m.bookmarkCursor)
+	})
+}
@@ -0,0 +1,492 @@
+package results
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// flameRootName is the display name of the synthetic root frame.
+const flameRootName = "all"
+
+// FlameNode is one frame of the flame graph: every span that shares the
+// same name path is aggregated into a single node.
+type FlameNode struct {
+	Name     string
+	Total    time.Duration // summed wall time of all aggregated spans
+	Self     time.Duration // time not covered by any child span
+	Count    int           // number of spans aggregated into this frame
+	Failures int           // number of aggregated spans with a failure outcome
+	Children []*FlameNode
+	Parent   *FlameNode
+
+	firstStart time.Time
+}
+
+// BuildFlameGraph aggregates the span tree into flame graph frames keyed by
+// name path. In time-order mode each root keeps its own frame and siblings are
+// ordered by first start time. In left-heavy mode identical stacks are merged
+// across roots (traces) and siblings are ordered by total time, heaviest first.
+func BuildFlameGraph(roots []*analyzer.TreeNode, leftHeavy bool) *FlameNode {
+	root := &FlameNode{Name: flameRootName}
+	for _, r := range roots {
+		if !isFlameSpan(r) {
+			continue
+		}
+		if leftHeavy {
+			root.add(r)
+			continue
+		}
+		frame := &FlameNode{Name: r.Name, Parent: root}
+		root.Children = append(root.Children, frame)
+		frame.accumulate(r)
+	}
+	for _, c := range root.Children {
+		root.Total += c.Total
+	}
+	root.sortChildren(leftHeavy)
+	return root
+}
+
+// isFlameSpan reports whether a node contributes time to the flame graph.
+// Markers and zero-duration spans have no width and are skipped.
+func isFlameSpan(n *analyzer.TreeNode) bool {
+	return !n.Hints.IsMarker && n.Duration() > 0
+}
+
+// add merges a span into the child frame with the same name, creating it if needed.
+func (f *FlameNode) add(n *analyzer.TreeNode) {
+	for _, c := range f.Children {
+		if c.Name == n.Name {
+			c.accumulate(n)
+			return
+		}
+	}
+	c := &FlameNode{Name: n.Name, Parent: f}
+	f.Children = append(f.Children, c)
+	c.accumulate(n)
+}
+
+// accumulate adds a span's timings to the frame and recurses into its children.
+func (f *FlameNode) accumulate(n *analyzer.TreeNode) {
+	f.Total += n.Duration()
+	f.Self += n.SelfTime()
+	f.Count++
+	if n.Hints.Outcome == "failure" {
+		f.Failures++
+	}
+	if f.firstStart.IsZero() || n.StartTime.Before(f.firstStart) {
+		f.firstStart = n.StartTime
+	}
+	for _, child := range n.Children {
+		if isFlameSpan(child) {
+			f.add(child)
+		}
+	}
+}
+
+func (f *FlameNode) sortChildren(leftHeavy bool) {
+	sort.SliceStable(f.Children, func(i, j int) bool {
+		a, b := f.Children[i], f.Children[j]
+		if leftHeavy {
+			if a.Total != b.Total {
+				return a.Total > b.Total
+			}
+			return a.Name < b.Name
+		}
+		return a.firstStart.Before(b.firstStart)
+	})
+	for _, c := range f.Children {
+		c.sortChildren(leftHeavy)
+	}
+}
+
+// Path returns the frame names from the root (exclusive) down to this frame.
+func (f *FlameNode) Path() []string {
+	var path []string
+	for n := f; n != nil && n.Parent != nil; n = n.Parent {
+		path = append([]string{n.Name}, path...)
+	}
+	return path
+}
+
+// find walks a name path from this frame, returning the deepest frame reached.
+func (f *FlameNode) find(path []string) *FlameNode {
+	cur := f
+	for _, name := range path {
+		var next *FlameNode
+		for _, c := range cur.Children {
+			if c.Name == name {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		cur = next
+	}
+	return cur
+}
+
+// flameCell is a frame placed on the icicle grid.
+type flameCell struct {
+	node  *FlameNode
+	start int
+	width int
+}
+
+// layoutFlame places the zoomed frame across the full width and its
+// descendants beneath it, one row per depth. Parallel children can sum to more
+// than their parent's wall time, so children are scaled against whichever is
+// larger. Frames narrower than one column are dropped along with their subtrees.
+func layoutFlame(zoom *FlameNode, width, maxRows int) [][]flameCell {
+	if zoom == nil || width < 1 || maxRows < 1 {
+		return nil
+	}
+	rows := [][]flameCell{{{node: zoom, start: 0, width: width}}}
+	var place func(cell flameCell, depth int)
+	place = func(cell flameCell, depth int) {
+		if depth >= maxRows || len(cell.node.Children) == 0 {
+			return
+		}
+		var sum time.Duration
+		for _, c := range cell.node.Children {
+			sum += c.Total
+		}
+		denom := cell.node.Total
+		if sum > denom {
+			denom = sum
+		}
+		if denom <= 0 {
+			return
+		}
+		scale := float64(cell.width) / float64(denom)
+		var cum time.Duration
+		for _, c := range cell.node.Children {
+			start := cell.start + int(float64(cum)*scale)
+			cum += c.Total
+			end := cell.start + int(float64(cum)*scale)
+			if end-start < 1 {
+				continue
+			}
+			for len(rows) <= depth {
+				rows = append(rows, nil)
+			}
+			child := flameCell{node: c, start: start, width: end - start}
+			rows[depth] = append(rows[depth], child)
+			place(child, depth+1)
+		}
+	}
+	place(rows[0][0], 1)
+	return rows
+}
+
+// flameRowCapacity returns how many icicle rows fit between header and footer.
+func (m Model) flameRowCapacity() int {
+	height := m.height
+	if height < 10 {
+		height = 10
+	}
+	// Header: topBorder + statsLine1 + statsLine2 + flame axis + blankLine = 5
+	// Footer: breadcrumb + statusLine + bottomBorder = 3
+	headerLines := 5
+	if m.hasEnrichmentLine() {
+		headerLines++
+	}
+	rows := height - headerLines - 3
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// flameGridWidth returns the number of columns available for frames.
+func (m Model) flameGridWidth() int {
+	width := m.width
+	if width < 40 {
+		width = 40
+	}
+	// borders + one column of padding on each side
+	return width - horizontalPad*2 - 4
+}
+
+func (m Model) flameLayout() [][]flameCell {
+	return layoutFlame(m.flameZoom, m.flameGridWidth(), m.flameRowCapacity())
+}
+
+// toggleFlame opens or closes the flame graph view.
+func (m *Model) toggleFlame() {
+	m.showFlame = !m.showFlame
+	if m.showFlame {
+		m.rebuildFlame()
+	}
+}
+
+// rebuildFlame regenerates the flame graph, keeping the current zoom and
+// selection when the same name paths still exist.
+func (m *Model) rebuildFlame() {
+	var zoomPath, selPath []string
+	if m.flameZoom != nil {
+		zoomPath = m.flameZoom.Path()
+	}
+	if m.flameSel != nil {
+		selPath = m.flameSel.Path()
+	}
+	m.flameRoot = BuildFlameGraph(m.roots, m.flameLeftHeavy)
+	m.flameZoom = m.flameRoot.find(zoomPath)
+	m.flameSel = m.flameRoot.find(selPath)
+	if !m.isFlameDescendant(m.flameSel) {
+		m.flameSel = m.flameZoom
+	}
+}
+
+// isFlameDescendant reports whether n is the zoomed frame or below it.
+func (m *Model) isFlameDescendant(n *FlameNode) bool {
+	for ; n != nil; n = n.Parent {
+		if n == m.flameZoom {
+			return true
+		}
+	}
+	return false
+}
+
+// updateFlame handles key presses while the flame graph is shown.
+func (m Model) updateFlame(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Help):
+		m.showHelpModal = true
+
+	case key.Matches(msg, m.keys.Flame):
+		m.showFlame = false
+
+	case key.Matches(msg, m.keys.LeftHeavy):
+		m.flameLeftHeavy = !m.flameLeftHeavy
+		m.rebuildFlame()
+
+	case key.Matches(msg, m.keys.Up):
+		if m.flameSel != m.flameZoom && m.flameSel.Parent != nil {
+			m.flameSel = m.flameSel.Parent
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveFlameSelectionDown()
+
+	case key.Matches(msg, m.keys.Left):
+		m.moveFlameSelectionSideways(-1)
+
+	case key.Matches(msg, m.keys.Right):
+		m.moveFlameSelectionSideways(1)
+
+	case key.Matches(msg, m.keys.Enter):
+		// Drill down: the selected frame becomes the full-width root
+		m.flameZoom = m.flameSel
+
+	case key.Matches(msg, m.keys.Back):
+		// Zoom out one level; at the top, leave the flame graph
+		if m.flameZoom.Parent == nil {
+			m.showFlame = false
+			return m, nil
+		}
+		m.flameSel = m.flameZoom
+		m.flameZoom = m.flameZoom.Parent
+	}
+	return m, nil
+}
+
+// moveFlameSelectionDown selects the first visible child of the selected frame.
+func (m *Model) moveFlameSelectionDown() {
+	for _, row := range m.flameLayout() {
+		for _, cell := range row {
+			if cell.node.Parent == m.flameSel {
+				m.flameSel = cell.node
+				return
+			}
+		}
+	}
+}
+
+// moveFlameSelectionSideways selects the neighbouring frame on the same row.
+func (m *Model) moveFlameSelectionSideways(delta int) {
+	for _, row := range m.flameLayout() {
+		for i, cell := range row {
+			if cell.node != m.flameSel {
+				continue
+			}
+			if j := i + delta; j >= 0 && j < len(row) {
+				m.flameSel = row[j].node
+			}
+			return
+		}
+	}
+}
+
+// renderFlameView renders the icicle view in place of the tree and timeline.
+func (m Model) renderFlameView(width, height int) string {
+	var b strings.Builder
+
+	totalWidth := width - horizontalPad*2
+	if totalWidth < 1 {
+		totalWidth = 80
+	}
+	contentWidth := totalWidth - 2
+	gridWidth := m.flameGridWidth()
+	rowCount := m.flameRowCapacity()
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(m.renderFlameAxis(contentWidth))
+	b.WriteString("\n")
+	blankLine := BorderStyle.Render("│") + strings.Repeat(" ", contentWidth) + BorderStyle.Render("│")
+	b.WriteString(blankLine)
+	b.WriteString("\n")
+
+	rows := layoutFlame(m.flameZoom, gridWidth, rowCount)
+	for i := 0; i < rowCount; i++ {
+		if i < len(rows) {
+			b.WriteString(BorderStyle.Render("│") + " " + m.renderFlameRow(rows[i], gridWidth) + " " + BorderStyle.Render("│"))
+		} else {
+			b.WriteString(blankLine)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFooter())
+
+	if m.showHelpModal {
+		return placeModalCentered(m.renderHelpModal(), width, height)
+	}
+	return addHorizontalPadding(b.String(), horizontalPad)
+}
+
+// renderFlameAxis renders the line above the frames: mode and zoomed total.
+func (m Model) renderFlameAxis(contentWidth int) string {
+	mode := "time order"
+	if m.flameLeftHeavy {
+		mode = "left-heavy"
+	}
+	text := HeaderStyle.Render("Flame graph") + HeaderCountStyle.Render(" · "+mode)
+	if m.flameZoom != nil {
+		text += HeaderCountStyle.Render(" · " + m.flameZoom.Name + " " + utils.HumanizeTime(m.flameZoom.Total.Seconds()))
+	}
+	text = ansi.Truncate(text, contentWidth-2, "…")
+	pad := contentWidth - 1 - lipgloss.Width(text)
+	if pad < 0 {
+		pad = 0
+	}
+	return BorderStyle.Render("│") + " " + text + strings.Repeat(" ", pad) + BorderStyle.Render("│")
+}
+
+// renderFlameRow renders one depth level of the icicle grid.
+func (m Model) renderFlameRow(cells []flameCell, width int) string {
+	var b strings.Builder
+	col := 0
+	for _, cell := range cells {
+		if cell.start > col {
+			b.WriteString(strings.Repeat(" ", cell.start-col))
+		}
+		b.WriteString(m.renderFlameCell(cell))
+		col = cell.start + cell.width
+	}
+	if col < width {
+		b.WriteString(strings.Repeat(" ", width-col))
+	}
+	return b.String()
+}
+
+// renderFlameCell renders a frame label on a colored background, leaving a
+// one-column gap on the right so adjacent frames stay distinguishable.
+func (m Model) renderFlameCell(cell flameCell) string {
+	style := flameFrameStyle(cell.node)
+	if cell.node == m.flameSel {
+		style = FlameSelectedStyle
+	}
+	if cell.width == 1 {
+		return style.Render(" ")
+	}
+	inner := cell.width - 1
+	label := cell.node.Name
+	if dur := utils.HumanizeTime(cell.node.Total.Seconds()); len(label)+len(dur)+1 <= inner {
+		label += " " + dur
+	}
+	label = ansi.Truncate(label, inner, "…")
+	label += strings.Repeat(" ", inner-lipgloss.Width(label))
+	return style.Render(label) + " "
+}
+
+// flameFrameStyle picks a stable background per frame name; frames containing
+// failures are always red.
+func flameFrameStyle(n *FlameNode) lipgloss.Style {
+	if n.Failures > 0 {
+		return FlameFrameStyle.Background(ColorRedDim)
+	}
+	palette := []lipgloss.Color{ColorBlueDim, ColorGreenDim, ColorYellowDim}
+	h := fnv.New32a()
+	h.Write([]byte(n.Name))
+	return FlameFrameStyle.Background(palette[h.Sum32()%uint32(len(palette))])
+}
+
+// renderFlameBreadcrumb shows the path to the selected frame and its timings.
+func (m Model) renderFlameBreadcrumb(totalWidth int) string {
+	contentWidth := totalWidth - 4
+	if contentWidth < 10 {
+		contentWidth = 10
+	}
+	sel := m.flameSel
+	if sel == nil {
+		return BorderStyle.Render("│") + strings.Repeat(" ", totalWidth-2) + BorderStyle.Render("│")
+	}
+
+	var bc strings.Builder
+	parts := append([]string{flameRootName}, sel.Path()...)
+	for i, part := range parts {
+		if i > 0 {
+			bc.WriteString(BreadcrumbSepStyle.Render(" › "))
+		}
+		if i == len(parts)-1 {
+			bc.WriteString(BreadcrumbActiveStyle.Render(part))
+		} else {
+			bc.WriteString(BreadcrumbStyle.Render(part))
+		}
+	}
+
+	stats := fmt.Sprintf("total %s", utils.HumanizeTime(sel.Total.Seconds()))
+	if m.flameZoom != nil && m.flameZoom.Total > 0 {
+		stats += fmt.Sprintf(" (%.1f%%)", float64(sel.Total)*100/float64(m.flameZoom.Total))
+	}
+	if sel.Parent != nil {
+		stats += fmt.Sprintf(" • self %s • ×%d", utils.HumanizeTime(sel.Self.Seconds()), sel.Count)
+	}
+	if sel.Failures > 0 {
+		stats += fmt.Sprintf(" • %d failed", sel.Failures)
+	}
+	statsStr := HeaderCountStyle.Render(stats)
+
+	text := bc.String()
+	if room := contentWidth - lipgloss.Width(statsStr) - 2; lipgloss.Width(text) > room {
+		text = ansi.TruncateLeft(text, lipgloss.Width(text)-room+1, "…")
+	}
+	pad := contentWidth - lipgloss.Width(text) - lipgloss.Width(statsStr)
+	if pad < 1 {
+		pad = 1
+	}
+	line := text + strings.Repeat(" ", pad) + statsStr
+	line = ansi.Truncate(line, contentWidth, "…")
+	pad = contentWidth - lipgloss.Width(line)
+	if pad < 0 {
+		pad = 0
+	}
+	return BorderStyle.Render("│") + " " + line + strings.Repeat(" ", pad) + " " + BorderStyle.Render("│")
+}
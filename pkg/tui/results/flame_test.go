@@ -0,0 +1,206 @@
+package results
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+// flameTestRoots returns two traces with the same root name so time-order and
+// left-heavy aggregation can be told apart.
+func flameTestRoots() []*analyzer.TreeNode {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []*analyzer.TreeNode{
+		{
+			Name:      "CI",
+			Hints:     enrichment.SpanHints{Category: "workflow", IsRoot: true},
+			StartTime: start,
+			EndTime:   start.Add(10 * time.Minute),
+			Children: []*analyzer.TreeNode{
+				{
+					Name:      "build",
+					Hints:     enrichment.SpanHints{Category: "job", Outcome: "success"},
+					StartTime: start,
+					EndTime:   start.Add(4 * time.Minute),
+					Children: []*analyzer.TreeNode{
+						{
+							Name:      "compile",
+							Hints:     enrichment.SpanHints{Category: "job", Outcome: "success"},
+							StartTime: start,
+							EndTime:   start.Add(3 * time.Minute),
+						},
+					},
+				},
+				{
+					Name:      "test",
+					Hints:     enrichment.SpanHints{Category: "job", Outcome: "failure"},
+					StartTime: start.Add(4 * time.Minute),
+					EndTime:   start.Add(10 * time.Minute),
+				},
+			},
+		},
+		{
+			Name:      "CI",
+			Hints:     enrichment.SpanHints{Category: "workflow", IsRoot: true},
+			StartTime: start.Add(20 * time.Minute),
+			EndTime:   start.Add(25 * time.Minute),
+			Children: []*analyzer.TreeNode{
+				{
+					Name:      "build",
+					Hints:     enrichment.SpanHints{Category: "job", Outcome: "success"},
+					StartTime: start.Add(20 * time.Minute),
+					EndTime:   start.Add(22 * time.Minute),
+				},
+				{
+					Name:      "reviewed",
+					Hints:     enrichment.SpanHints{Category: "review", IsMarker: true},
+					StartTime: start.Add(21 * time.Minute),
+					EndTime:   start.Add(21 * time.Minute),
+				},
+			},
+		},
+	}
+}
+
+func TestBuildFlameGraph(t *testing.T) {
+	t.Parallel()
+
+	t.Run("time order keeps roots separate", func(t *testing.T) {
+		root := BuildFlameGraph(flameTestRoots(), false)
+		assert.Equal(t, flameRootName, root.Name)
+		assert.Len(t, root.Children, 2)
+		assert.Equal(t, 15*time.Minute, root.Total)
+		assert.Equal(t, "build", root.Children[0].Children[0].Name)
+		assert.Equal(t, "test", root.Children[0].Children[1].Name)
+	})
+
+	t.Run("left-heavy merges identical stacks", func(t *testing.T) {
+		root := BuildFlameGraph(flameTestRoots(), true)
+		assert.Len(t, root.Children, 1)
+		ci := root.Children[0]
+		assert.Equal(t, 2, ci.Count)
+		assert.Equal(t, 15*time.Minute, ci.Total)
+		// build (4m + 2m) ties with test (6m); ties are broken by name
+		assert.Equal(t, "build", ci.Children[0].Name)
+		assert.Equal(t, 6*time.Minute, ci.Children[0].Total)
+		assert.Equal(t, 2, ci.Children[0].Count)
+	})
+
+	t.Run("computes self time and failures", func(t *testing.T) {
+		root := BuildFlameGraph(flameTestRoots(), true)
+		build := root.find([]string{"CI", "build"})
+		assert.Equal(t, "build", build.Name)
+		// first build: 4m - 3m compile, second build: 2m with no children
+		assert.Equal(t, 3*time.Minute, build.Self)
+		test := root.find([]string{"CI", "test"})
+		assert.Equal(t, 1, test.Failures)
+	})
+
+	t.Run("skips markers", func(t *testing.T) {
+		root := BuildFlameGraph(flameTestRoots(), true)
+		for _, c := range root.Children[0].Children {
+			assert.NotEqual(t, "reviewed", c.Name)
+		}
+	})
+}
+
+func TestLayoutFlame(t *testing.T) {
+	t.Parallel()
+
+	root := BuildFlameGraph(flameTestRoots(), true)
+	rows := layoutFlame(root, 100, 10)
+
+	assert.Len(t, rows, 4)
+	assert.Equal(t, 100, rows[0][0].width)
+	for _, row := range rows {
+		end := 0
+		for _, cell := range row {
+			assert.GreaterOrEqual(t, cell.start, end, "cells must not overlap")
+			end = cell.start + cell.width
+		}
+		assert.LessOrEqual(t, end, 100)
+	}
+
+	t.Run("limits rows", func(t *testing.T) {
+		assert.Len(t, layoutFlame(root, 100, 2), 2)
+	})
+}
+
+func TestFlameView(t *testing.T) {
+	t.Parallel()
+
+	press := func(m Model, k string) Model {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		return next.(Model)
+	}
+
+	t.Run("F toggles the flame graph", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "F")
+		assert.True(t, m.showFlame)
+		assert.Equal(t, m.flameRoot, m.flameZoom)
+		assert.Contains(t, m.View(), "Flame graph")
+		m = press(m, "F")
+		assert.False(t, m.showFlame)
+	})
+
+	t.Run("drills down and zooms out", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "F")
+		m = press(m, "j") // CI
+		m = press(m, "j") // build
+		assert.Equal(t, "build", m.flameSel.Name)
+
+		m = press(m, "enter")
+		assert.Equal(t, "build", m.flameZoom.Name)
+		assert.Contains(t, m.View(), "Checkout")
+
+		m = press(m, "esc")
+		assert.Equal(t, "CI", m.flameZoom.Name)
+		assert.Equal(t, "build", m.flameSel.Name)
+	})
+
+	t.Run("moves between siblings", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "F")
+		m = press(m, "j")
+		m = press(m, "j")
+		m = press(m, "l")
+		assert.Equal(t, "test", m.flameSel.Name)
+		m = press(m, "h")
+		assert.Equal(t, "build", m.flameSel.Name)
+		m = press(m, "k")
+		assert.Equal(t, "CI", m.flameSel.Name)
+	})
+
+	t.Run("left-heavy keeps selection", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "F")
+		m = press(m, "j")
+		m = press(m, "L")
+		assert.True(t, m.flameLeftHeavy)
+		assert.Equal(t, "CI", m.flameSel.Name)
+		assert.True(t, strings.Contains(m.View(), "left-heavy"))
+	})
+
+	t.Run("esc at top closes the view", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "F")
+		m = press(m, "esc")
+		assert.False(t, m.showFlame)
+	})
+}
@@ -80,6 +80,74 @@ func BuildInspectorTree(item *TreeItem) []*InspectorNode {
 			}
 			s.Children = append(s.Children, &InspectorNode{Label: "Duration", Value: utils.HumanizeTime(dur)})
 		}
+		if node := item.sourceNode; node != nil && !node.StartTime.IsZero() {
+			s.Children = append(s.Children, &InspectorNode{Label: "Self", Value: utils.HumanizeTime(node.Self.Seconds())})
+			if len(node.Gaps) > 0 {
+				gaps := &InspectorNode{
+					Label: fmt.Sprintf("Idle Gaps (%d)", len(node.Gaps)),
+					Value: utils.HumanizeTime(node.IdleTime().Seconds()),
+				}
+				for _, g := range node.Gaps {
+					gaps.Children = append(gaps.Children, &InspectorNode{
+						Label: "+" + utils.HumanizeTime(g.Start.Sub(node.StartTime).Seconds()),
+						Value: utils.HumanizeTime(g.Duration().Seconds()),
+					})
+				}
+				s.Children = append(s.Children, gaps)
+			}
+		}
+		sections = append(sections, s)
+	}
+
+	// Baseline
+	if rank, ok := item.BaselineRank(); ok {
+		s := &InspectorNode{Label: "Baseline", IsSection: true, Expanded: true}
+		outlier := "No"
+		if rank.Outlier {
+			outlier = "Yes"
+		}
+		s.Children = append(s.Children,
+			&InspectorNode{Label: "Branch", Value: rank.Branch},
+			&InspectorNode{Label: "Percentile", Value: fmt.Sprintf("p%.0f", rank.Percentile)},
+			&InspectorNode{Label: "Outlier", Value: outlier},
+			&InspectorNode{Label: "p50", Value: utils.HumanizeTime(rank.P50().Seconds())},
+			&InspectorNode{Label: "p95", Value: utils.HumanizeTime(rank.P95().Seconds())},
+			&InspectorNode{Label: "Runs", Value: fmt.Sprintf("%d", rank.Samples)},
+		)
+		sections = append(sections, s)
+	}
+
+	// Merge gate
+	if gate, ok := item.MergeGate(); ok {
+		s := &InspectorNode{Label: "Merge Gate", IsSection: true, Expanded: true}
+		s.Children = append(s.Children, &InspectorNode{Label: "Check", Value: gate.Check})
+		if gate.App != "" {
+			s.Children = append(s.Children, &InspectorNode{Label: "App", Value: gate.App})
+		}
+		requiredBy := "nothing (all checks count)"
+		switch gate.Source {
+		case analyzer.RequiredCheckSourceProtection:
+			requiredBy = "branch protection"
+		case analyzer.RequiredCheckSourceRuleset:
+			requiredBy = "ruleset"
+		}
+		s.Children = append(s.Children, &InspectorNode{Label: "Required By", Value: requiredBy})
+		if gate.Conclusion != "" {
+			s.Children = append(s.Children, &InspectorNode{Label: "Conclusion", Value: gate.Conclusion})
+		}
+		if !gate.IsPending() && gate.StartedMs != 0 {
+			s.Children = append(s.Children, &InspectorNode{Label: "Duration", Value: utils.HumanizeTime(float64(gate.CompletedMs-gate.StartedMs) / 1000)})
+		}
+		if slack := gate.SlackMs(); slack >= 0 {
+			s.Children = append(s.Children, &InspectorNode{Label: "Before Merge", Value: utils.HumanizeTime(float64(slack) / 1000)})
+		}
+		s.Children = append(s.Children, &InspectorNode{Label: "Required Checks", Value: fmt.Sprintf("%d", len(gate.Required))})
+		if len(gate.Pending) > 0 {
+			s.Children = append(s.Children, &InspectorNode{Label: "Pending", Value: strings.Join(gate.Pending, ", ")})
+		}
+		if len(gate.Missing) > 0 {
+			s.Children = append(s.Children, &InspectorNode{Label: "Not Reported", Value: strings.Join(gate.Missing, ", ")})
+		}
 		sections = append(sections, s)
 	}
 
@@ -282,6 +282,51 @@ func TestBuildInspectorTree_WithAttributes(t *testing.T) {
 	}
 }
 
+func TestBuildInspectorTree_WithBaseline(t *testing.T) {
+	item := &TreeItem{
+		ID:          "test-id",
+		DisplayName: "build",
+		ItemType:    ItemTypeIntermediate,
+		sourceNode: &analyzer.TreeNode{
+			Attrs: map[string]string{
+				analyzer.AttrBaselineBranch:     "main",
+				analyzer.AttrBaselinePercentile: "97.5",
+				analyzer.AttrBaselineOutlier:    "true",
+				analyzer.AttrBaselineP50Ms:      "240000",
+				analyzer.AttrBaselineP95Ms:      "300000",
+				analyzer.AttrBaselineSamples:    "20",
+			},
+		},
+	}
+
+	var baseline *InspectorNode
+	for _, s := range BuildInspectorTree(item) {
+		if s.Label == "Baseline" {
+			baseline = s
+		}
+	}
+	if baseline == nil {
+		t.Fatal("missing Baseline section")
+	}
+
+	values := make(map[string]string)
+	for _, c := range baseline.Children {
+		values[c.Label] = c.Value
+	}
+	want := map[string]string{"Branch": "main", "Percentile": "p98", "Outlier": "Yes", "p50": "4m", "p95": "5m", "Runs": "20"}
+	for label, v := range want {
+		if values[label] != v {
+			t.Errorf("%s = %q, want %q", label, values[label], v)
+		}
+	}
+
+	for _, s := range BuildInspectorTree(&TreeItem{ID: "plain", sourceNode: &analyzer.TreeNode{}}) {
+		if s.Label == "Baseline" {
+			t.Error("Baseline section shown without baseline attributes")
+		}
+	}
+}
+
 func TestBuildInspectorTree_WithEvents(t *testing.T) {
 	item := &TreeItem{
 		ID:          "test-id",
@@ -458,3 +503,89 @@ func TestBuildInspectorTree_WithTraceIdentity(t *testing.T) {
 		t.Errorf("expected 2 children (TraceID, SpanID), got %d", len(traceSection.Children))
 	}
 }
+
+func TestBuildInspectorTree_SelfTimeAndGaps(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	item := &TreeItem{
+		ID:          "test-id",
+		DisplayName: "Workflow",
+		ItemType:    ItemTypeRoot,
+		StartTime:   start,
+		EndTime:     start.Add(10 * time.Second),
+		sourceNode: &analyzer.TreeNode{
+			StartTime: start,
+			EndTime:   start.Add(10 * time.Second),
+			Self:      4 * time.Second,
+			Gaps: []analyzer.IdleGap{
+				{Start: start, End: start.Add(time.Second)},
+				{Start: start.Add(4 * time.Second), End: start.Add(7 * time.Second)},
+			},
+		},
+	}
+
+	sections := BuildInspectorTree(item)
+	timing := sections[1]
+
+	var self, gaps *InspectorNode
+	for _, c := range timing.Children {
+		switch c.Label {
+		case "Self":
+			self = c
+		case "Idle Gaps (2)":
+			gaps = c
+		}
+	}
+	if self == nil || self.Value != "4s" {
+		t.Fatalf("expected Self of 4s, got %+v", self)
+	}
+	if gaps == nil {
+		t.Fatal("missing Idle Gaps node")
+	}
+	if gaps.Value != "4s" {
+		t.Errorf("expected total idle of 4s, got %q", gaps.Value)
+	}
+	if len(gaps.Children) != 2 || gaps.Children[1].Label != "+4s" || gaps.Children[1].Value != "3s" {
+		t.Errorf("unexpected gap entries: %+v", gaps.Children)
+	}
+}
+
+func TestBuildInspectorTree_WithMergeGate(t *testing.T) {
+	item := &TreeItem{
+		ID:       "gate",
+		ItemType: ItemTypeInfo,
+		sourceNode: &analyzer.TreeNode{
+			Attrs: map[string]string{
+				analyzer.AttrMergeGateCheck:       "security/scan",
+				analyzer.AttrMergeGateApp:         "scanner",
+				analyzer.AttrMergeGateSource:      analyzer.RequiredCheckSourceRuleset,
+				analyzer.AttrMergeGateConclusion:  "success",
+				analyzer.AttrMergeGateStartedMs:   "60000",
+				analyzer.AttrMergeGateCompletedMs: "600000",
+				analyzer.AttrMergeGateMergedMs:    "720000",
+				analyzer.AttrMergeGateRequired:    "build\nsecurity/scan\ndocs",
+				analyzer.AttrMergeGateMissing:     "docs",
+			},
+		},
+	}
+
+	var gate *InspectorNode
+	for _, s := range BuildInspectorTree(item) {
+		if s.Label == "Merge Gate" {
+			gate = s
+		}
+	}
+	if gate == nil {
+		t.Fatal("missing Merge Gate section")
+	}
+
+	values := make(map[string]string)
+	for _, c := range gate.Children {
+		values[c.Label] = c.Value
+	}
+	want := map[string]string{"Check": "security/scan", "App": "scanner", "Required By": "ruleset", "Duration": "9m", "Before Merge": "2m", "Required Checks": "3", "Not Reported": "docs"}
+	for label, v := range want {
+		if values[label] != v {
+			t.Errorf("%s = %q, want %q", label, values[label], v)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package results
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -98,6 +99,22 @@ type TreeItem struct {
 	ResourceAttrs map[string]string
 }
 
+// BaselineRank returns the item's rank against a --baseline, if it has one.
+func (item *TreeItem) BaselineRank() (analyzer.BaselineRank, bool) {
+	if item.sourceNode == nil {
+		return analyzer.BaselineRank{}, false
+	}
+	return analyzer.BaselineRankFromAttrs(item.sourceNode.Attrs)
+}
+
+// MergeGate returns the merge gate recorded on the item's span, if any.
+func (item *TreeItem) MergeGate() (*analyzer.MergeGate, bool) {
+	if item.sourceNode == nil {
+		return nil, false
+	}
+	return analyzer.MergeGateFromAttrs(item.sourceNode.Attrs)
+}
+
 // BuildTreeItems converts TreeNodes into TreeItems for the TUI.
 // Each input URL becomes a top-level URL group node containing its workflows.
 func BuildTreeItems(roots []*analyzer.TreeNode, expandedState map[string]bool, inputURLs []string) []*TreeItem {
@@ -200,8 +217,10 @@ func BuildTreeItems(roots []*analyzer.TreeNode, expandedState map[string]bool, i
 }
 
 // buildURLGroupInfoItems creates info items that belong at the URL group level
-// (e.g. VCS changed files which are shared across all workflows in the group).
+// (e.g. VCS changed files which are shared across all workflows in the group,
+// and the merge gate of the PR).
 func buildURLGroupInfoItems(roots []*analyzer.TreeNode, parentID string, depth int) []*TreeItem {
+	var items []*TreeItem
 	// Find VCS change stats from the first non-marker root
 	for _, root := range roots {
 		if root.Hints.IsMarker || root.Hints.GroupKey == "activity" {
@@ -220,7 +239,7 @@ func buildURLGroupInfoItems(roots []*analyzer.TreeNode, parentID string, depth i
 			}
 		}
 		id := makeNodeID(parentID, "_info", 0)
-		return []*TreeItem{{
+		items = append(items, &TreeItem{
 			ID:          id,
 			Name:        fmt.Sprintf("Files: %s changed (+%s / -%s)", c, add, del),
 			DisplayName: fmt.Sprintf("Files: %s changed (+%s / -%s)", c, add, del),
@@ -228,9 +247,39 @@ func buildURLGroupInfoItems(roots []*analyzer.TreeNode, parentID string, depth i
 			ItemType:    ItemTypeInfo,
 			ParentID:    parentID,
 			Hints:       enrichment.SpanHints{Icon: "  ", Category: "diff", URL: filesURL},
-		}}
+		})
+		break
 	}
-	return nil
+
+	// The merge gate marker also stays in the activity group
+	for _, root := range roots {
+		gate, ok := analyzer.MergeGateFromAttrs(root.Attrs)
+		if !ok {
+			continue
+		}
+		name := fmt.Sprintf("Merge gate: %s", gate.Check)
+		if gate.App != "" {
+			name += fmt.Sprintf(" (%s)", gate.App)
+		}
+		switch {
+		case gate.IsPending():
+			name += " · still running"
+		case gate.SlackMs() >= 0:
+			name += fmt.Sprintf(" · %s before merge", utils.HumanizeTime(float64(gate.SlackMs())/1000))
+		}
+		items = append(items, &TreeItem{
+			ID:          makeNodeID(parentID, "_merge_gate", 0),
+			Name:        name,
+			DisplayName: name,
+			Depth:       depth,
+			ItemType:    ItemTypeInfo,
+			ParentID:    parentID,
+			Hints:       enrichment.SpanHints{Icon: "  ", Category: "merge_gate", URL: gate.URL, Color: root.Hints.Color, Outcome: root.Hints.Outcome},
+			sourceNode:  root,
+		})
+		break
+	}
+	return items
 }
 
 // aggregateOutcome returns an aggregate outcome from child nodes using Hints.
@@ -254,6 +303,12 @@ func aggregateOutcome(nodes []*analyzer.TreeNode) string {
 	return ""
 }
 
+// isActivityGroup reports whether the item is the Activity group of markers,
+// as opposed to the other synthetic groups (artifacts, workflow chains).
+func (item *TreeItem) isActivityGroup() bool {
+	return item.ItemType == ItemTypeActivityGroup && item.Hints.Category == ""
+}
+
 // partitionAndGroup splits roots into workflow items and marker items,
 // grouping all markers under a synthetic "Activity" node.
 func partitionAndGroup(roots []*analyzer.TreeNode, parentID string, depth int, expandedState map[string]bool) []*TreeItem {
@@ -302,13 +357,107 @@ func partitionAndGroup(roots []*analyzer.TreeNode, parentID string, depth int, e
 		items = append(items, activityGroup)
 	}
 
+	// Workflows linked by workflow_run triggers share a chain group, placed
+	// where the first of them would be
+	chainNodes := make(map[string][]*analyzer.TreeNode)
+	for _, wf := range workflows {
+		if chain := wf.Attrs[analyzer.AttrWorkflowChain]; chain != "" {
+			chainNodes[chain] = append(chainNodes[chain], wf)
+		}
+	}
+	grouped := make(map[string]bool)
 	for i, wf := range workflows {
-		items = append(items, convertNode(wf, parentID, i, depth, expandedState))
+		chain := wf.Attrs[analyzer.AttrWorkflowChain]
+		if len(chainNodes[chain]) < 2 {
+			items = append(items, convertNode(wf, parentID, i, depth, expandedState))
+			continue
+		}
+		if !grouped[chain] {
+			grouped[chain] = true
+			items = append(items, buildChainGroup(chainNodes[chain], makeNodeID(parentID, "_chain_"+chain, 0), parentID, depth, expandedState))
+		}
 	}
 
 	return items
 }
 
+// buildChainGroup creates the synthetic group of a workflow_run chain, its
+// runs in trigger order. The label carries the end-to-end latency from the
+// commit (or the first run) to the end of the last run.
+func buildChainGroup(nodes []*analyzer.TreeNode, groupID, parentID string, depth int, expandedState map[string]bool) *TreeItem {
+	position := func(n *analyzer.TreeNode) int {
+		p, _ := strconv.Atoi(n.Attrs[analyzer.AttrWorkflowChainPosition])
+		return p
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return position(nodes[i]) < position(nodes[j]) })
+
+	var names []string
+	var children []*TreeItem
+	var earliest, latest time.Time
+	pending := false
+	for i, n := range nodes {
+		names = append(names, n.Name)
+		children = append(children, convertNode(n, groupID, i, depth+1, expandedState))
+		if !n.StartTime.IsZero() && (earliest.IsZero() || n.StartTime.Before(earliest)) {
+			earliest = n.StartTime
+		}
+		if !n.EndTime.IsZero() && (latest.IsZero() || n.EndTime.After(latest)) {
+			latest = n.EndTime
+		}
+		if n.Hints.Outcome == "pending" {
+			pending = true
+		}
+	}
+
+	label := "Chain: " + strings.Join(names, " → ")
+	switch {
+	case pending:
+		label += " · still running"
+	case !latest.IsZero():
+		from, what := earliest, "first run"
+		if ms, err := strconv.ParseInt(nodes[0].Attrs[analyzer.AttrWorkflowChainCommitMs], 10, 64); err == nil && ms > 0 {
+			from, what = time.UnixMilli(ms), "commit"
+		}
+		label += fmt.Sprintf(" · %s %s to end", utils.HumanizeTime(latest.Sub(from).Seconds()), what)
+	}
+
+	outcome := aggregateOutcome(nodes)
+	color := "gray"
+	switch {
+	case pending:
+		outcome, color = "pending", "blue"
+	case outcome == "failure":
+		color = "red"
+	case outcome == "success":
+		color = "green"
+	}
+
+	// Chains replace top-level workflows, so they start expanded
+	if _, explicit := expandedState[groupID]; !explicit {
+		expandedState[groupID] = true
+	}
+	return &TreeItem{
+		ID:          groupID,
+		Name:        label,
+		DisplayName: label,
+		StartTime:   earliest,
+		EndTime:     latest,
+		Depth:       depth,
+		HasChildren: len(children) > 0,
+		IsExpanded:  expandedState[groupID],
+		ItemType:    ItemTypeActivityGroup,
+		ParentID:    parentID,
+		Children:    children,
+		Hints: enrichment.SpanHints{
+			Category: "workflow_chain",
+			Icon:     "🔗",
+			Color:    color,
+			Outcome:  outcome,
+			URL:      nodes[0].Hints.URL,
+		},
+	}
+}
+
 func convertNode(node *analyzer.TreeNode, parentID string, index, depth int, expandedState map[string]bool) *TreeItem {
 	id := makeNodeID(parentID, node.Name, index)
 
@@ -1,6 +1,7 @@
 package results
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -199,6 +200,39 @@ func TestBuildTreeItemsPartitioning(t *testing.T) {
 		}
 	})
 
+	t.Run("workflow_run chains grouped in trigger order", func(t *testing.T) {
+		chain := func(name, position string, start, end time.Duration) *analyzer.TreeNode {
+			return &analyzer.TreeNode{
+				Name:      name,
+				Hints:     enrichment.SpanHints{Category: "workflow", IsRoot: true, Outcome: "success"},
+				Attrs:     map[string]string{analyzer.AttrWorkflowChain: "1", analyzer.AttrWorkflowChainPosition: position, analyzer.AttrWorkflowChainCommitMs: fmt.Sprint(now.Add(-time.Minute).UnixMilli())},
+				StartTime: now.Add(start),
+				EndTime:   now.Add(end),
+			}
+		}
+		roots := []*analyzer.TreeNode{
+			chain("Deploy", "1", 10*time.Minute, 14*time.Minute),
+			{Name: "Lint", Hints: enrichment.SpanHints{Category: "workflow", IsRoot: true}, Attrs: map[string]string{analyzer.AttrWorkflowChain: "7"}},
+			chain("CI", "0", 0, 10*time.Minute),
+		}
+
+		model := &Model{hiddenState: map[string]bool{}}
+		model.treeItems = BuildTreeItems(roots, nil, nil)
+		children := model.treeItems[0].Children
+		assert.Len(t, children, 2)
+		group := children[0]
+		assert.Equal(t, ItemTypeActivityGroup, group.ItemType)
+		assert.Equal(t, "workflow_chain", group.Hints.Category)
+		assert.Equal(t, "Chain: CI → Deploy · 15m commit to end", group.Name)
+		assert.True(t, group.IsExpanded)
+		assert.Equal(t, now, group.StartTime)
+		assert.Equal(t, []string{"CI", "Deploy"}, []string{group.Children[0].Name, group.Children[1].Name})
+		assert.Equal(t, "Lint", children[1].Name, "a lone chain member stays a root")
+
+		model.hideActivityGroups()
+		assert.False(t, model.hiddenState[group.ID], "chains are not hidden like Activity")
+	})
+
 	t.Run("only markers produces only Activity group", func(t *testing.T) {
 		roots := []*analyzer.TreeNode{
 			{Name: "comment", Hints: enrichment.SpanHints{Category: "marker", IsMarker: true, GroupKey: "activity", User: "carol", EventType: "comment"}},
@@ -255,6 +289,35 @@ func TestBuildTreeItemsPartitioning(t *testing.T) {
 			assert.Equal(t, ItemTypeRoot, pr1Group.Children[1].ItemType)
 		}
 	})
+
+	t.Run("merge gate marker adds a URL group info item", func(t *testing.T) {
+		roots := []*analyzer.TreeNode{
+			{Name: "Tests", Hints: enrichment.SpanHints{Category: "workflow", IsRoot: true}, StartTime: now, EndTime: now.Add(time.Minute)},
+			{
+				Name:      "Merge gate: build",
+				Hints:     enrichment.SpanHints{Category: "marker", IsMarker: true, GroupKey: "activity", EventType: "merge_gate", Color: "green"},
+				StartTime: now.Add(time.Minute),
+				EndTime:   now.Add(time.Minute),
+				Attrs: map[string]string{
+					analyzer.AttrMergeGateCheck:       "build",
+					analyzer.AttrMergeGateApp:         "github-actions",
+					analyzer.AttrMergeGateCompletedMs: fmt.Sprintf("%d", now.Add(time.Minute).UnixMilli()),
+					analyzer.AttrMergeGateMergedMs:    fmt.Sprintf("%d", now.Add(4*time.Minute).UnixMilli()),
+				},
+			},
+		}
+
+		items := BuildTreeItems(roots, nil, nil)
+
+		children := items[0].Children
+		assert.Len(t, children, 3)
+		assert.Equal(t, ItemTypeInfo, children[0].ItemType)
+		assert.Equal(t, "Merge gate: build (github-actions) · 3m before merge", children[0].Name)
+		gate, ok := children[0].MergeGate()
+		assert.True(t, ok)
+		assert.Equal(t, "build", gate.Check)
+		assert.Equal(t, ItemTypeActivityGroup, children[1].ItemType, "the marker stays in the activity group")
+	})
 }
 
 func TestFlattenVisibleItems(t *testing.T) {
@@ -28,8 +28,25 @@ type KeyMap struct {
 	ResizeRight     key.Binding
 	NextFailed      key.Binding
 	NextBottleneck  key.Binding
+	NextGap         key.Binding
+	ZoomIn          key.Binding
+	ZoomOut         key.Binding
+	ZoomReset       key.Binding
+	PanLeft         key.Binding
+	PanRight        key.Binding
+	TimeCursor      key.Binding
+	CursorLeft      key.Binding
+	CursorRight     key.Binding
+	AddBookmark     key.Binding
+	Bookmarks       key.Binding
 	PageUp          key.Binding
 	PageDown        key.Binding
+	Flame           key.Binding
+	LeftHeavy       key.Binding
+	Aggregate       key.Binding
+	AggregateBy     key.Binding
+	Runners         key.Binding
+	Back            key.Binding
 	Help            key.Binding
 	Quit            key.Binding
 }
@@ -133,6 +150,50 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("N"),
 			key.WithHelp("N", "next bottleneck"),
 		),
+		NextGap: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "next idle gap"),
+		),
+		ZoomIn: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "zoom in"),
+		),
+		ZoomOut: key.NewBinding(
+			key.WithKeys("-", "_"),
+			key.WithHelp("-", "zoom out"),
+		),
+		ZoomReset: key.NewBinding(
+			key.WithKeys("0"),
+			key.WithHelp("0", "reset zoom"),
+		),
+		PanLeft: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "pan left"),
+		),
+		PanRight: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "pan right"),
+		),
+		TimeCursor: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "time cursor"),
+		),
+		CursorLeft: key.NewBinding(
+			key.WithKeys(","),
+			key.WithHelp(",", "time cursor left"),
+		),
+		CursorRight: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "time cursor right"),
+		),
+		AddBookmark: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "bookmark"),
+		),
+		Bookmarks: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'", "bookmarks"),
+		),
 		PageUp: key.NewBinding(
 			key.WithKeys("ctrl+u", "pgup"),
 			key.WithHelp("ctrl+u", "page up"),
@@ -141,6 +202,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+d", "pgdown"),
 			key.WithHelp("ctrl+d", "page down"),
 		),
+		Flame: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "flame graph"),
+		),
+		LeftHeavy: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "left-heavy"),
+		),
+		Aggregate: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "aggregate"),
+		),
+		AggregateBy: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "group by"),
+		),
+		Runners: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "runners"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "backspace"),
+			key.WithHelp("esc", "zoom out"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -160,6 +245,9 @@ const (
 	HelpModeSearch
 	HelpModeSearchActive // search filter active but not typing
 	HelpModeModal
+	HelpModeFlame
+	HelpModeAggregate
+	HelpModeRunners
 )
 
 // ShortHelpForMode returns context-sensitive help for the footer
@@ -171,6 +259,12 @@ func (k KeyMap) ShortHelpForMode(mode HelpMode) string {
 		return "↑↓ nav • enter/esc clear • / new search • n/N jump • s sort • ? help • q quit"
 	case HelpModeModal:
 		return "Tab pane • ←→ expand • /search • c copy • o open • [/] item • esc close"
+	case HelpModeAggregate:
+		return "↑↓ nav • enter drill in/jump • esc back • s sort • b group by • A close • ? help"
+	case HelpModeRunners:
+		return "↑↓ nav • enter drill in/jump • esc back • b group by • R close • ? help"
+	case HelpModeFlame:
+		return "↑↓←→ nav • enter zoom • esc zoom out • L left-heavy • F close • ? help"
 	default:
 		return "↑↓ nav • ^u/^d page • n/N jump • s sort • [/] resize • / search • ? help"
 	}
@@ -196,6 +290,7 @@ func (k KeyMap) FullHelp() [][]string {
 		{"space", "Toggle chart visibility"},
 		{"n", "Jump to next failed item"},
 		{"N", "Jump to next bottleneck"},
+		{"w", "Jump to next large idle gap"},
 		{"o", "Open in browser"},
 		{"i", "Item info"},
 		{"f", "Focus on selection"},
@@ -205,8 +300,18 @@ func (k KeyMap) FullHelp() [][]string {
 		{"e", "Mark logical end"},
 		{"s", "Cycle sort (start/duration↓/duration↑)"},
 		{"[/]", "Resize tree/timeline split"},
-
-
+		{"+/-", "Zoom timeline in/out (wheel over timeline)"},
+		{"</>", "Pan zoomed timeline"},
+		{"0", "Reset timeline zoom"},
+		{"t", "Toggle time cursor"},
+		{",/.", "Move time cursor"},
+		{"M", "Bookmark span (name it)"},
+		{"'", "Bookmark list (enter jump, d delete)"},
+		{"F", "Toggle flame graph (enter zoom, esc out)"},
+		{"L", "Flame graph: toggle left-heavy"},
+		{"A", "Toggle span aggregate table (enter drill in)"},
+		{"b", "Aggregate: cycle group-by key"},
+		{"R", "Toggle runner swimlanes (b runner/group/label)"},
 		{"r", "Reload data"},
 		{"p", "Open in Perfetto"},
 		{"/", "Search/filter"},
@@ -2,6 +2,7 @@ package results
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -66,6 +67,11 @@ type Model struct {
 	globalEnd     time.Time
 	chartStart    time.Time // calculated from non-hidden items
 	chartEnd      time.Time // calculated from non-hidden items
+	// Timeline zoom: the drawn window is a 1/zoom slice of the chart range
+	// starting zoomOffset (fraction of the chart range) in
+	zoom          float64
+	zoomOffset    float64
+	timeCursor    time.Time // zero when the time cursor is hidden
 	keys          KeyMap
 	// Statistics (full dataset)
 	summary     analyzer.Summary
@@ -153,6 +159,37 @@ type Model struct {
 	artifactNames  string
 	// Workflow definition files
 	workflowFiles []string
+	// Flame graph (icicle) view state
+	showFlame      bool
+	flameLeftHeavy bool       // merge identical stacks across traces, heaviest first
+	flameRoot      *FlameNode // aggregated graph of all roots
+	flameZoom      *FlameNode // frame currently drawn at full width
+	flameSel       *FlameNode // selected frame
+	// Aggregate table view state
+	showAggregate bool
+	aggGroupBy    string // "name" or an attribute key
+	aggSort       string // one of analyzer.AggregateSortFields
+	aggGroups     []analyzer.SpanGroup
+	aggCursor     int  // selected group
+	aggDrilled    bool // listing the selected group's individual spans
+	aggSpanCursor int  // selected span while drilled in
+	// Runner swimlane view state
+	showRunners     bool
+	runnersBy       string // one of analyzer.RunnerGroupings
+	runnerLanes     []analyzer.RunnerLane
+	runnerCursor    int  // selected lane
+	runnerDrilled   bool // listing the selected lane's jobs
+	runnerJobCursor int  // selected job while drilled in
+	// Saved session (restored on launch, written on exit)
+	sessionStore *SessionStore
+	sessionKey   string
+	// Bookmarks and the bookmark modal
+	bookmarks      []Bookmark
+	showBookmarks  bool
+	bookmarkCursor int
+	bookmarkNaming bool   // prompting for a bookmark name
+	bookmarkInput  string // name being typed
+	bookmarkTarget string // item ID the prompt applies to
 }
 
 // ReloadFunc is the function signature for reloading data
@@ -164,7 +201,7 @@ type ReloadFunc func(reporter LoadingReporter) ([]trace.ReadOnlySpan, time.Time,
 type OpenPerfettoFunc func(spans []trace.ReadOnlySpan, activityHidden bool)
 
 // NewModel creates a new TUI model from OTel spans
-func NewModel(spans []trace.ReadOnlySpan, globalStart, globalEnd time.Time, inputURLs []string, reloadFunc ReloadFunc, openPerfettoFunc OpenPerfettoFunc, enricher enrichment.Enricher) Model {
+func NewModel(spans []trace.ReadOnlySpan, globalStart, globalEnd time.Time, inputURLs []string, reloadFunc ReloadFunc, openPerfettoFunc OpenPerfettoFunc, enricher enrichment.Enricher, opts ...Option) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
@@ -235,6 +272,13 @@ func NewModel(spans []trace.ReadOnlySpan, globalStart, globalEnd time.Time, inpu
 	m.hideActivityGroups()
 	m.recalculateEffectiveTimes()
 	m.recalculateChartBounds()
+
+	for _, opt := range opts {
+		opt(&m)
+	}
+	if err := m.restoreSession(); err != nil {
+		m.reloadError = "Could not restore session: " + err.Error()
+	}
 	return m
 }
 
@@ -356,6 +400,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.selectionStart = -1
 		m.logicalEndID = ""
 		m.logicalEndTime = time.Time{}
+		if m.showFlame {
+			m.rebuildFlame()
+		}
+		if m.showAggregate {
+			m.rebuildAggregate()
+		}
+		if m.showRunners {
+			m.rebuildRunners()
+		}
 		return m, nil
 
 	case spinner.TickMsg:
@@ -408,6 +461,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Bookmark list / name prompt
+		if m.showBookmarks {
+			return m.updateBookmarks(msg)
+		}
+
 		// Handle detail modal
 		if m.showDetailModal {
 			// Inspector search input mode
@@ -650,6 +708,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Flame graph view has its own navigation
+		if m.showFlame {
+			return m.updateFlame(msg)
+		}
+		if m.showAggregate {
+			return m.updateAggregate(msg)
+		}
+		if m.showRunners {
+			return m.updateRunners(msg)
+		}
+
 		// Handle search input mode
 		if m.isSearching {
 			switch msg.Type {
@@ -800,6 +869,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, m.keys.Focus):
 			m.toggleFocus()
+			m.resetZoom()
+
+		case key.Matches(msg, m.keys.ZoomIn):
+			m.zoomBy(zoomStep)
+			return m, nil
+
+		case key.Matches(msg, m.keys.ZoomOut):
+			m.zoomBy(1 / zoomStep)
+			return m, nil
+
+		case key.Matches(msg, m.keys.ZoomReset):
+			m.resetZoom()
+			return m, nil
+
+		case key.Matches(msg, m.keys.PanLeft):
+			m.panBy(-panFraction)
+			return m, nil
+
+		case key.Matches(msg, m.keys.PanRight):
+			m.panBy(panFraction)
+			return m, nil
+
+		case key.Matches(msg, m.keys.TimeCursor):
+			m.toggleTimeCursor()
+			return m, nil
+
+		case key.Matches(msg, m.keys.CursorLeft):
+			m.moveTimeCursor(-1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.CursorRight):
+			m.moveTimeCursor(1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.AddBookmark):
+			m.startBookmark()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Bookmarks):
+			m.showBookmarks = true
+			if m.bookmarkCursor >= len(m.bookmarks) {
+				m.bookmarkCursor = 0
+			}
+			return m, nil
 
 		case key.Matches(msg, m.keys.ToggleExpandAll):
 			m.toggleExpandAll()
@@ -856,6 +969,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			})
 			return m, nil
 
+		case key.Matches(msg, m.keys.NextGap):
+			m.jumpToNext(func(item TreeItem) bool {
+				return item.sourceNode != nil && item.sourceNode.HasLargeGap()
+			})
+			return m, nil
+
 		case key.Matches(msg, m.keys.PageUp):
 			m.selectionStart = -1
 			halfPage := m.pageSize() / 2
@@ -877,6 +996,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Flame):
+			m.toggleFlame()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Aggregate):
+			m.toggleAggregate()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Runners):
+			m.toggleRunners()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Help):
 			m.showHelpModal = true
 			return m, nil
@@ -910,6 +1041,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Flame graph, aggregate table and runner lanes are keyboard-driven
+		if m.showFlame || m.showAggregate || m.showRunners {
+			return m, nil
+		}
+
+		// Wheel over the timeline zooms around the pointer, horizontal wheel pans
+		if col := m.timelineColumnAt(msg.X); col >= 0 {
+			switch msg.Button {
+			case tea.MouseButtonWheelUp:
+				m.zoomAtColumn(zoomStep, col)
+				return m, nil
+			case tea.MouseButtonWheelDown:
+				m.zoomAtColumn(1/zoomStep, col)
+				return m, nil
+			}
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelLeft:
+			m.panBy(-panFraction)
+			return m, nil
+		case tea.MouseButtonWheelRight:
+			m.panBy(panFraction)
+			return m, nil
+		}
+
 		// Handle mouse in main view
 		switch msg.Button {
 		case tea.MouseButtonWheelUp:
@@ -926,6 +1082,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case tea.MouseButtonLeft:
 			if msg.Action == tea.MouseActionRelease {
+				// Clicking in the timeline also places the time cursor there
+				if col := m.timelineColumnAt(msg.X); col >= 0 {
+					m.timeCursor = m.timeAtColumn(col, m.timelineWidth())
+				}
+
 				// Calculate which row was clicked
 				headerLines := 8
 				if m.hasEnrichmentLine() {
@@ -985,6 +1146,16 @@ func (m Model) View() string {
 		return placeModalCentered(ModalStyle.Render(loadingText), width, height)
 	}
 
+	if m.showFlame {
+		return m.renderFlameView(width, height)
+	}
+	if m.showAggregate {
+		return m.renderAggregateView(width, height)
+	}
+	if m.showRunners {
+		return m.renderRunnersView(width, height)
+	}
+
 	var b strings.Builder
 
 	// Calculate available height for items
@@ -1019,14 +1190,19 @@ func (m Model) View() string {
 	b.WriteString(m.renderTimeAxis())
 	b.WriteString("\n")
 
-	// Blank line between time axis and content (just outer borders, no middle separator)
+	// Blank line between time axis and content (just outer borders, no middle separator).
+	// While zoomed it shows the mini-map overview of the full chart range instead.
 	totalWidth := width - horizontalPad*2
 	if totalWidth < 1 {
 		totalWidth = 80
 	}
 	contentWidth := totalWidth - 2 // space between left and right borders
-	blankLine := BorderStyle.Render("│") + strings.Repeat(" ", contentWidth) + BorderStyle.Render("│")
-	b.WriteString(blankLine)
+	if m.isZoomed() {
+		b.WriteString(m.renderMiniMap())
+	} else {
+		blankLine := BorderStyle.Render("│") + strings.Repeat(" ", contentWidth) + BorderStyle.Render("│")
+		b.WriteString(blankLine)
+	}
 	b.WriteString("\n")
 
 	// Search bar (between blank line and content)
@@ -1156,6 +1332,10 @@ func (m Model) View() string {
 		return placeModalCentered(modal, width, height)
 	}
 
+	if m.showBookmarks {
+		return placeModalCentered(m.renderBookmarkModal(), width, height)
+	}
+
 	if m.showDetailModal {
 		modal, maxScroll := m.renderDetailModal(height-4, width-10)
 		// Clamp scroll to valid range
@@ -1323,11 +1503,15 @@ func (m *Model) logicalEndCol(timelineW int) int {
 	if m.logicalEndID == "" || m.logicalEndTime.IsZero() || m.chartStart.IsZero() || m.chartEnd.IsZero() {
 		return -1
 	}
-	chartDuration := m.chartEnd.Sub(m.chartStart)
+	viewStart, viewEnd := m.viewRange()
+	chartDuration := viewEnd.Sub(viewStart)
 	if chartDuration <= 0 {
 		return -1
 	}
-	endOffset := m.logicalEndTime.Sub(m.chartStart)
+	if m.isZoomed() && (m.logicalEndTime.Before(viewStart) || m.logicalEndTime.After(viewEnd)) {
+		return -1
+	}
+	endOffset := m.logicalEndTime.Sub(viewStart)
 	col := int(float64(endOffset) / float64(chartDuration) * float64(timelineW))
 	if col >= timelineW {
 		col = timelineW - 1
@@ -1881,12 +2065,12 @@ func (m *Model) toggleExpandAll() {
 	}
 }
 
-// hideActivityGroups hides Activity groups (not artifact groups) from the chart by default
+// hideActivityGroups hides Activity groups (not artifact or chain groups) from the chart by default
 func (m *Model) hideActivityGroups() {
 	var walk func(items []*TreeItem)
 	walk = func(items []*TreeItem) {
 		for _, item := range items {
-			if item.ItemType == ItemTypeActivityGroup && item.Hints.Category != "artifact" {
+			if item.isActivityGroup() {
 				m.hiddenState[item.ID] = true
 				m.toggleDescendants(item.Children, true)
 			}
@@ -2201,6 +2385,10 @@ func (m *Model) recalculateChartBounds() {
 			QueueCount:     m.summary.QueueCount,
 			RetriedRuns:    m.summary.RetriedRuns,
 			BillableMs:     m.summary.BillableMs,
+
+			AvgApprovalTimeMs: m.summary.AvgApprovalTimeMs,
+			MaxApprovalTimeMs: m.summary.MaxApprovalTimeMs,
+			ApprovalCount:     m.summary.ApprovalCount,
 		}
 		m.displayedStepCount = stepCount
 		m.displayedComputeMs = computeMs
@@ -2245,7 +2433,7 @@ func (m *Model) isActivityHidden() bool {
 	var walk func([]*TreeItem) bool
 	walk = func(items []*TreeItem) bool {
 		for _, item := range items {
-			if item.ItemType == ItemTypeActivityGroup && item.Hints.Category != "artifact" && m.hiddenState[item.ID] {
+			if item.isActivityGroup() && m.hiddenState[item.ID] {
 				return true
 			}
 			if walk(item.Children) {
@@ -2286,8 +2474,8 @@ func (m *Model) visibleSpans() []trace.ReadOnlySpan {
 }
 
 // Run starts the TUI
-func Run(spans []trace.ReadOnlySpan, globalStart, globalEnd time.Time, inputURLs []string, reloadFunc ReloadFunc, openPerfettoFunc OpenPerfettoFunc, enricher enrichment.Enricher) error {
-	m := NewModel(spans, globalStart, globalEnd, inputURLs, reloadFunc, openPerfettoFunc, enricher)
+func Run(spans []trace.ReadOnlySpan, globalStart, globalEnd time.Time, inputURLs []string, reloadFunc ReloadFunc, openPerfettoFunc OpenPerfettoFunc, enricher enrichment.Enricher, opts ...Option) error {
+	m := NewModel(spans, globalStart, globalEnd, inputURLs, reloadFunc, openPerfettoFunc, enricher, opts...)
 	// Mouse mode disabled by default to allow OSC 8 hyperlinks to work
 	// Press 'm' to toggle mouse mode for scrolling
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -2295,6 +2483,11 @@ func Run(spans []trace.ReadOnlySpan, globalStart, globalEnd time.Time, inputURLs
 	if err != nil {
 		return fmt.Errorf("tea.Program.Run failed: %w", err)
 	}
-	_ = finalModel
+	if fm, ok := finalModel.(Model); ok {
+		// The session is a convenience; failing to save it doesn't fail the run
+		if err := fm.saveSession(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: saving session failed: %v\n", err)
+		}
+	}
 	return nil
 }
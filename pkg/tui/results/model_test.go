@@ -2567,6 +2567,19 @@ func TestJumpToNext(t *testing.T) {
 
 		assert.GreaterOrEqual(t, m.cursor, 0)
 	})
+
+	t.Run("w jumps to next large idle gap", func(t *testing.T) {
+		m := createTestModel()
+		ci := m.roots[0]
+		ci.Gaps = []analyzer.IdleGap{{Start: ci.StartTime.Add(2 * time.Minute), End: ci.StartTime.Add(3 * time.Minute)}}
+		m.cursor = len(m.visibleItems) - 1
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+		m = newModel.(Model)
+
+		// Wraps around to the CI workflow, the only node with a gap
+		assert.Equal(t, "CI", m.visibleItems[m.cursor].Name)
+	})
 }
 
 func TestPageUpDown(t *testing.T) {
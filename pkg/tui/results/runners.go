@@ -0,0 +1,342 @@
+package results
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+// runnerKeyWidth is the width of the lane name column in the runner view.
+const runnerKeyWidth = 24
+
+// toggleRunners opens or closes the runner swimlane view.
+func (m *Model) toggleRunners() {
+	m.showRunners = !m.showRunners
+	if m.showRunners {
+		if m.runnersBy == "" {
+			m.runnersBy = analyzer.RunnersByName
+		}
+		m.rebuildRunners()
+	}
+}
+
+// rebuildRunners regroups the jobs into lanes for the current grouping.
+func (m *Model) rebuildRunners() {
+	m.runnerLanes = analyzer.RunnerLanes(m.roots, m.runnersBy)
+	m.runnerDrilled = false
+	m.runnerJobCursor = 0
+	if m.runnerCursor >= len(m.runnerLanes) {
+		m.runnerCursor = len(m.runnerLanes) - 1
+	}
+	if m.runnerCursor < 0 {
+		m.runnerCursor = 0
+	}
+}
+
+// cycleRunnerGrouping advances to the next lane grouping.
+func (m *Model) cycleRunnerGrouping() {
+	idx := 0
+	for i, g := range analyzer.RunnerGroupings {
+		if g == m.runnersBy {
+			idx = i
+		}
+	}
+	m.runnersBy = analyzer.RunnerGroupings[(idx+1)%len(analyzer.RunnerGroupings)]
+	m.runnerCursor = 0
+	m.rebuildRunners()
+}
+
+// runnerRowCount returns how many rows the runner view currently lists.
+func (m Model) runnerRowCount() int {
+	if m.runnerDrilled && m.runnerCursor < len(m.runnerLanes) {
+		return len(m.runnerLanes[m.runnerCursor].Jobs)
+	}
+	return len(m.runnerLanes)
+}
+
+// updateRunners handles key presses while the runner view is shown.
+func (m Model) updateRunners(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	drilled := m.runnerDrilled
+	cursor := &m.runnerCursor
+	if drilled {
+		cursor = &m.runnerJobCursor
+	}
+	rows := m.runnerRowCount()
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Help):
+		m.showHelpModal = true
+
+	case key.Matches(msg, m.keys.Runners):
+		m.showRunners = false
+
+	case key.Matches(msg, m.keys.Up):
+		if *cursor > 0 {
+			*cursor--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if *cursor < rows-1 {
+			*cursor++
+		}
+
+	case key.Matches(msg, m.keys.PageUp):
+		*cursor = max(0, *cursor-m.aggregatePageSize()/2)
+
+	case key.Matches(msg, m.keys.PageDown):
+		*cursor = max(0, min(rows-1, *cursor+m.aggregatePageSize()/2))
+
+	case key.Matches(msg, m.keys.AggregateBy):
+		if !drilled {
+			m.cycleRunnerGrouping()
+		}
+
+	case key.Matches(msg, m.keys.Enter), key.Matches(msg, m.keys.Right):
+		if !drilled {
+			if m.runnerCursor < len(m.runnerLanes) {
+				m.runnerDrilled = true
+				m.runnerJobCursor = 0
+			}
+			return m, nil
+		}
+		// Jump from a job back to the tree view
+		jobs := m.runnerLanes[m.runnerCursor].Jobs
+		if m.runnerJobCursor < len(jobs) && m.revealNode(jobs[m.runnerJobCursor].Node) {
+			m.showRunners = false
+		}
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Left):
+		if drilled {
+			m.runnerDrilled = false
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Back) {
+			m.showRunners = false
+		}
+	}
+	return m, nil
+}
+
+// renderRunnersView renders the runner swimlanes in place of the tree and
+// timeline.
+func (m Model) renderRunnersView(width, height int) string {
+	var b strings.Builder
+
+	totalWidth := width - horizontalPad*2
+	if totalWidth < 1 {
+		totalWidth = 80
+	}
+	contentWidth := totalWidth - 2
+	innerWidth := contentWidth - 2
+	pageSize := max(1, m.aggregatePageSize())
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(m.renderRunnersTitle(contentWidth))
+	b.WriteString("\n")
+	blankLine := BorderStyle.Render("│") + strings.Repeat(" ", contentWidth) + BorderStyle.Render("│")
+	b.WriteString(blankLine)
+	b.WriteString("\n")
+
+	row := func(content string) string {
+		pad := innerWidth - lipgloss.Width(content)
+		if pad < 0 {
+			content = ansi.Truncate(content, innerWidth, "…")
+			pad = 0
+		}
+		return BorderStyle.Render("│") + " " + content + strings.Repeat(" ", pad) + " " + BorderStyle.Render("│") + "\n"
+	}
+
+	var lines []string
+	var header string
+	cursor := m.runnerCursor
+	if m.runnerDrilled && m.runnerCursor < len(m.runnerLanes) {
+		cursor = m.runnerJobCursor
+		header, lines = m.runnerJobLines(innerWidth)
+	} else {
+		header, lines = m.runnerLaneLines(innerWidth)
+	}
+	b.WriteString(row(HeaderCountStyle.Render(header)))
+
+	if len(lines) == 0 {
+		lines = []string{HeaderCountStyle.Render("No jobs have runner information.")}
+		cursor = -1
+	}
+	start := 0
+	if len(lines) > pageSize {
+		start = max(0, min(cursor-pageSize/2, len(lines)-pageSize))
+	}
+	for i := 0; i < pageSize; i++ {
+		idx := start + i
+		if idx >= len(lines) {
+			b.WriteString(blankLine + "\n")
+			continue
+		}
+		line := lines[idx]
+		if idx == cursor {
+			line = SelectedStyle.Render(ansi.Strip(line) + strings.Repeat(" ", max(0, innerWidth-lipgloss.Width(line))))
+		}
+		b.WriteString(row(line))
+	}
+
+	b.WriteString(m.renderFooter())
+
+	if m.showHelpModal {
+		return placeModalCentered(m.renderHelpModal(), width, height)
+	}
+	return addHorizontalPadding(b.String(), horizontalPad)
+}
+
+// renderRunnersTitle renders the line above the lanes: grouping and legend.
+func (m Model) renderRunnersTitle(contentWidth int) string {
+	text := HeaderStyle.Render("Runners by "+m.runnersBy) +
+		HeaderCountStyle.Render(fmt.Sprintf(" · %d lanes · █ running ░ idle ▒ waiting for a runner", len(m.runnerLanes)))
+	if m.runnerDrilled && m.runnerCursor < len(m.runnerLanes) {
+		lane := m.runnerLanes[m.runnerCursor]
+		text += HeaderCountStyle.Render(" · ") + BreadcrumbActiveStyle.Render(lane.Key) +
+			HeaderCountStyle.Render(fmt.Sprintf(" (%d jobs)", len(lane.Jobs)))
+	}
+	text = ansi.Truncate(text, contentWidth-2, "…")
+	pad := max(0, contentWidth-1-lipgloss.Width(text))
+	return BorderStyle.Render("│") + " " + text + strings.Repeat(" ", pad) + BorderStyle.Render("│")
+}
+
+// runnerLaneLines renders one line per lane: its statistics and a bar of
+// when it was busy over the whole run.
+func (m Model) runnerLaneLines(width int) (string, []string) {
+	cols := []string{"jobs", "busy", "idle", "util", "conc", "waited"}
+	barWidth := max(10, width-runnerKeyWidth-len(cols)*aggregateNumWidth-1)
+
+	var h strings.Builder
+	h.WriteString(padRight(m.runnersBy, m.runnersBy, runnerKeyWidth))
+	for _, c := range cols {
+		h.WriteString(fmt.Sprintf("%*s", aggregateNumWidth, c))
+	}
+
+	lines := make([]string, 0, len(m.runnerLanes))
+	for _, lane := range m.runnerLanes {
+		name := ansi.Truncate(lane.Key, runnerKeyWidth-1, "…")
+		lines = append(lines, padRight(name, name, runnerKeyWidth)+
+			fmt.Sprintf("%*d", aggregateNumWidth, len(lane.Jobs))+
+			aggregateDuration(lane.Busy)+
+			aggregateDuration(lane.Idle())+
+			fmt.Sprintf("%*s", aggregateNumWidth, fmt.Sprintf("%.0f%%", lane.Utilization()))+
+			fmt.Sprintf("%*d", aggregateNumWidth, lane.MaxConcurrency)+
+			aggregateDuration(lane.Queue)+
+			" "+m.runnerBar(lane.Jobs, lane.Gaps, barWidth))
+	}
+	return h.String(), lines
+}
+
+// runnerJobLines renders the jobs of the drilled-down lane with the runner
+// each ran on and how long it waited for it.
+func (m Model) runnerJobLines(width int) (string, []string) {
+	lane := m.runnerLanes[m.runnerCursor]
+	nameWidth := max(10, (width-4*aggregateNumWidth-2)/3)
+	runnerWidth := max(8, nameWidth/2)
+	barWidth := max(10, width-2-nameWidth-runnerWidth-4*aggregateNumWidth-1)
+
+	header := "  " + padRight("job", "job", nameWidth) + padRight("runner", "runner", runnerWidth) +
+		fmt.Sprintf("%*s%*s%*s%*s", aggregateNumWidth, "queued", aggregateNumWidth, "waited", aggregateNumWidth, "start", aggregateNumWidth, "duration")
+
+	lines := make([]string, 0, len(lane.Jobs))
+	for _, job := range lane.Jobs {
+		n := job.Node
+		item := TreeItem{Hints: n.Hints}
+		name := ansi.Truncate(n.Name, nameWidth-1, "…")
+		runner := ansi.Truncate(job.Runner, runnerWidth-1, "…")
+		lines = append(lines, getStyledStatusIcon(item)+" "+padRight(name, name, nameWidth)+
+			padRight(runner, runner, runnerWidth)+
+			fmt.Sprintf("%*s", aggregateNumWidth, m.runnerOffset(job.QueuedAt()))+
+			aggregateDuration(job.Queue)+
+			fmt.Sprintf("%*s", aggregateNumWidth, m.runnerOffset(n.StartTime))+
+			aggregateDuration(n.Duration())+
+			" "+m.runnerBar([]analyzer.RunnerJob{job}, nil, barWidth))
+	}
+	return header, lines
+}
+
+// runnerOffset formats t relative to the start of the run.
+func (m Model) runnerOffset(t time.Time) string {
+	if m.globalStart.IsZero() {
+		return "-"
+	}
+	return "+" + utils.HumanizeTime(t.Sub(m.globalStart).Seconds())
+}
+
+// runnerBar draws jobs over the whole run: running, idle gaps between them,
+// and waiting for a runner while nothing ran.
+func (m Model) runnerBar(jobs []analyzer.RunnerJob, gaps []analyzer.IdleGap, width int) string {
+	total := m.globalEnd.Sub(m.globalStart)
+	if total <= 0 || width <= 0 {
+		return ""
+	}
+	col := func(t time.Time) int {
+		return max(0, min(width-1, int(float64(t.Sub(m.globalStart))/float64(total)*float64(width))))
+	}
+
+	cells := make([]rune, width)
+	styles := make([]lipgloss.Style, width)
+	for i := range cells {
+		cells[i], styles[i] = ' ', HeaderCountStyle
+	}
+	fill := func(from, to time.Time, r rune, style lipgloss.Style, over bool) {
+		for i := col(from); i <= col(to); i++ {
+			if over || cells[i] == ' ' {
+				cells[i], styles[i] = r, style
+			}
+		}
+	}
+	for _, g := range gaps {
+		fill(g.Start, g.End, '░', HeaderCountStyle, true)
+	}
+	for _, job := range jobs {
+		if job.Queue > 0 {
+			fill(job.QueuedAt(), job.Node.StartTime, '▒', BarFailureNonBlockingStyle, false)
+		}
+	}
+	for _, job := range jobs {
+		style := BarSuccessStyle
+		switch job.Node.Hints.Outcome {
+		case "failure":
+			style = BarFailureStyle
+		case "pending":
+			style = BarPendingStyle
+		}
+		fill(job.Node.StartTime, job.Node.EndTime, '█', style, true)
+	}
+
+	var b strings.Builder
+	for i, r := range cells {
+		b.WriteString(styles[i].Render(string(r)))
+	}
+	return b.String()
+}
+
+// renderRunnersBreadcrumb shows the selected lane (and job when drilled in).
+func (m Model) renderRunnersBreadcrumb(totalWidth int) string {
+	contentWidth := max(10, totalWidth-4)
+	var bc string
+	if m.runnerCursor < len(m.runnerLanes) {
+		lane := m.runnerLanes[m.runnerCursor]
+		if m.runnerDrilled && m.runnerJobCursor < len(lane.Jobs) {
+			bc = BreadcrumbStyle.Render(lane.Key) + BreadcrumbSepStyle.Render(" › ") +
+				BreadcrumbActiveStyle.Render(lane.Jobs[m.runnerJobCursor].Node.Name)
+		} else {
+			bc = BreadcrumbActiveStyle.Render(lane.Key)
+		}
+	}
+	bc = ansi.Truncate(bc, contentWidth, "…")
+	pad := max(0, contentWidth-lipgloss.Width(bc))
+	return BorderStyle.Render("│") + " " + bc + strings.Repeat(" ", pad) + " " + BorderStyle.Render("│")
+}
@@ -0,0 +1,98 @@
+package results
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stefanpenner/otel-explorer/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnersView(t *testing.T) {
+	t.Parallel()
+
+	press := func(m Model, k string) Model {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		return next.(Model)
+	}
+
+	// build and test ran back to back on the same runner
+	runnerModel := func() Model {
+		m := createTestModel()
+		for _, job := range m.roots[0].Children {
+			job.Attrs = map[string]string{
+				"type":                    "job",
+				analyzer.AttrRunnerName:   "runner-1",
+				analyzer.AttrRunnerLabels: "self-hosted,linux",
+			}
+		}
+		m.roots[0].Children[1].Attrs["queue_time_ms"] = "30000"
+		return m
+	}
+
+	t.Run("R toggles the runner lanes", func(t *testing.T) {
+		m := runnerModel()
+		m = press(m, "R")
+		assert.True(t, m.showRunners)
+		assert.Equal(t, analyzer.RunnersByName, m.runnersBy)
+		if assert.Len(t, m.runnerLanes, 1) {
+			assert.Equal(t, "runner-1", m.runnerLanes[0].Key)
+			assert.Len(t, m.runnerLanes[0].Jobs, 2)
+		}
+		assert.Contains(t, m.View(), "Runners by runner")
+
+		m = press(m, "R")
+		assert.False(t, m.showRunners)
+	})
+
+	t.Run("b cycles the grouping", func(t *testing.T) {
+		m := runnerModel()
+		m = press(m, "R")
+		m = press(m, "b")
+		assert.Equal(t, analyzer.RunnersByGroup, m.runnersBy)
+		assert.Equal(t, "unknown", m.runnerLanes[0].Key)
+		m = press(m, "b")
+		assert.Equal(t, analyzer.RunnersByLabel, m.runnersBy)
+		assert.Equal(t, "self-hosted,linux", m.runnerLanes[0].Key)
+	})
+
+	t.Run("drills into a lane and jumps to a job", func(t *testing.T) {
+		m := runnerModel()
+		m = press(m, "R")
+		m = press(m, "enter")
+		assert.True(t, m.runnerDrilled)
+		assert.Contains(t, m.View(), "30s", "shows how long the job waited")
+
+		m = press(m, "j")
+		m = press(m, "enter")
+		assert.False(t, m.showRunners)
+		assert.Equal(t, "test", m.visibleItems[m.cursor].Name)
+	})
+
+	t.Run("esc leaves the drill-down before closing", func(t *testing.T) {
+		m := runnerModel()
+		m = press(m, "R")
+		m = press(m, "enter")
+		m = press(m, "esc")
+		assert.True(t, m.showRunners)
+		assert.False(t, m.runnerDrilled)
+		m = press(m, "esc")
+		assert.False(t, m.showRunners)
+	})
+
+	t.Run("jobs without runners show an empty view", func(t *testing.T) {
+		m := createTestModel()
+		m = press(m, "R")
+		assert.Empty(t, m.runnerLanes)
+		assert.Contains(t, m.View(), "No jobs have runner information.")
+	})
+}
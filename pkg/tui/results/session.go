@@ -0,0 +1,291 @@
+package results
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// sessionVersion is bumped when the Session layout changes incompatibly;
+// sessions with another version are ignored.
+const sessionVersion = 1
+
+// Session is the view state saved per input, so reopening the explorer on
+// the same PR or trace restores where the user left off. Items are keyed by
+// their tree item IDs, which are derived from the span hierarchy and stay
+// stable across runs.
+type Session struct {
+	Version        int             `json:"version"`
+	SavedAt        time.Time       `json:"saved_at"`
+	Inputs         []string        `json:"inputs,omitempty"`
+	Expanded       map[string]bool `json:"expanded,omitempty"`
+	Hidden         map[string]bool `json:"hidden,omitempty"`
+	Focused        []string        `json:"focused,omitempty"`
+	PreFocusHidden map[string]bool `json:"pre_focus_hidden,omitempty"`
+	CursorID       string          `json:"cursor_id,omitempty"`
+	LogicalEndID   string          `json:"logical_end_id,omitempty"`
+	SortMode       SortMode        `json:"sort_mode"`
+	TreeWidth      int             `json:"tree_width,omitempty"`
+	Bookmarks      []Bookmark      `json:"bookmarks,omitempty"`
+}
+
+// Bookmark is a named reference to a span in the tree.
+type Bookmark struct {
+	Name   string `json:"name"`
+	ItemID string `json:"item_id"`
+	SpanID string `json:"span_id,omitempty"`
+}
+
+// SessionStore reads and writes sessions as JSON files in Dir.
+type SessionStore struct {
+	Dir string
+}
+
+// DefaultSessionDir returns the state directory for saved sessions:
+// $XDG_STATE_HOME/otel-explorer/sessions, falling back to ~/.local/state.
+func DefaultSessionDir() string {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".otel-explorer", "sessions")
+		}
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateDir, "otel-explorer", "sessions")
+}
+
+// NewSessionStore returns a store rooted at DefaultSessionDir.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{Dir: DefaultSessionDir()}
+}
+
+// SessionKey derives the session file key from the inputs (GitHub URLs) and,
+// for inputs without a stable URL such as trace files, the trace IDs they
+// contain. Order does not matter.
+func SessionKey(inputs []string, traceIDs []string) string {
+	parts := append([]string{}, inputs...)
+	sort.Strings(parts)
+	ids := append([]string{}, traceIDs...)
+	sort.Strings(ids)
+
+	h := sha256.New()
+	h.Write([]byte(strings.Join(parts, "\n")))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(ids, "\n")))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (s *SessionStore) path(key string) string {
+	return filepath.Join(s.Dir, key+".json")
+}
+
+// Load returns the saved session for key, or nil if there is none (or it was
+// written by an incompatible version).
+func (s *SessionStore) Load(key string) (*Session, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read session")
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse session %s", s.path(key))
+	}
+	if session.Version != sessionVersion {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// Save writes the session for key, replacing any previous one.
+func (s *SessionStore) Save(key string, session *Session) error {
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create session directory")
+	}
+	session.Version = sessionVersion
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode session")
+	}
+
+	// Write to a temp file and rename so a crash never leaves a torn file
+	tmp, err := os.CreateTemp(s.Dir, key+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create session file")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write session")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write session")
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to save session")
+	}
+	return nil
+}
+
+// Option configures a Model at construction time.
+type Option func(*Model)
+
+// WithSession restores the session saved under key (if any) and makes the
+// model save its state there when the TUI exits.
+func WithSession(store *SessionStore, key string) Option {
+	return func(m *Model) {
+		m.sessionStore = store
+		m.sessionKey = key
+	}
+}
+
+// captureSession snapshots the persistent parts of the view state.
+func (m *Model) captureSession() *Session {
+	s := &Session{
+		SavedAt:        time.Now(),
+		Inputs:         m.inputURLs,
+		Expanded:       copyBoolMap(m.expandedState),
+		Hidden:         trueKeys(m.hiddenState),
+		LogicalEndID:   m.logicalEndID,
+		SortMode:       m.sortMode,
+		TreeWidth:      m.treeWidth,
+		Bookmarks:      m.bookmarks,
+		PreFocusHidden: trueKeys(m.preFocusHiddenState),
+	}
+	if m.isFocused {
+		for id := range m.focusedIDs {
+			s.Focused = append(s.Focused, id)
+		}
+		sort.Strings(s.Focused)
+	}
+	if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
+		s.CursorID = m.visibleItems[m.cursor].ID
+	}
+	return s
+}
+
+// saveSession writes the current state to the session store, if configured.
+func (m *Model) saveSession() error {
+	if m.sessionStore == nil || m.sessionKey == "" {
+		return nil
+	}
+	return m.sessionStore.Save(m.sessionKey, m.captureSession())
+}
+
+// restoreSession loads the saved state for this input and applies it.
+// Items that no longer exist are ignored.
+func (m *Model) restoreSession() error {
+	if m.sessionStore == nil || m.sessionKey == "" {
+		return nil
+	}
+	s, err := m.sessionStore.Load(m.sessionKey)
+	if err != nil || s == nil {
+		return err
+	}
+	m.applySession(s)
+	return nil
+}
+
+// applySession applies a saved session to the model.
+func (m *Model) applySession(s *Session) {
+	for id, expanded := range s.Expanded {
+		m.expandedState[id] = expanded
+	}
+	m.hiddenState = copyBoolMap(s.Hidden)
+	if s.TreeWidth >= minTreeWidth && s.TreeWidth <= maxTreeWidth {
+		m.treeWidth = s.TreeWidth
+	}
+	if s.SortMode >= SortByStartTime && s.SortMode <= SortByDurationAsc {
+		m.sortMode = s.SortMode
+	}
+	m.bookmarks = s.Bookmarks
+
+	m.rebuildItems()
+
+	if len(s.Focused) > 0 {
+		m.isFocused = true
+		m.focusedIDs = make(map[string]bool, len(s.Focused))
+		for _, id := range s.Focused {
+			m.focusedIDs[id] = true
+		}
+		m.preFocusHiddenState = copyBoolMap(s.PreFocusHidden)
+	}
+
+	if item := m.findItemByID(s.LogicalEndID); item != nil {
+		m.logicalEndID = item.ID
+		m.logicalEndTime = item.EndTime
+		if item.EndTime.IsZero() || item.EndTime.Equal(item.StartTime) {
+			m.logicalEndTime = item.StartTime
+		}
+	}
+
+	m.recalculateEffectiveTimes()
+	m.rebuildVisibleItems()
+	m.recalculateChartBounds()
+
+	for i, item := range m.visibleItems {
+		if item.ID == s.CursorID {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// findItemByID returns the tree item with the given ID, searching collapsed
+// subtrees too.
+func (m *Model) findItemByID(id string) *TreeItem {
+	if id == "" {
+		return nil
+	}
+	var found *TreeItem
+	var walk func(items []*TreeItem)
+	walk = func(items []*TreeItem) {
+		for _, item := range items {
+			if found != nil {
+				return
+			}
+			if item.ID == id {
+				found = item
+				return
+			}
+			walk(item.Children)
+		}
+	}
+	walk(m.treeItems)
+	return found
+}
+
+// trueKeys returns a copy of the map without false entries, or nil if empty.
+func trueKeys(state map[string]bool) map[string]bool {
+	var out map[string]bool
+	for id, v := range state {
+		if !v {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]bool)
+		}
+		out[id] = true
+	}
+	return out
+}
+
+func copyBoolMap(src map[string]bool) map[string]bool {
+	dst := make(map[string]bool, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
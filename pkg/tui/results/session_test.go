@@ -0,0 +1,123 @@
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionKey(t *testing.T) {
+	t.Parallel()
+
+	a := SessionKey([]string{"https://github.com/o/r/pull/1", "https://github.com/o/r/pull/2"}, nil)
+	b := SessionKey([]string{"https://github.com/o/r/pull/2", "https://github.com/o/r/pull/1"}, nil)
+	assert.Equal(t, a, b, "input order should not matter")
+	assert.Len(t, a, 16)
+
+	assert.NotEqual(t, a, SessionKey([]string{"https://github.com/o/r/pull/1"}, nil))
+	assert.NotEqual(t,
+		SessionKey(nil, []string{"aaaa"}),
+		SessionKey(nil, []string{"bbbb"}),
+		"trace files with different traces get different sessions")
+}
+
+func TestSessionStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing session loads as nil", func(t *testing.T) {
+		store := &SessionStore{Dir: t.TempDir()}
+		s, err := store.Load("nope")
+		assert.NoError(t, err)
+		assert.Nil(t, s)
+	})
+
+	t.Run("save then load round trips", func(t *testing.T) {
+		store := &SessionStore{Dir: filepath.Join(t.TempDir(), "nested")}
+		in := &Session{
+			Expanded:  map[string]bool{"a": true, "b": false},
+			Hidden:    map[string]bool{"c": true},
+			SortMode:  SortByDurationDesc,
+			Bookmarks: []Bookmark{{Name: "slow", ItemID: "a/b", SpanID: "0102"}},
+		}
+		assert.NoError(t, store.Save("key", in))
+
+		out, err := store.Load("key")
+		assert.NoError(t, err)
+		assert.Equal(t, sessionVersion, out.Version)
+		assert.Equal(t, in.Expanded, out.Expanded)
+		assert.Equal(t, in.Hidden, out.Hidden)
+		assert.Equal(t, SortByDurationDesc, out.SortMode)
+		assert.Equal(t, in.Bookmarks, out.Bookmarks)
+	})
+
+	t.Run("other versions are ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		store := &SessionStore{Dir: dir}
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "old.json"), []byte(`{"version": 0}`), 0644))
+		s, err := store.Load("old")
+		assert.NoError(t, err)
+		assert.Nil(t, s)
+	})
+
+	t.Run("corrupt session is an error", func(t *testing.T) {
+		dir := t.TempDir()
+		store := &SessionStore{Dir: dir}
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{`), 0644))
+		_, err := store.Load("bad")
+		assert.Error(t, err)
+	})
+}
+
+func TestSessionRestore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("view state survives a save and restore", func(t *testing.T) {
+		store := &SessionStore{Dir: t.TempDir()}
+
+		m := createTestModel()
+		WithSession(store, "pr")(&m)
+		m.expandAll()
+		m.hiddenState["url-group/0/CI/0/test/1"] = true
+		m.logicalEndID = "url-group/0/CI/0/build/0"
+		m.sortMode = SortByDurationDesc
+		m.treeWidth = defaultTreeWidth + 10
+		m.bookmarks = []Bookmark{{Name: "checkout", ItemID: "url-group/0/CI/0/build/0/Checkout/0"}}
+		m.rebuildVisibleItems()
+		m.cursor = findVisibleIndex(&m, "url-group/0/CI/0/build/0/Checkout/0")
+		assert.NoError(t, m.saveSession())
+
+		restored := createTestModel()
+		WithSession(store, "pr")(&restored)
+		assert.NoError(t, restored.restoreSession())
+
+		assert.True(t, restored.expandedState["url-group/0/CI/0/build/0"])
+		assert.True(t, restored.hiddenState["url-group/0/CI/0/test/1"])
+		assert.Equal(t, "url-group/0/CI/0/build/0", restored.logicalEndID)
+		assert.Equal(t, SortByDurationDesc, restored.sortMode)
+		assert.Equal(t, defaultTreeWidth+10, restored.treeWidth)
+		assert.Equal(t, m.bookmarks, restored.bookmarks)
+		assert.Equal(t, "url-group/0/CI/0/build/0/Checkout/0", restored.visibleItems[restored.cursor].ID)
+	})
+
+	t.Run("items that no longer exist are ignored", func(t *testing.T) {
+		m := createTestModel()
+		m.applySession(&Session{
+			Expanded:     map[string]bool{"gone/0": true},
+			LogicalEndID: "gone/0",
+			CursorID:     "gone/0",
+			TreeWidth:    -5,
+		})
+
+		assert.Empty(t, m.logicalEndID)
+		assert.Equal(t, 0, m.cursor)
+		assert.Equal(t, defaultTreeWidth, m.treeWidth)
+	})
+
+	t.Run("no store means nothing is saved", func(t *testing.T) {
+		m := createTestModel()
+		assert.NoError(t, m.saveSession())
+		assert.NoError(t, m.restoreSession())
+	})
+}
@@ -120,6 +120,19 @@ var (
 				Foreground(ColorYellow)
 )
 
+// Timeline zoom styles: time cursor line and mini-map overview
+var (
+	TimeCursorStyle = lipgloss.NewStyle().
+			Foreground(ColorMagenta)
+
+	MiniMapStyle = lipgloss.NewStyle().
+			Foreground(ColorGrayDim)
+
+	MiniMapWindowStyle = lipgloss.NewStyle().
+				Foreground(ColorBlue).
+				Background(ColorSelectionBg)
+)
+
 // Hidden badge style (shows when item is excluded from chart via x key)
 var (
 	HiddenBadgeStyle = lipgloss.NewStyle().
@@ -299,6 +312,17 @@ var (
 				Foreground(ColorGrayDim)
 )
 
+// Flame graph frame styles (background is picked per frame)
+var (
+	FlameFrameStyle = lipgloss.NewStyle().
+			Foreground(ColorWhite)
+
+	FlameSelectedStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(ColorSurface0).
+				Background(ColorBlue)
+)
+
 // Statusline styles (LazyVim-style mode pill + segments)
 var (
 	StatusModePill = lipgloss.NewStyle().
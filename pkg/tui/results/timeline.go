@@ -434,8 +434,9 @@ type childMarkerPos struct {
 }
 
 // computeChildPositions calculates the timeline position for each immediate child.
-// Hidden children (present in hiddenState) are excluded from markers.
-func computeChildPositions(children []*TreeItem, globalStart, globalEnd time.Time, width int, styleFn func(*TreeItem) lipgloss.Style, hiddenState map[string]bool) []childMarkerPos {
+// Hidden children (present in hiddenState) are excluded from markers. Children
+// outside the range are clamped to its edges, or skipped when zoomed in on it.
+func computeChildPositions(children []*TreeItem, globalStart, globalEnd time.Time, width int, styleFn func(*TreeItem) lipgloss.Style, hiddenState map[string]bool, zoomed bool) []childMarkerPos {
 	totalDuration := globalEnd.Sub(globalStart)
 	if totalDuration <= 0 || width <= 0 {
 		return nil
@@ -450,6 +451,9 @@ func computeChildPositions(children []*TreeItem, globalStart, globalEnd time.Tim
 		if childStart.IsZero() {
 			continue
 		}
+		if zoomed && (childStart.After(globalEnd) || (!child.EndTime.IsZero() && child.EndTime.Before(globalStart))) {
+			continue // outside the visible window
+		}
 		if childStart.Before(globalStart) {
 			childStart = globalStart
 		}
@@ -475,7 +479,7 @@ func computeChildPositions(children []*TreeItem, globalStart, globalEnd time.Tim
 // styleFn selects the appropriate child style variant (normal vs selected).
 // If bgStyle is non-nil, empty space gets that background (for search-match rows).
 // If selected is true, parent uses selected styles and padding gets selection bg.
-func renderTimelineWithChildren(item TreeItem, globalStart, globalEnd time.Time, width int, url string, selected bool, bgStyle *lipgloss.Style, hiddenState map[string]bool) string {
+func renderTimelineWithChildren(item TreeItem, globalStart, globalEnd time.Time, width int, url string, selected bool, bgStyle *lipgloss.Style, hiddenState map[string]bool, zoomed bool) string {
 	if globalEnd.Before(globalStart) || globalEnd.Equal(globalStart) || width <= 0 {
 		if selected {
 			return SelectedBgStyle.Render(strings.Repeat(" ", width))
@@ -495,7 +499,7 @@ func renderTimelineWithChildren(item TreeItem, globalStart, globalEnd time.Time,
 	}
 
 	// Compute child marker positions
-	childPositions := computeChildPositions(item.Children, globalStart, globalEnd, width, childStyleFn, hiddenState)
+	childPositions := computeChildPositions(item.Children, globalStart, globalEnd, width, childStyleFn, hiddenState, zoomed)
 
 	// Build buffer tracking what's at each position
 	type cell struct {
@@ -619,21 +623,24 @@ func renderTimelineWithChildren(item TreeItem, globalStart, globalEnd time.Time,
 }
 
 // RenderTimelineBarWithChildren renders a timeline bar with dimmed child markers for collapsed items.
-// Hidden children are excluded from the child markers.
-func RenderTimelineBarWithChildren(item TreeItem, globalStart, globalEnd time.Time, width int, url string, hiddenState map[string]bool) string {
-	return renderTimelineWithChildren(item, globalStart, globalEnd, width, url, false, nil, hiddenState)
+// Hidden children are excluded from the child markers, as are children outside
+// the range when zoomed.
+func RenderTimelineBarWithChildren(item TreeItem, globalStart, globalEnd time.Time, width int, url string, hiddenState map[string]bool, zoomed bool) string {
+	return renderTimelineWithChildren(item, globalStart, globalEnd, width, url, false, nil, hiddenState, zoomed)
 }
 
 // RenderTimelineBarWithChildrenSelected renders a timeline bar with child markers and selection background.
-// Hidden children are excluded from the child markers.
-func RenderTimelineBarWithChildrenSelected(item TreeItem, globalStart, globalEnd time.Time, width int, url string, hiddenState map[string]bool) string {
-	return renderTimelineWithChildren(item, globalStart, globalEnd, width, url, true, nil, hiddenState)
+// Hidden children are excluded from the child markers, as are children outside
+// the range when zoomed.
+func RenderTimelineBarWithChildrenSelected(item TreeItem, globalStart, globalEnd time.Time, width int, url string, hiddenState map[string]bool, zoomed bool) string {
+	return renderTimelineWithChildren(item, globalStart, globalEnd, width, url, true, nil, hiddenState, zoomed)
 }
 
 // renderTimelineBarWithChildrenBg renders a timeline bar with child markers and a custom background.
-// Hidden children are excluded from the child markers.
-func renderTimelineBarWithChildrenBg(item TreeItem, globalStart, globalEnd time.Time, width int, url string, bg lipgloss.Style, hiddenState map[string]bool) string {
-	return renderTimelineWithChildren(item, globalStart, globalEnd, width, url, false, &bg, hiddenState)
+// Hidden children are excluded from the child markers, as are children outside
+// the range when zoomed.
+func renderTimelineBarWithChildrenBg(item TreeItem, globalStart, globalEnd time.Time, width int, url string, bg lipgloss.Style, hiddenState map[string]bool, zoomed bool) string {
+	return renderTimelineWithChildren(item, globalStart, globalEnd, width, url, false, &bg, hiddenState, zoomed)
 }
 
 // RenderTimelineBarDimmed renders a timeline bar in gray for items after the logical end.
@@ -768,6 +775,16 @@ func RenderTimelineBarDimmedSelected(item TreeItem, globalStart, globalEnd time.
 //
 // When `selected` is true the marker gets a selection-bg behind it.
 func overlayLogicalEndLine(timeline string, col, width int, selected bool) string {
+	markerStyle := LogicalEndBadgeStyle
+	if selected {
+		markerStyle = LogicalEndBadgeStyle.Background(ColorSelectionBg)
+	}
+	return overlayTimelineLine(timeline, col, width, markerStyle)
+}
+
+// overlayTimelineLine replaces the visible character at column col with a "│"
+// rendered in markerStyle, preserving the total visible width.
+func overlayTimelineLine(timeline string, col, width int, markerStyle lipgloss.Style) string {
 	if col < 0 || col >= width {
 		return timeline
 	}
@@ -882,11 +899,6 @@ func overlayLogicalEndLine(timeline string, col, width int, selected bool) strin
 		return timeline
 	}
 
-	// Build the replacement
-	markerStyle := LogicalEndBadgeStyle
-	if selected {
-		markerStyle = LogicalEndBadgeStyle.Background(ColorSelectionBg)
-	}
 	marker := markerStyle.Render("│")
 
 	return string(bytes[:beforeEnd]) + marker + string(bytes[afterStart:])
@@ -232,6 +232,14 @@ func (m Model) renderHeader() string {
 			partsPlain = append(partsPlain, fmt.Sprintf("Queue: avg %s / max %s", avgQ, maxQ))
 		}
 
+		// Environment approval time
+		if m.displayedSummary.ApprovalCount > 0 {
+			avgA := utils.HumanizeTime(m.displayedSummary.AvgApprovalTimeMs / 1000)
+			maxA := utils.HumanizeTime(m.displayedSummary.MaxApprovalTimeMs / 1000)
+			parts = append(parts, HeaderCountStyle.Render("Approval: avg ")+numStyle.Render(avgA)+HeaderCountStyle.Render(" / max ")+numStyle.Render(maxA))
+			partsPlain = append(partsPlain, fmt.Sprintf("Approval: avg %s / max %s", avgA, maxA))
+		}
+
 		// Retry rate
 		if m.displayedSummary.RetriedRuns > 0 && m.displayedSummary.TotalRuns > 0 {
 			retryPct := fmt.Sprintf("%.0f%%", float64(m.displayedSummary.RetriedRuns)/float64(m.displayedSummary.TotalRuns)*100)
@@ -280,8 +288,12 @@ func (m Model) renderTimeAxis() string {
 		timelineW = 10
 	}
 
-	// Tree part is empty (just padding to align with timeline)
+	// Tree part is empty (just padding to align with timeline), or shows the
+	// time cursor position
 	treePart := strings.Repeat(" ", treeW)
+	if !m.timeCursor.IsZero() {
+		treePart = m.renderTimeCursorLabel(treeW)
+	}
 
 	// Build time axis for the timeline area
 	if m.chartStart.IsZero() || m.chartEnd.IsZero() {
@@ -289,13 +301,17 @@ func (m Model) renderTimeAxis() string {
 		return BorderStyle.Render("│") + " " + treePart + SeparatorStyle.Render("│") + strings.Repeat(" ", timelineW) + BorderStyle.Render("│")
 	}
 
-	startTime := m.chartStart.Format("15:04:05")
-	endTime := m.chartEnd.Format("15:04:05")
-	durationSecs := m.chartEnd.Sub(m.chartStart).Seconds()
+	viewStart, viewEnd := m.viewRange()
+	startTime := formatAxisTime(viewStart, viewEnd.Sub(viewStart))
+	endTime := formatAxisTime(viewEnd, viewEnd.Sub(viewStart))
+	durationSecs := viewEnd.Sub(viewStart).Seconds()
 	if durationSecs < 0 {
 		durationSecs = 0
 	}
 	duration := utils.HumanizeTime(durationSecs)
+	if m.isZoomed() {
+		duration = fmt.Sprintf("%s (%.0fx)", duration, m.zoom)
+	}
 
 	// Style for numeric values
 	numStyle := lipgloss.NewStyle().Foreground(ColorBlue)
@@ -359,27 +375,24 @@ func (m Model) renderTimeAxis() string {
 	}
 
 	// Overlay ▼ marker at logical end position if set
-	logicalEndPos := -1
-	if m.logicalEndID != "" && !m.logicalEndTime.IsZero() && !m.chartStart.IsZero() && !m.chartEnd.IsZero() {
-		chartDuration := m.chartEnd.Sub(m.chartStart)
-		if chartDuration > 0 {
-			endOffset := m.logicalEndTime.Sub(m.chartStart)
-			logicalEndPos = int(float64(endOffset) / float64(chartDuration) * float64(timelineW))
-			if logicalEndPos >= timelineW {
-				logicalEndPos = timelineW - 1
-			}
-			if logicalEndPos < 0 {
-				logicalEndPos = 0
-			}
-			axisRunes[logicalEndPos] = '▼'
-		}
+	logicalEndPos := m.logicalEndCol(timelineW)
+	if logicalEndPos >= 0 {
+		axisRunes[logicalEndPos] = '▼'
+	}
+
+	// Overlay ▼ marker at the time cursor
+	timeCursorPos := m.columnForTime(m.timeCursor, timelineW)
+	if timeCursorPos >= 0 {
+		axisRunes[timeCursorPos] = '▼'
 	}
 
 	// Build styled output character by character
 	var timelineContent strings.Builder
 	for i, r := range axisRunes {
 		ch := string(r)
-		if i == logicalEndPos {
+		if i == timeCursorPos {
+			timelineContent.WriteString(TimeCursorStyle.Render(ch))
+		} else if i == logicalEndPos {
 			timelineContent.WriteString(LogicalEndBadgeStyle.Render(ch))
 		} else if r == '─' {
 			timelineContent.WriteString(ch)
@@ -561,6 +574,9 @@ func (m Model) renderItem(item TreeItem, isSelected bool, itemIdx int) string {
 			duration = 0
 		}
 		durationStr = fmt.Sprintf(" (%s)", utils.HumanizeTime(duration))
+		if rank, ok := item.BaselineRank(); ok {
+			durationStr = fmt.Sprintf(" (%s, %s)", utils.HumanizeTime(duration), rank.Label())
+		}
 		durationWidth = lipgloss.Width(durationStr)
 	}
 
@@ -699,33 +715,36 @@ func (m Model) renderItem(item TreeItem, isSelected bool, itemIdx int) string {
 	// For normal items, URL is passed so bar characters are clickable.
 	// For selected/hidden items, URL is omitted since we apply row-level hyperlink at the end.
 	// For collapsed items with children, overlay dimmed child markers as a sparkline summary.
+	viewStart, viewEnd := m.viewRange()
+	zoomed := m.isZoomed()
+	outOfView := zoomed && !itemInView(item, viewStart, viewEnd)
 	var timelineBar string
-	if isHidden && isSelected {
+	if (isHidden || outOfView) && isSelected {
 		// Hidden + selected: empty timeline with selection background
 		timelineBar = SelectedBgStyle.Render(strings.Repeat(" ", timelineW))
-	} else if isHidden {
+	} else if isHidden || outOfView {
 		timelineBar = strings.Repeat(" ", timelineW)
 	} else if isAfterEnd && isSelected {
 		// After logical end + selected: dimmed bar with selection background
-		timelineBar = RenderTimelineBarDimmedSelected(item, m.chartStart, m.chartEnd, timelineW)
+		timelineBar = RenderTimelineBarDimmedSelected(item, viewStart, viewEnd, timelineW)
 	} else if isAfterEnd {
 		// After logical end: dimmed gray bar
-		timelineBar = RenderTimelineBarDimmed(item, m.chartStart, m.chartEnd, timelineW)
+		timelineBar = RenderTimelineBarDimmed(item, viewStart, viewEnd, timelineW)
 	} else if isSelected && hasCollapsedChildren {
-		timelineBar = RenderTimelineBarWithChildrenSelected(item, m.chartStart, m.chartEnd, timelineW, "", m.hiddenState)
+		timelineBar = RenderTimelineBarWithChildrenSelected(item, viewStart, viewEnd, timelineW, "", m.hiddenState, zoomed)
 	} else if isSelected {
 		// Render with dimmed colors and selection background
-		timelineBar = RenderTimelineBarSelected(item, m.chartStart, m.chartEnd, timelineW, "")
+		timelineBar = RenderTimelineBarSelected(item, viewStart, viewEnd, timelineW, "")
 	} else if isSearchMatch && hasCollapsedChildren {
-		timelineBar = renderTimelineBarWithChildrenBg(item, m.chartStart, m.chartEnd, timelineW, item.Hints.URL, SearchRowBgStyle, m.hiddenState)
+		timelineBar = renderTimelineBarWithChildrenBg(item, viewStart, viewEnd, timelineW, item.Hints.URL, SearchRowBgStyle, m.hiddenState, zoomed)
 	} else if isSearchMatch {
 		// Search match: normal bar colors but with subtle row background on empty space
-		timelineBar = renderTimelineBarWithBg(item, m.chartStart, m.chartEnd, timelineW, item.Hints.URL, SearchRowBgStyle)
+		timelineBar = renderTimelineBarWithBg(item, viewStart, viewEnd, timelineW, item.Hints.URL, SearchRowBgStyle)
 	} else if hasCollapsedChildren {
-		timelineBar = RenderTimelineBarWithChildren(item, m.chartStart, m.chartEnd, timelineW, item.Hints.URL, m.hiddenState)
+		timelineBar = RenderTimelineBarWithChildren(item, viewStart, viewEnd, timelineW, item.Hints.URL, m.hiddenState, zoomed)
 	} else {
 		// Normal: full colors, pass URL so bar is clickable
-		timelineBar = RenderTimelineBar(item, m.chartStart, m.chartEnd, timelineW, item.Hints.URL)
+		timelineBar = RenderTimelineBar(item, viewStart, viewEnd, timelineW, item.Hints.URL)
 	}
 
 	// Overlay logical end vertical line on the timeline bar
@@ -734,6 +753,15 @@ func (m Model) renderItem(item TreeItem, isSelected bool, itemIdx int) string {
 		timelineBar = overlayLogicalEndLine(timelineBar, endCol, timelineW, isSelected)
 	}
 
+	// Overlay the time cursor line
+	if cursorCol := m.columnForTime(m.timeCursor, timelineW); cursorCol >= 0 {
+		cursorStyle := TimeCursorStyle
+		if isSelected {
+			cursorStyle = TimeCursorStyle.Background(ColorSelectionBg)
+		}
+		timelineBar = overlayTimelineLine(timelineBar, cursorCol, timelineW, cursorStyle)
+	}
+
 	// Combine with styled borders
 	midSep := SeparatorStyle.Render("│")
 
@@ -944,6 +972,15 @@ func (m Model) renderFooter() string {
 		segmentsPlain = append(segmentsPlain, " sort:"+m.sortMode.String()+" ")
 	}
 
+	if m.showFlame {
+		label := "flame"
+		if m.flameLeftHeavy {
+			label = "flame:left-heavy"
+		}
+		segments = append(segments, StatusSegment.Render(label))
+		segmentsPlain = append(segmentsPlain, " "+label+" ")
+	}
+
 	if m.treeWidth != defaultTreeWidth {
 		seg := StatusSegmentDim.Render(fmt.Sprintf("tree:%d", m.treeWidth))
 		segments = append(segments, seg)
@@ -960,6 +997,12 @@ func (m Model) renderFooter() string {
 		mode = HelpModeSearchActive
 	} else if m.showDetailModal {
 		mode = HelpModeModal
+	} else if m.showFlame {
+		mode = HelpModeFlame
+	} else if m.showAggregate {
+		mode = HelpModeAggregate
+	} else if m.showRunners {
+		mode = HelpModeRunners
 	}
 	helpHint = StatusSegmentDim.Render(m.keys.ShortHelpForMode(mode))
 	helpHintPlain = " " + m.keys.ShortHelpForMode(mode) + " "
@@ -990,7 +1033,13 @@ func (m Model) renderFooter() string {
 
 	// Breadcrumb line above status (only in normal mode with items)
 	breadcrumb := ""
-	if !m.isSearching && !m.showDetailModal && len(m.visibleItems) > 0 {
+	if m.showFlame {
+		breadcrumb = m.renderFlameBreadcrumb(totalWidth) + "\n"
+	} else if m.showAggregate {
+		breadcrumb = m.renderAggregateBreadcrumb(totalWidth) + "\n"
+	} else if m.showRunners {
+		breadcrumb = m.renderRunnersBreadcrumb(totalWidth) + "\n"
+	} else if !m.isSearching && !m.showDetailModal && len(m.visibleItems) > 0 {
 		breadcrumb = m.renderBreadcrumb(totalWidth) + "\n"
 	}
 
@@ -0,0 +1,301 @@
+package results
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stefanpenner/otel-explorer/pkg/utils"
+)
+
+const (
+	zoomStep    = 2.0  // factor applied per zoom in/out
+	maxZoom     = 4096 // deepest zoom relative to the full chart range
+	panFraction = 0.25 // share of the visible window moved per pan
+	minZoom     = 1.0  // full chart range
+)
+
+// isZoomed reports whether the timeline shows only part of the chart range.
+func (m *Model) isZoomed() bool {
+	return m.zoom > minZoom
+}
+
+// zoomLevel returns the current zoom factor, 1 meaning the full chart range.
+func (m *Model) zoomLevel() float64 {
+	if m.zoom < minZoom {
+		return minZoom
+	}
+	return m.zoom
+}
+
+// viewRange returns the time window currently drawn in the timeline column:
+// the full chartStart..chartEnd range, or the zoomed slice of it.
+func (m *Model) viewRange() (time.Time, time.Time) {
+	if !m.isZoomed() || m.chartStart.IsZero() || m.chartEnd.IsZero() {
+		return m.chartStart, m.chartEnd
+	}
+	total := m.chartEnd.Sub(m.chartStart)
+	if total <= 0 {
+		return m.chartStart, m.chartEnd
+	}
+	span := time.Duration(float64(total) / m.zoom)
+	start := m.chartStart.Add(time.Duration(m.zoomOffset * float64(total)))
+	return start, start.Add(span)
+}
+
+// setZoom applies a zoom level while keeping the instant `at` under the same
+// relative position (anchor, 0..1) of the visible window.
+func (m *Model) setZoom(zoom float64, at time.Time, anchor float64) {
+	if zoom < minZoom {
+		zoom = minZoom
+	}
+	if zoom > maxZoom {
+		zoom = maxZoom
+	}
+	total := m.chartEnd.Sub(m.chartStart)
+	if total <= 0 {
+		return
+	}
+	m.zoom = zoom
+	if !m.isZoomed() {
+		m.zoomOffset = 0
+		return
+	}
+	atFrac := float64(at.Sub(m.chartStart)) / float64(total)
+	m.setZoomOffset(atFrac - anchor/m.zoom)
+}
+
+// setZoomOffset moves the window start, clamped so the window stays inside
+// the chart range.
+func (m *Model) setZoomOffset(offset float64) {
+	maxOffset := 1 - 1/m.zoom
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	m.zoomOffset = offset
+}
+
+// zoomBy zooms the timeline by factor around the time cursor, or around the
+// centre of the visible window when no time cursor is set.
+func (m *Model) zoomBy(factor float64) {
+	viewStart, viewEnd := m.viewRange()
+	if viewEnd.Sub(viewStart) <= 0 {
+		return
+	}
+	at := viewStart.Add(viewEnd.Sub(viewStart) / 2)
+	anchor := 0.5
+	if !m.timeCursor.IsZero() && !m.timeCursor.Before(viewStart) && !m.timeCursor.After(viewEnd) {
+		at = m.timeCursor
+		anchor = float64(at.Sub(viewStart)) / float64(viewEnd.Sub(viewStart))
+	}
+	m.setZoom(m.zoomLevel()*factor, at, anchor)
+}
+
+// zoomAtColumn zooms by factor keeping the time under timeline column col fixed.
+func (m *Model) zoomAtColumn(factor float64, col int) {
+	timelineW := m.timelineWidth()
+	at := m.timeAtColumn(col, timelineW)
+	if at.IsZero() {
+		return
+	}
+	m.setZoom(m.zoomLevel()*factor, at, (float64(col)+0.5)/float64(timelineW))
+}
+
+// panBy shifts the visible window by a fraction of its own width.
+func (m *Model) panBy(fraction float64) {
+	if !m.isZoomed() {
+		return
+	}
+	m.setZoomOffset(m.zoomOffset + fraction/m.zoom)
+}
+
+// resetZoom shows the full chart range again.
+func (m *Model) resetZoom() {
+	m.zoom = 0
+	m.zoomOffset = 0
+}
+
+// timelineWidth returns the width of the timeline column, matching renderItem.
+func (m *Model) timelineWidth() int {
+	width := m.width
+	if width < 40 {
+		width = 40
+	}
+	totalWidth := width - horizontalPad*2
+	if totalWidth < 1 {
+		totalWidth = 80
+	}
+	timelineW := totalWidth - 4 - m.treeWidth
+	if timelineW < 10 {
+		timelineW = 10
+	}
+	return timelineW
+}
+
+// timelineColumnAt converts a screen x coordinate to a timeline column, or -1
+// when x is outside the timeline.
+func (m *Model) timelineColumnAt(x int) int {
+	col := x - horizontalPad - 3 - m.treeWidth
+	if col < 0 || col >= m.timelineWidth() {
+		return -1
+	}
+	return col
+}
+
+// timeAtColumn returns the instant at the middle of timeline column col.
+func (m *Model) timeAtColumn(col, timelineW int) time.Time {
+	viewStart, viewEnd := m.viewRange()
+	span := viewEnd.Sub(viewStart)
+	if span <= 0 || timelineW <= 0 || viewStart.IsZero() {
+		return time.Time{}
+	}
+	return viewStart.Add(time.Duration((float64(col) + 0.5) / float64(timelineW) * float64(span)))
+}
+
+// columnForTime returns the timeline column showing t, or -1 when t is
+// outside the visible window.
+func (m *Model) columnForTime(t time.Time, timelineW int) int {
+	viewStart, viewEnd := m.viewRange()
+	span := viewEnd.Sub(viewStart)
+	if t.IsZero() || span <= 0 || t.Before(viewStart) || t.After(viewEnd) {
+		return -1
+	}
+	col := int(float64(t.Sub(viewStart)) / float64(span) * float64(timelineW))
+	if col >= timelineW {
+		col = timelineW - 1
+	}
+	return col
+}
+
+// toggleTimeCursor shows the time cursor in the middle of the visible window,
+// or hides it.
+func (m *Model) toggleTimeCursor() {
+	if !m.timeCursor.IsZero() {
+		m.timeCursor = time.Time{}
+		return
+	}
+	timelineW := m.timelineWidth()
+	m.timeCursor = m.timeAtColumn(timelineW/2, timelineW)
+}
+
+// moveTimeCursor moves the time cursor by cols timeline columns, panning the
+// window when the cursor leaves it.
+func (m *Model) moveTimeCursor(cols int) {
+	timelineW := m.timelineWidth()
+	col := m.columnForTime(m.timeCursor, timelineW)
+	if col < 0 {
+		col = timelineW / 2
+	}
+	col += cols
+	if col < 0 || col >= timelineW {
+		before := m.zoomOffset
+		m.panBy(float64(cols) / float64(timelineW))
+		if m.zoomOffset == before {
+			// Already at the edge of the chart
+			col = max(0, min(col, timelineW-1))
+		} else {
+			col -= cols
+		}
+	}
+	m.timeCursor = m.timeAtColumn(col, timelineW)
+}
+
+// itemInView reports whether any part of the item falls inside the window.
+func itemInView(item TreeItem, viewStart, viewEnd time.Time) bool {
+	if item.StartTime.IsZero() {
+		return true
+	}
+	end := item.EndTime
+	if end.Before(item.StartTime) {
+		end = item.StartTime
+	}
+	return !end.Before(viewStart) && !item.StartTime.After(viewEnd)
+}
+
+// formatAxisTime formats a timestamp with sub-second precision once the
+// visible window is short enough for seconds to be ambiguous.
+func formatAxisTime(t time.Time, span time.Duration) string {
+	if span < time.Minute {
+		return t.Format("15:04:05.000")
+	}
+	return t.Format("15:04:05")
+}
+
+// renderTimeCursorLabel renders the time cursor position, absolute and
+// relative to the chart start, for the tree part of the time axis row.
+func (m Model) renderTimeCursorLabel(treeW int) string {
+	abs := m.timeCursor.Format("15:04:05.000")
+	rel := "+" + utils.HumanizeTime(m.timeCursor.Sub(m.chartStart).Seconds())
+	plain := "│ " + abs + " " + rel
+	if lipgloss.Width(plain) > treeW {
+		plain = "│ " + rel
+	}
+	if lipgloss.Width(plain) > treeW {
+		return strings.Repeat(" ", treeW)
+	}
+	styled := TimeCursorStyle.Render("│ "+abs) + " " + HeaderCountStyle.Render(rel)
+	if !strings.Contains(plain, abs) {
+		styled = TimeCursorStyle.Render("│ ") + HeaderCountStyle.Render(rel)
+	}
+	return styled + strings.Repeat(" ", treeW-lipgloss.Width(plain))
+}
+
+// renderMiniMap renders an overview of the whole chart range: span density
+// per column, with the zoomed window highlighted.
+func (m Model) renderMiniMap() string {
+	treeW := m.treeWidth
+	timelineW := m.timelineWidth()
+
+	label := "overview"
+	treePart := strings.Repeat(" ", max(0, treeW-len(label))) + label
+	if len(label) > treeW {
+		treePart = strings.Repeat(" ", treeW)
+	}
+
+	total := m.chartEnd.Sub(m.chartStart)
+	counts := make([]int, timelineW)
+	peak := 0
+	if total > 0 {
+		for _, item := range m.visibleItems {
+			if item.StartTime.IsZero() || item.Hints.IsMarker {
+				continue
+			}
+			from := int(float64(item.StartTime.Sub(m.chartStart)) / float64(total) * float64(timelineW))
+			to := int(float64(item.EndTime.Sub(m.chartStart)) / float64(total) * float64(timelineW))
+			from = max(0, from)
+			to = min(timelineW-1, max(from, to))
+			for c := from; c <= to; c++ {
+				counts[c]++
+				peak = max(peak, counts[c])
+			}
+		}
+	}
+
+	viewStart, viewEnd := m.viewRange()
+	winFrom, winTo := 0, timelineW-1
+	if total > 0 {
+		winFrom = int(float64(viewStart.Sub(m.chartStart)) / float64(total) * float64(timelineW))
+		winTo = int(float64(viewEnd.Sub(m.chartStart)) / float64(total) * float64(timelineW))
+		winFrom = max(0, min(winFrom, timelineW-1))
+		winTo = max(winFrom, min(winTo, timelineW-1))
+	}
+
+	levels := []rune(" ▁▂▃▄▅▆▇█")
+	var b strings.Builder
+	for c := 0; c < timelineW; c++ {
+		ch := levels[0]
+		if peak > 0 && counts[c] > 0 {
+			ch = levels[1+(counts[c]*(len(levels)-2))/peak]
+		}
+		if c >= winFrom && c <= winTo {
+			b.WriteString(MiniMapWindowStyle.Render(string(ch)))
+		} else {
+			b.WriteString(MiniMapStyle.Render(string(ch)))
+		}
+	}
+
+	return BorderStyle.Render("│") + " " + HeaderCountStyle.Render(treePart) + SeparatorStyle.Render("│") + b.String() + BorderStyle.Render("│")
+}
@@ -0,0 +1,167 @@
+package results
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func pressKey(m Model, k string) Model {
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return newModel.(Model)
+}
+
+func TestTimelineZoom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zoom in halves the window around its centre", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "+")
+
+		start, end := m.viewRange()
+		assert.True(t, m.isZoomed())
+		assert.Equal(t, m.chartEnd.Sub(m.chartStart)/2, end.Sub(start))
+		assert.Equal(t, m.chartStart.Add(m.chartEnd.Sub(m.chartStart)/4), start)
+	})
+
+	t.Run("zoom out never goes past the full range", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "+")
+		m = pressKey(m, "-")
+		m = pressKey(m, "-")
+
+		start, end := m.viewRange()
+		assert.False(t, m.isZoomed())
+		assert.Equal(t, m.chartStart, start)
+		assert.Equal(t, m.chartEnd, end)
+	})
+
+	t.Run("pan is clamped to the chart range", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "+")
+		for i := 0; i < 10; i++ {
+			m = pressKey(m, ">")
+		}
+		_, end := m.viewRange()
+		assert.Equal(t, m.chartEnd, end)
+
+		for i := 0; i < 10; i++ {
+			m = pressKey(m, "<")
+		}
+		start, _ := m.viewRange()
+		assert.Equal(t, m.chartStart, start)
+	})
+
+	t.Run("0 resets zoom", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "+")
+		m = pressKey(m, "0")
+		assert.False(t, m.isZoomed())
+	})
+
+	t.Run("wheel over timeline zooms around the pointer", func(t *testing.T) {
+		m := createTestModel()
+		timelineW := m.timelineWidth()
+		col := timelineW / 4
+		before := m.timeAtColumn(col, timelineW)
+
+		x := horizontalPad + 3 + m.treeWidth + col
+		newModel, _ := m.Update(tea.MouseMsg{X: x, Y: 10, Button: tea.MouseButtonWheelUp})
+		m = newModel.(Model)
+
+		assert.True(t, m.isZoomed())
+		after := m.timeAtColumn(col, timelineW)
+		assert.InDelta(t, 0, after.Sub(before).Seconds(), 1)
+	})
+
+	t.Run("wheel over tree still scrolls", func(t *testing.T) {
+		m := createTestModel()
+		newModel, _ := m.Update(tea.MouseMsg{X: horizontalPad + 3, Y: 10, Button: tea.MouseButtonWheelDown})
+		m = newModel.(Model)
+
+		assert.False(t, m.isZoomed())
+		assert.Equal(t, 1, m.cursor)
+	})
+}
+
+func TestTimeCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("t toggles the cursor in the middle of the window", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "t")
+		assert.False(t, m.timeCursor.IsZero())
+		assert.Equal(t, m.timelineWidth()/2, m.columnForTime(m.timeCursor, m.timelineWidth()))
+
+		m = pressKey(m, "t")
+		assert.True(t, m.timeCursor.IsZero())
+	})
+
+	t.Run("cursor moves one column at a time", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "t")
+		col := m.columnForTime(m.timeCursor, m.timelineWidth())
+
+		m = pressKey(m, ".")
+		assert.Equal(t, col+1, m.columnForTime(m.timeCursor, m.timelineWidth()))
+		m = pressKey(m, ",")
+		m = pressKey(m, ",")
+		assert.Equal(t, col-1, m.columnForTime(m.timeCursor, m.timelineWidth()))
+	})
+
+	t.Run("cursor pans the zoomed window at the edge", func(t *testing.T) {
+		m := createTestModel()
+		m = pressKey(m, "+")
+		m = pressKey(m, "t")
+		startBefore, _ := m.viewRange()
+		for i := 0; i < m.timelineWidth(); i++ {
+			m = pressKey(m, ".")
+		}
+		startAfter, end := m.viewRange()
+		assert.True(t, startAfter.After(startBefore))
+		assert.False(t, m.timeCursor.After(end))
+	})
+
+	t.Run("axis shows absolute and relative time", func(t *testing.T) {
+		m := createTestModel()
+		m.timeCursor = m.chartStart.Add(90 * time.Second)
+		axis := m.renderTimeAxis()
+		assert.Contains(t, axis, m.timeCursor.Format("15:04:05.000"))
+		assert.Contains(t, axis, "+1m 30s")
+	})
+}
+
+func TestZoomedView(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mini-map replaces the blank line while zoomed", func(t *testing.T) {
+		m := createTestModel()
+		assert.NotContains(t, m.View(), "overview")
+
+		m = pressKey(m, "+")
+		assert.Contains(t, m.View(), "overview")
+	})
+
+	t.Run("items outside the window render an empty bar", func(t *testing.T) {
+		m := createTestModel()
+		m.expandAll()
+		// Zoom into the last minute, after build finished
+		m.setZoom(5, m.chartEnd, 1)
+
+		var build TreeItem
+		for _, item := range m.visibleItems {
+			if item.Name == "build" {
+				build = item
+			}
+		}
+		viewStart, viewEnd := m.viewRange()
+		assert.False(t, itemInView(build, viewStart, viewEnd))
+
+		row := m.renderItem(build, false, -1)
+		timeline := row[strings.LastIndex(row, "│"):]
+		assert.NotContains(t, timeline, "█")
+	})
+}